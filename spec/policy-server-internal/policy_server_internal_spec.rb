@@ -27,6 +27,8 @@ module Bosh::Template::Test
         'max_open_connections' => 5,
         'connections_max_lifetime_seconds' => 54,
         'enforce_experimental_dynamic_egress_policies' => true,
+        'policy_snapshot_poll_interval_seconds' => 2,
+        'policy_snapshot_max_age_seconds' => 30,
       }
     end
 
@@ -73,6 +75,17 @@ module Bosh::Template::Test
       end
     end
 
+    describe 'drain' do
+      let(:template) {job.template('bin/drain')}
+      it 'waits for the drain wait and timeout before giving up on the process' do
+        merged_manifest_properties['drain_wait_seconds'] = 10
+        merged_manifest_properties['drain_timeout_seconds'] = 30
+        drain = template.render(merged_manifest_properties)
+        expect(drain).to include('pidfile=/var/vcap/sys/run/bpm/policy-server-internal/policy-server-internal.pid')
+        expect(drain).to include('timeout=45')
+      end
+    end
+
     describe 'policy-server-internal.json' do
       let(:template) {job.template('config/policy-server-internal.json')}
 
@@ -83,6 +96,12 @@ module Bosh::Template::Test
           'debug_server_port' => 1234,
           'health_check_port' => 2345,
           'internal_listen_port' => 3456,
+          'prometheus_listen_address' => '127.0.0.1',
+          'prometheus_listen_port' => 0,
+          'tracing_otlp_endpoint' => '',
+          'route_request_timeouts' => {},
+          'drain_wait_seconds' => 5,
+          'drain_timeout_seconds' => 20,
           'database' => {
             'type' => 'some-database-type',
             'user' => 'some-database-username',
@@ -101,6 +120,8 @@ module Bosh::Template::Test
           'metron_address' => '127.0.0.1:4567',
           'log_level' => 'error',
           'enforce_experimental_dynamic_egress_policies' => true,
+          'policy_snapshot_poll_interval_seconds' => 2,
+          'policy_snapshot_max_age_seconds' => 30,
 
           # hard-coded values, not exposed as bosh spec properties
           'debug_server_host' => '127.0.0.1',
@@ -129,6 +150,31 @@ module Bosh::Template::Test
           end
         end
       end
+
+      context 'when a read replica host is provided' do
+        before do
+          merged_manifest_properties['database']['read_replica_host'] = 'some-replica-host'
+          merged_manifest_properties['database']['read_replica_port'] = 5432
+          merged_manifest_properties['read_replica_check_interval_seconds'] = 2
+        end
+
+        it 'renders the read replica with the primary database settings' do
+          config = JSON.parse(template.render(merged_manifest_properties, consumes: links))
+          expect(config['read_replica_database']).to eq({
+            'type' => 'some-database-type',
+            'user' => 'some-database-username',
+            'password' => 'some-database-password',
+            'port' => 5432,
+            'database_name' => 'some-database-name',
+            'host' => 'some-replica-host',
+            'timeout' => 30,
+            'require_ssl' => true,
+            'ca_cert' => '/var/vcap/jobs/policy-server-internal/config/certs/database_ca.crt',
+          })
+          expect(config['read_replica_check_interval_seconds']).to eq(2)
+          expect(config['read_replica_max_staleness_seconds']).to eq(10)
+        end
+      end
     end
   end
 end
@@ -57,6 +57,17 @@ module Bosh::Template::Test
       end
     end
 
+    describe 'drain' do
+      let(:template) {job.template('bin/drain')}
+      it 'waits for the drain wait and timeout before giving up on the process' do
+        merged_manifest_properties['drain_wait_seconds'] = 10
+        merged_manifest_properties['drain_timeout_seconds'] = 30
+        drain = template.render(merged_manifest_properties)
+        expect(drain).to include('pidfile=/var/vcap/sys/run/bpm/policy-server/policy-server.pid')
+        expect(drain).to include('timeout=45')
+      end
+    end
+
     describe 'policy-server.json' do
       let(:template) {job.template('config/policy-server.json')}
 
@@ -94,9 +105,19 @@ module Bosh::Template::Test
           'metron_address' => '127.0.0.1:6789',
           'log_level' => 'debug',
           'cleanup_interval' => 60,
+          'cleanup_dry_run' => false,
+          'cleanup_max_delete_percent' => 0,
+          'tombstone_retention_period' => 604800,
+          'cleanup_event_poll_interval' => 0,
           'max_policies' => 2,
           'enable_space_developer_self_service' => true,
           'allowed_cors_domains' => ['some-cors-domain'],
+          'prometheus_listen_address' => '127.0.0.1',
+          'prometheus_listen_port' => 0,
+          'tracing_otlp_endpoint' => '',
+          'route_request_timeouts' => {},
+          'drain_wait_seconds' => 5,
+          'drain_timeout_seconds' => 20,
           'uaa_ca' => '/var/vcap/jobs/policy-server/config/certs/uaa_ca.crt',
           'request_timeout' => 5,
         })
@@ -213,6 +234,56 @@ module Bosh::Template::Test
           JSON.parse(template.render(merged_manifest_properties))
         }.to raise_error('policy_cleanup_interval must be at least 1 minute')
       end
+
+      it 'renders the prometheus port and address when provided' do
+        merged_manifest_properties['prometheus_port'] = 9100
+        merged_manifest_properties['prometheus_address'] = '10.0.0.1'
+        config = JSON.parse(template.render(merged_manifest_properties))
+        expect(config['prometheus_listen_port']).to eq(9100)
+        expect(config['prometheus_listen_address']).to eq('10.0.0.1')
+      end
+
+      it 'renders the route request timeouts when provided' do
+        merged_manifest_properties['route_request_timeouts'] = { 'policies_index' => 30 }
+        config = JSON.parse(template.render(merged_manifest_properties))
+        expect(config['route_request_timeouts']).to eq('policies_index' => 30)
+      end
+
+      it 'renders the tracing endpoint when provided' do
+        merged_manifest_properties['tracing_otlp_endpoint'] = 'http://127.0.0.1:4318'
+        config = JSON.parse(template.render(merged_manifest_properties))
+        expect(config['tracing_otlp_endpoint']).to eq('http://127.0.0.1:4318')
+      end
+
+      context 'when a read replica host is provided' do
+        before do
+          merged_manifest_properties['database']['read_replica_host'] = 'some-replica-host'
+          merged_manifest_properties['read_replica_max_staleness_seconds'] = 30
+        end
+
+        it 'renders the read replica with the primary database settings' do
+          config = JSON.parse(template.render(merged_manifest_properties))
+          expect(config['read_replica_database']).to eq({
+            'type' => 'postgres',
+            'user' => 'some-database-username',
+            'password' => 'some-database-password',
+            'host' => 'some-replica-host',
+            'port' => 5678,
+            'timeout' => 3,
+            'database_name' => 'some-database-name',
+            'require_ssl' => true,
+            'ca_cert' => '/var/vcap/jobs/policy-server/config/certs/database_ca.crt'
+          })
+          expect(config['read_replica_check_interval_seconds']).to eq(5)
+          expect(config['read_replica_max_staleness_seconds']).to eq(30)
+        end
+
+        it 'uses the read replica port when provided' do
+          merged_manifest_properties['database']['read_replica_port'] = 6789
+          config = JSON.parse(template.render(merged_manifest_properties))
+          expect(config['read_replica_database']['port']).to eq(6789)
+        end
+      end
     end
   end
 end
@@ -18,6 +18,8 @@ type Config struct {
 	MetricsEmitSeconds                int    `json:"metrics_emit_seconds" validate:"min=1"`
 	LogLevelAddress                   string `json:"log_level_address" validate:"nonzero"`
 	LogLevelPort                      int    `json:"log_level_port" validate:"min=1"`
+	PrometheusListenAddress           string `json:"prometheus_listen_address"`
+	PrometheusListenPort              int    `json:"prometheus_listen_port" validate:"min=0"`
 }
 
 func NewConfig(configJSON []byte) (*Config, error) {
@@ -24,8 +24,9 @@ var _ = Describe("Config", func() {
 				"metrics_emit_seconds": 6,
 				"metron_port": 8080,
 				"log_level_address": "log-level-address",
-				"log_level_port": 9090
-
+				"log_level_port": 9090,
+				"prometheus_listen_address": "127.0.0.1",
+				"prometheus_listen_port": 9100
 			}`)
 
 			parsedConfig, err := NewConfig(configJSON)
@@ -43,6 +44,8 @@ var _ = Describe("Config", func() {
 			Expect(parsedConfig.MetronPort).To(Equal(8080))
 			Expect(parsedConfig.LogLevelAddress).To(Equal("log-level-address"))
 			Expect(parsedConfig.LogLevelPort).To(Equal(9090))
+			Expect(parsedConfig.PrometheusListenAddress).To(Equal("127.0.0.1"))
+			Expect(parsedConfig.PrometheusListenPort).To(Equal(9100))
 		})
 	})
 
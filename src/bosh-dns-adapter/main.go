@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"lib/common"
+	"lib/prometheus"
 	"net"
 	"net/http"
 	"os"
@@ -79,8 +80,16 @@ func main() {
 
 	requestLogger := logger.Session("serve-request")
 
-	metricSender := metrics.MetricsSender{
-		Logger: logger.Session("bosh-dns-adapter"),
+	var prometheusRegistry *prometheus.Registry
+	if config.PrometheusListenPort > 0 {
+		prometheusRegistry = prometheus.NewRegistry("bosh_dns_adapter")
+	}
+
+	metricSender := prometheus.MetricsSender{
+		Sender: &metrics.MetricsSender{
+			Logger: logger.Session("bosh-dns-adapter"),
+		},
+		Registry: prometheusRegistry,
 	}
 
 	metricsWrap := func(name string, handler http.Handler) http.Handler {
@@ -153,6 +162,11 @@ func main() {
 		{"metrics-emitter", metricsEmitter},
 		{"log-level-server", lagerlevel.NewServer(config.LogLevelAddress, config.LogLevelPort, reconfigurableSink, logger.Session("log-level-server"))},
 	}
+	if prometheusRegistry != nil {
+		prometheusRegistry.AddSources(uptimeSource)
+		prometheusAddress := fmt.Sprintf("%s:%d", config.PrometheusListenAddress, config.PrometheusListenPort)
+		members = append(members, grouper.Member{Name: "prometheus-server", Runner: prometheus.NewServer(prometheusAddress, prometheusRegistry)})
+	}
 	group := grouper.NewOrdered(os.Interrupt, members)
 	monitor := ifrit.Invoke(sigmon.New(group))
 
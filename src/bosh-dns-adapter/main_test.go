@@ -33,6 +33,7 @@ var _ = Describe("Main", func() {
 		dnsAdapterPort                         string
 		fakeMetron                             metrics.FakeMetron
 		logLevelPort                           int
+		prometheusPort                         int
 	)
 
 	BeforeEach(func() {
@@ -59,6 +60,7 @@ var _ = Describe("Main", func() {
 
 		dnsAdapterPort = fmt.Sprintf("%d", ports.PickAPort())
 		logLevelPort = ports.PickAPort()
+		prometheusPort = ports.PickAPort()
 	})
 
 	JustBeforeEach(func() {
@@ -90,7 +92,9 @@ var _ = Describe("Main", func() {
 			"metron_port": %d,
 			"metrics_emit_seconds": 2,
 			"log_level_port": %d,
-			"log_level_address": "127.0.0.1"
+			"log_level_address": "127.0.0.1",
+			"prometheus_listen_address": "127.0.0.1",
+			"prometheus_listen_port": %d
 		}`, dnsAdapterAddress,
 			dnsAdapterPort,
 			strings.TrimPrefix(urlParts[1], "//"),
@@ -100,6 +104,7 @@ var _ = Describe("Main", func() {
 			caFileName,
 			fakeMetron.Port(),
 			logLevelPort,
+			prometheusPort,
 		)
 
 		tempConfigFile, err = ioutil.TempFile(os.TempDir(), "sd")
@@ -198,6 +203,13 @@ var _ = Describe("Main", func() {
 					metricWithOrigin("bosh-dns-adapter"),
 				)))
 			})
+
+			It("serves the request metrics on the prometheus endpoint", func() {
+				Expect(scrapePrometheus(prometheusPort)).To(SatisfyAll(
+					ContainSubstring(`bosh_dns_adapter_request_duration_seconds_count{route="get_ips"} 1`),
+					ContainSubstring("bosh_dns_adapter_uptime "),
+				))
+			})
 		})
 
 		Context("when things don't go well", func() {
@@ -216,6 +228,10 @@ var _ = Describe("Main", func() {
 					metricWithOrigin("bosh-dns-adapter"),
 				)))
 			})
+
+			It("serves the failed request count on the prometheus endpoint", func() {
+				Expect(scrapePrometheus(prometheusPort)).To(ContainSubstring("bosh_dns_adapter_dns_request_failures_total 1"))
+			})
 		})
 	})
 
@@ -581,6 +597,17 @@ func makeDNSRequest(url string, expectedResponseCode int) {
 	Expect(resp.StatusCode).To(Equal(expectedResponseCode))
 }
 
+func scrapePrometheus(port int) string {
+	resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", port))
+	Expect(err).ToNot(HaveOccurred())
+	defer resp.Body.Close()
+	Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+	body, err := ioutil.ReadAll(resp.Body)
+	Expect(err).ToNot(HaveOccurred())
+	return string(body)
+}
+
 func metricWithName(name string) types.GomegaMatcher {
 	return WithTransform(func(ev metrics.Event) string {
 		return ev.Name
@@ -3,6 +3,8 @@ package common
 import (
 	"crypto/tls"
 	"fmt"
+	"lib/tracing"
+	"net/http"
 	"policy-server/server_metrics"
 	"policy-server/store"
 	"time"
@@ -11,7 +13,6 @@ import (
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerflags"
 	"github.com/tedsuo/ifrit"
-	"github.com/tedsuo/ifrit/http_server"
 	"github.com/tedsuo/rata"
 )
 
@@ -21,6 +22,9 @@ const (
 	ERROR        = "error"
 	FATAL        = "fatal"
 	emitInterval = 30 * time.Second
+
+	traceFlushInterval = 5 * time.Second
+	traceMaxQueueSize  = 2048
 )
 
 func GetLagerConfig() lagerflags.LagerConfig {
@@ -29,23 +33,50 @@ func GetLagerConfig() lagerflags.LagerConfig {
 	return lagerConfig
 }
 
-func InitMetricsEmitter(logger lager.Logger, wrappedStore *store.MetricsWrapper, db metrics.Db) *metrics.MetricsEmitter {
+func InitMetricsEmitter(logger lager.Logger, sources []metrics.MetricSource) *metrics.MetricsEmitter {
+	return metrics.NewMetricsEmitter(logger, emitInterval, sources...)
+}
+
+// InitMetricSources returns the gauges emitted by both policy servers.
+func InitMetricSources(wrappedStore *store.MetricsWrapper, db metrics.Db) []metrics.MetricSource {
 	totalPoliciesSource := server_metrics.NewTotalPoliciesSource(wrappedStore)
+	freeTagsSource := server_metrics.NewFreeTagsSource(wrappedStore)
+	freeTagsPercentSource := server_metrics.NewFreeTagsPercentSource(wrappedStore)
 	uptimeSource := metrics.NewUptimeSource()
 	dbMonitorSource := metrics.NewDBMonitorSource(db)
-	return metrics.NewMetricsEmitter(logger, emitInterval,
-		uptimeSource, totalPoliciesSource, dbMonitorSource)
+	return []metrics.MetricSource{
+		uptimeSource, totalPoliciesSource, freeTagsSource, freeTagsPercentSource, dbMonitorSource,
+	}
+}
+
+// InitTracer returns a tracer that batches spans and exports them to the
+// OTLP/HTTP collector at endpoint.
+func InitTracer(logger lager.Logger, endpoint, serviceName string) *tracing.Tracer {
+	exporter := &tracing.OTLPExporter{
+		Endpoint:    endpoint,
+		ServiceName: serviceName,
+		Client:      &http.Client{Timeout: 10 * time.Second},
+	}
+	return tracing.NewTracer(exporter, logger.Session("tracer"), traceFlushInterval, traceMaxQueueSize)
 }
 
-func InitServer(logger lager.Logger, tlsConfig *tls.Config, host string, port int, handlers rata.Handlers, routes rata.Routes) ifrit.Runner {
+// InitServer returns a runner for an http server, which is served over TLS
+// when tlsConfig is set. When signalled it shuts down gracefully as
+// coordinated by drain.
+func InitServer(logger lager.Logger, tlsConfig *tls.Config, host string, port int, handlers rata.Handlers, routes rata.Routes, drain *Drain) ifrit.Runner {
 	router, err := rata.NewRouter(routes, handlers)
 	if err != nil {
 		logger.Fatal("create-rata-router", err) // not tested
 	}
 
 	addr := fmt.Sprintf("%s:%d", host, port)
-	if tlsConfig != nil {
-		return http_server.NewTLSServer(addr, router, tlsConfig)
+	return &server{
+		logger: logger.Session("http-server", lager.Data{"address": addr}),
+		server: &http.Server{
+			Addr:      addr,
+			Handler:   router,
+			TLSConfig: tlsConfig,
+		},
+		drain: drain,
 	}
-	return http_server.New(addr, router)
 }
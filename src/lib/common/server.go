@@ -0,0 +1,100 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+// Drain coordinates the graceful shutdown of the servers of a process. The
+// first server to be signalled starts the drain. From then on Draining
+// reports true so that health checks fail and load balancers move traffic
+// away. After Wait every server stops accepting new requests, and servers
+// give up on in-flight requests once Timeout has passed on top of that.
+type Drain struct {
+	Wait    time.Duration
+	Timeout time.Duration
+
+	mutex     sync.Mutex
+	startedAt time.Time
+}
+
+func (d *Drain) Start() {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.startedAt.IsZero() {
+		d.startedAt = time.Now()
+	}
+}
+
+func (d *Drain) Draining() bool {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return !d.startedAt.IsZero()
+}
+
+// Deadline is when in-flight work should be abandoned. It is the zero time
+// until the drain has started.
+func (d *Drain) Deadline() time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.startedAt.IsZero() {
+		return time.Time{}
+	}
+	return d.startedAt.Add(d.Wait + d.Timeout)
+}
+
+func (d *Drain) closeAt() time.Time {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	return d.startedAt.Add(d.Wait)
+}
+
+type server struct {
+	logger lager.Logger
+	server *http.Server
+	drain  *Drain
+}
+
+func (s *server) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return err
+	}
+	if s.server.TLSConfig != nil {
+		listener = tls.NewListener(listener, s.server.TLSConfig)
+	}
+
+	serveErrors := make(chan error, 1)
+	go func() {
+		serveErrors <- s.server.Serve(listener)
+	}()
+	close(ready)
+
+	select {
+	case err := <-serveErrors:
+		return err
+	case <-signals:
+	}
+
+	s.drain.Start()
+	s.logger.Info("draining", lager.Data{"deadline": s.drain.Deadline()})
+	time.Sleep(time.Until(s.drain.closeAt()))
+
+	ctx, cancel := context.WithDeadline(context.Background(), s.drain.Deadline())
+	defer cancel()
+	err = s.server.Shutdown(ctx)
+	if err != nil {
+		s.logger.Error("drain-timed-out", err)
+		return s.server.Close()
+	}
+
+	s.logger.Info("drained")
+	return nil
+}
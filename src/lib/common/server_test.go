@@ -0,0 +1,132 @@
+package common_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"lib/common"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/ports"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/rata"
+)
+
+var _ = Describe("Drain", func() {
+	It("is not draining until started", func() {
+		drain := &common.Drain{Wait: time.Second, Timeout: 2 * time.Second}
+		Expect(drain.Draining()).To(BeFalse())
+		Expect(drain.Deadline().IsZero()).To(BeTrue())
+
+		drain.Start()
+		Expect(drain.Draining()).To(BeTrue())
+		Expect(drain.Deadline()).To(BeTemporally("~", time.Now().Add(3*time.Second), 100*time.Millisecond))
+	})
+
+	It("keeps the deadline of the first start", func() {
+		drain := &common.Drain{Timeout: time.Second}
+		drain.Start()
+		deadline := drain.Deadline()
+
+		time.Sleep(10 * time.Millisecond)
+		drain.Start()
+		Expect(drain.Deadline()).To(Equal(deadline))
+	})
+})
+
+var _ = Describe("InitServer", func() {
+	var (
+		drain   *common.Drain
+		address string
+		release chan struct{}
+		started chan struct{}
+		process ifrit.Process
+	)
+
+	BeforeEach(func() {
+		drain = &common.Drain{Wait: 500 * time.Millisecond, Timeout: 500 * time.Millisecond}
+		release = make(chan struct{})
+		started = make(chan struct{}, 1)
+
+		routes := rata.Routes{
+			{Name: "health", Method: "GET", Path: "/health"},
+			{Name: "slow", Method: "GET", Path: "/slow"},
+		}
+		handlers := rata.Handlers{
+			"health": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if drain.Draining() {
+					w.WriteHeader(http.StatusServiceUnavailable)
+				}
+			}),
+			"slow": http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				started <- struct{}{}
+				<-release
+				w.Write([]byte("done"))
+			}),
+		}
+
+		port := ports.PickAPort()
+		address = fmt.Sprintf("http://127.0.0.1:%d", port)
+		server := common.InitServer(lagertest.NewTestLogger("test"), nil, "127.0.0.1", port, handlers, routes, drain)
+		process = ifrit.Invoke(server)
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Kill)
+	})
+
+	getStatus := func(path string) (int, error) {
+		resp, err := http.Get(address + path)
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+
+	It("fails health checks while still serving during the drain wait", func() {
+		Expect(getStatus("/health")).To(Equal(http.StatusOK))
+
+		process.Signal(os.Interrupt)
+
+		Eventually(func() (int, error) { return getStatus("/health") }).Should(Equal(http.StatusServiceUnavailable))
+		Eventually(func() error {
+			_, err := getStatus("/health")
+			return err
+		}).Should(HaveOccurred())
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("waits for in-flight requests to finish", func() {
+		responses := make(chan string, 1)
+		go func() {
+			defer GinkgoRecover()
+			resp, err := http.Get(address + "/slow")
+			Expect(err).NotTo(HaveOccurred())
+			body, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			responses <- string(body)
+		}()
+		Eventually(started).Should(Receive())
+
+		process.Signal(os.Interrupt)
+		Consistently(process.Wait(), "600ms").ShouldNot(Receive())
+
+		close(release)
+		Eventually(responses).Should(Receive(Equal("done")))
+		Eventually(process.Wait()).Should(Receive(BeNil()))
+	})
+
+	It("gives up on in-flight requests at the deadline", func() {
+		go http.Get(address + "/slow")
+		Eventually(started).Should(Receive())
+
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait(), "2s").Should(Receive())
+		close(release)
+	})
+})
@@ -0,0 +1,30 @@
+package prometheus
+
+import "time"
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
+	SendDuration(string, time.Duration)
+}
+
+// MetricsSender forwards every counter and duration to Sender and, when
+// the Prometheus endpoint is enabled, records it in Registry as well.
+type MetricsSender struct {
+	Sender   metricsSender
+	Registry *Registry
+}
+
+func (s *MetricsSender) IncrementCounter(name string) {
+	s.Sender.IncrementCounter(name)
+	if s.Registry != nil {
+		s.Registry.IncrementCounter(name)
+	}
+}
+
+func (s *MetricsSender) SendDuration(name string, duration time.Duration) {
+	s.Sender.SendDuration(name, duration)
+	if s.Registry != nil {
+		s.Registry.SendDuration(name, duration)
+	}
+}
@@ -0,0 +1,68 @@
+package prometheus_test
+
+import (
+	"bytes"
+	"lib/prometheus"
+	"lib/prometheus/fakes"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MetricsSender", func() {
+	var (
+		fakeSender *fakes.MetricsSender
+		registry   *prometheus.Registry
+		sender     *prometheus.MetricsSender
+	)
+
+	BeforeEach(func() {
+		fakeSender = &fakes.MetricsSender{}
+		registry = prometheus.NewRegistry("test")
+		sender = &prometheus.MetricsSender{
+			Sender:   fakeSender,
+			Registry: registry,
+		}
+	})
+
+	scrape := func() string {
+		buffer := &bytes.Buffer{}
+		n, err := registry.WriteTo(buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(buffer.Len()))
+		return buffer.String()
+	}
+
+	It("sends counters to both the sender and the registry", func() {
+		sender.IncrementCounter("SomeError")
+
+		Expect(fakeSender.IncrementCounterCallCount()).To(Equal(1))
+		Expect(fakeSender.IncrementCounterArgsForCall(0)).To(Equal("SomeError"))
+		Expect(scrape()).To(ContainSubstring("test_some_error_total 1\n"))
+	})
+
+	It("sends durations to both the sender and the registry", func() {
+		sender.SendDuration("SomeTime", time.Second)
+
+		Expect(fakeSender.SendDurationCallCount()).To(Equal(1))
+		name, duration := fakeSender.SendDurationArgsForCall(0)
+		Expect(name).To(Equal("SomeTime"))
+		Expect(duration).To(Equal(time.Second))
+		Expect(scrape()).To(ContainSubstring("test_some_duration_seconds_count 1\n"))
+	})
+
+	Context("when there is no registry", func() {
+		BeforeEach(func() {
+			sender.Registry = nil
+		})
+
+		It("only sends to the sender", func() {
+			sender.IncrementCounter("SomeError")
+			sender.SendDuration("SomeTime", time.Second)
+
+			Expect(fakeSender.IncrementCounterCallCount()).To(Equal(1))
+			Expect(fakeSender.SendDurationCallCount()).To(Equal(1))
+		})
+	})
+})
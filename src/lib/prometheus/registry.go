@@ -0,0 +1,235 @@
+package prometheus
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+)
+
+const requestTimeSuffix = "RequestTime"
+
+// DefaultBuckets are the upper bounds, in seconds, of the duration
+// histograms.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Registry records the counters and durations that are sent to metron and
+// serves them, along with the current value of its metric sources, in the
+// Prometheus text exposition format.
+//
+// Metric names are converted from the CamelCase used with dropsonde and
+// prefixed with Namespace:
+//   - counters become <namespace>_<name>_total
+//   - durations named <Route>RequestTime, as sent by the request metrics
+//     middleware, become <namespace>_request_duration_seconds{route="<route>"}
+//   - other durations become <namespace>_<name without Time>_duration_seconds
+//   - sources become gauges named <namespace>_<name>
+type Registry struct {
+	Namespace string
+	Buckets   []float64
+
+	mutex      sync.Mutex
+	counters   map[string]float64
+	histograms map[string]*histogram
+	sources    []metrics.MetricSource
+}
+
+type histogram struct {
+	name   string
+	labels string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+func NewRegistry(namespace string) *Registry {
+	return &Registry{
+		Namespace:  namespace,
+		Buckets:    DefaultBuckets,
+		counters:   map[string]float64{},
+		histograms: map[string]*histogram{},
+	}
+}
+
+// AddSources registers gauges that are read every time the registry is
+// scraped.
+func (r *Registry) AddSources(sources ...metrics.MetricSource) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.sources = append(r.sources, sources...)
+}
+
+func (r *Registry) IncrementCounter(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.counters[r.metricName(name)+"_total"]++
+}
+
+func (r *Registry) SendDuration(name string, duration time.Duration) {
+	var metricName, labels string
+	if route := strings.TrimSuffix(name, requestTimeSuffix); route != name && route != "" {
+		metricName = r.metricName("request_duration_seconds")
+		labels = fmt.Sprintf(`route=%q`, snakeCase(route))
+	} else {
+		metricName = r.metricName(strings.TrimSuffix(name, "Time")) + "_duration_seconds"
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := metricName + "{" + labels + "}"
+	h, ok := r.histograms[key]
+	if !ok {
+		h = &histogram{
+			name:   metricName,
+			labels: labels,
+			counts: make([]uint64, len(r.Buckets)),
+		}
+		r.histograms[key] = h
+	}
+
+	seconds := duration.Seconds()
+	for i, upperBound := range r.Buckets {
+		if seconds <= upperBound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+func (r *Registry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	r.WriteTo(w)
+}
+
+// WriteTo writes every metric in the text exposition format, implementing
+// io.WriterTo. Sources whose getter fails are left out.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mutex.Lock()
+	sources := append([]metrics.MetricSource{}, r.sources...)
+	r.mutex.Unlock()
+
+	gauges := map[string]float64{}
+	for _, source := range sources {
+		value, err := source.Getter()
+		if err != nil {
+			continue
+		}
+		gauges[r.metricName(source.Name)] = value
+	}
+
+	buffer := &bytes.Buffer{}
+	r.writeMetrics(buffer, gauges)
+	return buffer.WriteTo(w)
+}
+
+func (r *Registry) writeMetrics(w io.Writer, gauges map[string]float64) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, name := range sortedKeys(r.counters) {
+		fmt.Fprintf(w, "# TYPE %s counter\n%s %s\n", name, name, formatFloat(r.counters[name]))
+	}
+
+	for _, name := range sortedKeys(gauges) {
+		fmt.Fprintf(w, "# TYPE %s gauge\n%s %s\n", name, name, formatFloat(gauges[name]))
+	}
+
+	var keys []string
+	for key := range r.histograms {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	typed := map[string]bool{}
+	for _, key := range keys {
+		h := r.histograms[key]
+		if !typed[h.name] {
+			typed[h.name] = true
+			fmt.Fprintf(w, "# TYPE %s histogram\n", h.name)
+		}
+		for i, upperBound := range r.Buckets {
+			fmt.Fprintf(w, "%s_bucket{%s} %d\n", h.name, withLabel(h.labels, "le", formatFloat(upperBound)), h.counts[i])
+		}
+		fmt.Fprintf(w, "%s_bucket{%s} %d\n", h.name, withLabel(h.labels, "le", "+Inf"), h.count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", h.name, braces(h.labels), formatFloat(h.sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, braces(h.labels), h.count)
+	}
+}
+
+func (r *Registry) metricName(name string) string {
+	if r.Namespace == "" {
+		return snakeCase(name)
+	}
+	return r.Namespace + "_" + snakeCase(name)
+}
+
+// snakeCase converts a CamelCase metric name to snake_case, keeping
+// acronyms together, and replaces characters Prometheus does not allow.
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var out []rune
+	for i, c := range runes {
+		if unicode.IsUpper(c) && i > 0 {
+			previous := runes[i-1]
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && startsWord(runes[i+1:])) {
+				out = append(out, '_')
+			}
+		}
+		switch {
+		case c < unicode.MaxASCII && (unicode.IsLetter(c) || unicode.IsDigit(c)):
+			out = append(out, unicode.ToLower(c))
+		default:
+			out = append(out, '_')
+		}
+	}
+	return string(out)
+}
+
+// startsWord reports whether the lower case letters that follow an upper
+// case one make it the start of a new word rather than the end of an
+// acronym, as in "IPs".
+func startsWord(rest []rune) bool {
+	if len(rest) == 0 || !unicode.IsLower(rest[0]) {
+		return false
+	}
+	pluralAcronym := rest[0] == 's' && (len(rest) == 1 || !unicode.IsLower(rest[1]))
+	return !pluralAcronym
+}
+
+func withLabel(labels, name, value string) string {
+	label := fmt.Sprintf("%s=%q", name, value)
+	if labels == "" {
+		return label
+	}
+	return labels + "," + label
+}
+
+func braces(labels string) string {
+	if labels == "" {
+		return ""
+	}
+	return "{" + labels + "}"
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(values map[string]float64) []string {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
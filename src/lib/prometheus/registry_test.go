@@ -0,0 +1,149 @@
+package prometheus_test
+
+import (
+	"bytes"
+	"errors"
+	"lib/prometheus"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/metrics"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Registry", func() {
+	var registry *prometheus.Registry
+
+	BeforeEach(func() {
+		registry = prometheus.NewRegistry("policy_server")
+		registry.Buckets = []float64{0.1, 1}
+	})
+
+	scrape := func() string {
+		buffer := &bytes.Buffer{}
+		n, err := registry.WriteTo(buffer)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(buffer.Len()))
+		return buffer.String()
+	}
+
+	Describe("IncrementCounter", func() {
+		It("exposes a counter named after the metric", func() {
+			registry.IncrementCounter("DNSRequestFailures")
+			registry.IncrementCounter("DNSRequestFailures")
+			registry.IncrementCounter("StoreAllError")
+
+			Expect(scrape()).To(Equal(`# TYPE policy_server_dns_request_failures_total counter
+policy_server_dns_request_failures_total 2
+# TYPE policy_server_store_all_error_total counter
+policy_server_store_all_error_total 1
+`))
+		})
+	})
+
+	It("keeps acronyms together when converting names", func() {
+		registry.IncrementCounter("GetIPsFailure")
+		registry.IncrementCounter("HTTPServerError")
+
+		Expect(scrape()).To(ContainSubstring("policy_server_get_ips_failure_total 1\n"))
+		Expect(scrape()).To(ContainSubstring("policy_server_http_server_error_total 1\n"))
+	})
+
+	Describe("SendDuration", func() {
+		It("exposes request times as a histogram labelled with the route", func() {
+			registry.SendDuration("InternalPoliciesRequestTime", 50*time.Millisecond)
+			registry.SendDuration("InternalPoliciesRequestTime", 500*time.Millisecond)
+			registry.SendDuration("CreatePoliciesRequestTime", 2*time.Second)
+
+			Expect(scrape()).To(Equal(`# TYPE policy_server_request_duration_seconds histogram
+policy_server_request_duration_seconds_bucket{route="create_policies",le="0.1"} 0
+policy_server_request_duration_seconds_bucket{route="create_policies",le="1"} 0
+policy_server_request_duration_seconds_bucket{route="create_policies",le="+Inf"} 1
+policy_server_request_duration_seconds_sum{route="create_policies"} 2
+policy_server_request_duration_seconds_count{route="create_policies"} 1
+policy_server_request_duration_seconds_bucket{route="internal_policies",le="0.1"} 1
+policy_server_request_duration_seconds_bucket{route="internal_policies",le="1"} 2
+policy_server_request_duration_seconds_bucket{route="internal_policies",le="+Inf"} 2
+policy_server_request_duration_seconds_sum{route="internal_policies"} 0.55
+policy_server_request_duration_seconds_count{route="internal_policies"} 2
+`))
+		})
+
+		It("exposes other times as their own histogram", func() {
+			registry.SendDuration("StoreAllSuccessTime", 200*time.Millisecond)
+
+			Expect(scrape()).To(Equal(`# TYPE policy_server_store_all_success_duration_seconds histogram
+policy_server_store_all_success_duration_seconds_bucket{le="0.1"} 0
+policy_server_store_all_success_duration_seconds_bucket{le="1"} 1
+policy_server_store_all_success_duration_seconds_bucket{le="+Inf"} 1
+policy_server_store_all_success_duration_seconds_sum 0.2
+policy_server_store_all_success_duration_seconds_count 1
+`))
+		})
+	})
+
+	Describe("AddSources", func() {
+		It("exposes each source as a gauge read at scrape time", func() {
+			value := 3.0
+			registry.AddSources(metrics.MetricSource{
+				Name:   "totalPolicies",
+				Unit:   "",
+				Getter: func() (float64, error) { return value, nil },
+			})
+
+			Expect(scrape()).To(ContainSubstring("policy_server_total_policies 3\n"))
+
+			value = 4
+			Expect(scrape()).To(Equal("# TYPE policy_server_total_policies gauge\npolicy_server_total_policies 4\n"))
+		})
+
+		Context("when a source fails", func() {
+			It("leaves it out", func() {
+				registry.AddSources(
+					metrics.MetricSource{
+						Name:   "freeTags",
+						Getter: func() (float64, error) { return 0, errors.New("banana") },
+					},
+					metrics.MetricSource{
+						Name:   "uptime",
+						Getter: func() (float64, error) { return 10, nil },
+					},
+				)
+
+				Expect(scrape()).To(Equal("# TYPE policy_server_uptime gauge\npolicy_server_uptime 10\n"))
+			})
+		})
+	})
+
+	Describe("WriteTo", func() {
+		It("returns the error when the writer fails", func() {
+			registry.IncrementCounter("SomeError")
+
+			_, err := registry.WriteTo(failingWriter{})
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+
+	Describe("ServeHTTP", func() {
+		It("serves the metrics in the text exposition format", func() {
+			registry.IncrementCounter("SomeError")
+
+			resp := httptest.NewRecorder()
+			request, err := http.NewRequest("GET", "/metrics", nil)
+			Expect(err).NotTo(HaveOccurred())
+			registry.ServeHTTP(resp, request)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Header().Get("Content-Type")).To(Equal("text/plain; version=0.0.4"))
+			Expect(resp.Body.String()).To(Equal("# TYPE policy_server_some_error_total counter\npolicy_server_some_error_total 1\n"))
+		})
+	})
+})
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("banana")
+}
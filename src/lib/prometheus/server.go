@@ -0,0 +1,15 @@
+package prometheus
+
+import (
+	"net/http"
+
+	"github.com/tedsuo/ifrit"
+	"github.com/tedsuo/ifrit/http_server"
+)
+
+// NewServer returns a runner that serves the registry on /metrics.
+func NewServer(addr string, registry *Registry) ifrit.Runner {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", registry)
+	return http_server.New(addr, mux)
+}
@@ -0,0 +1,131 @@
+package testsupport
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+)
+
+// CollectedSpan is a span received by a FakeCollector.
+type CollectedSpan struct {
+	ServiceName   string
+	TraceID       string
+	SpanID        string
+	ParentSpanID  string
+	Name          string
+	Kind          int
+	Attributes    map[string]interface{}
+	StatusCode    int
+	StatusMessage string
+}
+
+// FakeCollector is an in-process OpenTelemetry collector that accepts OTLP
+// over HTTP with the JSON encoding and keeps every span it receives.
+type FakeCollector struct {
+	server *httptest.Server
+
+	mutex sync.Mutex
+	spans []CollectedSpan
+}
+
+func NewFakeCollector() *FakeCollector {
+	c := &FakeCollector{}
+	c.server = httptest.NewServer(http.HandlerFunc(c.serveTraces))
+	return c
+}
+
+func (c *FakeCollector) URL() string {
+	return c.server.URL
+}
+
+func (c *FakeCollector) Close() {
+	c.server.Close()
+}
+
+func (c *FakeCollector) Spans() []CollectedSpan {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]CollectedSpan{}, c.spans...)
+}
+
+// SpanNames returns the names of the spans received so far.
+func (c *FakeCollector) SpanNames() []string {
+	var names []string
+	for _, span := range c.Spans() {
+		names = append(names, span.Name)
+	}
+	return names
+}
+
+type otlpAttribute struct {
+	Key   string                 `json:"key"`
+	Value map[string]interface{} `json:"value"`
+}
+
+type otlpRequest struct {
+	ResourceSpans []struct {
+		Resource struct {
+			Attributes []otlpAttribute `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []struct {
+			Spans []struct {
+				TraceID      string          `json:"traceId"`
+				SpanID       string          `json:"spanId"`
+				ParentSpanID string          `json:"parentSpanId"`
+				Name         string          `json:"name"`
+				Kind         int             `json:"kind"`
+				Attributes   []otlpAttribute `json:"attributes"`
+				Status       struct {
+					Code    int    `json:"code"`
+					Message string `json:"message"`
+				} `json:"status"`
+			} `json:"spans"`
+		} `json:"scopeSpans"`
+	} `json:"resourceSpans"`
+}
+
+func (c *FakeCollector) serveTraces(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" || req.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	var request otlpRequest
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resourceSpans := range request.ResourceSpans {
+		serviceName, _ := attributeValues(resourceSpans.Resource.Attributes)["service.name"].(string)
+		for _, scopeSpans := range resourceSpans.ScopeSpans {
+			for _, span := range scopeSpans.Spans {
+				c.spans = append(c.spans, CollectedSpan{
+					ServiceName:   serviceName,
+					TraceID:       span.TraceID,
+					SpanID:        span.SpanID,
+					ParentSpanID:  span.ParentSpanID,
+					Name:          span.Name,
+					Kind:          span.Kind,
+					Attributes:    attributeValues(span.Attributes),
+					StatusCode:    span.Status.Code,
+					StatusMessage: span.Status.Message,
+				})
+			}
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func attributeValues(attributes []otlpAttribute) map[string]interface{} {
+	values := map[string]interface{}{}
+	for _, attribute := range attributes {
+		for _, value := range attribute.Value {
+			values[attribute.Key] = value
+		}
+	}
+	return values
+}
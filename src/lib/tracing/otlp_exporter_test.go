@@ -0,0 +1,120 @@
+package tracing_test
+
+import (
+	"errors"
+	"lib/testsupport"
+	"lib/tracing"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OTLPExporter", func() {
+	var (
+		collector *testsupport.FakeCollector
+		exporter  *tracing.OTLPExporter
+		spans     []tracing.SpanData
+	)
+
+	BeforeEach(func() {
+		collector = testsupport.NewFakeCollector()
+		exporter = &tracing.OTLPExporter{
+			Endpoint:    collector.URL(),
+			ServiceName: "some-service",
+			Client:      http.DefaultClient,
+		}
+
+		parent, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		Expect(err).NotTo(HaveOccurred())
+		child, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-00f067aa0ba902b7-01")
+		Expect(err).NotTo(HaveOccurred())
+
+		spans = []tracing.SpanData{
+			{
+				Name:        "parent",
+				Kind:        tracing.SpanKindServer,
+				SpanContext: parent,
+				StartTime:   time.Unix(1, 0),
+				EndTime:     time.Unix(2, 0),
+				Attributes: map[string]interface{}{
+					"http.method":      "GET",
+					"http.status_code": 200,
+				},
+			},
+			{
+				Name:          "child",
+				Kind:          tracing.SpanKindClient,
+				SpanContext:   child,
+				ParentSpanID:  parent.SpanID,
+				StartTime:     time.Unix(1, 0),
+				EndTime:       time.Unix(1, 5),
+				Error:         true,
+				StatusMessage: "banana",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		collector.Close()
+	})
+
+	It("sends the spans to the collector", func() {
+		Expect(exporter.ExportSpans(spans)).To(Succeed())
+
+		Expect(collector.Spans()).To(Equal([]testsupport.CollectedSpan{
+			{
+				ServiceName: "some-service",
+				TraceID:     "0af7651916cd43dd8448eb211c80319c",
+				SpanID:      "b7ad6b7169203331",
+				Name:        "parent",
+				Kind:        2,
+				Attributes: map[string]interface{}{
+					"http.method":      "GET",
+					"http.status_code": "200",
+				},
+			},
+			{
+				ServiceName:   "some-service",
+				TraceID:       "0af7651916cd43dd8448eb211c80319c",
+				SpanID:        "00f067aa0ba902b7",
+				ParentSpanID:  "b7ad6b7169203331",
+				Name:          "child",
+				Kind:          3,
+				Attributes:    map[string]interface{}{},
+				StatusCode:    2,
+				StatusMessage: "banana",
+			},
+		}))
+	})
+
+	Context("when the collector rejects the spans", func() {
+		BeforeEach(func() {
+			exporter.Endpoint = collector.URL() + "/some-other-path"
+		})
+
+		It("returns an error", func() {
+			err := exporter.ExportSpans(spans)
+			Expect(err).To(MatchError(HavePrefix("post spans: 404")))
+		})
+	})
+
+	Context("when the collector cannot be reached", func() {
+		BeforeEach(func() {
+			exporter.Client = &http.Client{Transport: failingTransport{}}
+		})
+
+		It("returns an error", func() {
+			err := exporter.ExportSpans(spans)
+			Expect(err).To(MatchError(ContainSubstring("post spans:")))
+			Expect(err).To(MatchError(ContainSubstring("banana")))
+		})
+	})
+})
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("banana")
+}
@@ -0,0 +1,32 @@
+package tracing_test
+
+import (
+	"lib/tracing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpanContext", func() {
+	It("round trips through a traceparent header value", func() {
+		sc, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sc.TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+		Expect(sc.SpanID.String()).To(Equal("b7ad6b7169203331"))
+		Expect(sc.Traceparent()).To(Equal("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"))
+	})
+
+	DescribeTable("rejecting invalid traceparent header values",
+		func(value string) {
+			_, err := tracing.ParseTraceparent(value)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty", ""),
+		Entry("too few parts", "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331"),
+		Entry("invalid version", "ff-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"),
+		Entry("short trace id", "00-0af7651916cd43dd-b7ad6b7169203331-01"),
+		Entry("non hex span id", "00-0af7651916cd43dd8448eb211c80319c-zzzzzzzzzzzzzzzz-01"),
+		Entry("all zero trace id", "00-00000000000000000000000000000000-b7ad6b7169203331-01"),
+	)
+})
@@ -0,0 +1,173 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	TraceparentHeader = "traceparent"
+
+	maxBatchSize = 512
+)
+
+//go:generate counterfeiter -o fakes/span_exporter.go --fake-name SpanExporter . spanExporter
+type spanExporter interface {
+	ExportSpans([]SpanData) error
+}
+
+// Tracer starts spans and, when run, exports the finished ones in batches
+// every FlushInterval and on shutdown. Spans that finish while the queue is
+// full are dropped rather than slowing down requests.
+//
+// A nil Tracer starts nil spans, so tracing can be left out of a component
+// by not setting its Tracer.
+type Tracer struct {
+	Exporter      spanExporter
+	Logger        lager.Logger
+	FlushInterval time.Duration
+
+	queue chan SpanData
+}
+
+func NewTracer(exporter spanExporter, logger lager.Logger, flushInterval time.Duration, maxQueueSize int) *Tracer {
+	return &Tracer{
+		Exporter:      exporter,
+		Logger:        logger,
+		FlushInterval: flushInterval,
+		queue:         make(chan SpanData, maxQueueSize),
+	}
+}
+
+// Start starts a span that is a child of the span in ctx, or the root of a
+// new trace if there is none, and returns a context carrying it.
+func (t *Tracer) Start(ctx context.Context, name string) (context.Context, *Span) {
+	return t.start(ctx, name, SpanKindInternal)
+}
+
+// StartClient starts a span for a call to another service.
+func (t *Tracer) StartClient(ctx context.Context, name string) (context.Context, *Span) {
+	return t.start(ctx, name, SpanKindClient)
+}
+
+func (t *Tracer) start(ctx context.Context, name string, kind SpanKind) (context.Context, *Span) {
+	if t == nil {
+		return ctx, nil
+	}
+
+	data := SpanData{
+		Name:       name,
+		Kind:       kind,
+		StartTime:  time.Now(),
+		Attributes: map[string]interface{}{},
+	}
+	if parent, ok := SpanContextFromContext(ctx); ok {
+		data.SpanContext.TraceID = parent.TraceID
+		data.ParentSpanID = parent.SpanID
+	} else {
+		data.SpanContext.TraceID = newTraceID()
+	}
+	data.SpanContext.SpanID = newSpanID()
+
+	span := &Span{tracer: t, data: data}
+	return ContextWithSpanContext(ctx, data.SpanContext), span
+}
+
+// Wrap starts a server span named name around every request, continuing
+// the trace from the request's traceparent header if it has one.
+func (t *Tracer) Wrap(name string, handler http.Handler) http.Handler {
+	if t == nil {
+		return handler
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		if sc, err := ParseTraceparent(req.Header.Get(TraceparentHeader)); err == nil {
+			ctx = ContextWithSpanContext(ctx, sc)
+		}
+
+		ctx, span := t.start(ctx, name, SpanKindServer)
+		defer span.End()
+		span.SetAttribute("http.method", req.Method)
+		span.SetAttribute("http.target", req.URL.Path)
+
+		recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+		handler.ServeHTTP(recorder, req.WithContext(ctx))
+
+		span.SetAttribute("http.status_code", recorder.statusCode)
+		if recorder.statusCode >= http.StatusInternalServerError {
+			span.RecordError(fmt.Errorf("%d %s", recorder.statusCode, http.StatusText(recorder.statusCode)))
+		}
+	})
+}
+
+// Inject sets the traceparent header for the span in ctx, if any, so that
+// the receiving service can continue the trace.
+func Inject(ctx context.Context, header http.Header) {
+	if sc, ok := SpanContextFromContext(ctx); ok {
+		header.Set(TraceparentHeader, sc.Traceparent())
+	}
+}
+
+func (t *Tracer) enqueue(span SpanData) {
+	select {
+	case t.queue <- span:
+	default:
+		t.Logger.Debug("span-dropped", lager.Data{"name": span.Name})
+	}
+}
+
+func (t *Tracer) Run(signals <-chan os.Signal, ready chan<- struct{}) error {
+	ticker := time.NewTicker(t.FlushInterval)
+	defer ticker.Stop()
+
+	close(ready)
+
+	var batch []SpanData
+	for {
+		select {
+		case span := <-t.queue:
+			batch = append(batch, span)
+			if len(batch) >= maxBatchSize {
+				batch = t.export(batch)
+			}
+		case <-ticker.C:
+			batch = t.export(batch)
+		case <-signals:
+			for {
+				select {
+				case span := <-t.queue:
+					batch = append(batch, span)
+				default:
+					t.export(batch)
+					return nil
+				}
+			}
+		}
+	}
+}
+
+func (t *Tracer) export(batch []SpanData) []SpanData {
+	if len(batch) == 0 {
+		return batch
+	}
+	if err := t.Exporter.ExportSpans(batch); err != nil {
+		t.Logger.Error("export-spans", err, lager.Data{"spans": len(batch)})
+	}
+	return nil
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(statusCode int) {
+	r.statusCode = statusCode
+	r.ResponseWriter.WriteHeader(statusCode)
+}
@@ -0,0 +1,251 @@
+package tracing_test
+
+import (
+	"context"
+	"errors"
+	"lib/tracing"
+	"lib/tracing/fakes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("Tracer", func() {
+	var (
+		exporter *fakes.SpanExporter
+		logger   *lagertest.TestLogger
+		tracer   *tracing.Tracer
+	)
+
+	BeforeEach(func() {
+		exporter = &fakes.SpanExporter{}
+		logger = lagertest.NewTestLogger("test")
+		tracer = tracing.NewTracer(exporter, logger, 10*time.Millisecond, 100)
+	})
+
+	exportedSpans := func() []tracing.SpanData {
+		var spans []tracing.SpanData
+		for i := 0; i < exporter.ExportSpansCallCount(); i++ {
+			spans = append(spans, exporter.ExportSpansArgsForCall(i)...)
+		}
+		return spans
+	}
+
+	Describe("Start", func() {
+		It("starts a new trace when there is no parent", func() {
+			ctx, span := tracer.Start(context.Background(), "some-span")
+
+			Expect(span.SpanContext().IsValid()).To(BeTrue())
+			sc, ok := tracing.SpanContextFromContext(ctx)
+			Expect(ok).To(BeTrue())
+			Expect(sc).To(Equal(span.SpanContext()))
+		})
+
+		It("starts a child of the span in the context", func() {
+			ctx, parent := tracer.Start(context.Background(), "parent")
+			_, child := tracer.StartClient(ctx, "child")
+
+			Expect(child.SpanContext().TraceID).To(Equal(parent.SpanContext().TraceID))
+			Expect(child.SpanContext().SpanID).NotTo(Equal(parent.SpanContext().SpanID))
+		})
+	})
+
+	Describe("Run", func() {
+		var (
+			process ifrit.Process
+			ctx     context.Context
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
+
+		JustBeforeEach(func() {
+			process = ifrit.Invoke(tracer)
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		It("exports finished spans with their parent, attributes and status", func() {
+			ctx, parent := tracer.Start(ctx, "parent")
+			_, child := tracer.StartClient(ctx, "child")
+			child.SetAttribute("some-key", "some-value")
+			child.RecordError(errors.New("banana"))
+			child.End()
+			parent.End()
+
+			Eventually(exportedSpans).Should(HaveLen(2))
+			spans := exportedSpans()
+			Expect(spans[0].Name).To(Equal("child"))
+			Expect(spans[0].Kind).To(Equal(tracing.SpanKindClient))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+			Expect(spans[0].Attributes).To(Equal(map[string]interface{}{"some-key": "some-value"}))
+			Expect(spans[0].Error).To(BeTrue())
+			Expect(spans[0].StatusMessage).To(Equal("banana"))
+			Expect(spans[0].EndTime).NotTo(BeTemporally("<", spans[0].StartTime))
+
+			Expect(spans[1].Name).To(Equal("parent"))
+			Expect(spans[1].Kind).To(Equal(tracing.SpanKindInternal))
+			Expect(spans[1].ParentSpanID).To(Equal(tracing.SpanID{}))
+			Expect(spans[1].Error).To(BeFalse())
+		})
+
+		It("only exports a span once", func() {
+			_, span := tracer.Start(ctx, "some-span")
+			span.End()
+			span.End()
+
+			Eventually(exportedSpans).Should(HaveLen(1))
+			Consistently(exportedSpans, "50ms").Should(HaveLen(1))
+		})
+
+		Context("when signalled before the next flush", func() {
+			BeforeEach(func() {
+				tracer.FlushInterval = time.Hour
+			})
+
+			It("exports the remaining spans", func() {
+				_, span := tracer.Start(ctx, "some-span")
+				span.End()
+
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive(BeNil()))
+				Expect(exportedSpans()).To(HaveLen(1))
+			})
+		})
+
+		Context("when exporting fails", func() {
+			BeforeEach(func() {
+				exporter.ExportSpansReturns(errors.New("banana"))
+			})
+
+			It("logs the error", func() {
+				_, span := tracer.Start(ctx, "some-span")
+				span.End()
+
+				Eventually(logger).Should(gbytes.Say("export-spans.*banana"))
+			})
+		})
+	})
+
+	Describe("Wrap", func() {
+		var (
+			handler     http.Handler
+			innerCtx    context.Context
+			innerStatus int
+			process     ifrit.Process
+		)
+
+		BeforeEach(func() {
+			innerStatus = http.StatusOK
+			handler = tracer.Wrap("SomeRoute", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				innerCtx = req.Context()
+				w.WriteHeader(innerStatus)
+			}))
+			process = ifrit.Invoke(tracer)
+		})
+
+		AfterEach(func() {
+			process.Signal(os.Interrupt)
+			Eventually(process.Wait()).Should(Receive())
+		})
+
+		serve := func(header http.Header) {
+			req, err := http.NewRequest("POST", "/some/path", nil)
+			Expect(err).NotTo(HaveOccurred())
+			for key, values := range header {
+				req.Header[key] = values
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		It("records a server span for the request", func() {
+			serve(nil)
+
+			Eventually(exportedSpans).Should(HaveLen(1))
+			span := exportedSpans()[0]
+			Expect(span.Name).To(Equal("SomeRoute"))
+			Expect(span.Kind).To(Equal(tracing.SpanKindServer))
+			Expect(span.Attributes).To(Equal(map[string]interface{}{
+				"http.method":      "POST",
+				"http.target":      "/some/path",
+				"http.status_code": 200,
+			}))
+
+			sc, ok := tracing.SpanContextFromContext(innerCtx)
+			Expect(ok).To(BeTrue())
+			Expect(sc).To(Equal(span.SpanContext))
+		})
+
+		It("continues the trace from the traceparent header", func() {
+			serve(http.Header{"Traceparent": {"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}})
+
+			Eventually(exportedSpans).Should(HaveLen(1))
+			span := exportedSpans()[0]
+			Expect(span.SpanContext.TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+			Expect(span.ParentSpanID.String()).To(Equal("b7ad6b7169203331"))
+		})
+
+		It("ignores an invalid traceparent header", func() {
+			serve(http.Header{"Traceparent": {"garbage"}})
+
+			Eventually(exportedSpans).Should(HaveLen(1))
+			Expect(exportedSpans()[0].ParentSpanID).To(Equal(tracing.SpanID{}))
+		})
+
+		It("marks server errors as failed", func() {
+			innerStatus = http.StatusInternalServerError
+			serve(nil)
+
+			Eventually(exportedSpans).Should(HaveLen(1))
+			span := exportedSpans()[0]
+			Expect(span.Error).To(BeTrue())
+			Expect(span.Attributes["http.status_code"]).To(Equal(500))
+		})
+	})
+
+	Context("when the tracer is nil", func() {
+		It("does nothing", func() {
+			var nilTracer *tracing.Tracer
+
+			ctx, span := nilTracer.Start(context.Background(), "some-span")
+			span.SetAttribute("some-key", "some-value")
+			span.RecordError(errors.New("banana"))
+			span.End()
+			Expect(span.SpanContext().IsValid()).To(BeFalse())
+			_, ok := tracing.SpanContextFromContext(ctx)
+			Expect(ok).To(BeFalse())
+
+			handler := http.NotFoundHandler()
+			Expect(nilTracer.Wrap("SomeRoute", handler)).To(BeIdenticalTo(handler))
+		})
+	})
+
+	Describe("Inject", func() {
+		It("sets the traceparent header for the span in the context", func() {
+			ctx, span := tracer.Start(context.Background(), "some-span")
+			header := http.Header{}
+			tracing.Inject(ctx, header)
+
+			sc, err := tracing.ParseTraceparent(header.Get("traceparent"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sc).To(Equal(span.SpanContext()))
+		})
+
+		It("does nothing without a span", func() {
+			header := http.Header{}
+			tracing.Inject(context.Background(), header)
+			Expect(header).To(BeEmpty())
+		})
+	})
+})
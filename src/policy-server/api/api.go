@@ -1,6 +1,9 @@
 package api
 
-import "policy-server/store"
+import (
+	"policy-server/store"
+	"time"
+)
 
 var ICMPDefault = -1
 
@@ -35,17 +38,21 @@ type EgressPoliciesPayload struct {
 type Policy struct {
 	Source      Source      `json:"source"`
 	Destination Destination `json:"destination"`
+	ExpiresAt   *time.Time  `json:"expires_at,omitempty"`
 }
 
 type EgressPolicy struct {
 	ID          string             `json:"id,omitempty"`
 	Source      *EgressSource      `json:"source"`
 	Destination *EgressDestination `json:"destination"`
+	Action      string             `json:"action,omitempty"`
+	ExpiresAt   *time.Time         `json:"expires_at,omitempty"`
 }
 
 type EgressSource struct {
 	ID   string `json:"id"`
 	Type string `json:"type,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 type EgressDestination struct {
@@ -90,6 +97,14 @@ type Tag struct {
 	Type string `json:"type"`
 }
 
+type Tombstone struct {
+	ID           int           `json:"id"`
+	Type         string        `json:"type"`
+	DeletedAt    time.Time     `json:"deleted_at"`
+	Policy       *Policy       `json:"policy,omitempty"`
+	EgressPolicy *EgressPolicy `json:"egress_policy,omitempty"`
+}
+
 type Space struct {
 	Name    string `json:"name"`
 	OrgGUID string `json:"organization_guid"`
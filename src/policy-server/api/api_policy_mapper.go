@@ -85,6 +85,7 @@ func (p *Policy) asStorePolicy() store.Policy {
 				End:   p.Destination.Ports.End,
 			},
 		},
+		ExpiresAt: p.ExpiresAt,
 	}
 }
 
@@ -103,6 +104,7 @@ func mapStorePolicy(storePolicy store.Policy) Policy {
 				End:   storePolicy.Destination.Ports.End,
 			},
 		},
+		ExpiresAt: storePolicy.ExpiresAt,
 	}
 }
 
@@ -122,3 +124,29 @@ func MapStoreTags(tags []store.Tag) []Tag {
 	}
 	return apiTags
 }
+
+func MapStoreTombstone(tombstone store.Tombstone) Tombstone {
+	apiTombstone := Tombstone{
+		ID:        tombstone.ID,
+		Type:      tombstone.Type,
+		DeletedAt: tombstone.DeletedAt,
+	}
+	if tombstone.Policy != nil {
+		policy := mapStorePolicy(*tombstone.Policy)
+		apiTombstone.Policy = &policy
+	}
+	if tombstone.EgressPolicy != nil {
+		egressPolicy := withDestinationPointer(*tombstone.EgressPolicy)
+		apiTombstone.EgressPolicy = &egressPolicy
+	}
+	return apiTombstone
+}
+
+func MapStoreTombstones(tombstones []store.Tombstone) []Tombstone {
+	apiTombstones := []Tombstone{}
+
+	for _, tombstone := range tombstones {
+		apiTombstones = append(apiTombstones, MapStoreTombstone(tombstone))
+	}
+	return apiTombstones
+}
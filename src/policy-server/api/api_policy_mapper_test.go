@@ -5,6 +5,7 @@ import (
 	"errors"
 	"policy-server/api"
 	"policy-server/store"
+	"time"
 
 	"policy-server/api/fakes"
 
@@ -235,6 +236,44 @@ var _ = Describe("ApiPolicyMapper", func() {
 				}`)))
 			})
 		})
+		Context("when the policy has an expiry", func() {
+			It("includes the expires_at field", func() {
+				expiresAt := time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)
+				payload, err := mapper.AsBytes([]store.Policy{
+					{
+						Source: store.Source{ID: "some-src-id"},
+						Destination: store.Destination{
+							ID:       "some-dst-id",
+							Protocol: "some-protocol",
+							Ports: store.Ports{
+								Start: 8080,
+								End:   8080,
+							},
+						},
+						ExpiresAt: &expiresAt,
+					},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(payload).To(MatchJSON([]byte(`{
+					"total_policies": 1,
+					"policies": [
+						{
+							"source": { "id": "some-src-id" },
+							"destination": {
+								"id": "some-dst-id",
+								"protocol": "some-protocol",
+								"ports": {
+									"start": 8080,
+									"end": 8080
+								}
+							},
+							"expires_at": "2030-01-01T12:00:00Z"
+						}
+					]
+				}`)))
+			})
+		})
+
 		Context("when marshalling fails", func() {
 			BeforeEach(func() {
 				fakeMarshaler.MarshalReturns(nil, errors.New("banana"))
@@ -324,4 +363,49 @@ var _ = Describe("ApiPolicyMapper", func() {
 			),
 		)
 	})
+
+	Describe("MapStoreTombstones", func() {
+		It("maps c2c and egress tombstones", func() {
+			deletedAt := time.Now()
+			result := api.MapStoreTombstones([]store.Tombstone{{
+				ID:   1,
+				Type: "c2c",
+				Policy: &store.Policy{
+					Source:      store.Source{ID: "some-src-id"},
+					Destination: store.Destination{ID: "some-dst-id", Protocol: "tcp", Ports: store.Ports{Start: 8080, End: 8081}},
+				},
+				DeletedAt: deletedAt,
+			}, {
+				ID:   2,
+				Type: "egress",
+				EgressPolicy: &store.EgressPolicy{
+					ID:          "some-egress-id",
+					Source:      store.EgressSource{ID: "some-app-id", Type: "app"},
+					Destination: store.EgressDestination{GUID: "some-destination-guid"},
+					Action:      "allow",
+				},
+				DeletedAt: deletedAt,
+			}})
+
+			Expect(result).To(Equal([]api.Tombstone{{
+				ID:   1,
+				Type: "c2c",
+				Policy: &api.Policy{
+					Source:      api.Source{ID: "some-src-id"},
+					Destination: api.Destination{ID: "some-dst-id", Protocol: "tcp", Ports: api.Ports{Start: 8080, End: 8081}},
+				},
+				DeletedAt: deletedAt,
+			}, {
+				ID:   2,
+				Type: "egress",
+				EgressPolicy: &api.EgressPolicy{
+					ID:          "some-egress-id",
+					Source:      &api.EgressSource{ID: "some-app-id", Type: "app"},
+					Destination: &api.EgressDestination{GUID: "some-destination-guid"},
+					Action:      "allow",
+				},
+				DeletedAt: deletedAt,
+			}}))
+		})
+	})
 })
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
 
@@ -15,7 +16,7 @@ type EgressPolicyMapper struct {
 
 //go:generate counterfeiter -o fakes/egress_validator.go --fake-name EgressValidator . egressValidator
 type egressValidator interface {
-	ValidateEgressPolicies([]EgressPolicy) error
+	ValidateEgressPolicies(context.Context, []EgressPolicy) error
 }
 
 type payload struct {
@@ -32,6 +33,8 @@ func withPopulatedDestinations(storeEgressPolicy store.EgressPolicy) EgressPolic
 			ID:   storeEgressPolicy.Source.ID,
 			Type: storeEgressPolicy.Source.Type,
 		},
+		Action:    storeEgressPolicy.Action,
+		ExpiresAt: storeEgressPolicy.ExpiresAt,
 	}
 }
 
@@ -45,6 +48,8 @@ func withDestinationPointer(storeEgressPolicy store.EgressPolicy) EgressPolicy {
 			ID:   storeEgressPolicy.Source.ID,
 			Type: storeEgressPolicy.Source.Type,
 		},
+		Action:    storeEgressPolicy.Action,
+		ExpiresAt: storeEgressPolicy.ExpiresAt,
 	}
 }
 
@@ -75,14 +80,14 @@ func (p *EgressPolicyMapper) AsBytes(storeEgressPolicies []store.EgressPolicy) (
 	return p.AsBytesWithStrategy(storeEgressPolicies, withDestinationPointer)
 }
 
-func (p *EgressPolicyMapper) AsStoreEgressPolicy(bytes []byte) ([]store.EgressPolicy, error) {
+func (p *EgressPolicyMapper) AsStoreEgressPolicy(ctx context.Context, bytes []byte) ([]store.EgressPolicy, error) {
 	payload := &EgressPoliciesPayload{}
 	err := p.Unmarshaler.Unmarshal(bytes, payload)
 	if err != nil {
 		return []store.EgressPolicy{}, fmt.Errorf("unmarshal json: %s", err)
 	}
 
-	err = p.Validator.ValidateEgressPolicies(payload.EgressPolicies)
+	err = p.Validator.ValidateEgressPolicies(ctx, payload.EgressPolicies)
 	if err != nil {
 		return []store.EgressPolicy{}, fmt.Errorf("validating egress policies: %s", err)
 	}
@@ -104,5 +109,7 @@ func asStoreEgressPolicy(apiEgressPolicy EgressPolicy) store.EgressPolicy {
 			ID:   apiEgressPolicy.Source.ID,
 			Type: apiEgressPolicy.Source.Type,
 		},
+		Action:    apiEgressPolicy.Action,
+		ExpiresAt: apiEgressPolicy.ExpiresAt,
 	}
 }
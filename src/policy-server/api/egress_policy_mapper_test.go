@@ -1,6 +1,7 @@
 package api_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"policy-server/api"
@@ -40,12 +41,13 @@ var _ = Describe("EgressPolicyMapper", func() {
 					},
                     {
 						"source": { "id": "some-src-id-2", "type": "space"  },
-						"destination": { "id": "some-dst-id-2" }
+						"destination": { "id": "some-dst-id-2" },
+						"action": "deny"
 					}
 				]
 			}`)
 
-			policies, err := mapper.AsStoreEgressPolicy(payloadBytes)
+			policies, err := mapper.AsStoreEgressPolicy(context.Background(), payloadBytes)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(policies).To(HaveLen(2))
 			Expect(policies[0].Source.ID).To(Equal("some-src-id"))
@@ -54,11 +56,13 @@ var _ = Describe("EgressPolicyMapper", func() {
 			Expect(policies[1].Source.ID).To(Equal("some-src-id-2"))
 			Expect(policies[1].Source.Type).To(Equal("space"))
 			Expect(policies[1].Destination.GUID).To(Equal("some-dst-id-2"))
+			Expect(policies[0].Action).To(Equal(""))
+			Expect(policies[1].Action).To(Equal("deny"))
 		})
 
 		Context("when unmarshalling fails", func() {
 			It("wraps and returns an error", func() {
-				_, err := mapper.AsStoreEgressPolicy([]byte("garbage"))
+				_, err := mapper.AsStoreEgressPolicy(context.Background(), []byte("garbage"))
 				Expect(err).To(MatchError(errors.New("unmarshal json: invalid character 'g' looking for beginning of value")))
 			})
 		})
@@ -76,7 +80,7 @@ var _ = Describe("EgressPolicyMapper", func() {
 						}
 					]
 				}`)
-				_, err := mapper.AsStoreEgressPolicy(payloadBytes)
+				_, err := mapper.AsStoreEgressPolicy(context.Background(), payloadBytes)
 
 				Expect(err).To(MatchError(errors.New("validating egress policies: does not validate")))
 			})
@@ -1,11 +1,13 @@
 package api
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"policy-server/store"
 	"sort"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/httperror"
 )
@@ -17,13 +19,14 @@ type EgressDestinationStore interface {
 
 //go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
 type ccClient interface {
-	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
-	GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error)
+	GetLiveSpaceGUIDs(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveOrgGUIDs(ctx context.Context, token string, orgGUIDs []string) (map[string]struct{}, error)
 }
 
 //go:generate counterfeiter -o fakes/uua_client.go --fake-name UAAClient . uaaClient
 type uaaClient interface {
-	GetToken() (string, error)
+	GetToken(context.Context) (string, error)
 }
 
 type EgressValidator struct {
@@ -35,16 +38,20 @@ type EgressValidator struct {
 func DestinationKeyFunc(policy EgressPolicy) string { return policy.Destination.GUID }
 func SourceKeyFunc(policy EgressPolicy) string { return policy.Source.ID }
 
-func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error {
+func (v *EgressValidator) ValidateEgressPolicies(ctx context.Context, policies []EgressPolicy) error {
 	for _, policy := range policies {
 		if policy.Source == nil {
 			return policyMetadataError("missing egress source", policy)
 		}
-		if policy.Source.ID == "" {
+		if policy.Source.Type == "default" {
+			if policy.Source.ID != "" {
+				return policyMetadataError("default egress source must not have an ID", policy)
+			}
+		} else if policy.Source.ID == "" {
 			return policyMetadataError("missing egress source ID", policy)
 		}
-		if policy.Source.Type != "" && policy.Source.Type != "app" && policy.Source.Type != "space" {
-			return policyMetadataError("source type must be app or space", policy)
+		if policy.Source.Type != "" && policy.Source.Type != "app" && policy.Source.Type != "space" && policy.Source.Type != "org" && policy.Source.Type != "default" {
+			return policyMetadataError("source type must be app, space, org or default", policy)
 		}
 		if policy.Destination == nil {
 			return policyMetadataError("missing egress destination", policy)
@@ -52,9 +59,15 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 		if policy.Destination.GUID == "" {
 			return policyMetadataError("missing egress destination id", policy)
 		}
+		if policy.Action != "" && policy.Action != "allow" && policy.Action != "deny" {
+			return policyMetadataError("action must be allow or deny", policy)
+		}
+		if policy.ExpiresAt != nil && !policy.ExpiresAt.After(time.Now()) {
+			return policyMetadataError("expires_at must be in the future", policy)
+		}
 	}
 
-	token, err := v.UAAClient.GetToken()
+	token, err := v.UAAClient.GetToken(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get uaa token: %s", err)
 	}
@@ -62,7 +75,7 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 	appGUIDSet := sourceAppGUIDs(policies)
 
 	if len(appGUIDSet) > 0 {
-		liveAppGUIDs, err := v.CCClient.GetLiveAppGUIDs(token, keys(appGUIDSet))
+		liveAppGUIDs, err := v.CCClient.GetLiveAppGUIDs(ctx, token, keys(appGUIDSet))
 		if err != nil {
 			return fmt.Errorf("failed to get live app guids: %s", err)
 		}
@@ -77,7 +90,7 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 	spaceGUIDSet := sourceSpaceGUIDs(policies)
 
 	if len(spaceGUIDSet) > 0 {
-		liveSpaceGUIDs, err := v.CCClient.GetLiveSpaceGUIDs(token, keys(spaceGUIDSet))
+		liveSpaceGUIDs, err := v.CCClient.GetLiveSpaceGUIDs(ctx, token, keys(spaceGUIDSet))
 		if err != nil {
 			return fmt.Errorf("failed to get live space guids: %s", err)
 		}
@@ -89,6 +102,21 @@ func (v *EgressValidator) ValidateEgressPolicies(policies []EgressPolicy) error
 		}
 	}
 
+	orgGUIDSet := sourceOrgGUIDs(policies)
+
+	if len(orgGUIDSet) > 0 {
+		liveOrgGUIDs, err := v.CCClient.GetLiveOrgGUIDs(ctx, token, keys(orgGUIDSet))
+		if err != nil {
+			return fmt.Errorf("failed to get live org guids: %s", err)
+		}
+
+		missingOrgGUIDs := relativeComplement(orgGUIDSet, liveOrgGUIDs)
+
+		if len(missingOrgGUIDs) > 0 {
+			return composeMetadataError("org", missingOrgGUIDs, SourceKeyFunc, policies)
+		}
+	}
+
 	destinationGUIDSet := destinationGUIDs(policies)
 	destinations, err := v.DestinationStore.GetByGUID(keys(destinationGUIDSet)...)
 	if err != nil {
@@ -163,6 +191,16 @@ func sourceSpaceGUIDs(policies []EgressPolicy) map[string]struct{} {
 	return guidSet
 }
 
+func sourceOrgGUIDs(policies []EgressPolicy) map[string]struct{} {
+	guidSet := make(map[string]struct{})
+	for _, policy := range policies {
+		if policy.Source.Type == "org" {
+			guidSet[policy.Source.ID] = struct{}{}
+		}
+	}
+	return guidSet
+}
+
 func keys(set map[string]struct{}) []string {
 	var keys []string
 	for key, _ := range set {
@@ -1,10 +1,12 @@
 package api_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/api"
 	"policy-server/api/fakes"
 	"policy-server/store"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/httperror"
 	. "github.com/onsi/ginkgo"
@@ -38,6 +40,10 @@ var _ = Describe("Egress PolicyValidator", func() {
 			"source-space-id": {},
 		}, nil)
 
+		ccClient.GetLiveOrgGUIDsReturns(map[string]struct{}{
+			"source-org-id": {},
+		}, nil)
+
 		uaaClient.GetTokenReturns("valid-token", nil)
 
 		egressPolicies = []api.EgressPolicy{
@@ -56,13 +62,13 @@ var _ = Describe("Egress PolicyValidator", func() {
 
 	Describe("ValidateEgressPolicies", func() {
 		It("should not return an error when given a valid egress policy", func() {
-			Expect(validator.ValidateEgressPolicies(egressPolicies)).To(Succeed())
+			Expect(validator.ValidateEgressPolicies(context.Background(), egressPolicies)).To(Succeed())
 		})
 
 		It("requires a source", func() {
 			egressPolicies[0].Source = nil
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("missing egress source")))
 		})
 
@@ -95,7 +101,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 				},
 			}
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("app guids not found: [non-existent, non-existent-2]")))
 
 			egressPolicyError, ok := err.(httperror.MetadataError)
@@ -106,7 +112,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 
 			Expect(uaaClient.GetTokenCallCount()).To(Equal(1))
 
-			passedToken, passedAppGUIDs := ccClient.GetLiveAppGUIDsArgsForCall(0)
+			_, passedToken, passedAppGUIDs := ccClient.GetLiveAppGUIDsArgsForCall(0)
 			Expect(passedToken).To(Equal("valid-token"))
 			Expect(passedAppGUIDs).To(ConsistOf("source-app-id", "non-existent", "non-existent-2"))
 
@@ -145,7 +151,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 
 			destinationStore.GetByGUIDReturns([]store.EgressDestination{{GUID: "existing-guid"}}, nil)
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("destination guids not found: [non-existent-2, non-existent-guid]")))
 
 			egressPolicyError, ok := err.(httperror.MetadataError)
@@ -159,7 +165,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 
 		It("returns an error if it can't query live app guids", func() {
 			ccClient.GetLiveAppGUIDsReturns(nil, errors.New("foxtrot"))
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("failed to get live app guids: foxtrot")))
 		})
 
@@ -194,7 +200,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 				},
 			}
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("space guids not found: [non-existent-space, non-existent-space-2]")))
 			egressPolicyError, ok := err.(httperror.MetadataError)
 			Expect(ok).To(BeTrue(), "expected error to be of type MetadataError")
@@ -205,7 +211,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 
 			Expect(uaaClient.GetTokenCallCount()).To(Equal(1))
 
-			passedToken, passedSpaceGUIDs := ccClient.GetLiveSpaceGUIDsArgsForCall(0)
+			_, passedToken, passedSpaceGUIDs := ccClient.GetLiveSpaceGUIDsArgsForCall(0)
 			Expect(passedToken).To(Equal("valid-token"))
 			Expect(passedSpaceGUIDs).To(ConsistOf("source-space-id", "non-existent-space", "non-existent-space-2"))
 
@@ -216,48 +222,136 @@ var _ = Describe("Egress PolicyValidator", func() {
 			egressPolicies[0].Source.Type = "space"
 
 			ccClient.GetLiveSpaceGUIDsReturns(nil, errors.New("india"))
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("failed to get live space guids: india")))
 		})
 
+		It("requires the source org to exist", func() {
+			egressPolicies = []api.EgressPolicy{
+				{
+					Source: &api.EgressSource{
+						ID:   "source-org-id",
+						Type: "org",
+					},
+					Destination: &api.EgressDestination{
+						GUID: "abc123",
+					},
+				},
+				{
+					Source: &api.EgressSource{
+						ID:   "non-existent-org",
+						Type: "org",
+					},
+					Destination: &api.EgressDestination{
+						GUID: "def456",
+					},
+				},
+			}
+
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("org guids not found: [non-existent-org]")))
+			egressPolicyError, ok := err.(httperror.MetadataError)
+			Expect(ok).To(BeTrue(), "expected error to be of type MetadataError")
+			Expect(egressPolicyError.Metadata()).To(Equal(map[string]interface{}{
+				"policies with missing orgs": egressPolicies[1:],
+			}))
+
+			_, passedToken, passedOrgGUIDs := ccClient.GetLiveOrgGUIDsArgsForCall(0)
+			Expect(passedToken).To(Equal("valid-token"))
+			Expect(passedOrgGUIDs).To(ConsistOf("source-org-id", "non-existent-org"))
+
+			Expect(ccClient.GetLiveAppGUIDsCallCount()).To(Equal(0))
+			Expect(ccClient.GetLiveSpaceGUIDsCallCount()).To(Equal(0))
+		})
+
+		It("returns an error if it can't query live org guids", func() {
+			egressPolicies[0].Source.Type = "org"
+
+			ccClient.GetLiveOrgGUIDsReturns(nil, errors.New("juliet"))
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("failed to get live org guids: juliet")))
+		})
+
 		It("returns an error if it can't query  destination guids", func() {
 			destinationStore.GetByGUIDReturns(nil, errors.New("can't get destinations"))
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("failed to get egress destinations: can't get destinations")))
 		})
 
 		It("returns an error when it is unable to obtain a token", func() {
 			uaaClient.GetTokenReturns("", errors.New("kilo"))
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("failed to get uaa token: kilo")))
 		})
 
-		It("type must be app, space or empty", func() {
+		It("type must be app, space, org or empty", func() {
 			egressPolicies[0].Source.Type = "invalid"
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
-			Expect(err).To(MatchError(ContainSubstring("source type must be app or space")))
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("source type must be app, space, org or default")))
 
-			for _, validType := range []string{"app", "space", ""} {
+			for _, validType := range []string{"app", "space", "org", ""} {
 				egressPolicies[0].Source.Type = validType
 				egressPolicies[0].Source.ID = "source-" + validType + "-id"
-				err := validator.ValidateEgressPolicies(egressPolicies)
+				err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 				Expect(err).NotTo(HaveOccurred())
 			}
 		})
 
+		It("allows a default source without an ID", func() {
+			egressPolicies[0].Source = &api.EgressSource{Type: "default"}
+
+			Expect(validator.ValidateEgressPolicies(context.Background(), egressPolicies)).To(Succeed())
+			Expect(ccClient.GetLiveAppGUIDsCallCount()).To(Equal(0))
+			Expect(ccClient.GetLiveSpaceGUIDsCallCount()).To(Equal(0))
+			Expect(ccClient.GetLiveOrgGUIDsCallCount()).To(Equal(0))
+		})
+
+		It("rejects a default source with an ID", func() {
+			egressPolicies[0].Source = &api.EgressSource{Type: "default", ID: "some-app-guid"}
+
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("default egress source must not have an ID")))
+		})
+
+		It("allows allow and deny actions", func() {
+			egressPolicies[0].Action = "allow"
+			Expect(validator.ValidateEgressPolicies(context.Background(), egressPolicies)).To(Succeed())
+
+			egressPolicies[0].Action = "deny"
+			Expect(validator.ValidateEgressPolicies(context.Background(), egressPolicies)).To(Succeed())
+		})
+
+		It("rejects an unknown action", func() {
+			egressPolicies[0].Action = "reject"
+
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("action must be allow or deny")))
+		})
+
+		It("requires expires_at to be in the future", func() {
+			expiresAt := time.Now().Add(-time.Minute)
+			egressPolicies[0].ExpiresAt = &expiresAt
+
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
+			Expect(err).To(MatchError(ContainSubstring("expires_at must be in the future")))
+
+			expiresAt = time.Now().Add(time.Hour)
+			Expect(validator.ValidateEgressPolicies(context.Background(), egressPolicies)).To(Succeed())
+		})
+
 		It("requires a source guid", func() {
 			egressPolicies[0].Source.ID = ""
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("missing egress source ID")))
 		})
 
 		It("requires a destination", func() {
 			egressPolicies[0].Destination = nil
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			Expect(err).To(MatchError(ContainSubstring("missing egress destination")))
 		})
 
@@ -271,7 +365,7 @@ var _ = Describe("Egress PolicyValidator", func() {
 				},
 			}
 
-			err := validator.ValidateEgressPolicies(egressPolicies)
+			err := validator.ValidateEgressPolicies(context.Background(), egressPolicies)
 			egressPolicyError := err.(httperror.MetadataError)
 			Expect(egressPolicyError.Metadata()).To(Equal(map[string]interface{}{"bad_egress_policy": egressPolicies[0]}))
 		})
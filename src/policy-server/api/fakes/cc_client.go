@@ -2,13 +2,15 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 )
 
 type CCClient struct {
-	GetLiveAppGUIDsStub        func(token string, appGUIDs []string) (map[string]struct{}, error)
+	GetLiveAppGUIDsStub        func(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error)
 	getLiveAppGUIDsMutex       sync.RWMutex
 	getLiveAppGUIDsArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
 	}
@@ -20,9 +22,10 @@ type CCClient struct {
 		result1 map[string]struct{}
 		result2 error
 	}
-	GetLiveSpaceGUIDsStub        func(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveSpaceGUIDsStub        func(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error)
 	getLiveSpaceGUIDsMutex       sync.RWMutex
 	getLiveSpaceGUIDsArgsForCall []struct {
+		ctx        context.Context
 		token      string
 		spaceGUIDs []string
 	}
@@ -34,11 +37,26 @@ type CCClient struct {
 		result1 map[string]struct{}
 		result2 error
 	}
+	GetLiveOrgGUIDsStub        func(ctx context.Context, token string, orgGUIDs []string) (map[string]struct{}, error)
+	getLiveOrgGUIDsMutex       sync.RWMutex
+	getLiveOrgGUIDsArgsForCall []struct {
+		ctx      context.Context
+		token    string
+		orgGUIDs []string
+	}
+	getLiveOrgGUIDsReturns struct {
+		result1 map[string]struct{}
+		result2 error
+	}
+	getLiveOrgGUIDsReturnsOnCall map[int]struct {
+		result1 map[string]struct{}
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CCClient) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
+func (fake *CCClient) GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
 	var appGUIDsCopy []string
 	if appGUIDs != nil {
 		appGUIDsCopy = make([]string, len(appGUIDs))
@@ -47,13 +65,14 @@ func (fake *CCClient) GetLiveAppGUIDs(token string, appGUIDs []string) (map[stri
 	fake.getLiveAppGUIDsMutex.Lock()
 	ret, specificReturn := fake.getLiveAppGUIDsReturnsOnCall[len(fake.getLiveAppGUIDsArgsForCall)]
 	fake.getLiveAppGUIDsArgsForCall = append(fake.getLiveAppGUIDsArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetLiveAppGUIDs", []interface{}{token, appGUIDsCopy})
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetLiveAppGUIDs", []interface{}{ctx, token, appGUIDsCopy})
 	fake.getLiveAppGUIDsMutex.Unlock()
 	if fake.GetLiveAppGUIDsStub != nil {
-		return fake.GetLiveAppGUIDsStub(token, appGUIDs)
+		return fake.GetLiveAppGUIDsStub(ctx, token, appGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -67,10 +86,10 @@ func (fake *CCClient) GetLiveAppGUIDsCallCount() int {
 	return len(fake.getLiveAppGUIDsArgsForCall)
 }
 
-func (fake *CCClient) GetLiveAppGUIDsArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetLiveAppGUIDsArgsForCall(i int) (context.Context, string, []string) {
 	fake.getLiveAppGUIDsMutex.RLock()
 	defer fake.getLiveAppGUIDsMutex.RUnlock()
-	return fake.getLiveAppGUIDsArgsForCall[i].token, fake.getLiveAppGUIDsArgsForCall[i].appGUIDs
+	return fake.getLiveAppGUIDsArgsForCall[i].ctx, fake.getLiveAppGUIDsArgsForCall[i].token, fake.getLiveAppGUIDsArgsForCall[i].appGUIDs
 }
 
 func (fake *CCClient) GetLiveAppGUIDsReturns(result1 map[string]struct{}, result2 error) {
@@ -95,7 +114,7 @@ func (fake *CCClient) GetLiveAppGUIDsReturnsOnCall(i int, result1 map[string]str
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error) {
+func (fake *CCClient) GetLiveSpaceGUIDs(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error) {
 	var spaceGUIDsCopy []string
 	if spaceGUIDs != nil {
 		spaceGUIDsCopy = make([]string, len(spaceGUIDs))
@@ -104,13 +123,14 @@ func (fake *CCClient) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[
 	fake.getLiveSpaceGUIDsMutex.Lock()
 	ret, specificReturn := fake.getLiveSpaceGUIDsReturnsOnCall[len(fake.getLiveSpaceGUIDsArgsForCall)]
 	fake.getLiveSpaceGUIDsArgsForCall = append(fake.getLiveSpaceGUIDsArgsForCall, struct {
+		ctx        context.Context
 		token      string
 		spaceGUIDs []string
-	}{token, spaceGUIDsCopy})
-	fake.recordInvocation("GetLiveSpaceGUIDs", []interface{}{token, spaceGUIDsCopy})
+	}{ctx, token, spaceGUIDsCopy})
+	fake.recordInvocation("GetLiveSpaceGUIDs", []interface{}{ctx, token, spaceGUIDsCopy})
 	fake.getLiveSpaceGUIDsMutex.Unlock()
 	if fake.GetLiveSpaceGUIDsStub != nil {
-		return fake.GetLiveSpaceGUIDsStub(token, spaceGUIDs)
+		return fake.GetLiveSpaceGUIDsStub(ctx, token, spaceGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -124,10 +144,10 @@ func (fake *CCClient) GetLiveSpaceGUIDsCallCount() int {
 	return len(fake.getLiveSpaceGUIDsArgsForCall)
 }
 
-func (fake *CCClient) GetLiveSpaceGUIDsArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetLiveSpaceGUIDsArgsForCall(i int) (context.Context, string, []string) {
 	fake.getLiveSpaceGUIDsMutex.RLock()
 	defer fake.getLiveSpaceGUIDsMutex.RUnlock()
-	return fake.getLiveSpaceGUIDsArgsForCall[i].token, fake.getLiveSpaceGUIDsArgsForCall[i].spaceGUIDs
+	return fake.getLiveSpaceGUIDsArgsForCall[i].ctx, fake.getLiveSpaceGUIDsArgsForCall[i].token, fake.getLiveSpaceGUIDsArgsForCall[i].spaceGUIDs
 }
 
 func (fake *CCClient) GetLiveSpaceGUIDsReturns(result1 map[string]struct{}, result2 error) {
@@ -152,6 +172,64 @@ func (fake *CCClient) GetLiveSpaceGUIDsReturnsOnCall(i int, result1 map[string]s
 	}{result1, result2}
 }
 
+func (fake *CCClient) GetLiveOrgGUIDs(ctx context.Context, token string, orgGUIDs []string) (map[string]struct{}, error) {
+	var orgGUIDsCopy []string
+	if orgGUIDs != nil {
+		orgGUIDsCopy = make([]string, len(orgGUIDs))
+		copy(orgGUIDsCopy, orgGUIDs)
+	}
+	fake.getLiveOrgGUIDsMutex.Lock()
+	ret, specificReturn := fake.getLiveOrgGUIDsReturnsOnCall[len(fake.getLiveOrgGUIDsArgsForCall)]
+	fake.getLiveOrgGUIDsArgsForCall = append(fake.getLiveOrgGUIDsArgsForCall, struct {
+		ctx      context.Context
+		token    string
+		orgGUIDs []string
+	}{ctx, token, orgGUIDsCopy})
+	fake.recordInvocation("GetLiveOrgGUIDs", []interface{}{ctx, token, orgGUIDsCopy})
+	fake.getLiveOrgGUIDsMutex.Unlock()
+	if fake.GetLiveOrgGUIDsStub != nil {
+		return fake.GetLiveOrgGUIDsStub(ctx, token, orgGUIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getLiveOrgGUIDsReturns.result1, fake.getLiveOrgGUIDsReturns.result2
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsCallCount() int {
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
+	return len(fake.getLiveOrgGUIDsArgsForCall)
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsArgsForCall(i int) (context.Context, string, []string) {
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
+	return fake.getLiveOrgGUIDsArgsForCall[i].ctx, fake.getLiveOrgGUIDsArgsForCall[i].token, fake.getLiveOrgGUIDsArgsForCall[i].orgGUIDs
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsReturns(result1 map[string]struct{}, result2 error) {
+	fake.GetLiveOrgGUIDsStub = nil
+	fake.getLiveOrgGUIDsReturns = struct {
+		result1 map[string]struct{}
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetLiveOrgGUIDsReturnsOnCall(i int, result1 map[string]struct{}, result2 error) {
+	fake.GetLiveOrgGUIDsStub = nil
+	if fake.getLiveOrgGUIDsReturnsOnCall == nil {
+		fake.getLiveOrgGUIDsReturnsOnCall = make(map[int]struct {
+			result1 map[string]struct{}
+			result2 error
+		})
+	}
+	fake.getLiveOrgGUIDsReturnsOnCall[i] = struct {
+		result1 map[string]struct{}
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CCClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -159,6 +237,8 @@ func (fake *CCClient) Invocations() map[string][][]interface{} {
 	defer fake.getLiveAppGUIDsMutex.RUnlock()
 	fake.getLiveSpaceGUIDsMutex.RLock()
 	defer fake.getLiveSpaceGUIDsMutex.RUnlock()
+	fake.getLiveOrgGUIDsMutex.RLock()
+	defer fake.getLiveOrgGUIDsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/api"
 	"sync"
 )
 
 type EgressValidator struct {
-	ValidateEgressPoliciesStub        func([]api.EgressPolicy) error
+	ValidateEgressPoliciesStub        func(context.Context, []api.EgressPolicy) error
 	validateEgressPoliciesMutex       sync.RWMutex
 	validateEgressPoliciesArgsForCall []struct {
-		arg1 []api.EgressPolicy
+		arg1 context.Context
+		arg2 []api.EgressPolicy
 	}
 	validateEgressPoliciesReturns struct {
 		result1 error
@@ -22,21 +24,22 @@ type EgressValidator struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressValidator) ValidateEgressPolicies(arg1 []api.EgressPolicy) error {
-	var arg1Copy []api.EgressPolicy
-	if arg1 != nil {
-		arg1Copy = make([]api.EgressPolicy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *EgressValidator) ValidateEgressPolicies(arg1 context.Context, arg2 []api.EgressPolicy) error {
+	var arg2Copy []api.EgressPolicy
+	if arg2 != nil {
+		arg2Copy = make([]api.EgressPolicy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.validateEgressPoliciesMutex.Lock()
 	ret, specificReturn := fake.validateEgressPoliciesReturnsOnCall[len(fake.validateEgressPoliciesArgsForCall)]
 	fake.validateEgressPoliciesArgsForCall = append(fake.validateEgressPoliciesArgsForCall, struct {
-		arg1 []api.EgressPolicy
-	}{arg1Copy})
-	fake.recordInvocation("ValidateEgressPolicies", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []api.EgressPolicy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("ValidateEgressPolicies", []interface{}{arg1, arg2Copy})
 	fake.validateEgressPoliciesMutex.Unlock()
 	if fake.ValidateEgressPoliciesStub != nil {
-		return fake.ValidateEgressPoliciesStub(arg1)
+		return fake.ValidateEgressPoliciesStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -50,10 +53,10 @@ func (fake *EgressValidator) ValidateEgressPoliciesCallCount() int {
 	return len(fake.validateEgressPoliciesArgsForCall)
 }
 
-func (fake *EgressValidator) ValidateEgressPoliciesArgsForCall(i int) []api.EgressPolicy {
+func (fake *EgressValidator) ValidateEgressPoliciesArgsForCall(i int) (context.Context, []api.EgressPolicy) {
 	fake.validateEgressPoliciesMutex.RLock()
 	defer fake.validateEgressPoliciesMutex.RUnlock()
-	return fake.validateEgressPoliciesArgsForCall[i].arg1
+	return fake.validateEgressPoliciesArgsForCall[i].arg1, fake.validateEgressPoliciesArgsForCall[i].arg2
 }
 
 func (fake *EgressValidator) ValidateEgressPoliciesReturns(result1 error) {
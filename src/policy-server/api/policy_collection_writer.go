@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"policy-server/store"
+	"sort"
 
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 )
@@ -34,6 +35,7 @@ func (p *policyCollectionWriter) AsBytes(policies []store.Policy, egressPolicies
 	for _, egressPolicy := range egressPolicies {
 		apiEgressPolicies = append(apiEgressPolicies, mapStoreEgressPolicy(egressPolicy))
 	}
+	sort.Stable(byEgressPrecedence(apiEgressPolicies))
 
 	policyCollection := PolicyCollectionPayload{
 		TotalPolicies:       len(policies),
@@ -57,5 +59,32 @@ func mapStoreEgressPolicy(storeEgressPolicy store.EgressPolicy) EgressPolicy {
 			Type: storeEgressPolicy.Source.Type,
 		},
 		Destination: &destination,
+		Action:      storeEgressPolicy.Action,
+		ExpiresAt:   storeEgressPolicy.ExpiresAt,
 	}
 }
+
+var egressSourcePrecedence = map[string]int{
+	"":        0,
+	"app":     0,
+	"space":   1,
+	"org":     2,
+	"default": 3,
+}
+
+// byEgressPrecedence orders egress policies so that the first policy matching
+// a packet decides it: every deny comes before every allow, so an allow can
+// never override a deny, and within each action policies with a more specific
+// source (app, then space, org and default) come first.
+type byEgressPrecedence []EgressPolicy
+
+func (p byEgressPrecedence) Len() int      { return len(p) }
+func (p byEgressPrecedence) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p byEgressPrecedence) Less(i, j int) bool {
+	iDeny := p[i].Action == "deny"
+	jDeny := p[j].Action == "deny"
+	if iDeny != jDeny {
+		return iDeny
+	}
+	return egressSourcePrecedence[p[i].Source.Type] < egressSourcePrecedence[p[j].Source.Type]
+}
@@ -99,6 +99,54 @@ var _ = Describe("PolicyCollectionWriter", func() {
 			))
 		})
 
+		It("orders egress policies by precedence", func() {
+			egressPolicies := []store.EgressPolicy{
+				{ID: "default-allow", Source: store.EgressSource{Type: "default"}, Action: "allow"},
+				{ID: "space-allow", Source: store.EgressSource{ID: "some-space-guid", Type: "space"}, Action: "allow"},
+				{ID: "space-deny", Source: store.EgressSource{ID: "some-space-guid", Type: "space"}, Action: "deny"},
+				{ID: "org-deny", Source: store.EgressSource{ID: "some-org-guid", Type: "org"}, Action: "deny"},
+				{ID: "app-allow", Source: store.EgressSource{ID: "some-app-guid", Type: "app"}, Action: "allow"},
+				{ID: "app-deny", Source: store.EgressSource{ID: "some-app-guid", Type: "app"}, Action: "deny"},
+			}
+
+			payload, err := writer.AsBytes([]store.Policy{}, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			var collection api.PolicyCollectionPayload
+			Expect(json.Unmarshal(payload, &collection)).To(Succeed())
+
+			var order []string
+			for _, egressPolicy := range collection.EgressPolicies {
+				order = append(order, egressPolicy.Source.Type+"-"+egressPolicy.Action)
+			}
+			Expect(order).To(Equal([]string{
+				"app-deny",
+				"space-deny",
+				"org-deny",
+				"app-allow",
+				"space-allow",
+				"default-allow",
+			}))
+		})
+
+		It("puts an org deny ahead of an app allow", func() {
+			egressPolicies := []store.EgressPolicy{
+				{ID: "app-allow", Source: store.EgressSource{ID: "some-app-guid", Type: "app"}, Action: "allow"},
+				{ID: "org-deny", Source: store.EgressSource{ID: "some-org-guid", Type: "org"}, Action: "deny"},
+			}
+
+			payload, err := writer.AsBytes([]store.Policy{}, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			var collection api.PolicyCollectionPayload
+			Expect(json.Unmarshal(payload, &collection)).To(Succeed())
+			Expect(collection.EgressPolicies).To(HaveLen(2))
+			Expect(collection.EgressPolicies[0].Source.Type).To(Equal("org"))
+			Expect(collection.EgressPolicies[0].Action).To(Equal("deny"))
+			Expect(collection.EgressPolicies[1].Source.Type).To(Equal("app"))
+			Expect(collection.EgressPolicies[1].Action).To(Equal("allow"))
+		})
+
 		Context("when marshalling fails", func() {
 			BeforeEach(func() {
 				fakeMarshaler.MarshalReturns(nil, errors.New("banana"))
@@ -3,6 +3,7 @@ package api
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 //go:generate counterfeiter -o fakes/policyValidator.go --fake-name PolicyValidator . policyValidator
@@ -49,6 +50,10 @@ func (v *PolicyValidator) ValidatePolicies(policies []Policy) error {
 		if policy.Source.Tag != "" || policy.Destination.Tag != "" {
 			return errors.New("tags may not be specified")
 		}
+
+		if policy.ExpiresAt != nil && !policy.ExpiresAt.After(time.Now()) {
+			return errors.New("expires_at must be in the future")
+		}
 	}
 	return nil
 }
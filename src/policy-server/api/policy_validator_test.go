@@ -2,6 +2,7 @@ package api_test
 
 import (
 	"policy-server/api"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -249,5 +250,30 @@ var _ = Describe("PolicyValidator", func() {
 				Expect(err).To(MatchError("tags may not be specified"))
 			})
 		})
+
+		Context("when expires_at is not in the future", func() {
+			It("returns a useful error", func() {
+				expiresAt := time.Now().Add(-time.Minute)
+				policies := []api.Policy{
+					{
+						Source: api.Source{
+							ID: "foo",
+						},
+						Destination: api.Destination{
+							ID:       "bar",
+							Protocol: "tcp",
+							Ports: api.Ports{
+								Start: 123,
+								End:   456,
+							},
+						},
+						ExpiresAt: &expiresAt,
+					},
+				}
+
+				err := validator.ValidatePolicies(policies)
+				Expect(err).To(MatchError("expires_at must be in the future"))
+			})
+		})
 	})
 })
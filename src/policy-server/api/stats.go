@@ -0,0 +1,198 @@
+package api
+
+import (
+	"policy-server/store"
+	"sort"
+)
+
+// distributionBuckets are the lower bounds of the buckets policy counts are
+// grouped into. Each bucket ends where the next one starts.
+var distributionBuckets = []int{1, 2, 6, 11, 51, 101, 501}
+
+type Stats struct {
+	TotalPolicies       int `json:"total_policies"`
+	TotalEgressPolicies int `json:"total_egress_policies"`
+	// PoliciesPerApp counts the c2c policies each app is the source or
+	// destination of.
+	PoliciesPerApp Distribution `json:"policies_per_app"`
+	// PoliciesPerSpace and PoliciesPerOrg count the c2c policies by the
+	// space and org of their source app.
+	PoliciesPerSpace        Distribution            `json:"policies_per_space"`
+	PoliciesPerOrg          Distribution            `json:"policies_per_org"`
+	FanOut                  Distribution            `json:"fan_out"`
+	FanIn                   Distribution            `json:"fan_in"`
+	EgressPoliciesPerSource map[string]Distribution `json:"egress_policies_per_source"`
+	EgressDestinations      DestinationStats        `json:"egress_destinations"`
+	Tags                    TagStats                `json:"tags"`
+}
+
+// Distribution describes how many policies each of Count apps, spaces or
+// orgs has. Top lists the ones with the most policies, most first.
+type Distribution struct {
+	Count   int         `json:"count"`
+	Max     int         `json:"max"`
+	Mean    float64     `json:"mean"`
+	Buckets []Bucket    `json:"buckets"`
+	Top     []GUIDCount `json:"top"`
+}
+
+// Bucket counts the apps, spaces or orgs with between Min and Max policies.
+// The last bucket has no Max.
+type Bucket struct {
+	Min   int `json:"min"`
+	Max   int `json:"max,omitempty"`
+	Count int `json:"count"`
+}
+
+type GUIDCount struct {
+	GUID     string `json:"guid"`
+	Policies int    `json:"policies"`
+}
+
+type DestinationStats struct {
+	Count  int                `json:"count"`
+	Unused int                `json:"unused"`
+	Top    []DestinationCount `json:"top"`
+}
+
+type DestinationCount struct {
+	GUID     string `json:"guid"`
+	Name     string `json:"name"`
+	Policies int    `json:"policies"`
+}
+
+type TagStats struct {
+	TagLength   int            `json:"tag_length"`
+	Max         int            `json:"max"`
+	Populated   int            `json:"populated"`
+	Used        int            `json:"used"`
+	UsedPercent float64        `json:"used_percent"`
+	UsedByType  map[string]int `json:"used_by_type"`
+}
+
+// MapStoreStats builds the distributions from the per app, space and org
+// counts in stats, keeping the top entries of each.
+func MapStoreStats(stats store.Stats, top int) Stats {
+	perApp := map[string]int{}
+	for _, count := range stats.FanOut {
+		perApp[count.GUID] += count.Count
+	}
+	for _, count := range stats.FanIn {
+		perApp[count.GUID] += count.Count
+	}
+
+	perSource := map[string]map[string]int{}
+	for _, count := range stats.EgressSources {
+		if perSource[count.Type] == nil {
+			perSource[count.Type] = map[string]int{}
+		}
+		perSource[count.Type][count.GUID] = count.Count
+	}
+	egressPoliciesPerSource := map[string]Distribution{}
+	for sourceType, counts := range perSource {
+		egressPoliciesPerSource[sourceType] = distribution(counts, top)
+	}
+
+	return Stats{
+		TotalPolicies:           stats.TotalPolicies,
+		TotalEgressPolicies:     stats.TotalEgressPolicies,
+		PoliciesPerApp:          distribution(perApp, top),
+		PoliciesPerSpace:        distribution(guidCountsToMap(stats.PoliciesPerSpace), top),
+		PoliciesPerOrg:          distribution(guidCountsToMap(stats.PoliciesPerOrg), top),
+		FanOut:                  distribution(guidCountsToMap(stats.FanOut), top),
+		FanIn:                   distribution(guidCountsToMap(stats.FanIn), top),
+		EgressPoliciesPerSource: egressPoliciesPerSource,
+		EgressDestinations:      destinationStats(stats.EgressDestinations, top),
+		Tags:                    tagStats(stats.Tags),
+	}
+}
+
+func guidCountsToMap(counts []store.GUIDCount) map[string]int {
+	m := map[string]int{}
+	for _, count := range counts {
+		m[count.GUID] = count.Count
+	}
+	return m
+}
+
+func distribution(counts map[string]int, top int) Distribution {
+	d := Distribution{
+		Count:   len(counts),
+		Buckets: make([]Bucket, len(distributionBuckets)),
+		Top:     []GUIDCount{},
+	}
+	for i, min := range distributionBuckets {
+		d.Buckets[i].Min = min
+		if i+1 < len(distributionBuckets) {
+			d.Buckets[i].Max = distributionBuckets[i+1] - 1
+		}
+	}
+
+	total := 0
+	for guid, count := range counts {
+		total += count
+		if count > d.Max {
+			d.Max = count
+		}
+		for i := len(distributionBuckets) - 1; i >= 0; i-- {
+			if count >= distributionBuckets[i] {
+				d.Buckets[i].Count++
+				break
+			}
+		}
+		d.Top = append(d.Top, GUIDCount{GUID: guid, Policies: count})
+	}
+	if len(counts) > 0 {
+		d.Mean = float64(total) / float64(len(counts))
+	}
+
+	sort.Slice(d.Top, func(i, j int) bool {
+		if d.Top[i].Policies != d.Top[j].Policies {
+			return d.Top[i].Policies > d.Top[j].Policies
+		}
+		return d.Top[i].GUID < d.Top[j].GUID
+	})
+	if len(d.Top) > top {
+		d.Top = d.Top[:top]
+	}
+	return d
+}
+
+func destinationStats(counts []store.EgressDestinationCount, top int) DestinationStats {
+	stats := DestinationStats{
+		Count: len(counts),
+		Top:   []DestinationCount{},
+	}
+	for _, count := range counts {
+		if count.Count == 0 {
+			stats.Unused++
+			continue
+		}
+		stats.Top = append(stats.Top, DestinationCount{GUID: count.GUID, Name: count.Name, Policies: count.Count})
+	}
+
+	sort.Slice(stats.Top, func(i, j int) bool {
+		if stats.Top[i].Policies != stats.Top[j].Policies {
+			return stats.Top[i].Policies > stats.Top[j].Policies
+		}
+		return stats.Top[i].GUID < stats.Top[j].GUID
+	})
+	if len(stats.Top) > top {
+		stats.Top = stats.Top[:top]
+	}
+	return stats
+}
+
+func tagStats(tags store.TagStats) TagStats {
+	stats := TagStats{
+		TagLength:  tags.TagLength,
+		Max:        tags.Max,
+		Populated:  tags.Populated,
+		Used:       tags.Used,
+		UsedByType: tags.UsedByType,
+	}
+	if tags.Max > 0 {
+		stats.UsedPercent = float64(tags.Used) * 100 / float64(tags.Max)
+	}
+	return stats
+}
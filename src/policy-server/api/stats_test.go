@@ -0,0 +1,156 @@
+package api_test
+
+import (
+	"policy-server/api"
+	"policy-server/store"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MapStoreStats", func() {
+	var stats store.Stats
+
+	BeforeEach(func() {
+		stats = store.Stats{
+			TotalPolicies:       7,
+			TotalEgressPolicies: 4,
+			FanOut: []store.GUIDCount{
+				{GUID: "app-a", Count: 6},
+				{GUID: "app-b", Count: 1},
+			},
+			FanIn: []store.GUIDCount{
+				{GUID: "app-b", Count: 6},
+				{GUID: "app-c", Count: 1},
+			},
+			PoliciesPerSpace: []store.GUIDCount{
+				{GUID: "space-a", Count: 6},
+				{GUID: "space-b", Count: 1},
+			},
+			PoliciesPerOrg: []store.GUIDCount{
+				{GUID: "org-a", Count: 7},
+			},
+			EgressSources: []store.EgressSourceCount{
+				{Type: "app", GUID: "app-a", Count: 2},
+				{Type: "space", GUID: "space-a", Count: 1},
+				{Type: "default", Count: 1},
+			},
+			EgressDestinations: []store.EgressDestinationCount{
+				{GUID: "dest-a", Name: "a", Count: 1},
+				{GUID: "dest-b", Name: "b", Count: 3},
+				{GUID: "dest-c", Name: "c", Count: 0},
+			},
+			Tags: store.TagStats{
+				TagLength:  1,
+				Max:        255,
+				Populated:  255,
+				Used:       51,
+				UsedByType: map[string]int{"app": 51},
+			},
+		}
+	})
+
+	It("builds the distributions", func() {
+		result := api.MapStoreStats(stats, 10)
+
+		Expect(result.TotalPolicies).To(Equal(7))
+		Expect(result.TotalEgressPolicies).To(Equal(4))
+
+		Expect(result.FanOut.Count).To(Equal(2))
+		Expect(result.FanOut.Max).To(Equal(6))
+		Expect(result.FanOut.Mean).To(Equal(3.5))
+		Expect(result.FanOut.Buckets).To(Equal([]api.Bucket{
+			{Min: 1, Max: 1, Count: 1},
+			{Min: 2, Max: 5, Count: 0},
+			{Min: 6, Max: 10, Count: 1},
+			{Min: 11, Max: 50, Count: 0},
+			{Min: 51, Max: 100, Count: 0},
+			{Min: 101, Max: 500, Count: 0},
+			{Min: 501, Count: 0},
+		}))
+		Expect(result.FanOut.Top).To(Equal([]api.GUIDCount{
+			{GUID: "app-a", Policies: 6},
+			{GUID: "app-b", Policies: 1},
+		}))
+
+		Expect(result.FanIn.Top).To(Equal([]api.GUIDCount{
+			{GUID: "app-b", Policies: 6},
+			{GUID: "app-c", Policies: 1},
+		}))
+
+		Expect(result.PoliciesPerApp.Count).To(Equal(3))
+		Expect(result.PoliciesPerApp.Top).To(Equal([]api.GUIDCount{
+			{GUID: "app-b", Policies: 7},
+			{GUID: "app-a", Policies: 6},
+			{GUID: "app-c", Policies: 1},
+		}))
+	})
+
+	It("builds the distributions per space and org", func() {
+		result := api.MapStoreStats(stats, 10)
+
+		Expect(result.PoliciesPerSpace.Count).To(Equal(2))
+		Expect(result.PoliciesPerSpace.Max).To(Equal(6))
+		Expect(result.PoliciesPerSpace.Top).To(Equal([]api.GUIDCount{
+			{GUID: "space-a", Policies: 6},
+			{GUID: "space-b", Policies: 1},
+		}))
+
+		Expect(result.PoliciesPerOrg.Count).To(Equal(1))
+		Expect(result.PoliciesPerOrg.Top).To(Equal([]api.GUIDCount{{GUID: "org-a", Policies: 7}}))
+	})
+
+	It("groups the egress policies by source type", func() {
+		result := api.MapStoreStats(stats, 10)
+
+		Expect(result.EgressPoliciesPerSource).To(HaveLen(3))
+		Expect(result.EgressPoliciesPerSource["app"].Top).To(Equal([]api.GUIDCount{{GUID: "app-a", Policies: 2}}))
+		Expect(result.EgressPoliciesPerSource["space"].Top).To(Equal([]api.GUIDCount{{GUID: "space-a", Policies: 1}}))
+		Expect(result.EgressPoliciesPerSource["default"].Count).To(Equal(1))
+	})
+
+	It("reports the usage of egress destinations", func() {
+		result := api.MapStoreStats(stats, 10)
+
+		Expect(result.EgressDestinations).To(Equal(api.DestinationStats{
+			Count:  3,
+			Unused: 1,
+			Top: []api.DestinationCount{
+				{GUID: "dest-b", Name: "b", Policies: 3},
+				{GUID: "dest-a", Name: "a", Policies: 1},
+			},
+		}))
+	})
+
+	It("reports the tag utilization", func() {
+		result := api.MapStoreStats(stats, 10)
+
+		Expect(result.Tags).To(Equal(api.TagStats{
+			TagLength:   1,
+			Max:         255,
+			Populated:   255,
+			Used:        51,
+			UsedPercent: 20,
+			UsedByType:  map[string]int{"app": 51},
+		}))
+	})
+
+	It("keeps only the top entries", func() {
+		result := api.MapStoreStats(stats, 1)
+
+		Expect(result.PoliciesPerApp.Top).To(Equal([]api.GUIDCount{{GUID: "app-b", Policies: 7}}))
+		Expect(result.EgressDestinations.Top).To(HaveLen(1))
+	})
+
+	Context("when there are no policies", func() {
+		It("returns empty distributions", func() {
+			result := api.MapStoreStats(store.Stats{}, 10)
+
+			Expect(result.FanOut.Count).To(Equal(0))
+			Expect(result.FanOut.Mean).To(Equal(0.0))
+			Expect(result.FanOut.Top).To(BeEmpty())
+			Expect(result.EgressPoliciesPerSource).To(BeEmpty())
+			Expect(result.Tags.UsedPercent).To(Equal(0.0))
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package asg_importer_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestAsgImporter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "AsgImporter Suite")
+}
@@ -0,0 +1,368 @@
+package asg_importer
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"policy-server/cc_client"
+	"policy-server/store"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken(context.Context) (string, error)
+}
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	GetSecurityGroups(ctx context.Context, token string) ([]cc_client.SecurityGroup, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_destination_store.go --fake-name EgressDestinationStore . egressDestinationStore
+type egressDestinationStore interface {
+	All() ([]store.EgressDestination, error)
+	Create([]store.EgressDestination) ([]store.EgressDestination, error)
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
+type egressPolicyStore interface {
+	All(context.Context) ([]store.EgressPolicy, error)
+	Create(context.Context, []store.EgressPolicy) ([]store.EgressPolicy, error)
+}
+
+type Importer struct {
+	Logger            lager.Logger
+	UAAClient         uaaClient
+	CCClient          ccClient
+	DestinationStore  egressDestinationStore
+	EgressPolicyStore egressPolicyStore
+}
+
+type Report struct {
+	DryRun               bool                      `json:"dry_run"`
+	Destinations         []store.EgressDestination `json:"destinations"`
+	ExistingDestinations []string                  `json:"existing_destinations"`
+	EgressPolicies       []store.EgressPolicy      `json:"egress_policies"`
+	SkippedRules         []SkippedRule             `json:"skipped_rules"`
+	SkippedBindings      []SkippedBinding          `json:"skipped_bindings"`
+}
+
+type SkippedRule struct {
+	SecurityGroup string                      `json:"security_group"`
+	Rule          cc_client.SecurityGroupRule `json:"rule"`
+	Reason        string                      `json:"reason"`
+}
+
+type SkippedBinding struct {
+	SecurityGroup string `json:"security_group"`
+	SpaceGUID     string `json:"space_guid,omitempty"`
+	Reason        string `json:"reason"`
+}
+
+// Import converts every ASG known to Cloud Controller into egress
+// destinations, one per protocol, IP range and port range, and binds each of
+// them to the spaces the ASG is bound to for running apps. ASGs that are
+// enabled globally for running apps are bound with a default source. Running
+// the import again skips destinations and policies that already exist, so it
+// can be repeated safely. An existing destination is only reused when both its
+// name and its rules match; a destination with the same name but different
+// rules is rejected rather than resolved arbitrarily. When dryRun is set nothing is written and the report
+// describes what would have been created.
+func (i *Importer) Import(dryRun bool) (Report, error) {
+	report := Report{
+		DryRun:               dryRun,
+		Destinations:         []store.EgressDestination{},
+		ExistingDestinations: []string{},
+		EgressPolicies:       []store.EgressPolicy{},
+		SkippedRules:         []SkippedRule{},
+		SkippedBindings:      []SkippedBinding{},
+	}
+
+	ctx := context.Background()
+
+	token, err := i.UAAClient.GetToken(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("get UAA token failed: %s", err)
+	}
+
+	securityGroups, err := i.CCClient.GetSecurityGroups(ctx, token)
+	if err != nil {
+		return Report{}, fmt.Errorf("get security groups from Cloud-Controller failed: %s", err)
+	}
+
+	existingDestinations, err := i.DestinationStore.All()
+	if err != nil {
+		return Report{}, fmt.Errorf("database read failed for egress destinations: %s", err)
+	}
+	destinationsByKey := make(map[string]store.EgressDestination)
+	existingNames := make(map[string]struct{})
+	for _, destination := range existingDestinations {
+		destinationsByKey[destinationKey(destination)] = destination
+		existingNames[destination.Name] = struct{}{}
+	}
+
+	existingPolicies, err := i.EgressPolicyStore.All(ctx)
+	if err != nil {
+		return Report{}, fmt.Errorf("database read failed for egress policies: %s", err)
+	}
+	existingBindings := make(map[string]struct{})
+	for _, policy := range existingPolicies {
+		existingBindings[bindingKey(policy.Source, policy.Destination.GUID)] = struct{}{}
+	}
+
+	destinationKeysByGroup := make(map[string][]string)
+	for _, securityGroup := range securityGroups {
+		destinations, skippedRules := destinationsForSecurityGroup(securityGroup)
+		report.SkippedRules = append(report.SkippedRules, skippedRules...)
+
+		for _, destination := range destinations {
+			key := destinationKey(destination)
+			destinationKeysByGroup[securityGroup.GUID] = append(destinationKeysByGroup[securityGroup.GUID], key)
+			if _, ok := destinationsByKey[key]; ok {
+				report.ExistingDestinations = append(report.ExistingDestinations, destination.Name)
+				continue
+			}
+			if _, ok := existingNames[destination.Name]; ok {
+				return Report{}, fmt.Errorf("egress destination '%s' already exists with different rules", destination.Name)
+			}
+			report.Destinations = append(report.Destinations, destination)
+		}
+	}
+
+	if !dryRun && len(report.Destinations) > 0 {
+		createdDestinations, err := i.DestinationStore.Create(report.Destinations)
+		if err != nil {
+			return Report{}, fmt.Errorf("creating egress destinations: %s", err)
+		}
+		report.Destinations = createdDestinations
+	}
+	for _, destination := range report.Destinations {
+		destinationsByKey[destinationKey(destination)] = destination
+	}
+
+	for _, securityGroup := range securityGroups {
+		sources, skippedBindings := sourcesForSecurityGroup(securityGroup)
+		report.SkippedBindings = append(report.SkippedBindings, skippedBindings...)
+
+		for _, source := range sources {
+			for _, key := range destinationKeysByGroup[securityGroup.GUID] {
+				destination := destinationsByKey[key]
+				if destination.GUID != "" {
+					if _, ok := existingBindings[bindingKey(source, destination.GUID)]; ok {
+						continue
+					}
+				}
+				report.EgressPolicies = append(report.EgressPolicies, store.EgressPolicy{
+					Source:      source,
+					Destination: destination,
+					Action:      "allow",
+				})
+			}
+		}
+	}
+
+	if !dryRun && len(report.EgressPolicies) > 0 {
+		createdPolicies, err := i.EgressPolicyStore.Create(ctx, report.EgressPolicies)
+		if err != nil {
+			return Report{}, fmt.Errorf("creating egress policies: %s", err)
+		}
+		report.EgressPolicies = createdPolicies
+	}
+
+	i.Logger.Info("imported-security-groups", lager.Data{
+		"dry_run":               dryRun,
+		"security_groups":       len(securityGroups),
+		"destinations":          len(report.Destinations),
+		"existing_destinations": len(report.ExistingDestinations),
+		"egress_policies":       len(report.EgressPolicies),
+		"skipped_rules":         len(report.SkippedRules),
+		"skipped_bindings":      len(report.SkippedBindings),
+	})
+
+	return report, nil
+}
+
+func bindingKey(source store.EgressSource, destinationGUID string) string {
+	return fmt.Sprintf("%s/%s/%s", source.Type, source.ID, destinationGUID)
+}
+
+func sourcesForSecurityGroup(securityGroup cc_client.SecurityGroup) ([]store.EgressSource, []SkippedBinding) {
+	var sources []store.EgressSource
+	var skipped []SkippedBinding
+
+	if securityGroup.GloballyEnabled.Running {
+		sources = append(sources, store.EgressSource{Type: "default"})
+	}
+	if securityGroup.GloballyEnabled.Staging {
+		skipped = append(skipped, SkippedBinding{
+			SecurityGroup: securityGroup.Name,
+			Reason:        "staging security groups are not supported by egress policies",
+		})
+	}
+
+	for _, space := range securityGroup.Relationships.RunningSpaces.Data {
+		sources = append(sources, store.EgressSource{Type: "space", ID: space.GUID})
+	}
+	for _, space := range securityGroup.Relationships.StagingSpaces.Data {
+		skipped = append(skipped, SkippedBinding{
+			SecurityGroup: securityGroup.Name,
+			SpaceGUID:     space.GUID,
+			Reason:        "staging security groups are not supported by egress policies",
+		})
+	}
+
+	return sources, skipped
+}
+
+// destinationKey identifies a destination by its name together with its
+// protocol, ports, IP ranges and ICMP type and code, so that an existing
+// destination is only reused when it allows exactly the same traffic.
+func destinationKey(destination store.EgressDestination) string {
+	key := fmt.Sprintf("%s/%s/%v/%v", destination.Name, destination.Protocol, destination.Ports, destination.IPRanges)
+	if destination.Protocol == "icmp" {
+		key += fmt.Sprintf("/%d/%d", destination.ICMPType, destination.ICMPCode)
+	}
+	return key
+}
+
+func destinationsForSecurityGroup(securityGroup cc_client.SecurityGroup) ([]store.EgressDestination, []SkippedRule) {
+	var destinations []store.EgressDestination
+	var skipped []SkippedRule
+
+	for _, rule := range securityGroup.Rules {
+		ruleDestinations, err := destinationsForRule(rule)
+		if err != nil {
+			skipped = append(skipped, SkippedRule{
+				SecurityGroup: securityGroup.Name,
+				Rule:          rule,
+				Reason:        err.Error(),
+			})
+			continue
+		}
+
+		for _, destination := range ruleDestinations {
+			destination.Name = fmt.Sprintf("%s-%d", securityGroup.Name, len(destinations)+1)
+			if destination.Description == "" {
+				destination.Description = fmt.Sprintf("imported from application security group %s", securityGroup.Name)
+			}
+			destinations = append(destinations, destination)
+		}
+	}
+
+	return destinations, skipped
+}
+
+func destinationsForRule(rule cc_client.SecurityGroupRule) ([]store.EgressDestination, error) {
+	var protocols []string
+	switch rule.Protocol {
+	case "tcp", "udp", "icmp":
+		protocols = []string{rule.Protocol}
+	case "all":
+		protocols = []string{"tcp", "udp", "icmp"}
+	default:
+		return nil, fmt.Errorf("unsupported protocol '%s'", rule.Protocol)
+	}
+
+	var ipRanges []store.IPRange
+	for _, destination := range strings.Split(rule.Destination, ",") {
+		ipRange, err := parseIPRange(strings.TrimSpace(destination))
+		if err != nil {
+			return nil, err
+		}
+		ipRanges = append(ipRanges, ipRange)
+	}
+
+	portRanges := []store.Ports{{Start: 1, End: 65535}}
+	if rule.Ports != "" {
+		var err error
+		portRanges, err = parsePorts(rule.Ports)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var destinations []store.EgressDestination
+	for _, protocol := range protocols {
+		for _, ipRange := range ipRanges {
+			if protocol == "icmp" {
+				destination := store.EgressDestination{
+					Description: rule.Description,
+					Protocol:    protocol,
+					IPRanges:    []store.IPRange{ipRange},
+					ICMPType:    -1,
+					ICMPCode:    -1,
+				}
+				if rule.Type != nil {
+					destination.ICMPType = *rule.Type
+				}
+				if rule.Code != nil {
+					destination.ICMPCode = *rule.Code
+				}
+				destinations = append(destinations, destination)
+				continue
+			}
+
+			for _, portRange := range portRanges {
+				destinations = append(destinations, store.EgressDestination{
+					Description: rule.Description,
+					Protocol:    protocol,
+					Ports:       []store.Ports{portRange},
+					IPRanges:    []store.IPRange{ipRange},
+				})
+			}
+		}
+	}
+
+	return destinations, nil
+}
+
+func parseIPRange(destination string) (store.IPRange, error) {
+	if strings.Contains(destination, "/") {
+		ip, ipNet, err := net.ParseCIDR(destination)
+		if err != nil || ip.To4() == nil {
+			return store.IPRange{}, fmt.Errorf("invalid destination '%s', must be an IPv4 address, range or CIDR", destination)
+		}
+		start := ipNet.IP.To4()
+		end := make(net.IP, net.IPv4len)
+		binary.BigEndian.PutUint32(end, binary.BigEndian.Uint32(start)|^binary.BigEndian.Uint32(ipNet.Mask))
+		return store.IPRange{Start: start.String(), End: end.String()}, nil
+	}
+
+	parts := strings.Split(destination, "-")
+	if len(parts) > 2 {
+		return store.IPRange{}, fmt.Errorf("invalid destination '%s', must be an IPv4 address, range or CIDR", destination)
+	}
+	for _, part := range parts {
+		ip := net.ParseIP(part)
+		if ip == nil || ip.To4() == nil {
+			return store.IPRange{}, fmt.Errorf("invalid destination '%s', must be an IPv4 address, range or CIDR", destination)
+		}
+	}
+	return store.IPRange{Start: parts[0], End: parts[len(parts)-1]}, nil
+}
+
+func parsePorts(ports string) ([]store.Ports, error) {
+	var portRanges []store.Ports
+	for _, portRange := range strings.Split(ports, ",") {
+		parts := strings.Split(strings.TrimSpace(portRange), "-")
+		if len(parts) > 2 {
+			return nil, fmt.Errorf("invalid ports '%s'", ports)
+		}
+
+		var bounds []int
+		for _, part := range parts {
+			port, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || port < 1 || port > 65535 {
+				return nil, fmt.Errorf("invalid ports '%s'", ports)
+			}
+			bounds = append(bounds, port)
+		}
+		portRanges = append(portRanges, store.Ports{Start: bounds[0], End: bounds[len(bounds)-1]})
+	}
+	return portRanges, nil
+}
@@ -0,0 +1,376 @@
+package asg_importer_test
+
+import (
+	"encoding/json"
+	"errors"
+	"policy-server/asg_importer"
+	"policy-server/asg_importer/fakes"
+	"policy-server/cc_client"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+const securityGroupsJSON = `[
+	{
+		"guid": "asg-1-guid",
+		"name": "public-networks",
+		"globally_enabled": {"running": false, "staging": false},
+		"rules": [
+			{"protocol": "tcp", "destination": "10.10.10.0/24", "ports": "80,443", "description": "web traffic"},
+			{"protocol": "icmp", "destination": "10.10.11.5", "type": 8, "code": 0}
+		],
+		"relationships": {
+			"running_spaces": {"data": [{"guid": "space-1-guid"}]},
+			"staging_spaces": {"data": [{"guid": "space-2-guid"}]}
+		}
+	},
+	{
+		"guid": "asg-2-guid",
+		"name": "dns",
+		"globally_enabled": {"running": true, "staging": true},
+		"rules": [
+			{"protocol": "udp", "destination": "10.0.0.1-10.0.0.3", "ports": "53"},
+			{"protocol": "tcp", "destination": "fd00::/8", "ports": "53"}
+		],
+		"relationships": {
+			"running_spaces": {"data": []},
+			"staging_spaces": {"data": []}
+		}
+	}
+]`
+
+var _ = Describe("Importer", func() {
+	var (
+		importer             *asg_importer.Importer
+		fakeUAAClient        *fakes.UAAClient
+		fakeCCClient         *fakes.CCClient
+		fakeDestinationStore *fakes.EgressDestinationStore
+		fakeEgressStore      *fakes.EgressPolicyStore
+		logger               *lagertest.TestLogger
+		securityGroups       []cc_client.SecurityGroup
+	)
+
+	BeforeEach(func() {
+		Expect(json.Unmarshal([]byte(securityGroupsJSON), &securityGroups)).To(Succeed())
+
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+
+		fakeCCClient = &fakes.CCClient{}
+		fakeCCClient.GetSecurityGroupsReturns(securityGroups, nil)
+
+		fakeDestinationStore = &fakes.EgressDestinationStore{}
+		fakeDestinationStore.CreateStub = func(destinations []store.EgressDestination) ([]store.EgressDestination, error) {
+			created := []store.EgressDestination{}
+			for _, destination := range destinations {
+				destination.GUID = destination.Name + "-guid"
+				created = append(created, destination)
+			}
+			return created, nil
+		}
+
+		fakeEgressStore = &fakes.EgressPolicyStore{}
+		fakeEgressStore.CreateStub = func(policies []store.EgressPolicy) ([]store.EgressPolicy, error) {
+			return policies, nil
+		}
+
+		logger = lagertest.NewTestLogger("test")
+
+		importer = &asg_importer.Importer{
+			Logger:            logger,
+			UAAClient:         fakeUAAClient,
+			CCClient:          fakeCCClient,
+			DestinationStore:  fakeDestinationStore,
+			EgressPolicyStore: fakeEgressStore,
+		}
+	})
+
+	It("creates a destination for every protocol, ip range and port range of each ASG", func() {
+		report, err := importer.Import(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeCCClient.GetSecurityGroupsCallCount()).To(Equal(1))
+		_, token := fakeCCClient.GetSecurityGroupsArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+
+		Expect(fakeDestinationStore.CreateCallCount()).To(Equal(1))
+		Expect(fakeDestinationStore.CreateArgsForCall(0)).To(Equal([]store.EgressDestination{
+			{
+				Name:        "public-networks-1",
+				Description: "web traffic",
+				Protocol:    "tcp",
+				Ports:       []store.Ports{{Start: 80, End: 80}},
+				IPRanges:    []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+			},
+			{
+				Name:        "public-networks-2",
+				Description: "web traffic",
+				Protocol:    "tcp",
+				Ports:       []store.Ports{{Start: 443, End: 443}},
+				IPRanges:    []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+			},
+			{
+				Name:        "public-networks-3",
+				Description: "imported from application security group public-networks",
+				Protocol:    "icmp",
+				IPRanges:    []store.IPRange{{Start: "10.10.11.5", End: "10.10.11.5"}},
+				ICMPType:    8,
+				ICMPCode:    0,
+			},
+			{
+				Name:        "dns-1",
+				Description: "imported from application security group dns",
+				Protocol:    "udp",
+				Ports:       []store.Ports{{Start: 53, End: 53}},
+				IPRanges:    []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.3"}},
+			},
+		}))
+
+		Expect(report.DryRun).To(BeFalse())
+		Expect(report.Destinations).To(HaveLen(4))
+		Expect(report.Destinations[0].GUID).To(Equal("public-networks-1-guid"))
+	})
+
+	It("binds the destinations to the running spaces of each ASG", func() {
+		_, err := importer.Import(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeEgressStore.CreateCallCount()).To(Equal(1))
+		_, policies := fakeEgressStore.CreateArgsForCall(0)
+
+		var bindings []string
+		for _, policy := range policies {
+			Expect(policy.Action).To(Equal("allow"))
+			bindings = append(bindings, policy.Source.Type+":"+policy.Source.ID+"->"+policy.Destination.GUID)
+		}
+		Expect(bindings).To(ConsistOf(
+			"space:space-1-guid->public-networks-1-guid",
+			"space:space-1-guid->public-networks-2-guid",
+			"space:space-1-guid->public-networks-3-guid",
+			"default:->dns-1-guid",
+		))
+	})
+
+	It("reports the rules and bindings that cannot be imported", func() {
+		report, err := importer.Import(false)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(report.SkippedRules).To(HaveLen(1))
+		Expect(report.SkippedRules[0].SecurityGroup).To(Equal("dns"))
+		Expect(report.SkippedRules[0].Reason).To(Equal("invalid destination 'fd00::/8', must be an IPv4 address, range or CIDR"))
+
+		Expect(report.SkippedBindings).To(ConsistOf(
+			asg_importer.SkippedBinding{
+				SecurityGroup: "public-networks",
+				SpaceGUID:     "space-2-guid",
+				Reason:        "staging security groups are not supported by egress policies",
+			},
+			asg_importer.SkippedBinding{
+				SecurityGroup: "dns",
+				Reason:        "staging security groups are not supported by egress policies",
+			},
+		))
+	})
+
+	Context("when the protocol is all", func() {
+		BeforeEach(func() {
+			securityGroups[1].Rules = []cc_client.SecurityGroupRule{{Protocol: "all", Destination: "10.0.0.0/8"}}
+			fakeCCClient.GetSecurityGroupsReturns(securityGroups[1:], nil)
+		})
+
+		It("creates tcp, udp and icmp destinations covering all ports", func() {
+			_, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			destinations := fakeDestinationStore.CreateArgsForCall(0)
+			Expect(destinations).To(HaveLen(3))
+			Expect(destinations[0].Protocol).To(Equal("tcp"))
+			Expect(destinations[0].Ports).To(Equal([]store.Ports{{Start: 1, End: 65535}}))
+			Expect(destinations[1].Protocol).To(Equal("udp"))
+			Expect(destinations[2].Protocol).To(Equal("icmp"))
+			Expect(destinations[2].ICMPType).To(Equal(-1))
+			Expect(destinations[2].ICMPCode).To(Equal(-1))
+			Expect(destinations[2].IPRanges).To(Equal([]store.IPRange{{Start: "10.0.0.0", End: "10.255.255.255"}}))
+		})
+	})
+
+	Context("when some destinations and policies were imported before", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{
+				{
+					GUID:     "existing-guid",
+					Name:     "public-networks-1",
+					Protocol: "tcp",
+					Ports:    []store.Ports{{Start: 80, End: 80}},
+					IPRanges: []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+				},
+			}, nil)
+			fakeEgressStore.AllReturns([]store.EgressPolicy{
+				{
+					Source:      store.EgressSource{Type: "space", ID: "space-1-guid"},
+					Destination: store.EgressDestination{GUID: "existing-guid"},
+				},
+			}, nil)
+		})
+
+		It("skips them", func() {
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.ExistingDestinations).To(Equal([]string{"public-networks-1"}))
+			for _, destination := range fakeDestinationStore.CreateArgsForCall(0) {
+				Expect(destination.Name).NotTo(Equal("public-networks-1"))
+			}
+
+			_, policies := fakeEgressStore.CreateArgsForCall(0)
+			Expect(policies).To(HaveLen(3))
+			for _, policy := range policies {
+				Expect(policy.Destination.GUID).NotTo(Equal("existing-guid"))
+			}
+		})
+	})
+
+	Context("when several existing destinations share a name", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{
+				{
+					GUID:     "other-guid",
+					Name:     "public-networks-1",
+					Protocol: "udp",
+					Ports:    []store.Ports{{Start: 80, End: 80}},
+					IPRanges: []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+				},
+				{
+					GUID:     "existing-guid",
+					Name:     "public-networks-1",
+					Protocol: "tcp",
+					Ports:    []store.Ports{{Start: 80, End: 80}},
+					IPRanges: []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+				},
+			}, nil)
+		})
+
+		It("reuses the one whose rules match", func() {
+			report, err := importer.Import(false)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(report.ExistingDestinations).To(Equal([]string{"public-networks-1"}))
+
+			_, policies := fakeEgressStore.CreateArgsForCall(0)
+			var destinationGUIDs []string
+			for _, policy := range policies {
+				destinationGUIDs = append(destinationGUIDs, policy.Destination.GUID)
+			}
+			Expect(destinationGUIDs).To(ContainElement("existing-guid"))
+			Expect(destinationGUIDs).NotTo(ContainElement("other-guid"))
+		})
+	})
+
+	Context("when an existing destination has the same name but different rules", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns([]store.EgressDestination{
+				{
+					GUID:     "other-guid",
+					Name:     "public-networks-1",
+					Protocol: "tcp",
+					Ports:    []store.Ports{{Start: 8080, End: 8080}},
+					IPRanges: []store.IPRange{{Start: "10.10.10.0", End: "10.10.10.255"}},
+				},
+			}, nil)
+		})
+
+		It("returns an error and writes nothing", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("egress destination 'public-networks-1' already exists with different rules"))
+
+			Expect(fakeDestinationStore.CreateCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when running as a dry run", func() {
+		It("reports what would be created without writing anything", func() {
+			report, err := importer.Import(true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeDestinationStore.CreateCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.CreateCallCount()).To(Equal(0))
+
+			Expect(report.DryRun).To(BeTrue())
+			Expect(report.Destinations).To(HaveLen(4))
+			Expect(report.EgressPolicies).To(HaveLen(4))
+			Expect(report.EgressPolicies[0].Destination.Name).To(Equal("public-networks-1"))
+		})
+	})
+
+	Context("when getting the UAA token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("get UAA token failed: potato"))
+		})
+	})
+
+	Context("when getting the security groups fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSecurityGroupsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("get security groups from Cloud-Controller failed: potato"))
+		})
+	})
+
+	Context("when listing the existing destinations fails", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("database read failed for egress destinations: potato"))
+		})
+	})
+
+	Context("when listing the existing egress policies fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.AllReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("database read failed for egress policies: potato"))
+		})
+	})
+
+	Context("when creating the destinations fails", func() {
+		BeforeEach(func() {
+			fakeDestinationStore.CreateReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error and does not create policies", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("creating egress destinations: potato"))
+			Expect(fakeEgressStore.CreateCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when creating the egress policies fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.CreateReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			_, err := importer.Import(false)
+			Expect(err).To(MatchError("creating egress policies: potato"))
+		})
+	})
+})
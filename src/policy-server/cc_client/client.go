@@ -1,20 +1,28 @@
 package cc_client
 
 import (
+	"context"
 	"fmt"
+	"lib/tracing"
 	"net/http"
 	"net/url"
 	"policy-server/api"
 	"strconv"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/lager"
 )
 
+// GUIDsPerRequest is the most guids the client filters a single Cloud
+// Controller request by. Longer lists are split across several requests.
+const GUIDsPerRequest = 100
+
 type Client struct {
 	Logger     lager.Logger
-	JSONClient json_client.JsonClient
+	JSONClient jsonClient
+	Tracer     *tracing.Tracer
 }
 
 type AppsV3Response struct {
@@ -32,6 +40,7 @@ type AppsV3Response struct {
 	} `json:"pagination"`
 	Resources []struct {
 		GUID  string `json:"guid"`
+		Name  string `json:"name"`
 		Links struct {
 			Space struct {
 				Href string `json:"href"`
@@ -41,6 +50,32 @@ type AppsV3Response struct {
 }
 
 type SpacesV3Response struct {
+	Pagination struct {
+		TotalPages int `json:"total_pages"`
+		First      struct {
+			Href string `json:"href"`
+		} `json:"first"`
+		Last struct {
+			Href string `json:"href"`
+		} `json:"last"`
+		Next struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []struct {
+		GUID          string `json:"guid"`
+		Name          string `json:"name"`
+		Relationships struct {
+			Organization struct {
+				Data struct {
+					GUID string `json:"guid"`
+				} `json:"data"`
+			} `json:"organization"`
+		} `json:"relationships"`
+	} `json:"resources"`
+}
+
+type OrganizationsV3Response struct {
 	Pagination struct {
 		TotalPages int `json:"total_pages"`
 		First      struct {
@@ -55,9 +90,72 @@ type SpacesV3Response struct {
 	} `json:"pagination"`
 	Resources []struct {
 		GUID string `json:"guid"`
+		Name string `json:"name"`
 	} `json:"resources"`
 }
 
+type SecurityGroupsV3Response struct {
+	Pagination struct {
+		TotalPages int `json:"total_pages"`
+		Next       struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []SecurityGroup `json:"resources"`
+}
+
+type SecurityGroup struct {
+	GUID            string `json:"guid"`
+	Name            string `json:"name"`
+	GloballyEnabled struct {
+		Running bool `json:"running"`
+		Staging bool `json:"staging"`
+	} `json:"globally_enabled"`
+	Rules         []SecurityGroupRule `json:"rules"`
+	Relationships struct {
+		RunningSpaces struct {
+			Data []struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"running_spaces"`
+		StagingSpaces struct {
+			Data []struct {
+				GUID string `json:"guid"`
+			} `json:"data"`
+		} `json:"staging_spaces"`
+	} `json:"relationships"`
+}
+
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports"`
+	Type        *int   `json:"type"`
+	Code        *int   `json:"code"`
+	Description string `json:"description"`
+}
+
+type AuditEventsV3Response struct {
+	Pagination struct {
+		TotalPages int `json:"total_pages"`
+		Next       struct {
+			Href string `json:"href"`
+		} `json:"next"`
+	} `json:"pagination"`
+	Resources []AuditEvent `json:"resources"`
+}
+
+type AuditEvent struct {
+	GUID      string    `json:"guid"`
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Target    struct {
+		GUID string `json:"guid"`
+		Type string `json:"type"`
+		Name string `json:"name"`
+	} `json:"target"`
+}
+
 type SpaceResponse struct {
 	Entity struct {
 		Name             string `json:"name"`
@@ -77,14 +175,14 @@ type SpacesResponse struct {
 	} `json:"resources"`
 }
 
-func (c *Client) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
+func (c *Client) GetAllAppGUIDs(ctx context.Context, token string) (map[string]struct{}, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
 	set := make(map[string]struct{})
 	nextPage := "?"
 	for nextPage != "" {
 		queryParams := strings.Split(nextPage, "?")[1]
-		response, err := c.makeAppsV3Request(queryParams, token)
+		response, err := c.makeAppsV3Request(ctx, queryParams, token)
 		if err != nil {
 			return nil, err
 		}
@@ -97,50 +195,46 @@ func (c *Client) GetAllAppGUIDs(token string) (map[string]struct{}, error) {
 	return set, nil
 }
 
-func (c *Client) makeAppsV3Request(queryParams, token string) (AppsV3Response, error) {
+func (c *Client) makeAppsV3Request(ctx context.Context, queryParams, token string) (AppsV3Response, error) {
 	route := "/v3/apps"
 	if queryParams != "" {
 		route = fmt.Sprintf("%s?%s", route, queryParams)
 	}
 	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	err := c.get(ctx, "GetAllAppGUIDs", route, &response, token)
 	if err != nil {
 		return AppsV3Response{}, fmt.Errorf("json client do: %s", err)
 	}
 	return response, nil
 }
 
-func (c *Client) GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error) {
+func (c *Client) GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
-	values := url.Values{}
-	values.Add("guids", strings.Join(appGUIDs, ","))
-	values.Add("per_page", strconv.Itoa(len(appGUIDs)))
-
-	route := fmt.Sprintf("/v3/apps?%s", values.Encode())
+	set := make(map[string]struct{})
+	err := eachGUIDsPage("/v3/apps", appGUIDs, func(route string) (string, error) {
+		var response AppsV3Response
+		err := c.get(ctx, "GetLiveAppGUIDs", route, &response, token)
+		if err != nil {
+			return "", err
+		}
 
-	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+		for _, r := range response.Resources {
+			set[r.GUID] = struct{}{}
+		}
+		return response.Pagination.Next.Href, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("json client do: %s", err)
 	}
 
-	if response.Pagination.TotalPages > 1 {
-		return nil, fmt.Errorf("pagination support not yet implemented")
-	}
-
-	set := make(map[string]struct{})
-	for _, r := range response.Resources {
-		set[r.GUID] = struct{}{}
-	}
-
 	return set, nil
 }
 
-func (c *Client) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error) {
+func (c *Client) GetLiveSpaceGUIDs(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
-	allSpaceGUIDs, err := c.getAllSpaceGUIDs(token)
+	allSpaceGUIDs, err := c.getAllSpaceGUIDs(ctx, token)
 	if err != nil {
 		return nil, err
 	}
@@ -155,13 +249,13 @@ func (c *Client) GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[strin
 	return liveSpaceGUIDs, nil
 }
 
-func (c *Client) getAllSpaceGUIDs(token string) (map[string]struct{}, error) {
+func (c *Client) getAllSpaceGUIDs(ctx context.Context, token string) (map[string]struct{}, error) {
 	allSpaceGUIDs := make(map[string]struct{})
 
 	route := "/v3/spaces"
 	for route != "" {
 		var response SpacesV3Response
-		err := c.JSONClient.Do("GET", route, nil, &response, token)
+		err := c.get(ctx, "GetLiveSpaceGUIDs", route, &response, token)
 		if err != nil {
 			return nil, fmt.Errorf("json client do: %s", err)
 		}
@@ -175,8 +269,46 @@ func (c *Client) getAllSpaceGUIDs(token string) (map[string]struct{}, error) {
 	return allSpaceGUIDs, nil
 }
 
-func (c *Client) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error) {
-	mapping, err := c.GetAppSpaces(token, appGUIDs)
+func (c *Client) GetLiveOrgGUIDs(ctx context.Context, token string, orgGUIDs []string) (map[string]struct{}, error) {
+	token = fmt.Sprintf("bearer %s", token)
+
+	allOrgGUIDs, err := c.getAllOrgGUIDs(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	liveOrgGUIDs := make(map[string]struct{})
+	for _, org := range orgGUIDs {
+		if _, ok := allOrgGUIDs[org]; ok {
+			liveOrgGUIDs[org] = struct{}{}
+		}
+	}
+
+	return liveOrgGUIDs, nil
+}
+
+func (c *Client) getAllOrgGUIDs(ctx context.Context, token string) (map[string]struct{}, error) {
+	allOrgGUIDs := make(map[string]struct{})
+
+	route := "/v3/organizations"
+	for route != "" {
+		var response OrganizationsV3Response
+		err := c.get(ctx, "GetLiveOrgGUIDs", route, &response, token)
+		if err != nil {
+			return nil, fmt.Errorf("json client do: %s", err)
+		}
+
+		for _, org := range response.Resources {
+			allOrgGUIDs[org.GUID] = struct{}{}
+		}
+		route = nextPageRoute("/v3/organizations", response.Pagination.Next.Href)
+	}
+
+	return allOrgGUIDs, nil
+}
+
+func (c *Client) GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error) {
+	mapping, err := c.GetAppSpaces(ctx, token, appGUIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -194,46 +326,253 @@ func (c *Client) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error
 	return ret, nil
 }
 
-func (c *Client) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
+func (c *Client) GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
 	if len(appGUIDs) < 1 {
 		return map[string]string{}, nil
 	}
 
 	token = fmt.Sprintf("bearer %s", token)
 
-	values := url.Values{}
-	values.Add("guids", strings.Join(appGUIDs, ","))
-	values.Add("per_page", strconv.Itoa(len(appGUIDs)))
+	set := make(map[string]string)
+	err := eachGUIDsPage("/v3/apps", appGUIDs, func(route string) (string, error) {
+		var response AppsV3Response
+		err := c.get(ctx, "GetAppSpaces", route, &response, token)
+		if err != nil {
+			return "", err
+		}
 
-	route := fmt.Sprintf("/v3/apps?%s", values.Encode())
+		for _, r := range response.Resources {
+			href := r.Links.Space.Href
+			parts := strings.Split(href, "/")
+			appID := r.GUID
+			spaceID := parts[len(parts)-1]
+			set[appID] = spaceID
+		}
+		return response.Pagination.Next.Href, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json client do: %s", err)
+	}
+	return set, nil
+}
 
-	var response AppsV3Response
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+func (c *Client) GetAppNames(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
+	if len(appGUIDs) < 1 {
+		return map[string]string{}, nil
+	}
+
+	names := make(map[string]string)
+	err := eachGUIDsPage("/v3/apps", appGUIDs, func(route string) (string, error) {
+		var response AppsV3Response
+		err := c.get(ctx, "GetAppNames", route, &response, fmt.Sprintf("bearer %s", token))
+		if err != nil {
+			return "", err
+		}
+
+		for _, r := range response.Resources {
+			names[r.GUID] = r.Name
+		}
+		return response.Pagination.Next.Href, nil
+	})
 	if err != nil {
 		return nil, fmt.Errorf("json client do: %s", err)
 	}
+	return names, nil
+}
 
-	if response.Pagination.TotalPages > 1 {
-		return nil, fmt.Errorf("pagination support not yet implemented")
+func (c *Client) GetSpaceNames(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error) {
+	if len(spaceGUIDs) < 1 {
+		return map[string]string{}, nil
 	}
 
-	set := make(map[string]string)
-	for _, r := range response.Resources {
-		href := r.Links.Space.Href
-		parts := strings.Split(href, "/")
-		appID := r.GUID
-		spaceID := parts[len(parts)-1]
-		set[appID] = spaceID
+	names := make(map[string]string)
+	err := eachGUIDsPage("/v3/spaces", spaceGUIDs, func(route string) (string, error) {
+		var response SpacesV3Response
+		err := c.get(ctx, "GetSpaceNames", route, &response, fmt.Sprintf("bearer %s", token))
+		if err != nil {
+			return "", err
+		}
+
+		for _, r := range response.Resources {
+			names[r.GUID] = r.Name
+		}
+		return response.Pagination.Next.Href, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json client do: %s", err)
 	}
-	return set, nil
+	return names, nil
+}
+
+// GetSpaceOrgs maps each of the spaces that still exist to its org.
+func (c *Client) GetSpaceOrgs(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error) {
+	if len(spaceGUIDs) < 1 {
+		return map[string]string{}, nil
+	}
+
+	orgs := make(map[string]string)
+	err := eachGUIDsPage("/v3/spaces", spaceGUIDs, func(route string) (string, error) {
+		var response SpacesV3Response
+		err := c.get(ctx, "GetSpaceOrgs", route, &response, fmt.Sprintf("bearer %s", token))
+		if err != nil {
+			return "", err
+		}
+
+		for _, r := range response.Resources {
+			orgs[r.GUID] = r.Relationships.Organization.Data.GUID
+		}
+		return response.Pagination.Next.Href, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json client do: %s", err)
+	}
+	return orgs, nil
+}
+
+func (c *Client) GetOrgNames(ctx context.Context, token string, orgGUIDs []string) (map[string]string, error) {
+	if len(orgGUIDs) < 1 {
+		return map[string]string{}, nil
+	}
+
+	names := make(map[string]string)
+	err := eachGUIDsPage("/v3/organizations", orgGUIDs, func(route string) (string, error) {
+		var response OrganizationsV3Response
+		err := c.get(ctx, "GetOrgNames", route, &response, fmt.Sprintf("bearer %s", token))
+		if err != nil {
+			return "", err
+		}
+
+		for _, r := range response.Resources {
+			names[r.GUID] = r.Name
+		}
+		return response.Pagination.Next.Href, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("json client do: %s", err)
+	}
+	return names, nil
+}
+
+// Ping makes the cheapest authenticated request Cloud Controller serves, a
+// single page of one organization, to check that it is reachable and
+// accepts the token.
+func (c *Client) Ping(ctx context.Context, token string) error {
+	var response OrganizationsV3Response
+	err := c.get(ctx, "Ping", "/v3/organizations?per_page=1", &response, fmt.Sprintf("bearer %s", token))
+	if err != nil {
+		return fmt.Errorf("json client do: %s", err)
+	}
+	return nil
+}
+
+// get makes a GET request to Cloud Controller in a client span named after
+// the calling method.
+func (c *Client) get(ctx context.Context, method, route string, response interface{}, token string) error {
+	ctx, span := c.Tracer.StartClient(ctx, "cc_client."+method)
+	defer span.End()
+	span.SetAttribute("http.target", route)
+
+	err := c.JSONClient.Do(ctx, "GET", route, nil, response, token)
+	span.RecordError(err)
+	return err
+}
+
+// eachGUIDsPage lists the resources at path with the given guids, asking for
+// at most GUIDsPerRequest of them at a time so that the URL stays short and
+// the page size within what Cloud Controller allows. It calls getPage with
+// the route of every page and follows the next page link it returns.
+func eachGUIDsPage(path string, guids []string, getPage func(route string) (string, error)) error {
+	for start := 0; start < len(guids); start += GUIDsPerRequest {
+		end := start + GUIDsPerRequest
+		if end > len(guids) {
+			end = len(guids)
+		}
+
+		route := guidsRoute(path, guids[start:end])
+		for route != "" {
+			next, err := getPage(route)
+			if err != nil {
+				return err
+			}
+			route = nextPageRoute(path, next)
+		}
+	}
+	return nil
+}
+
+// nextPageRoute turns a pagination link, which Cloud Controller gives as an
+// absolute URL, into a route on path.
+func nextPageRoute(path, href string) string {
+	parts := strings.SplitN(href, "?", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return fmt.Sprintf("%s?%s", path, parts[1])
+}
+
+func guidsRoute(path string, guids []string) string {
+	values := url.Values{}
+	values.Add("guids", strings.Join(guids, ","))
+	values.Add("per_page", strconv.Itoa(len(guids)))
+	return fmt.Sprintf("%s?%s", path, values.Encode())
+}
+
+func (c *Client) GetSecurityGroups(ctx context.Context, token string) ([]SecurityGroup, error) {
+	token = fmt.Sprintf("bearer %s", token)
+
+	securityGroups := []SecurityGroup{}
+
+	route := "/v3/security_groups"
+	for route != "" {
+		var response SecurityGroupsV3Response
+		err := c.get(ctx, "GetSecurityGroups", route, &response, token)
+		if err != nil {
+			return nil, fmt.Errorf("json client do: %s", err)
+		}
+
+		securityGroups = append(securityGroups, response.Resources...)
+		route = nextPageRoute("/v3/security_groups", response.Pagination.Next.Href)
+	}
+
+	return securityGroups, nil
+}
+
+// GetAuditEvents returns the audit events of the given types created at or
+// after the given time, oldest first. Cloud Controller only compares whole
+// seconds, so events from earlier in the same second are returned as well and
+// callers polling with the time of the last event they saw should skip the
+// events they already have by GUID.
+func (c *Client) GetAuditEvents(ctx context.Context, token string, eventTypes []string, createdAfter time.Time) ([]AuditEvent, error) {
+	token = fmt.Sprintf("bearer %s", token)
+
+	values := url.Values{}
+	values.Add("types", strings.Join(eventTypes, ","))
+	values.Add("created_ats[gte]", createdAfter.UTC().Format(time.RFC3339))
+	values.Add("order_by", "created_at")
+
+	auditEvents := []AuditEvent{}
+
+	route := fmt.Sprintf("/v3/audit_events?%s", values.Encode())
+	for route != "" {
+		var response AuditEventsV3Response
+		err := c.get(ctx, "GetAuditEvents", route, &response, token)
+		if err != nil {
+			return nil, fmt.Errorf("json client do: %s", err)
+		}
+
+		auditEvents = append(auditEvents, response.Resources...)
+		route = nextPageRoute("/v3/audit_events", response.Pagination.Next.Href)
+	}
+
+	return auditEvents, nil
 }
 
-func (c *Client) GetSpace(token, spaceGUID string) (*api.Space, error) {
+func (c *Client) GetSpace(ctx context.Context, token, spaceGUID string) (*api.Space, error) {
 	token = fmt.Sprintf("bearer %s", token)
 	route := fmt.Sprintf("/v2/spaces/%s", spaceGUID)
 
 	var response SpaceResponse
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	err := c.get(ctx, "GetSpace", route, &response, token)
 	if err != nil {
 		typedErr, ok := err.(*json_client.HttpResponseCodeError)
 		if !ok {
@@ -251,7 +590,7 @@ func (c *Client) GetSpace(token, spaceGUID string) (*api.Space, error) {
 	}, nil
 }
 
-func (c *Client) GetUserSpace(token, userGUID string, space api.Space) (*api.Space, error) {
+func (c *Client) GetUserSpace(ctx context.Context, token, userGUID string, space api.Space) (*api.Space, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
 	values := url.Values{}
@@ -262,7 +601,7 @@ func (c *Client) GetUserSpace(token, userGUID string, space api.Space) (*api.Spa
 	route := fmt.Sprintf("/v2/spaces?%s", values.Encode())
 
 	var response SpacesResponse
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	err := c.get(ctx, "GetUserSpace", route, &response, token)
 	if err != nil {
 		return nil, fmt.Errorf("json client do: %s", err)
 	}
@@ -281,13 +620,13 @@ func (c *Client) GetUserSpace(token, userGUID string, space api.Space) (*api.Spa
 	}, nil
 }
 
-func (c *Client) GetUserSpaces(token, userGUID string) (map[string]struct{}, error) {
+func (c *Client) GetUserSpaces(ctx context.Context, token, userGUID string) (map[string]struct{}, error) {
 	token = fmt.Sprintf("bearer %s", token)
 
 	route := fmt.Sprintf("/v2/users/%s/spaces", userGUID)
 
 	var response SpacesResponse
-	err := c.JSONClient.Do("GET", route, nil, &response, token)
+	err := c.get(ctx, "GetUserSpaces", route, &response, token)
 	if err != nil {
 		return nil, fmt.Errorf("json client do: %s", err)
 	}
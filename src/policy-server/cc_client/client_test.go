@@ -1,19 +1,26 @@
 package cc_client_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"lib/tracing"
+	tracingfakes "lib/tracing/fakes"
 	"net/http"
+	"os"
 	"policy-server/api"
 	"policy-server/cc_client"
+	"policy-server/cc_client/fakes"
 	"policy-server/cc_client/fixtures"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
-	"code.cloudfoundry.org/cf-networking-helpers/fakes"
 	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/tedsuo/ifrit"
 )
 
 var _ = Describe("Client", func() {
@@ -35,19 +42,19 @@ var _ = Describe("Client", func() {
 	Describe("GetAllAppGUIDs", func() {
 		Context("when there is a single page of app guids", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 					_ = json.Unmarshal([]byte(fixtures.AppsV3), respData)
 					return nil
 				}
 			})
 
 			It("returns the app guids", func() {
-				apps, err := client.GetAllAppGUIDs("some-token")
+				apps, err := client.GetAllAppGUIDs(context.Background(), "some-token")
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-				method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+				_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 				Expect(method).To(Equal("GET"))
 				Expect(route).To(Equal("/v3/apps"))
@@ -66,7 +73,7 @@ var _ = Describe("Client", func() {
 
 		Context("when there are multiple pages", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 					if route == "/v3/apps?page=2&per_page=1" {
 						json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
 					} else if route == "/v3/apps?page=3&per_page=1" {
@@ -79,26 +86,26 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns all the app guids", func() {
-				apps, err := client.GetAllAppGUIDs("some-token")
+				apps, err := client.GetAllAppGUIDs(context.Background(), "some-token")
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
 
-				method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+				_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 				Expect(method).To(Equal("GET"))
 				Expect(route).To(Equal("/v3/apps"))
 				Expect(reqData).To(BeNil())
 				Expect(token).To(Equal("bearer some-token"))
 
-				method, route, reqData, _, token = fakeJSONClient.DoArgsForCall(1)
+				_, method, route, reqData, _, token = fakeJSONClient.DoArgsForCall(1)
 
 				Expect(method).To(Equal("GET"))
 				Expect(route).To(Equal("/v3/apps?page=2&per_page=1"))
 				Expect(reqData).To(BeNil())
 				Expect(token).To(Equal("bearer some-token"))
 
-				method, route, reqData, _, token = fakeJSONClient.DoArgsForCall(2)
+				_, method, route, reqData, _, token = fakeJSONClient.DoArgsForCall(2)
 
 				Expect(method).To(Equal("GET"))
 				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
@@ -119,7 +126,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the error", func() {
-				_, err := client.GetAllAppGUIDs("some-token")
+				_, err := client.GetAllAppGUIDs(context.Background(), "some-token")
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
@@ -127,19 +134,19 @@ var _ = Describe("Client", func() {
 
 	Describe("GetLiveAppGUIDs", func() {
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.AppsV3LiveAppGUIDs), respData)
 				return nil
 			}
 		})
 
 		It("Returns the app guids", func() {
-			appGUIDs, err := client.GetLiveAppGUIDs("some-token", []string{"live-app-1-guid", "live-app-2-guid"})
+			appGUIDs, err := client.GetLiveAppGUIDs(context.Background(), "some-token", []string{"live-app-1-guid", "live-app-2-guid"})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(Equal("/v3/apps?guids=live-app-1-guid%2Clive-app-2-guid&per_page=2"))
@@ -158,22 +165,59 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the error", func() {
-				_, err := client.GetLiveAppGUIDs("some-token", []string{})
+				_, err := client.GetLiveAppGUIDs(context.Background(), "some-token", []string{"some-guid"})
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
 
 		Context("when there are multiple pages", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-					_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+					if route == "/v3/apps?page=2&per_page=1" {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
+					} else if route == "/v3/apps?page=3&per_page=1" {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg3), respData)
+					} else {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					}
 					return nil
 				}
 			})
 
-			It("should immediately return an error", func() {
-				_, err := client.GetLiveAppGUIDs("some-token", []string{})
-				Expect(err).To(MatchError("pagination support not yet implemented"))
+			It("follows the next page links", func() {
+				appGUIDs, err := client.GetLiveAppGUIDs(context.Background(), "some-token", []string{"live-app-1-guid", "live-app-2-guid", "live-app-3-guid"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(1)
+				Expect(route).To(Equal("/v3/apps?page=2&per_page=1"))
+				_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
+
+				Expect(appGUIDs).To(Equal(map[string]struct{}{
+					"live-app-1-guid": {},
+					"live-app-2-guid": {},
+					"live-app-3-guid": {},
+				}))
+			})
+		})
+
+		Context("when there are more guids than fit in one request", func() {
+			It("splits them across requests", func() {
+				guids := make([]string, cc_client.GUIDsPerRequest+1)
+				for i := range guids {
+					guids[i] = fmt.Sprintf("app-%d-guid", i)
+				}
+
+				_, err := client.GetLiveAppGUIDs(context.Background(), "some-token", guids)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+				_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+				Expect(route).To(HaveSuffix(fmt.Sprintf("per_page=%d", cc_client.GUIDsPerRequest)))
+				Expect(route).NotTo(ContainSubstring(guids[cc_client.GUIDsPerRequest]))
+				_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+				Expect(route).To(Equal(fmt.Sprintf("/v3/apps?guids=%s&per_page=1", guids[cc_client.GUIDsPerRequest])))
 			})
 		})
 	})
@@ -184,7 +228,7 @@ var _ = Describe("Client", func() {
 		)
 
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				passedToken = token
 				if route == "/v3/spaces?page=2" {
 					_ = json.Unmarshal([]byte(fixtures.LiveSpacesPage2), respData)
@@ -196,7 +240,7 @@ var _ = Describe("Client", func() {
 		})
 
 		It("returns the live space guids filtered by given space guids", func() {
-			liveSpaceGUIDs, err := client.GetLiveSpaceGUIDs("some-token", []string{"live-space-1-guid", "live-space-2-guid", "dead-space-1-guid"})
+			liveSpaceGUIDs, err := client.GetLiveSpaceGUIDs(context.Background(), "some-token", []string{"live-space-1-guid", "live-space-2-guid", "dead-space-1-guid"})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(liveSpaceGUIDs).To(Equal(map[string]struct{}{
 				"live-space-1-guid": {},
@@ -212,27 +256,97 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the error", func() {
-				_, err := client.GetLiveSpaceGUIDs("some-token", []string{})
+				_, err := client.GetLiveSpaceGUIDs(context.Background(), "some-token", []string{})
+				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
+			})
+		})
+	})
+
+	Describe("GetLiveOrgGUIDs", func() {
+		var (
+			passedToken string
+		)
+
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				passedToken = token
+				if route == "/v3/organizations?page=2" {
+					_ = json.Unmarshal([]byte(fixtures.LiveOrgsPage2), respData)
+				} else {
+					_ = json.Unmarshal([]byte(fixtures.LiveOrgsPage1), respData)
+				}
+				return nil
+			}
+		})
+
+		It("returns the live org guids filtered by given org guids", func() {
+			liveOrgGUIDs, err := client.GetLiveOrgGUIDs(context.Background(), "some-token", []string{"live-org-1-guid", "live-org-2-guid", "dead-org-1-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(liveOrgGUIDs).To(Equal(map[string]struct{}{
+				"live-org-1-guid": {},
+				"live-org-2-guid": {},
+			}))
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+			_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+			Expect(route).To(Equal("/v3/organizations"))
+			Expect(passedToken).To(Equal("bearer some-token"))
+
+			_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/organizations?page=2"))
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := client.GetLiveOrgGUIDs(context.Background(), "some-token", []string{})
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
 	})
 
+	Describe("Ping", func() {
+		It("requests a single organization", func() {
+			err := client.Ping(context.Background(), "some-token")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
+			_, method, route, _, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/organizations?per_page=1"))
+			Expect(token).To(Equal("bearer some-token"))
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				err := client.Ping(context.Background(), "some-token")
+				Expect(err).To(MatchError("json client do: banana"))
+			})
+		})
+	})
+
 	Describe("GetSpaceGUIDs", func() {
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.AppsV3), respData)
 				return nil
 			}
 		})
 
 		It("Returns the space guids", func() {
-			spaceGUIDs, err := client.GetSpaceGUIDs("some-token", []string{"live-app-1-guid", "live-app-2-guid"})
+			spaceGUIDs, err := client.GetSpaceGUIDs(context.Background(), "some-token", []string{"live-app-1-guid", "live-app-2-guid"})
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(Equal("/v3/apps?guids=live-app-1-guid%2Clive-app-2-guid&per_page=2"))
@@ -244,7 +358,7 @@ var _ = Describe("Client", func() {
 
 		Context("when called with an empty list of app GUIDs", func() {
 			It("returns an empty slice of space guids", func() {
-				spaceGUIDs, err := client.GetSpaceGUIDs("some-token", []string{})
+				spaceGUIDs, err := client.GetSpaceGUIDs(context.Background(), "some-token", []string{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(spaceGUIDs).To(BeEmpty())
 			})
@@ -252,7 +366,7 @@ var _ = Describe("Client", func() {
 
 		Context("when called with nil list of app GUIDs", func() {
 			It("returns an empty slice of space guids", func() {
-				spaceGUIDs, err := client.GetSpaceGUIDs("some-token", nil)
+				spaceGUIDs, err := client.GetSpaceGUIDs(context.Background(), "some-token", nil)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(spaceGUIDs).To(BeEmpty())
 			})
@@ -264,7 +378,116 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetSpaceGUIDs("some-token", []string{"foo"})
+				_, err := client.GetSpaceGUIDs(context.Background(), "some-token", []string{"foo"})
+				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
+			})
+		})
+	})
+
+	Describe("GetSecurityGroups", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				if route == "/v3/security_groups?page=2" {
+					_ = json.Unmarshal([]byte(fixtures.SecurityGroupsPage2), respData)
+				} else {
+					_ = json.Unmarshal([]byte(fixtures.SecurityGroupsPage1), respData)
+				}
+				return nil
+			}
+		})
+
+		It("returns the security groups from every page", func() {
+			securityGroups, err := client.GetSecurityGroups(context.Background(), "some-token")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/security_groups"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(Equal("bearer some-token"))
+
+			_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/security_groups?page=2"))
+
+			Expect(securityGroups).To(HaveLen(2))
+
+			Expect(securityGroups[0].GUID).To(Equal("asg-1-guid"))
+			Expect(securityGroups[0].Name).To(Equal("public-networks"))
+			Expect(securityGroups[0].GloballyEnabled.Running).To(BeTrue())
+			Expect(securityGroups[0].GloballyEnabled.Staging).To(BeFalse())
+			Expect(securityGroups[0].Rules).To(HaveLen(2))
+			Expect(securityGroups[0].Rules[0].Protocol).To(Equal("tcp"))
+			Expect(securityGroups[0].Rules[0].Destination).To(Equal("10.10.10.0/24"))
+			Expect(securityGroups[0].Rules[0].Ports).To(Equal("443,80,8080"))
+			Expect(securityGroups[0].Rules[0].Description).To(Equal("web traffic"))
+			Expect(*securityGroups[0].Rules[1].Type).To(Equal(8))
+			Expect(*securityGroups[0].Rules[1].Code).To(Equal(0))
+			Expect(securityGroups[0].Relationships.RunningSpaces.Data).To(HaveLen(1))
+			Expect(securityGroups[0].Relationships.RunningSpaces.Data[0].GUID).To(Equal("space-1-guid"))
+
+			Expect(securityGroups[1].GUID).To(Equal("asg-2-guid"))
+			Expect(securityGroups[1].Relationships.StagingSpaces.Data[0].GUID).To(Equal("space-2-guid"))
+			Expect(securityGroups[1].Relationships.RunningSpaces.Data).To(HaveLen(2))
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := client.GetSecurityGroups(context.Background(), "some-token")
+				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
+			})
+		})
+	})
+
+	Describe("GetAuditEvents", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				if route == "/v3/audit_events?page=2" {
+					_ = json.Unmarshal([]byte(fixtures.AuditEventsPage2), respData)
+				} else {
+					_ = json.Unmarshal([]byte(fixtures.AuditEventsPage1), respData)
+				}
+				return nil
+			}
+		})
+
+		It("returns the audit events created at or after the given time from every page", func() {
+			createdAfter := time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC)
+			auditEvents, err := client.GetAuditEvents(context.Background(), "some-token", []string{"audit.app.delete-request", "audit.space.delete-request"}, createdAfter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/audit_events?created_ats%5Bgte%5D=2020-03-10T09%3A00%3A00Z&order_by=created_at&types=audit.app.delete-request%2Caudit.space.delete-request"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(Equal("bearer some-token"))
+
+			_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+			Expect(route).To(Equal("/v3/audit_events?page=2"))
+
+			Expect(auditEvents).To(HaveLen(3))
+			Expect(auditEvents[0].GUID).To(Equal("event-1-guid"))
+			Expect(auditEvents[0].Type).To(Equal("audit.app.delete-request"))
+			Expect(auditEvents[0].CreatedAt).To(Equal(time.Date(2020, 3, 10, 10, 0, 0, 0, time.UTC)))
+			Expect(auditEvents[0].Target.GUID).To(Equal("app-1-guid"))
+			Expect(auditEvents[0].Target.Type).To(Equal("app"))
+			Expect(auditEvents[1].Type).To(Equal("audit.space.delete-request"))
+			Expect(auditEvents[1].Target.GUID).To(Equal("space-2-guid"))
+			Expect(auditEvents[2].Target.GUID).To(Equal("app-2-guid"))
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := client.GetAuditEvents(context.Background(), "some-token", []string{"audit.app.delete-request"}, time.Now())
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
@@ -272,7 +495,7 @@ var _ = Describe("Client", func() {
 
 	Describe("GetSpace", func() {
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.Space), respData)
 				return nil
 			}
@@ -284,12 +507,12 @@ var _ = Describe("Client", func() {
 				OrgGUID: "6e1ca5aa-55f1-4110-a97f-1f3473e771b9",
 			}
 
-			matchingSpace, err := client.GetSpace("some-token", "some-space-guid")
+			matchingSpace, err := client.GetSpace(context.Background(), "some-token", "some-space-guid")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(Equal("/v2/spaces/some-space-guid"))
@@ -299,13 +522,24 @@ var _ = Describe("Client", func() {
 			Expect(matchingSpace).To(Equal(&space))
 		})
 
+		It("makes the request within the given context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := client.GetSpace(ctx, "some-token", "some-space-guid")
+			Expect(err).NotTo(HaveOccurred())
+
+			passedCtx, _, _, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+			Expect(passedCtx.Err()).To(Equal(context.Canceled))
+		})
+
 		Context("when the json client returns an error", func() {
 			BeforeEach(func() {
 				fakeJSONClient.DoReturns(errors.New("banana"))
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetSpace("some-token", "some-space-guid")
+				_, err := client.GetSpace(context.Background(), "some-token", "some-space-guid")
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
@@ -319,7 +553,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns nil", func() {
-				space, err := client.GetSpace("some-token", "some-space-guid")
+				space, err := client.GetSpace(context.Background(), "some-token", "some-space-guid")
 				Expect(err).NotTo(HaveOccurred())
 				Expect(space).To(BeNil())
 			})
@@ -334,10 +568,59 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetSpace("some-token", "some-space-guid")
+				_, err := client.GetSpace(context.Background(), "some-token", "some-space-guid")
 				Expect(err).To(MatchError(ContainSubstring("json client do: http status 418: i am a teapot")))
 			})
 		})
+
+		Context("when a tracer is set", func() {
+			var (
+				exporter *tracingfakes.SpanExporter
+				process  ifrit.Process
+			)
+
+			BeforeEach(func() {
+				exporter = &tracingfakes.SpanExporter{}
+				client.Tracer = tracing.NewTracer(exporter, logger, 10*time.Millisecond, 10)
+				process = ifrit.Invoke(client.Tracer)
+			})
+
+			AfterEach(func() {
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive())
+			})
+
+			It("records a client span for the request", func() {
+				ctx, parent := client.Tracer.Start(context.Background(), "parent")
+				_, err := client.GetSpace(ctx, "some-token", "some-space-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(exporter.ExportSpansCallCount).Should(Equal(1))
+				spans := exporter.ExportSpansArgsForCall(0)
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("cc_client.GetSpace"))
+				Expect(spans[0].Kind).To(Equal(tracing.SpanKindClient))
+				Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+				Expect(spans[0].Attributes).To(HaveKeyWithValue("http.target", "/v2/spaces/some-space-guid"))
+				Expect(spans[0].Error).To(BeFalse())
+			})
+
+			Context("when the request fails", func() {
+				BeforeEach(func() {
+					fakeJSONClient.DoReturns(errors.New("banana"))
+				})
+
+				It("marks the span as failed", func() {
+					_, err := client.GetSpace(context.Background(), "some-token", "some-space-guid")
+					Expect(err).To(HaveOccurred())
+
+					Eventually(exporter.ExportSpansCallCount).Should(Equal(1))
+					spans := exporter.ExportSpansArgsForCall(0)
+					Expect(spans[0].Error).To(BeTrue())
+					Expect(spans[0].StatusMessage).To(Equal("banana"))
+				})
+			})
+		})
 	})
 
 	Describe("GetAppSpaces", func() {
@@ -360,19 +643,19 @@ var _ = Describe("Client", func() {
 				"live-app-4-guid": "space-2-guid",
 				"live-app-5-guid": "space-3-guid",
 			}
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.AppsV3), respData)
 				return nil
 			}
 		})
 
 		It("returns the map from app to its space", func() {
-			appSpaceMap, err := client.GetAppSpaces("some-token", appGUIDs)
+			appSpaceMap, err := client.GetAppSpaces(context.Background(), "some-token", appGUIDs)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(ContainSubstring("/v3/apps?guids="))
@@ -388,7 +671,7 @@ var _ = Describe("Client", func() {
 
 		Context("when the list of app GUIDs is empty", func() {
 			It("returns an empty slice", func() {
-				appSpaceMap, err := client.GetAppSpaces("some-token", []string{})
+				appSpaceMap, err := client.GetAppSpaces(context.Background(), "some-token", []string{})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(appSpaceMap).To(BeEmpty())
 			})
@@ -400,41 +683,252 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetAppSpaces("some-token", []string{"some-guid"})
+				_, err := client.GetAppSpaces(context.Background(), "some-token", []string{"some-guid"})
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
 
 		Context("when there are multiple pages", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
-					_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+					if route == "/v3/apps?page=2&per_page=1" {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg2), respData)
+					} else if route == "/v3/apps?page=3&per_page=1" {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePagesPg3), respData)
+					} else {
+						_ = json.Unmarshal([]byte(fixtures.AppsV3MultiplePages), respData)
+					}
 					return nil
 				}
 			})
 
-			It("should immediately return an error", func() {
-				_, err := client.GetAppSpaces("some-token", []string{"some-guid"})
-				Expect(err).To(MatchError("pagination support not yet implemented"))
+			It("follows the next page links", func() {
+				appSpaces, err := client.GetAppSpaces(context.Background(), "some-token", []string{"live-app-1-guid", "live-app-2-guid", "live-app-3-guid"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(3))
+				_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(1)
+				Expect(route).To(Equal("/v3/apps?page=2&per_page=1"))
+				_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(2)
+				Expect(route).To(Equal("/v3/apps?page=3&per_page=1"))
+
+				Expect(appSpaces).To(Equal(map[string]string{
+					"live-app-1-guid": "space-1-guid",
+					"live-app-2-guid": "space-1-guid",
+					"live-app-3-guid": "space-2-guid",
+				}))
+			})
+		})
+
+		Context("when there are more guids than fit in one request", func() {
+			It("splits them across requests", func() {
+				guids := make([]string, cc_client.GUIDsPerRequest+1)
+				for i := range guids {
+					guids[i] = fmt.Sprintf("app-%d-guid", i)
+				}
+
+				_, err := client.GetAppSpaces(context.Background(), "some-token", guids)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+				_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+				Expect(route).To(HaveSuffix(fmt.Sprintf("per_page=%d", cc_client.GUIDsPerRequest)))
+				Expect(route).NotTo(ContainSubstring(guids[cc_client.GUIDsPerRequest]))
+				_, _, route, _, _, _ = fakeJSONClient.DoArgsForCall(1)
+				Expect(route).To(Equal(fmt.Sprintf("/v3/apps?guids=%s&per_page=1", guids[cc_client.GUIDsPerRequest])))
+			})
+		})
+	})
+
+	Describe("GetAppNames", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				_ = json.Unmarshal([]byte(`{
+					"pagination": {"total_pages": 1},
+					"resources": [
+						{"guid": "app-1-guid", "name": "app-1"},
+						{"guid": "app-2-guid", "name": "app-2"}
+					]
+				}`), respData)
+				return nil
+			}
+		})
+
+		It("returns the map from app guid to app name", func() {
+			names, err := client.GetAppNames(context.Background(), "some-token", []string{"app-1-guid", "app-2-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(Equal(map[string]string{
+				"app-1-guid": "app-1",
+				"app-2-guid": "app-2",
+			}))
+
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/apps?guids=app-1-guid%2Capp-2-guid&per_page=2"))
+			Expect(reqData).To(BeNil())
+			Expect(token).To(Equal("bearer some-token"))
+		})
+
+		Context("when the list of app GUIDs is empty", func() {
+			It("does not call CC", func() {
+				names, err := client.GetAppNames(context.Background(), "some-token", []string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names).To(BeEmpty())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns a helpful error", func() {
+				_, err := client.GetAppNames(context.Background(), "some-token", []string{"some-guid"})
+				Expect(err).To(MatchError("json client do: banana"))
 			})
 		})
+
+		Context("when there are more guids than fit in one request", func() {
+			It("splits them across requests", func() {
+				guids := make([]string, 2*cc_client.GUIDsPerRequest)
+				for i := range guids {
+					guids[i] = fmt.Sprintf("app-%d-guid", i)
+				}
+
+				_, err := client.GetAppNames(context.Background(), "some-token", guids)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when the names span several pages", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+					if route == "/v3/apps?page=2&per_page=1" {
+						_ = json.Unmarshal([]byte(`{"resources": [{"guid": "app-2-guid", "name": "app-2"}]}`), respData)
+					} else {
+						_ = json.Unmarshal([]byte(`{
+							"pagination": {"next": {"href": "https://api.example.com/v3/apps?page=2&per_page=1"}},
+							"resources": [{"guid": "app-1-guid", "name": "app-1"}]
+						}`), respData)
+					}
+					return nil
+				}
+			})
+
+			It("returns the names from every page", func() {
+				names, err := client.GetAppNames(context.Background(), "some-token", []string{"app-1-guid", "app-2-guid"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(names).To(Equal(map[string]string{
+					"app-1-guid": "app-1",
+					"app-2-guid": "app-2",
+				}))
+			})
+		})
+	})
+
+	Describe("GetSpaceNames", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				_ = json.Unmarshal([]byte(`{
+					"pagination": {"total_pages": 1},
+					"resources": [{"guid": "live-space-3-guid", "name": "space-3"}]
+				}`), respData)
+				return nil
+			}
+		})
+
+		It("returns the map from space guid to space name", func() {
+			names, err := client.GetSpaceNames(context.Background(), "some-token", []string{"live-space-3-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveKeyWithValue("live-space-3-guid", "space-3"))
+
+			_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+			Expect(route).To(Equal("/v3/spaces?guids=live-space-3-guid&per_page=1"))
+		})
+	})
+
+	Describe("GetSpaceOrgs", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				_ = json.Unmarshal([]byte(fixtures.LiveSpacesPage2), respData)
+				return nil
+			}
+		})
+
+		It("returns the map from space guid to org guid", func() {
+			orgs, err := client.GetSpaceOrgs(context.Background(), "some-token", []string{"live-space-2-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgs).To(Equal(map[string]string{
+				"live-space-2-guid": "3638bc38-4e7a-45c9-8119-40af6f58b088",
+			}))
+
+			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
+			_, method, route, _, _, token := fakeJSONClient.DoArgsForCall(0)
+			Expect(method).To(Equal("GET"))
+			Expect(route).To(Equal("/v3/spaces?guids=live-space-2-guid&per_page=1"))
+			Expect(token).To(Equal("bearer some-token"))
+		})
+
+		Context("when there are no spaces", func() {
+			It("does not call Cloud Controller", func() {
+				orgs, err := client.GetSpaceOrgs(context.Background(), "some-token", []string{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(orgs).To(BeEmpty())
+				Expect(fakeJSONClient.DoCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the json client returns an error", func() {
+			BeforeEach(func() {
+				fakeJSONClient.DoStub = nil
+				fakeJSONClient.DoReturns(errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, err := client.GetSpaceOrgs(context.Background(), "some-token", []string{"live-space-2-guid"})
+				Expect(err).To(MatchError("json client do: banana"))
+			})
+		})
+	})
+
+	Describe("GetOrgNames", func() {
+		BeforeEach(func() {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
+				_ = json.Unmarshal([]byte(`{
+					"pagination": {"total_pages": 1},
+					"resources": [{"guid": "live-org-2-guid", "name": "org-2"}]
+				}`), respData)
+				return nil
+			}
+		})
+
+		It("returns the map from org guid to org name", func() {
+			names, err := client.GetOrgNames(context.Background(), "some-token", []string{"live-org-2-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(names).To(HaveKeyWithValue("live-org-2-guid", "org-2"))
+
+			_, _, route, _, _, _ := fakeJSONClient.DoArgsForCall(0)
+			Expect(route).To(Equal("/v3/organizations?guids=live-org-2-guid&per_page=1"))
+		})
 	})
 
 	Describe("GetUserSpaces", func() {
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.UserSpaces), respData)
 				return nil
 			}
 		})
 
 		It("returns the list of spaces a user has access to", func() {
-			userSpaces, err := client.GetUserSpaces("some-token", "some-user-guid")
+			userSpaces, err := client.GetUserSpaces(context.Background(), "some-token", "some-user-guid")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(Equal("/v2/users/some-user-guid/spaces"))
@@ -453,7 +947,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetUserSpaces("some-token", "some-user-guid")
+				_, err := client.GetUserSpaces(context.Background(), "some-token", "some-user-guid")
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
@@ -465,19 +959,19 @@ var _ = Describe("Client", func() {
 			OrgGUID: "some-org-guid",
 		}
 		BeforeEach(func() {
-			fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+			fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 				_ = json.Unmarshal([]byte(fixtures.UserSpace), respData)
 				return nil
 			}
 		})
 
 		It("returns the matching spaces for the user", func() {
-			matchingSpace, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+			matchingSpace, err := client.GetUserSpace(context.Background(), "some-token", "some-developer-guid", space)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeJSONClient.DoCallCount()).To(Equal(1))
 
-			method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
+			_, method, route, reqData, _, token := fakeJSONClient.DoArgsForCall(0)
 
 			Expect(method).To(Equal("GET"))
 			Expect(route).To(Equal("/v2/spaces?q=developer_guid%3Asome-developer-guid&q=name%3Asome-space-name&q=organization_guid%3Asome-org-guid"))
@@ -489,14 +983,14 @@ var _ = Describe("Client", func() {
 
 		Context("when the user has no spaces", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 					_ = json.Unmarshal([]byte(fixtures.UserSpaceEmpty), respData)
 					return nil
 				}
 			})
 
 			It("returns nil", func() {
-				space, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+				space, err := client.GetUserSpace(context.Background(), "some-token", "some-developer-guid", space)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(space).To(BeNil())
 			})
@@ -504,14 +998,14 @@ var _ = Describe("Client", func() {
 
 		Context("when more than one space is returned", func() {
 			BeforeEach(func() {
-				fakeJSONClient.DoStub = func(method, route string, reqData, respData interface{}, token string) error {
+				fakeJSONClient.DoStub = func(_ context.Context, method, route string, reqData, respData interface{}, token string) error {
 					_ = json.Unmarshal([]byte(fixtures.Spaces), respData)
 					return nil
 				}
 			})
 
 			It("returns an error", func() {
-				_, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+				_, err := client.GetUserSpace(context.Background(), "some-token", "some-developer-guid", space)
 				Expect(err).To(MatchError("found more than one matching space"))
 			})
 		})
@@ -522,7 +1016,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetUserSpace("some-token", "some-developer-guid", space)
+				_, err := client.GetUserSpace(context.Background(), "some-token", "some-developer-guid", space)
 				Expect(err).To(MatchError(ContainSubstring("json client do: banana")))
 			})
 		})
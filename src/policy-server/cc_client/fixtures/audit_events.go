@@ -0,0 +1,120 @@
+package fixtures
+
+const AuditEventsPage1 = `{
+   "pagination": {
+      "total_results": 3,
+      "total_pages": 2,
+      "first": {
+         "href": "https://api.example.com/v3/audit_events?page=1"
+      },
+      "last": {
+         "href": "https://api.example.com/v3/audit_events?page=2"
+      },
+      "next": {
+         "href": "https://api.example.com/v3/audit_events?page=2"
+      },
+      "previous": null
+   },
+   "resources": [
+      {
+         "guid": "event-1-guid",
+         "created_at": "2020-03-10T10:00:00Z",
+         "updated_at": "2020-03-10T10:00:00Z",
+         "type": "audit.app.delete-request",
+         "actor": {
+            "guid": "user-guid",
+            "type": "user",
+            "name": "admin"
+         },
+         "target": {
+            "guid": "app-1-guid",
+            "type": "app",
+            "name": "my-app"
+         },
+         "data": {
+            "request": {
+               "recursive": true
+            }
+         },
+         "space": {
+            "guid": "space-1-guid"
+         },
+         "organization": {
+            "guid": "org-1-guid"
+         }
+      },
+      {
+         "guid": "event-2-guid",
+         "created_at": "2020-03-10T10:00:05Z",
+         "updated_at": "2020-03-10T10:00:05Z",
+         "type": "audit.space.delete-request",
+         "actor": {
+            "guid": "user-guid",
+            "type": "user",
+            "name": "admin"
+         },
+         "target": {
+            "guid": "space-2-guid",
+            "type": "space",
+            "name": "my-space"
+         },
+         "data": {
+            "request": {
+               "recursive": true
+            }
+         },
+         "space": {
+            "guid": "space-2-guid"
+         },
+         "organization": {
+            "guid": "org-1-guid"
+         }
+      }
+   ]
+}`
+
+const AuditEventsPage2 = `{
+   "pagination": {
+      "total_results": 3,
+      "total_pages": 2,
+      "first": {
+         "href": "https://api.example.com/v3/audit_events?page=1"
+      },
+      "last": {
+         "href": "https://api.example.com/v3/audit_events?page=2"
+      },
+      "next": null,
+      "previous": {
+         "href": "https://api.example.com/v3/audit_events?page=1"
+      }
+   },
+   "resources": [
+      {
+         "guid": "event-3-guid",
+         "created_at": "2020-03-10T10:01:00Z",
+         "updated_at": "2020-03-10T10:01:00Z",
+         "type": "audit.app.delete-request",
+         "actor": {
+            "guid": "user-guid",
+            "type": "user",
+            "name": "admin"
+         },
+         "target": {
+            "guid": "app-2-guid",
+            "type": "app",
+            "name": "my-other-app"
+         },
+         "data": {
+            "request": {
+               "recursive": false
+            }
+         },
+         "space": {
+            "guid": "space-1-guid"
+         },
+         "organization": {
+            "guid": "org-1-guid"
+         }
+      }
+   ]
+}`
@@ -0,0 +1,111 @@
+package fixtures
+
+const SecurityGroupsPage1 = `{
+   "pagination": {
+      "total_results": 2,
+      "total_pages": 2,
+      "first": {
+         "href": "https://api.example.com/v3/security_groups?page=1"
+      },
+      "last": {
+         "href": "https://api.example.com/v3/security_groups?page=2"
+      },
+      "next": {
+         "href": "https://api.example.com/v3/security_groups?page=2"
+      },
+      "previous": null
+   },
+   "resources": [
+      {
+         "guid": "asg-1-guid",
+         "created_at": "2020-02-20T17:42:08Z",
+         "updated_at": "2020-02-20T17:42:08Z",
+         "name": "public-networks",
+         "globally_enabled": {
+            "running": true,
+            "staging": false
+         },
+         "rules": [
+            {
+               "protocol": "tcp",
+               "destination": "10.10.10.0/24",
+               "ports": "443,80,8080",
+               "description": "web traffic"
+            },
+            {
+               "protocol": "icmp",
+               "destination": "10.10.11.0/24",
+               "type": 8,
+               "code": 0
+            }
+         ],
+         "relationships": {
+            "staging_spaces": {
+               "data": []
+            },
+            "running_spaces": {
+               "data": [
+                  {
+                     "guid": "space-1-guid"
+                  }
+               ]
+            }
+         }
+      }
+   ]
+}`
+
+const SecurityGroupsPage2 = `{
+   "pagination": {
+      "total_results": 2,
+      "total_pages": 2,
+      "first": {
+         "href": "https://api.example.com/v3/security_groups?page=1"
+      },
+      "last": {
+         "href": "https://api.example.com/v3/security_groups?page=2"
+      },
+      "next": null,
+      "previous": {
+         "href": "https://api.example.com/v3/security_groups?page=1"
+      }
+   },
+   "resources": [
+      {
+         "guid": "asg-2-guid",
+         "created_at": "2020-02-20T17:42:08Z",
+         "updated_at": "2020-02-20T17:42:08Z",
+         "name": "dns",
+         "globally_enabled": {
+            "running": false,
+            "staging": true
+         },
+         "rules": [
+            {
+               "protocol": "udp",
+               "destination": "10.0.0.1-10.0.0.3",
+               "ports": "53"
+            }
+         ],
+         "relationships": {
+            "staging_spaces": {
+               "data": [
+                  {
+                     "guid": "space-2-guid"
+                  }
+               ]
+            },
+            "running_spaces": {
+               "data": [
+                  {
+                     "guid": "space-2-guid"
+                  },
+                  {
+                     "guid": "space-3-guid"
+                  }
+               ]
+            }
+         }
+      }
+   ]
+}`
@@ -0,0 +1,49 @@
+package cc_client
+
+import (
+	"context"
+	"lib/tracing"
+	"net/http"
+
+	"code.cloudfoundry.org/cf-networking-helpers/json_client"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/json_client.go --fake-name JSONClient . jsonClient
+type jsonClient interface {
+	Do(ctx context.Context, method, route string, reqData, respData interface{}, token string) error
+}
+
+// ContextJSONClient makes the same requests as json_client.JsonClient, but
+// binds each one to a context so that a Cloud Controller call is abandoned
+// once the request that needed it is cancelled or out of time. Each request
+// also carries the traceparent header of the span in its context, so Cloud
+// Controller can continue the trace.
+type ContextJSONClient struct {
+	Logger     lager.Logger
+	HTTPClient json_client.HttpClient
+	URL        string
+}
+
+func NewContextJSONClient(logger lager.Logger, httpClient json_client.HttpClient, url string) *ContextJSONClient {
+	return &ContextJSONClient{
+		Logger:     logger,
+		HTTPClient: httpClient,
+		URL:        url,
+	}
+}
+
+func (c *ContextJSONClient) Do(ctx context.Context, method, route string, reqData, respData interface{}, token string) error {
+	httpClient := &contextHTTPClient{ctx: ctx, httpClient: c.HTTPClient}
+	return json_client.New(c.Logger, httpClient, c.URL).Do(method, route, reqData, respData, token)
+}
+
+type contextHTTPClient struct {
+	ctx        context.Context
+	httpClient json_client.HttpClient
+}
+
+func (c *contextHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	tracing.Inject(c.ctx, req.Header)
+	return c.httpClient.Do(req.WithContext(c.ctx))
+}
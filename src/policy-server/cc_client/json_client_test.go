@@ -0,0 +1,74 @@
+package cc_client_test
+
+import (
+	"context"
+	"lib/tracing"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/cc_client"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContextJSONClient", func() {
+	var (
+		server          *httptest.Server
+		jsonClient      *cc_client.ContextJSONClient
+		receivedHeaders http.Header
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			Expect(req.URL.Path).To(Equal("/v3/organizations"))
+			Expect(req.Header.Get("Authorization")).To(Equal("bearer some-token"))
+			receivedHeaders = req.Header
+			w.Write([]byte(`{"resources": [{"guid": "some-org-guid"}]}`))
+		}))
+		jsonClient = cc_client.NewContextJSONClient(lagertest.NewTestLogger("test"), http.DefaultClient, server.URL)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("makes the request and decodes the response", func() {
+		var response cc_client.OrganizationsV3Response
+		err := jsonClient.Do(context.Background(), "GET", "/v3/organizations", nil, &response, "bearer some-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(response.Resources).To(HaveLen(1))
+		Expect(response.Resources[0].GUID).To(Equal("some-org-guid"))
+	})
+
+	It("does not set a traceparent header without a span in the context", func() {
+		var response cc_client.OrganizationsV3Response
+		err := jsonClient.Do(context.Background(), "GET", "/v3/organizations", nil, &response, "bearer some-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(receivedHeaders.Get("traceparent")).To(BeEmpty())
+	})
+
+	Context("when the context carries a span", func() {
+		It("sets the traceparent header so Cloud Controller can continue the trace", func() {
+			sc, err := tracing.ParseTraceparent("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01")
+			Expect(err).NotTo(HaveOccurred())
+			ctx := tracing.ContextWithSpanContext(context.Background(), sc)
+
+			var response cc_client.OrganizationsV3Response
+			err = jsonClient.Do(ctx, "GET", "/v3/organizations", nil, &response, "bearer some-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(receivedHeaders.Get("traceparent")).To(Equal("00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"))
+		})
+	})
+
+	Context("when the context is done", func() {
+		It("abandons the request", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var response cc_client.OrganizationsV3Response
+			err := jsonClient.Do(ctx, "GET", "/v3/organizations", nil, &response, "bearer some-token")
+			Expect(err).To(MatchError(ContainSubstring("context canceled")))
+		})
+	})
+})
@@ -0,0 +1,259 @@
+package cleaner
+
+import (
+	"context"
+	"fmt"
+	"policy-server/cc_client"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	AppDeleteEventType   = "audit.app.delete-request"
+	SpaceDeleteEventType = "audit.space.delete-request"
+)
+
+//go:generate counterfeiter -o fakes/events_cc_client.go --fake-name EventsCCClient . eventsCCClient
+type eventsCCClient interface {
+	GetAuditEvents(ctx context.Context, token string, eventTypes []string, createdAfter time.Time) ([]cc_client.AuditEvent, error)
+	GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error)
+	GetLiveSpaceGUIDs(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_by_guids_store.go --fake-name PolicyByGuidsStore . policyByGuidsStore
+type policyByGuidsStore interface {
+	ByGuids(ctx context.Context, srcGuids, dstGuids []string, inSourceAndDest bool) ([]store.Policy, error)
+	Delete(context.Context, []store.Policy) error
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_by_source_store.go --fake-name EgressPolicyBySourceStore . egressPolicyBySourceStore
+type egressPolicyBySourceStore interface {
+	GetByFilter(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error)
+	Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
+}
+
+// EventCleaner deletes the policies of apps and spaces as soon as Cloud
+// Controller records their deletion in its audit events. Only the events
+// created since the previous poll are read, so it is much cheaper than the
+// full scan done by PolicyCleaner, which still runs to catch anything missed
+// here, such as events from before startup or targets that were not yet gone
+// when their event was seen.
+//
+// Cloud Controller filters audit events by whole seconds, so each poll also
+// returns the events from the second of the last event seen. Their GUIDs are
+// remembered so that they are only handled once.
+type EventCleaner struct {
+	Logger         lager.Logger
+	Store          policyByGuidsStore
+	EgressStore    egressPolicyBySourceStore
+	UAAClient      uaaClient
+	CCClient       eventsCCClient
+	MetricsSender  metricsSender
+	TombstoneStore tombstoneStore
+	DryRun         bool
+	LastEventTime  time.Time
+
+	seenEventGUIDs map[string]struct{}
+}
+
+func NewEventCleaner(logger lager.Logger, store policyByGuidsStore, egressStore egressPolicyBySourceStore, uaaClient uaaClient,
+	ccClient eventsCCClient, metricsSender metricsSender) *EventCleaner {
+	return &EventCleaner{
+		Logger:        logger,
+		Store:         store,
+		EgressStore:   egressStore,
+		UAAClient:     uaaClient,
+		CCClient:      ccClient,
+		MetricsSender: metricsSender,
+		LastEventTime: time.Now(),
+	}
+}
+
+func (e *EventCleaner) DeletePoliciesForDeletedResources() error {
+	ctx := context.Background()
+
+	token, err := e.UAAClient.GetToken(ctx)
+	if err != nil {
+		e.Logger.Error("get-uaa-token-failed", err)
+		return fmt.Errorf("get UAA token failed: %s", err)
+	}
+
+	auditEvents, err := e.CCClient.GetAuditEvents(ctx, token, []string{AppDeleteEventType, SpaceDeleteEventType}, e.LastEventTime)
+	if err != nil {
+		e.Logger.Error("cc-get-audit-events-failed", err)
+		return fmt.Errorf("get audit events from Cloud-Controller failed: %s", err)
+	}
+
+	newEvents := e.unseenEvents(auditEvents)
+	if len(newEvents) == 0 {
+		return nil
+	}
+
+	var appGUIDs, spaceGUIDs []string
+	for _, auditEvent := range newEvents {
+		switch auditEvent.Type {
+		case AppDeleteEventType:
+			appGUIDs = append(appGUIDs, auditEvent.Target.GUID)
+		case SpaceDeleteEventType:
+			spaceGUIDs = append(spaceGUIDs, auditEvent.Target.GUID)
+		}
+	}
+
+	deletedAppGUIDs, err := e.deletedGUIDs(appGUIDs, func(guids []string) (map[string]struct{}, error) {
+		return e.CCClient.GetLiveAppGUIDs(ctx, token, guids)
+	})
+	if err != nil {
+		e.Logger.Error("cc-get-app-guids-failed", err)
+		return fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
+	}
+
+	deletedSpaceGUIDs, err := e.deletedGUIDs(spaceGUIDs, func(guids []string) (map[string]struct{}, error) {
+		return e.CCClient.GetLiveSpaceGUIDs(ctx, token, guids)
+	})
+	if err != nil {
+		e.Logger.Error("get-live-space-guids-failed", err)
+		return fmt.Errorf("get live space guids failed: %s", err)
+	}
+
+	err = e.deletePolicies(ctx, deletedAppGUIDs, deletedSpaceGUIDs)
+	if err != nil {
+		return err
+	}
+
+	e.LastEventTime = auditEvents[len(auditEvents)-1].CreatedAt
+	e.seenEventGUIDs = map[string]struct{}{}
+	for _, auditEvent := range auditEvents {
+		if !auditEvent.CreatedAt.Before(e.LastEventTime.Truncate(time.Second)) {
+			e.seenEventGUIDs[auditEvent.GUID] = struct{}{}
+		}
+	}
+	return nil
+}
+
+func (e *EventCleaner) unseenEvents(auditEvents []cc_client.AuditEvent) []cc_client.AuditEvent {
+	var unseen []cc_client.AuditEvent
+	for _, auditEvent := range auditEvents {
+		if _, ok := e.seenEventGUIDs[auditEvent.GUID]; !ok {
+			unseen = append(unseen, auditEvent)
+		}
+	}
+	return unseen
+}
+
+func (e *EventCleaner) deletePolicies(ctx context.Context, appGUIDs, spaceGUIDs []string) error {
+	policies := []store.Policy{}
+	if len(appGUIDs) > 0 {
+		var err error
+		policies, err = e.Store.ByGuids(ctx, appGUIDs, appGUIDs, false)
+		if err != nil {
+			e.Logger.Error("store-list-policies-failed", err)
+			return fmt.Errorf("database read failed for c2c policies: %s", err)
+		}
+	}
+
+	egressPolicies := []store.EgressPolicy{}
+	sourceGUIDs := append(append([]string{}, appGUIDs...), spaceGUIDs...)
+	if len(sourceGUIDs) > 0 {
+		var err error
+		egressPolicies, err = e.EgressStore.GetByFilter(ctx, store.EgressPolicyFilter{
+			SourceIDs:   sourceGUIDs,
+			SourceTypes: []string{"app", "space"},
+		})
+		if err != nil {
+			e.Logger.Error("store-list-policies-failed", err)
+			return fmt.Errorf("database read failed for egress policies: %s", err)
+		}
+	}
+
+	if len(policies) == 0 && len(egressPolicies) == 0 {
+		return nil
+	}
+
+	if e.DryRun {
+		e.Logger.Info("found policies of deleted resources (dry run):", lager.Data{
+			"deleted_apps":          appGUIDs,
+			"deleted_spaces":        spaceGUIDs,
+			"total_c2c_policies":    len(policies),
+			"c2c_policies":          policies,
+			"total_egress_policies": len(egressPolicies),
+			"egress_policies":       egressPolicies,
+		})
+		return nil
+	}
+
+	e.Logger.Info("deleting policies of deleted resources:", lager.Data{
+		"deleted_apps":          appGUIDs,
+		"deleted_spaces":        spaceGUIDs,
+		"total_c2c_policies":    len(policies),
+		"total_egress_policies": len(egressPolicies),
+	})
+
+	var err error
+	if e.TombstoneStore != nil {
+		err = e.TombstoneStore.DeleteWithTombstones(ctx, policies, egressPolicies)
+		if err != nil {
+			e.Logger.Error("store-delete-policies-with-tombstones-failed", err)
+			return fmt.Errorf("database write failed: %s", err)
+		}
+	} else {
+		err = e.deleteWithoutTombstones(ctx, policies, egressPolicies)
+		if err != nil {
+			return err
+		}
+	}
+
+	for range policies {
+		e.MetricsSender.IncrementCounter("EventCleanupPoliciesDeleted")
+	}
+	for range egressPolicies {
+		e.MetricsSender.IncrementCounter("EventCleanupEgressPoliciesDeleted")
+	}
+
+	return nil
+}
+
+func (e *EventCleaner) deleteWithoutTombstones(ctx context.Context, policies []store.Policy, egressPolicies []store.EgressPolicy) error {
+	if len(policies) > 0 {
+		err := e.Store.Delete(ctx, policies)
+		if err != nil {
+			e.Logger.Error("store-delete-policies-failed", err)
+			return fmt.Errorf("database write failed: %s", err)
+		}
+	}
+
+	if len(egressPolicies) > 0 {
+		egressPolicyGUIDs := make([]string, len(egressPolicies))
+		for i, egressPolicy := range egressPolicies {
+			egressPolicyGUIDs[i] = egressPolicy.ID
+		}
+
+		_, err := e.EgressStore.Delete(ctx, egressPolicyGUIDs...)
+		if err != nil {
+			e.Logger.Error("egress-store-delete-policies-failed", err)
+			return fmt.Errorf("database write failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *EventCleaner) deletedGUIDs(guids []string, getLiveGUIDs func([]string) (map[string]struct{}, error)) ([]string, error) {
+	if len(guids) == 0 {
+		return nil, nil
+	}
+
+	liveGUIDs, err := getLiveGUIDs(guids)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedGUIDs []string
+	for _, guid := range guids {
+		if _, ok := liveGUIDs[guid]; !ok {
+			deletedGUIDs = append(deletedGUIDs, guid)
+		}
+	}
+	return deletedGUIDs, nil
+}
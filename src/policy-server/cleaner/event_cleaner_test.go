@@ -0,0 +1,391 @@
+package cleaner_test
+
+import (
+	"context"
+	"errors"
+	"policy-server/cc_client"
+	"policy-server/cleaner"
+	"policy-server/cleaner/fakes"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("EventCleaner", func() {
+	var (
+		eventCleaner    *cleaner.EventCleaner
+		fakeStore       *fakes.PolicyByGuidsStore
+		fakeEgressStore *fakes.EgressPolicyBySourceStore
+		fakeUAAClient   *fakes.UAAClient
+		fakeCCClient    *fakes.EventsCCClient
+		fakeMetrics     *fakes.MetricsSender
+		logger          *lagertest.TestLogger
+		startTime       time.Time
+		c2cPolicies     []store.Policy
+		egressPolicies  []store.EgressPolicy
+
+		defaultEgressPolicy = store.EgressPolicy{
+			ID:     "default-egress-policy-guid",
+			Source: store.EgressSource{Type: "default"},
+		}
+	)
+
+	contains := func(values []string, value string) bool {
+		for _, v := range values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+
+	auditEvent := func(guid, eventType, targetGUID string, createdAt time.Time) cc_client.AuditEvent {
+		event := cc_client.AuditEvent{GUID: guid, Type: eventType, CreatedAt: createdAt}
+		event.Target.GUID = targetGUID
+		return event
+	}
+
+	BeforeEach(func() {
+		c2cPolicies = []store.Policy{{
+			Source: store.Source{ID: "deleted-app-guid"},
+			Destination: store.Destination{
+				ID:       "live-app-guid",
+				Protocol: "tcp",
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			ID:     "egress-policy-guid-1",
+			Source: store.EgressSource{ID: "deleted-app-guid", Type: "app"},
+		}, {
+			ID:     "egress-policy-guid-2",
+			Source: store.EgressSource{ID: "deleted-space-guid", Type: "space"},
+		}}
+
+		fakeStore = &fakes.PolicyByGuidsStore{}
+		fakeStore.ByGuidsReturns(c2cPolicies, nil)
+		fakeEgressStore = &fakes.EgressPolicyBySourceStore{}
+		fakeEgressStore.GetByFilterStub = func(_ context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error) {
+			var matching []store.EgressPolicy
+			for _, policy := range append(egressPolicies, defaultEgressPolicy) {
+				if contains(filter.SourceIDs, policy.Source.ID) && contains(filter.SourceTypes, policy.Source.Type) {
+					matching = append(matching, policy)
+				}
+			}
+			return matching, nil
+		}
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("valid-token", nil)
+		fakeMetrics = &fakes.MetricsSender{}
+		logger = lagertest.NewTestLogger("test")
+
+		startTime = time.Date(2020, 3, 10, 9, 0, 0, 0, time.UTC)
+		fakeCCClient = &fakes.EventsCCClient{}
+		fakeCCClient.GetAuditEventsReturns([]cc_client.AuditEvent{
+			auditEvent("event-1", "audit.app.delete-request", "deleted-app-guid", startTime.Add(time.Second)),
+			auditEvent("event-2", "audit.app.delete-request", "still-live-app-guid", startTime.Add(2*time.Second)),
+			auditEvent("event-3", "audit.space.delete-request", "deleted-space-guid", startTime.Add(3*time.Second)),
+		}, nil)
+		fakeCCClient.GetLiveAppGUIDsReturns(map[string]struct{}{"still-live-app-guid": {}}, nil)
+		fakeCCClient.GetLiveSpaceGUIDsReturns(map[string]struct{}{}, nil)
+
+		eventCleaner = cleaner.NewEventCleaner(logger, fakeStore, fakeEgressStore, fakeUAAClient, fakeCCClient, fakeMetrics)
+		eventCleaner.LastEventTime = startTime
+	})
+
+	It("starts from the current time", func() {
+		eventCleaner = cleaner.NewEventCleaner(logger, fakeStore, fakeEgressStore, fakeUAAClient, fakeCCClient, fakeMetrics)
+		Expect(eventCleaner.LastEventTime).To(BeTemporally("~", time.Now(), time.Minute))
+	})
+
+	It("deletes the policies of apps and spaces deleted since the last poll", func() {
+		err := eventCleaner.DeletePoliciesForDeletedResources()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(fakeCCClient.GetAuditEventsCallCount()).To(Equal(1))
+		_, token, eventTypes, createdAfter := fakeCCClient.GetAuditEventsArgsForCall(0)
+		Expect(token).To(Equal("valid-token"))
+		Expect(eventTypes).To(Equal([]string{"audit.app.delete-request", "audit.space.delete-request"}))
+		Expect(createdAfter).To(Equal(startTime))
+
+		_, _, appGUIDs := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
+		Expect(appGUIDs).To(Equal([]string{"deleted-app-guid", "still-live-app-guid"}))
+		_, _, spaceGUIDs := fakeCCClient.GetLiveSpaceGUIDsArgsForCall(0)
+		Expect(spaceGUIDs).To(Equal([]string{"deleted-space-guid"}))
+
+		Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
+		_, srcGUIDs, dstGUIDs, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+		Expect(srcGUIDs).To(Equal([]string{"deleted-app-guid"}))
+		Expect(dstGUIDs).To(Equal([]string{"deleted-app-guid"}))
+		Expect(inSourceAndDest).To(BeFalse())
+
+		_, filter := fakeEgressStore.GetByFilterArgsForCall(0)
+		Expect(filter).To(Equal(store.EgressPolicyFilter{
+			SourceIDs:   []string{"deleted-app-guid", "deleted-space-guid"},
+			SourceTypes: []string{"app", "space"},
+		}))
+
+		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+		_, deleted := fakeStore.DeleteArgsForCall(0)
+		Expect(deleted).To(Equal(c2cPolicies))
+		Expect(fakeEgressStore.DeleteCallCount()).To(Equal(1))
+		_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+		Expect(deletedGUIDs).To(Equal([]string{"egress-policy-guid-1", "egress-policy-guid-2"}))
+
+		Expect(logger).To(gbytes.Say("deleting policies of deleted resources:.*deleted_apps.*deleted-app-guid.*deleted_spaces.*deleted-space-guid"))
+		Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(3))
+		Expect(fakeMetrics.IncrementCounterArgsForCall(0)).To(Equal("EventCleanupPoliciesDeleted"))
+		Expect(fakeMetrics.IncrementCounterArgsForCall(1)).To(Equal("EventCleanupEgressPoliciesDeleted"))
+
+		Expect(eventCleaner.LastEventTime).To(Equal(startTime.Add(3 * time.Second)))
+	})
+
+	Context("when there is a default egress policy", func() {
+		It("does not delete it", func() {
+			eventCleaner.TombstoneStore = &fakes.TombstoneStore{}
+
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			tombstoneStore := eventCleaner.TombstoneStore.(*fakes.TombstoneStore)
+			Expect(tombstoneStore.DeleteWithTombstonesCallCount()).To(Equal(1))
+			_, _, deletedEgressPolicies := tombstoneStore.DeleteWithTombstonesArgsForCall(0)
+			Expect(deletedEgressPolicies).To(Equal(egressPolicies))
+			Expect(deletedEgressPolicies).NotTo(ContainElement(defaultEgressPolicy))
+		})
+	})
+
+	Context("when there are no new events", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAuditEventsReturns([]cc_client.AuditEvent{}, nil)
+		})
+
+		It("does nothing", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(0))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.GetByFilterCallCount()).To(Equal(0))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime))
+		})
+	})
+
+	Context("when only spaces were deleted", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAuditEventsReturns([]cc_client.AuditEvent{
+				auditEvent("event-3", "audit.space.delete-request", "deleted-space-guid", startTime.Add(time.Second)),
+			}, nil)
+		})
+
+		It("only deletes egress policies", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(0))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+			Expect(deletedGUIDs).To(Equal([]string{"egress-policy-guid-2"}))
+		})
+	})
+
+	Context("when the next poll returns events from the same second again", func() {
+		It("only handles the events it has not seen", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeCCClient.GetAuditEventsReturns([]cc_client.AuditEvent{
+				auditEvent("event-3", "audit.space.delete-request", "deleted-space-guid", startTime.Add(3*time.Second)),
+				auditEvent("event-4", "audit.app.delete-request", "another-deleted-app-guid", startTime.Add(3*time.Second+500*time.Millisecond)),
+			}, nil)
+
+			err = eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, createdAfter := fakeCCClient.GetAuditEventsArgsForCall(1)
+			Expect(createdAfter).To(Equal(startTime.Add(3 * time.Second)))
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(2))
+			_, _, appGUIDs := fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
+			Expect(appGUIDs).To(Equal([]string{"another-deleted-app-guid"}))
+			Expect(fakeCCClient.GetLiveSpaceGUIDsCallCount()).To(Equal(1))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime.Add(3*time.Second + 500*time.Millisecond)))
+		})
+
+		It("does nothing when every event has been seen", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeCCClient.GetAuditEventsReturns([]cc_client.AuditEvent{
+				auditEvent("event-3", "audit.space.delete-request", "deleted-space-guid", startTime.Add(3*time.Second)),
+			}, nil)
+
+			err = eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(1))
+			Expect(fakeCCClient.GetLiveSpaceGUIDsCallCount()).To(Equal(1))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime.Add(3 * time.Second)))
+		})
+	})
+
+	Context("when dry run is set", func() {
+		BeforeEach(func() {
+			eventCleaner.DryRun = true
+			eventCleaner.TombstoneStore = &fakes.TombstoneStore{}
+		})
+
+		It("logs the policies without deleting them", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
+			Expect(fakeEgressStore.GetByFilterCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+			Expect(eventCleaner.TombstoneStore.(*fakes.TombstoneStore).DeleteWithTombstonesCallCount()).To(Equal(0))
+			Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(0))
+
+			Expect(logger).To(gbytes.Say("found policies of deleted resources \\(dry run\\):.*deleted_apps.*deleted-app-guid"))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime.Add(3 * time.Second)))
+		})
+	})
+
+	Context("when a tombstone store is configured", func() {
+		var fakeTombstoneStore *fakes.TombstoneStore
+
+		BeforeEach(func() {
+			fakeTombstoneStore = &fakes.TombstoneStore{}
+			eventCleaner.TombstoneStore = fakeTombstoneStore
+		})
+
+		It("deletes the policies along with recording them as tombstones", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTombstoneStore.DeleteWithTombstonesCallCount()).To(Equal(1))
+			_, tombstonePolicies, tombstoneEgressPolicies := fakeTombstoneStore.DeleteWithTombstonesArgsForCall(0)
+			Expect(tombstonePolicies).To(Equal(c2cPolicies))
+			Expect(tombstoneEgressPolicies).To(Equal(egressPolicies))
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(len(c2cPolicies) + len(egressPolicies)))
+		})
+
+		Context("when deleting the policies with their tombstones fails", func() {
+			BeforeEach(func() {
+				fakeTombstoneStore.DeleteWithTombstonesReturns(errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				err := eventCleaner.DeletePoliciesForDeletedResources()
+				Expect(err).To(MatchError("database write failed: potato"))
+				Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Context("when getting the UAA token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("get UAA token failed: potato"))
+			Expect(logger).To(gbytes.Say("get-uaa-token-failed.*potato"))
+		})
+	})
+
+	Context("when getting the audit events fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAuditEventsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("get audit events from Cloud-Controller failed: potato"))
+			Expect(logger).To(gbytes.Say("cc-get-audit-events-failed.*potato"))
+		})
+	})
+
+	Context("when checking the apps fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetLiveAppGUIDsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error and does not advance past the events", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("get app guids from Cloud-Controller failed: potato"))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime))
+		})
+	})
+
+	Context("when checking the spaces fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetLiveSpaceGUIDsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("get live space guids failed: potato"))
+		})
+	})
+
+	Context("when listing the c2c policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.ByGuidsReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("database read failed for c2c policies: potato"))
+		})
+	})
+
+	Context("when listing the egress policies fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.GetByFilterStub = nil
+			fakeEgressStore.GetByFilterReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("database read failed for egress policies: potato"))
+		})
+	})
+
+	Context("when deleting the c2c policies fails", func() {
+		BeforeEach(func() {
+			fakeStore.DeleteReturns(errors.New("potato"))
+		})
+
+		It("returns an error and does not advance past the events", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("database write failed: potato"))
+			Expect(logger).To(gbytes.Say("store-delete-policies-failed.*potato"))
+			Expect(eventCleaner.LastEventTime).To(Equal(startTime))
+		})
+	})
+
+	Context("when deleting the egress policies fails", func() {
+		BeforeEach(func() {
+			fakeEgressStore.DeleteReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an error", func() {
+			err := eventCleaner.DeletePoliciesForDeletedResources()
+			Expect(err).To(MatchError("database write failed: potato"))
+			Expect(logger).To(gbytes.Say("egress-store-delete-policies-failed.*potato"))
+		})
+	})
+})
@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type EgressPolicyStore struct {
-	AllStub        func() ([]store.EgressPolicy, error)
+	AllStub        func(context.Context) ([]store.EgressPolicy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		arg1 context.Context
+	}
+	allReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -18,9 +21,10 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	DeleteStub        func(guids ...string) ([]store.EgressPolicy, error)
+	DeleteStub        func(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
+		ctx   context.Context
 		guids []string
 	}
 	deleteReturns struct {
@@ -35,14 +39,16 @@ type EgressPolicyStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyStore) All() ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) All(arg1 context.Context) ([]store.EgressPolicy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,6 +62,12 @@ func (fake *EgressPolicyStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *EgressPolicyStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].arg1
+}
+
 func (fake *EgressPolicyStore) AllReturns(result1 []store.EgressPolicy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -78,16 +90,17 @@ func (fake *EgressPolicyStore) AllReturnsOnCall(i int, result1 []store.EgressPol
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) Delete(guids ...string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		ctx   context.Context
 		guids []string
-	}{guids})
-	fake.recordInvocation("Delete", []interface{}{guids})
+	}{ctx, guids})
+	fake.recordInvocation("Delete", []interface{}{ctx, guids})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(guids...)
+		return fake.DeleteStub(ctx, guids...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -101,10 +114,10 @@ func (fake *EgressPolicyStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *EgressPolicyStore) DeleteArgsForCall(i int) []string {
+func (fake *EgressPolicyStore) DeleteArgsForCall(i int) (context.Context, []string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].guids
+	return fake.deleteArgsForCall[i].ctx, fake.deleteArgsForCall[i].guids
 }
 
 func (fake *EgressPolicyStore) DeleteReturns(result1 []store.EgressPolicy, result2 error) {
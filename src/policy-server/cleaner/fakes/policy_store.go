@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type PolicyStore struct {
-	AllStub        func() ([]store.Policy, error)
+	AllStub        func(context.Context) ([]store.Policy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		arg1 context.Context
+	}
+	allReturns struct {
 		result1 []store.Policy
 		result2 error
 	}
@@ -18,10 +21,11 @@ type PolicyStore struct {
 		result1 []store.Policy
 		result2 error
 	}
-	DeleteStub        func([]store.Policy) error
+	DeleteStub        func(context.Context, []store.Policy) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 []store.Policy
+		arg1 context.Context
+		arg2 []store.Policy
 	}
 	deleteReturns struct {
 		result1 error
@@ -33,14 +37,16 @@ type PolicyStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyStore) All() ([]store.Policy, error) {
+func (fake *PolicyStore) All(arg1 context.Context) ([]store.Policy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -54,6 +60,12 @@ func (fake *PolicyStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *PolicyStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].arg1
+}
+
 func (fake *PolicyStore) AllReturns(result1 []store.Policy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -76,21 +88,22 @@ func (fake *PolicyStore) AllReturnsOnCall(i int, result1 []store.Policy, result2
 	}{result1, result2}
 }
 
-func (fake *PolicyStore) Delete(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *PolicyStore) Delete(arg1 context.Context, arg2 []store.Policy) error {
+	var arg2Copy []store.Policy
+	if arg2 != nil {
+		arg2Copy = make([]store.Policy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Delete", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.Policy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2Copy})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1)
+		return fake.DeleteStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -104,10 +117,10 @@ func (fake *PolicyStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *PolicyStore) DeleteArgsForCall(i int) []store.Policy {
+func (fake *PolicyStore) DeleteArgsForCall(i int) (context.Context, []store.Policy) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
 }
 
 func (fake *PolicyStore) DeleteReturns(result1 error) {
@@ -2,14 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"sync"
 )
 
 type UAAClient struct {
-	GetTokenStub        func() (string, error)
+	GetTokenStub        func(context.Context) (string, error)
 	getTokenMutex       sync.RWMutex
-	getTokenArgsForCall []struct{}
-	getTokenReturns     struct {
+	getTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	getTokenReturns struct {
 		result1 string
 		result2 error
 	}
@@ -21,14 +24,16 @@ type UAAClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *UAAClient) GetToken() (string, error) {
+func (fake *UAAClient) GetToken(arg1 context.Context) (string, error) {
 	fake.getTokenMutex.Lock()
 	ret, specificReturn := fake.getTokenReturnsOnCall[len(fake.getTokenArgsForCall)]
-	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct{}{})
-	fake.recordInvocation("GetToken", []interface{}{})
+	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("GetToken", []interface{}{arg1})
 	fake.getTokenMutex.Unlock()
 	if fake.GetTokenStub != nil {
-		return fake.GetTokenStub()
+		return fake.GetTokenStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -42,6 +47,12 @@ func (fake *UAAClient) GetTokenCallCount() int {
 	return len(fake.getTokenArgsForCall)
 }
 
+func (fake *UAAClient) GetTokenArgsForCall(i int) context.Context {
+	fake.getTokenMutex.RLock()
+	defer fake.getTokenMutex.RUnlock()
+	return fake.getTokenArgsForCall[i].arg1
+}
+
 func (fake *UAAClient) GetTokenReturns(result1 string, result2 error) {
 	fake.GetTokenStub = nil
 	fake.getTokenReturns = struct {
@@ -1,8 +1,10 @@
 package cleaner
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/lager"
@@ -10,25 +12,43 @@ import (
 
 //go:generate counterfeiter -o fakes/uua_client.go --fake-name UAAClient . uaaClient
 type uaaClient interface {
-	GetToken() (string, error)
+	GetToken(context.Context) (string, error)
 }
 
 //go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
 type ccClient interface {
-	GetLiveAppGUIDs(token string, appGUIDs []string) (map[string]struct{}, error)
-	GetLiveSpaceGUIDs(token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveAppGUIDs(ctx context.Context, token string, appGUIDs []string) (map[string]struct{}, error)
+	GetLiveSpaceGUIDs(ctx context.Context, token string, spaceGUIDs []string) (map[string]struct{}, error)
+	GetLiveOrgGUIDs(ctx context.Context, token string, orgGUIDs []string) (map[string]struct{}, error)
+}
+
+//go:generate counterfeiter -o fakes/metrics_sender.go --fake-name MetricsSender . metricsSender
+type metricsSender interface {
+	IncrementCounter(string)
 }
 
 //go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
 type policyStore interface {
-	All() ([]store.Policy, error)
-	Delete([]store.Policy) error
+	All(context.Context) ([]store.Policy, error)
+	Delete(context.Context, []store.Policy) error
 }
 
 //go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
 type egressPolicyStore interface {
-	All() ([]store.EgressPolicy, error)
-	Delete(guids ...string) ([]store.EgressPolicy, error)
+	All(context.Context) ([]store.EgressPolicy, error)
+	Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
+}
+
+//go:generate counterfeiter -o fakes/tombstone_store.go --fake-name TombstoneStore . tombstoneStore
+type tombstoneStore interface {
+	DeleteWithTombstones(context.Context, []store.Policy, []store.EgressPolicy) error
+	DeleteOlderThan(time.Time) (int64, error)
+}
+
+//go:generate counterfeiter -o fakes/tag_store.go --fake-name TagStore . tagStore
+type tagStore interface {
+	Tags() ([]store.Tag, error)
+	ReleaseTags([]string) (int64, error)
 }
 
 type PolicyCleaner struct {
@@ -39,10 +59,29 @@ type PolicyCleaner struct {
 	CCClient              ccClient
 	CCAppRequestChunkSize int
 	RequestTimeout        time.Duration
+	MetricsSender         metricsSender
+	DryRun                bool
+	MaxDeletePercent      int
+	TombstoneStore        tombstoneStore
+	TombstoneRetention    time.Duration
+	TagStore              tagStore
+
+	lastSuccessMutex sync.Mutex
+	lastSuccess      time.Time
+}
+
+type ThresholdExceededError struct {
+	Stale int
+	Total int
+	Limit int
+}
+
+func (e ThresholdExceededError) Error() string {
+	return fmt.Sprintf("refusing to delete %d of %d policies, more than the cleanup limit of %d%%", e.Stale, e.Total, e.Limit)
 }
 
 func NewPolicyCleaner(logger lager.Logger, store policyStore, egressStore egressPolicyStore, uaaClient uaaClient,
-	ccClient ccClient, ccAppRequestChunkSize int, requestTimeout time.Duration) *PolicyCleaner {
+	ccClient ccClient, ccAppRequestChunkSize int, requestTimeout time.Duration, metricsSender metricsSender) *PolicyCleaner {
 	return &PolicyCleaner{
 		Logger:                logger,
 		Store:                 store,
@@ -51,77 +90,254 @@ func NewPolicyCleaner(logger lager.Logger, store policyStore, egressStore egress
 		CCClient:              ccClient,
 		CCAppRequestChunkSize: ccAppRequestChunkSize,
 		RequestTimeout:        requestTimeout,
+		MetricsSender:         metricsSender,
 	}
 }
 
 func (p *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
-	policies, err := p.Store.All()
+	return p.CleanupStalePolicies(context.Background(), p.DryRun, false)
+}
+
+// CleanupStalePolicies finds c2c and egress policies that reference apps,
+// spaces or orgs that no longer exist in Cloud Controller, along with expired
+// policies, and deletes them. When dryRun is set the policies are only
+// reported. Unless confirmed is set, nothing is deleted if the stale policies
+// exceed MaxDeletePercent of all policies, since a partial response from
+// Cloud Controller would otherwise look like a mass deletion of apps. When a
+// TombstoneStore is set, stale policies are deleted through it so that their
+// tombstones are written in the same transaction and they can be restored.
+// Finally, when a TagStore is set, the
+// tags of dead apps that no longer have any c2c policy are released so the
+// tag space does not run out.
+func (p *PolicyCleaner) CleanupStalePolicies(ctx context.Context, dryRun, confirmed bool) ([]store.Policy, []store.EgressPolicy, error) {
+	policies, err := p.Store.All(ctx)
 	if err != nil {
 		p.Logger.Error("store-list-policies-failed", err)
 		return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("database read failed for c2c policies: %s", err)
 	}
 
-	egressPolicies, err := p.EgressStore.All()
+	egressPolicies, err := p.EgressStore.All(ctx)
 	if err != nil {
 		p.Logger.Error("store-list-policies-failed", err)
 		return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("database read failed for egress policies: %s", err)
 	}
 
-	token, err := p.UAAClient.GetToken()
+	token, err := p.UAAClient.GetToken(ctx)
 	if err != nil {
 		p.Logger.Error("get-uaa-token-failed", err)
 		return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("get UAA token failed: %s", err)
 	}
 
-	policiesToDelete, err := p.getC2CPoliciesToDelete(policies, token)
+	policiesToDelete, err := p.getC2CPoliciesToDelete(ctx, policies, token)
 	if err != nil {
 		return []store.Policy{}, []store.EgressPolicy{}, err
 	}
 
-	egressPoliciesToDelete, err := p.getEgressPoliciesToDelete(egressPolicies, token)
+	egressPoliciesToDelete, err := p.getEgressPoliciesToDelete(ctx, egressPolicies, token)
 	if err != nil {
 		return []store.Policy{}, []store.EgressPolicy{}, err
 	}
 
+	staleCount := len(policiesToDelete) + len(egressPoliciesToDelete)
+	totalCount := len(policies) + len(egressPolicies)
+	if !dryRun && !confirmed && p.exceedsDeleteThreshold(staleCount, totalCount) {
+		err := ThresholdExceededError{Stale: staleCount, Total: totalCount, Limit: p.MaxDeletePercent}
+		p.Logger.Error("cleanup-threshold-exceeded", err, lager.Data{
+			"total_c2c_policies":    len(policiesToDelete),
+			"total_egress_policies": len(egressPoliciesToDelete),
+		})
+		p.MetricsSender.IncrementCounter("CleanupThresholdExceeded")
+		return []store.Policy{}, []store.EgressPolicy{}, err
+	}
+
+	expiredPolicies := getExpiredPolicies(policies, policiesToDelete, time.Now())
+	expiredEgressPolicies := getExpiredEgressPolicies(egressPolicies, egressPoliciesToDelete, time.Now())
+	if len(expiredPolicies) > 0 || len(expiredEgressPolicies) > 0 {
+		p.Logger.Info("deleting expired policies:", lager.Data{
+			"total_c2c_policies":    len(expiredPolicies),
+			"total_egress_policies": len(expiredEgressPolicies),
+		})
+	}
+	stalePolicies, staleEgressPolicies := policiesToDelete, egressPoliciesToDelete
+	policiesToDelete = append(policiesToDelete, expiredPolicies...)
+	egressPoliciesToDelete = append(egressPoliciesToDelete, expiredEgressPolicies...)
+
+	if dryRun {
+		p.Logger.Info("found stale policies (dry run):", lager.Data{
+			"total_c2c_policies":    len(policiesToDelete),
+			"stale_c2c_policies":    policiesToDelete,
+			"total_egress_policies": len(egressPoliciesToDelete),
+			"stale_egress_policies": egressPoliciesToDelete,
+		})
+		return policiesToDelete, egressPoliciesToDelete, nil
+	}
+
 	p.Logger.Info("deleting stale policies:", lager.Data{
 		"total_c2c_policies":    len(policiesToDelete),
 		"stale_c2c_policies":    policiesToDelete,
 		"total_egress_policies": len(egressPoliciesToDelete),
 		"stale_egress_policies": egressPoliciesToDelete,
 	})
-	err = p.Store.Delete(policiesToDelete)
+
+	untombstonedPolicies, untombstonedEgressPolicies := policiesToDelete, egressPoliciesToDelete
+	if p.TombstoneStore != nil && staleCount > 0 {
+		err = p.TombstoneStore.DeleteWithTombstones(ctx, stalePolicies, staleEgressPolicies)
+		if err != nil {
+			p.Logger.Error("store-delete-policies-with-tombstones-failed", err)
+			return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("database write failed: %s", err)
+		}
+		untombstonedPolicies, untombstonedEgressPolicies = expiredPolicies, expiredEgressPolicies
+	}
+
+	err = p.Store.Delete(ctx, untombstonedPolicies)
 	if err != nil {
 		p.Logger.Error("store-delete-policies-failed", err)
 		return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("database write failed: %s", err)
 	}
 
-	egressPoliciesGUIDsToDelete := make([]string, len(egressPoliciesToDelete))
-	for i, egressPolicy := range egressPoliciesToDelete {
+	egressPoliciesGUIDsToDelete := make([]string, len(untombstonedEgressPolicies))
+	for i, egressPolicy := range untombstonedEgressPolicies {
 		egressPoliciesGUIDsToDelete[i] = egressPolicy.ID
 	}
 
-	_, err = p.EgressStore.Delete(egressPoliciesGUIDsToDelete...)
+	_, err = p.EgressStore.Delete(ctx, egressPoliciesGUIDsToDelete...)
 	if err != nil {
 		p.Logger.Error("egress-store-delete-policies-failed", err)
 		return []store.Policy{}, []store.EgressPolicy{}, fmt.Errorf("database write failed: %s", err)
 	}
 
+	for range expiredPolicies {
+		p.MetricsSender.IncrementCounter("PoliciesExpired")
+	}
+	for range expiredEgressPolicies {
+		p.MetricsSender.IncrementCounter("EgressPoliciesExpired")
+	}
+
+	p.purgeTombstones()
+	p.reclaimTags(ctx, token, policies, policiesToDelete, confirmed)
+
 	return policiesToDelete, egressPoliciesToDelete, nil
 }
 
+func (p *PolicyCleaner) purgeTombstones() {
+	if p.TombstoneStore == nil || p.TombstoneRetention <= 0 {
+		return
+	}
+
+	purged, err := p.TombstoneStore.DeleteOlderThan(time.Now().Add(-p.TombstoneRetention))
+	if err != nil {
+		p.Logger.Error("store-purge-tombstones-failed", err)
+		return
+	}
+	if purged > 0 {
+		p.Logger.Info("purged-tombstones", lager.Data{"total_tombstones": purged})
+	}
+}
+
+func (p *PolicyCleaner) reclaimTags(ctx context.Context, token string, policies, deletedPolicies []store.Policy, confirmed bool) {
+	if p.TagStore == nil {
+		return
+	}
+
+	tags, err := p.TagStore.Tags()
+	if err != nil {
+		p.Logger.Error("store-list-tags-failed", err)
+		return
+	}
+
+	inUse := make(map[string]struct{})
+	for _, policy := range policies {
+		if containsPolicy(deletedPolicies, policy) {
+			continue
+		}
+		inUse[policy.Source.ID] = struct{}{}
+		inUse[policy.Destination.ID] = struct{}{}
+	}
+
+	var appGUIDs []string
+	for _, tag := range tags {
+		if tag.Type != "app" {
+			continue
+		}
+		if _, ok := inUse[tag.ID]; ok {
+			continue
+		}
+		appGUIDs = append(appGUIDs, tag.ID)
+	}
+
+	var deadAppGUIDs []string
+	for _, appGUIDchunk := range getChunks(appGUIDs, p.CCAppRequestChunkSize) {
+		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(ctx, token, appGUIDchunk)
+		if err != nil {
+			p.Logger.Error("cc-get-app-guids-failed", err)
+			return
+		}
+
+		for _, guid := range appGUIDchunk {
+			if _, ok := liveAppGUIDs[guid]; !ok {
+				deadAppGUIDs = append(deadAppGUIDs, guid)
+			}
+		}
+	}
+
+	if len(deadAppGUIDs) == 0 {
+		return
+	}
+
+	if !confirmed && p.exceedsDeleteThreshold(len(deadAppGUIDs), len(tags)) {
+		err := fmt.Errorf("refusing to release %d of %d tags, more than the cleanup limit of %d%%", len(deadAppGUIDs), len(tags), p.MaxDeletePercent)
+		p.Logger.Error("reclaim-tags-threshold-exceeded", err)
+		p.MetricsSender.IncrementCounter("CleanupThresholdExceeded")
+		return
+	}
+
+	released, err := p.TagStore.ReleaseTags(deadAppGUIDs)
+	if err != nil {
+		p.Logger.Error("store-release-tags-failed", err)
+		return
+	}
+
+	if released > 0 {
+		p.Logger.Info("reclaimed-tags", lager.Data{"total_tags": released})
+	}
+	for i := int64(0); i < released; i++ {
+		p.MetricsSender.IncrementCounter("TagsReclaimed")
+	}
+}
+
+func (p *PolicyCleaner) exceedsDeleteThreshold(staleCount, totalCount int) bool {
+	if p.MaxDeletePercent <= 0 || totalCount == 0 {
+		return false
+	}
+	return staleCount*100 > totalCount*p.MaxDeletePercent
+}
+
 func (p *PolicyCleaner) DeleteStalePoliciesWrapper() error {
 	_, _, err := p.DeleteStalePolicies()
+	if err == nil {
+		p.lastSuccessMutex.Lock()
+		p.lastSuccess = time.Now()
+		p.lastSuccessMutex.Unlock()
+	}
 	return err
 }
 
-func (p *PolicyCleaner) getC2CPoliciesToDelete(policies []store.Policy, token string) ([]store.Policy, error) {
+// LastSuccessfulRun returns when the periodic cleanup last completed
+// without error, or the zero time if it has not yet.
+func (p *PolicyCleaner) LastSuccessfulRun() time.Time {
+	p.lastSuccessMutex.Lock()
+	defer p.lastSuccessMutex.Unlock()
+	return p.lastSuccess
+}
+
+func (p *PolicyCleaner) getC2CPoliciesToDelete(ctx context.Context, policies []store.Policy, token string) ([]store.Policy, error) {
 	var c2cPoliciesToDelete []store.Policy
 
 	appGUIDs := policyAppGUIDs(policies)
 	appGUIDchunks := getChunks(appGUIDs, p.CCAppRequestChunkSize)
 
 	for _, appGUIDchunk := range appGUIDchunks {
-		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(token, appGUIDchunk)
+		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(ctx, token, appGUIDchunk)
 		if err != nil {
 			p.Logger.Error("cc-get-app-guids-failed", err)
 			return nil, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
@@ -136,11 +352,12 @@ func (p *PolicyCleaner) getC2CPoliciesToDelete(policies []store.Policy, token st
 	return c2cPoliciesToDelete, nil
 }
 
-func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressPolicy, token string) ([]store.EgressPolicy, error) {
-	var spaceEgressPolicyGUIDs, appEgressPolicyGUIDs []string
+func (p *PolicyCleaner) getEgressPoliciesToDelete(ctx context.Context, egressPolicies []store.EgressPolicy, token string) ([]store.EgressPolicy, error) {
+	var spaceEgressPolicyGUIDs, appEgressPolicyGUIDs, orgEgressPolicyGUIDs []string
 	spaceEgressPolicies := make(map[string][]store.EgressPolicy)
 	var egressPoliciesToDelete []store.EgressPolicy
 	appEgressPolicies := make(map[string][]store.EgressPolicy)
+	orgEgressPolicies := make(map[string][]store.EgressPolicy)
 
 	for _, egressPolicy := range egressPolicies {
 		if egressPolicy.Source.Type == "space" {
@@ -151,12 +368,16 @@ func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressP
 			appEgressPolicyGUIDs = append(appEgressPolicyGUIDs, egressPolicy.Source.ID)
 			appEgressPolicies[egressPolicy.Source.ID] = append(appEgressPolicies[egressPolicy.Source.ID], egressPolicy)
 		}
+		if egressPolicy.Source.Type == "org" {
+			orgEgressPolicyGUIDs = append(orgEgressPolicyGUIDs, egressPolicy.Source.ID)
+			orgEgressPolicies[egressPolicy.Source.ID] = append(orgEgressPolicies[egressPolicy.Source.ID], egressPolicy)
+		}
 	}
 
 	appGUIDchunks := getChunks(appEgressPolicyGUIDs, p.CCAppRequestChunkSize)
 
 	for _, appGUIDchunk := range appGUIDchunks {
-		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(token, appGUIDchunk)
+		liveAppGUIDs, err := p.CCClient.GetLiveAppGUIDs(ctx, token, appGUIDchunk)
 		if err != nil {
 			p.Logger.Error("cc-get-app-guids-failed", err)
 			return nil, fmt.Errorf("get app guids from Cloud-Controller failed: %s", err)
@@ -166,25 +387,78 @@ func (p *PolicyCleaner) getEgressPoliciesToDelete(egressPolicies []store.EgressP
 		egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressAppPolicies(appEgressPolicies, staleAppGUIDs)...)
 	}
 
-	liveSpaceGUIDs, err := p.CCClient.GetLiveSpaceGUIDs(token, spaceEgressPolicyGUIDs)
+	liveSpaceGUIDs, err := p.CCClient.GetLiveSpaceGUIDs(ctx, token, spaceEgressPolicyGUIDs)
 	if err != nil {
 		p.Logger.Error("get-live-space-guids-failed", err)
 		return nil, fmt.Errorf("get live space guids failed: %s", err)
 	}
-	egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressSpacePolicies(spaceEgressPolicies, liveSpaceGUIDs)...)
+	egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressPolicies(spaceEgressPolicies, liveSpaceGUIDs)...)
+
+	if len(orgEgressPolicyGUIDs) > 0 {
+		liveOrgGUIDs, err := p.CCClient.GetLiveOrgGUIDs(ctx, token, orgEgressPolicyGUIDs)
+		if err != nil {
+			p.Logger.Error("get-live-org-guids-failed", err)
+			return nil, fmt.Errorf("get live org guids failed: %s", err)
+		}
+		egressPoliciesToDelete = append(egressPoliciesToDelete, getStaleEgressPolicies(orgEgressPolicies, liveOrgGUIDs)...)
+	}
+
 	return egressPoliciesToDelete, nil
 }
 
-func getStaleEgressSpacePolicies(spacePolicies map[string][]store.EgressPolicy, liveSpaceGUIDs map[string]struct{}) []store.EgressPolicy {
-	var staleSpaceEgressPolicies []store.EgressPolicy
-	for spaceGUID := range liveSpaceGUIDs {
-		delete(spacePolicies, spaceGUID)
+func getExpiredPolicies(policies, alreadyDeleting []store.Policy, now time.Time) []store.Policy {
+	var expiredPolicies []store.Policy
+	for _, policy := range policies {
+		if policy.ExpiresAt == nil || policy.ExpiresAt.After(now) {
+			continue
+		}
+		if containsPolicy(alreadyDeleting, policy) {
+			continue
+		}
+		expiredPolicies = append(expiredPolicies, policy)
+	}
+	return expiredPolicies
+}
+
+func containsPolicy(policies []store.Policy, policy store.Policy) bool {
+	for _, p := range policies {
+		if p.Source.ID == policy.Source.ID && p.Destination == policy.Destination {
+			return true
+		}
+	}
+	return false
+}
+
+func getExpiredEgressPolicies(egressPolicies, alreadyDeleting []store.EgressPolicy, now time.Time) []store.EgressPolicy {
+	deleting := make(map[string]struct{})
+	for _, egressPolicy := range alreadyDeleting {
+		deleting[egressPolicy.ID] = struct{}{}
+	}
+
+	var expiredEgressPolicies []store.EgressPolicy
+	for _, egressPolicy := range egressPolicies {
+		if egressPolicy.ExpiresAt == nil || egressPolicy.ExpiresAt.After(now) {
+			continue
+		}
+		if _, ok := deleting[egressPolicy.ID]; ok {
+			continue
+		}
+		deleting[egressPolicy.ID] = struct{}{}
+		expiredEgressPolicies = append(expiredEgressPolicies, egressPolicy)
+	}
+	return expiredEgressPolicies
+}
+
+func getStaleEgressPolicies(sourcePolicies map[string][]store.EgressPolicy, liveSourceGUIDs map[string]struct{}) []store.EgressPolicy {
+	var staleEgressPolicies []store.EgressPolicy
+	for sourceGUID := range liveSourceGUIDs {
+		delete(sourcePolicies, sourceGUID)
 	}
-	for _, policies := range spacePolicies {
-		staleSpaceEgressPolicies = append(staleSpaceEgressPolicies, policies...)
+	for _, policies := range sourcePolicies {
+		staleEgressPolicies = append(staleEgressPolicies, policies...)
 	}
 
-	return staleSpaceEgressPolicies
+	return staleEgressPolicies
 }
 
 func getStaleEgressAppPolicies(appPolicies map[string][]store.EgressPolicy, staleAppGUIDs map[string]struct{}) []store.EgressPolicy {
@@ -1,6 +1,7 @@
 package cleaner_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/cleaner"
 	"policy-server/cleaner/fakes"
@@ -21,6 +22,7 @@ var _ = Describe("PolicyCleaner", func() {
 		fakeEgressStore *fakes.EgressPolicyStore
 		fakeUAAClient   *fakes.UAAClient
 		fakeCCClient    *fakes.CCClient
+		fakeMetrics     *fakes.MetricsSender
 		logger          *lagertest.TestLogger
 		c2cPolicies     []store.Policy
 		egressPolicies  []store.EgressPolicy
@@ -141,14 +143,15 @@ var _ = Describe("PolicyCleaner", func() {
 		fakeEgressStore = &fakes.EgressPolicyStore{}
 		fakeUAAClient = &fakes.UAAClient{}
 		fakeCCClient = &fakes.CCClient{}
+		fakeMetrics = &fakes.MetricsSender{}
 		logger = lagertest.NewTestLogger("test")
-		policyCleaner = cleaner.NewPolicyCleaner(logger, fakeStore, fakeEgressStore, fakeUAAClient, fakeCCClient, 0, 5*time.Second)
+		policyCleaner = cleaner.NewPolicyCleaner(logger, fakeStore, fakeEgressStore, fakeUAAClient, fakeCCClient, 0, 5*time.Second, fakeMetrics)
 
 		fakeUAAClient.GetTokenReturns("valid-token", nil)
 		fakeStore.AllReturns(c2cPolicies, nil)
 		fakeEgressStore.AllReturns(egressPolicies, nil)
 		fakeCCClient.GetLiveSpaceGUIDsReturns(map[string]struct{}{"live-egress-space-guid": {}}, nil)
-		fakeCCClient.GetLiveAppGUIDsStub = func(token string, appGUIDs []string) (map[string]struct{}, error) {
+		fakeCCClient.GetLiveAppGUIDsStub = func(_ context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
 			liveGUIDs := make(map[string]struct{})
 			for _, guid := range appGUIDs {
 				if guid == "live-guid" || guid == "live-egress-app-guid" {
@@ -167,28 +170,30 @@ var _ = Describe("PolicyCleaner", func() {
 		Expect(fakeEgressStore.AllCallCount()).To(Equal(1))
 		Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 		Expect(fakeCCClient.GetLiveSpaceGUIDsCallCount()).To(Equal(1))
-		token0, guids0 := fakeCCClient.GetLiveSpaceGUIDsArgsForCall(0)
+		_, token0, guids0 := fakeCCClient.GetLiveSpaceGUIDsArgsForCall(0)
 		Expect(token0).To(Equal("valid-token"))
 		Expect(guids0).To(ConsistOf(
 			"live-egress-space-guid",
 			"dead-egress-space-guid",
 		))
 		Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(2))
-		token, guids := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
+		_, token, guids := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
 		Expect(token).To(Equal("valid-token"))
 		Expect(guids).To(ConsistOf("live-guid", "dead-guid"))
 
-		_, guids = fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
+		_, _, guids = fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
 		Expect(guids).To(ConsistOf("live-egress-app-guid", "dead-egress-app-guid"))
 
 		stalePolicies := c2cPolicies[1:]
 		staleEgressPolicies := egressPolicies[2:]
 
 		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-		Expect(fakeStore.DeleteArgsForCall(0)).To(Equal(stalePolicies))
+		_, deleted := fakeStore.DeleteArgsForCall(0)
+		Expect(deleted).To(Equal(stalePolicies))
 
 		Expect(fakeEgressStore.DeleteCallCount()).To(Equal(1))
-		Expect(fakeEgressStore.DeleteArgsForCall(0)).To(Equal([]string{"dead-egress-policy-guid-3", "dead-egress-policy-guid-4"}))
+		_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+		Expect(deletedGUIDs).To(Equal([]string{"dead-egress-policy-guid-3", "dead-egress-policy-guid-4"}))
 
 		Expect(logger).To(gbytes.Say("deleting stale policies:.*c2c_policies.*dead-guid.*dead-guid.*egress_policies.*dead-egress-app-guid.*dead-egress-space-guid.*total_c2c_policies\":2.*total_egress_policies\":2"))
 		Expect(deletedPolicies).To(Equal(stalePolicies))
@@ -205,6 +210,7 @@ var _ = Describe("PolicyCleaner", func() {
 				CCClient:              fakeCCClient,
 				CCAppRequestChunkSize: 1,
 				RequestTimeout:        time.Duration(5) * time.Second,
+				MetricsSender:         fakeMetrics,
 			}
 		})
 
@@ -215,9 +221,9 @@ var _ = Describe("PolicyCleaner", func() {
 			Expect(fakeStore.AllCallCount()).To(Equal(1))
 			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(4))
-			token0, guids0 := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
+			_, token0, guids0 := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
 			Expect(token0).To(Equal("valid-token"))
-			token1, guids1 := fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
+			_, token1, guids1 := fakeCCClient.GetLiveAppGUIDsArgsForCall(1)
 			Expect(token1).To(Equal("valid-token"))
 			Expect([][]string{guids0, guids1}).To(ConsistOf(
 				[]string{"live-guid"},
@@ -227,7 +233,7 @@ var _ = Describe("PolicyCleaner", func() {
 			stalePolicies := c2cPolicies[1:]
 			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
 
-			deletedPolicies := fakeStore.DeleteArgsForCall(0)
+			_, deletedPolicies := fakeStore.DeleteArgsForCall(0)
 			Expect(deletedPolicies).To(Equal(stalePolicies))
 
 			Expect(logger).To(gbytes.Say("deleting stale policies:.*c2c_policies.*dead-guid.*dead-guid.*total_c2c_policies\":2"))
@@ -237,6 +243,483 @@ var _ = Describe("PolicyCleaner", func() {
 		})
 	})
 
+	Context("when there are egress policies with an org source", func() {
+		var orgEgressPolicies []store.EgressPolicy
+
+		BeforeEach(func() {
+			orgEgressPolicies = []store.EgressPolicy{{
+				ID:     "live-egress-policy-guid-5",
+				Source: store.EgressSource{ID: "live-egress-org-guid", Type: "org"},
+				Destination: store.EgressDestination{
+					Protocol: "tcp",
+					IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.4"}},
+				},
+			}, {
+				ID:     "dead-egress-policy-guid-6",
+				Source: store.EgressSource{ID: "dead-egress-org-guid", Type: "org"},
+				Destination: store.EgressDestination{
+					Protocol: "tcp",
+					IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.4"}},
+				},
+			}}
+
+			fakeEgressStore.AllReturns(append(egressPolicies, orgEgressPolicies...), nil)
+			fakeCCClient.GetLiveOrgGUIDsReturns(map[string]struct{}{"live-egress-org-guid": {}}, nil)
+		})
+
+		It("deletes egress policies that reference orgs that do not exist", func() {
+			_, deletedEgressPolicies, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveOrgGUIDsCallCount()).To(Equal(1))
+			_, token, guids := fakeCCClient.GetLiveOrgGUIDsArgsForCall(0)
+			Expect(token).To(Equal("valid-token"))
+			Expect(guids).To(ConsistOf("live-egress-org-guid", "dead-egress-org-guid"))
+
+			Expect(fakeEgressStore.DeleteCallCount()).To(Equal(1))
+			_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+			Expect(deletedGUIDs).To(Equal([]string{"dead-egress-policy-guid-3", "dead-egress-policy-guid-4", "dead-egress-policy-guid-6"}))
+			Expect(deletedEgressPolicies).To(ContainElement(orgEgressPolicies[1]))
+			Expect(deletedEgressPolicies).NotTo(ContainElement(orgEgressPolicies[0]))
+		})
+
+		It("returns a helpful error when get live org guids call fails", func() {
+			fakeCCClient.GetLiveOrgGUIDsReturns(nil, errors.New("zulu"))
+
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).To(MatchError("get live org guids failed: zulu"))
+			Expect(logger).To(gbytes.Say("get-live-org-guids-failed.*zulu"))
+		})
+	})
+
+	Context("when there are expired policies", func() {
+		var expiredPolicy store.Policy
+		var expiredEgressPolicy store.EgressPolicy
+
+		BeforeEach(func() {
+			past := time.Now().Add(-time.Minute)
+			future := time.Now().Add(time.Hour)
+
+			expiredPolicy = store.Policy{
+				Source: store.Source{ID: "live-guid", Tag: "tag"},
+				Destination: store.Destination{
+					ID:       "live-guid",
+					Tag:      "tag",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 9000, End: 9000},
+				},
+				ExpiresAt: &past,
+			}
+			unexpiredPolicy := store.Policy{
+				Source: store.Source{ID: "live-guid", Tag: "tag"},
+				Destination: store.Destination{
+					ID:       "live-guid",
+					Tag:      "tag",
+					Protocol: "tcp",
+					Ports:    store.Ports{Start: 9001, End: 9001},
+				},
+				ExpiresAt: &future,
+			}
+			fakeStore.AllReturns(append(c2cPolicies, expiredPolicy, unexpiredPolicy), nil)
+
+			expiredEgressPolicy = store.EgressPolicy{
+				ID:        "expired-egress-policy-guid",
+				Source:    store.EgressSource{ID: "live-egress-app-guid", Type: "app"},
+				ExpiresAt: &past,
+			}
+			fakeEgressStore.AllReturns(append(egressPolicies, expiredEgressPolicy, expiredEgressPolicy), nil)
+		})
+
+		It("deletes the expired policies along with the stale ones", func() {
+			deletedPolicies, deletedEgressPolicies, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(deletedPolicies).To(Equal(append(c2cPolicies[1:], expiredPolicy)))
+			_, deleted := fakeStore.DeleteArgsForCall(0)
+			Expect(deleted).To(Equal(deletedPolicies))
+
+			Expect(deletedEgressPolicies).To(ContainElement(expiredEgressPolicy))
+			_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+			Expect(deletedGUIDs).To(Equal([]string{"dead-egress-policy-guid-3", "dead-egress-policy-guid-4", "expired-egress-policy-guid"}))
+		})
+
+		It("emits a metric for each expired policy", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetrics.IncrementCounterArgsForCall(0)).To(Equal("PoliciesExpired"))
+			Expect(fakeMetrics.IncrementCounterArgsForCall(1)).To(Equal("EgressPoliciesExpired"))
+		})
+
+		It("logs the expired policies", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(logger).To(gbytes.Say("deleting expired policies:.*total_c2c_policies\":1.*total_egress_policies\":1"))
+		})
+	})
+
+	Context("when running in dry run mode", func() {
+		BeforeEach(func() {
+			policyCleaner.DryRun = true
+		})
+
+		It("reports the stale policies without deleting them", func() {
+			stalePolicies, staleEgressPolicies, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stalePolicies).To(Equal(c2cPolicies[1:]))
+			Expect(staleEgressPolicies).To(Equal(egressPolicies[2:]))
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+
+			Expect(logger).To(gbytes.Say("found stale policies \\(dry run\\):.*total_c2c_policies\":2.*total_egress_policies\":2"))
+		})
+	})
+
+	Describe("LastSuccessfulRun", func() {
+		It("is zero until the periodic cleanup succeeds", func() {
+			Expect(policyCleaner.LastSuccessfulRun()).To(BeZero())
+
+			Expect(policyCleaner.DeleteStalePoliciesWrapper()).To(Succeed())
+			Expect(policyCleaner.LastSuccessfulRun()).To(BeTemporally("~", time.Now(), time.Second))
+		})
+
+		Context("when the periodic cleanup fails", func() {
+			BeforeEach(func() {
+				fakeStore.AllReturns(nil, errors.New("banana"))
+			})
+
+			It("is not updated", func() {
+				Expect(policyCleaner.DeleteStalePoliciesWrapper()).To(HaveOccurred())
+				Expect(policyCleaner.LastSuccessfulRun()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("CleanupStalePolicies", func() {
+		It("passes the context to the stores and clients", func() {
+			ctx := context.WithValue(context.Background(), "some-key", "some-value")
+			_, _, err := policyCleaner.CleanupStalePolicies(ctx, false, true)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStore.AllArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeEgressStore.AllArgsForCall(0)).To(Equal(ctx))
+			Expect(fakeUAAClient.GetTokenArgsForCall(0)).To(Equal(ctx))
+			passedCtx, _, _ := fakeCCClient.GetLiveAppGUIDsArgsForCall(0)
+			Expect(passedCtx).To(Equal(ctx))
+			passedCtx, _ = fakeStore.DeleteArgsForCall(0)
+			Expect(passedCtx).To(Equal(ctx))
+			passedCtx, _ = fakeEgressStore.DeleteArgsForCall(0)
+			Expect(passedCtx).To(Equal(ctx))
+		})
+
+		Context("when dry run is requested", func() {
+			It("reports the stale policies without deleting them", func() {
+				stalePolicies, staleEgressPolicies, err := policyCleaner.CleanupStalePolicies(context.Background(), true, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(stalePolicies).To(Equal(c2cPolicies[1:]))
+				Expect(staleEgressPolicies).To(Equal(egressPolicies[2:]))
+
+				Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the stale policies exceed the delete threshold", func() {
+			BeforeEach(func() {
+				policyCleaner.MaxDeletePercent = 50
+			})
+
+			It("refuses to delete them", func() {
+				_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), false, false)
+				Expect(err).To(Equal(cleaner.ThresholdExceededError{Stale: 4, Total: 7, Limit: 50}))
+				Expect(err).To(MatchError("refusing to delete 4 of 7 policies, more than the cleanup limit of 50%"))
+
+				Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+			})
+
+			It("logs and emits a metric", func() {
+				policyCleaner.CleanupStalePolicies(context.Background(), false, false)
+
+				Expect(logger).To(gbytes.Say("cleanup-threshold-exceeded.*refusing to delete 4 of 7 policies"))
+				Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetrics.IncrementCounterArgsForCall(0)).To(Equal("CleanupThresholdExceeded"))
+			})
+
+			It("is applied to the periodic cleanup", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).To(BeAssignableToTypeOf(cleaner.ThresholdExceededError{}))
+				Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			})
+
+			Context("when the cleanup is confirmed", func() {
+				It("deletes the stale policies", func() {
+					_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), false, true)
+					Expect(err).NotTo(HaveOccurred())
+
+					_, deleted := fakeStore.DeleteArgsForCall(0)
+					Expect(deleted).To(Equal(c2cPolicies[1:]))
+					_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+					Expect(deletedGUIDs).To(Equal([]string{"dead-egress-policy-guid-3", "dead-egress-policy-guid-4"}))
+				})
+			})
+
+			Context("when dry run is requested", func() {
+				It("reports the stale policies", func() {
+					stalePolicies, _, err := policyCleaner.CleanupStalePolicies(context.Background(), true, false)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stalePolicies).To(Equal(c2cPolicies[1:]))
+				})
+			})
+		})
+
+		Context("when the stale policies are within the delete threshold", func() {
+			BeforeEach(func() {
+				policyCleaner.MaxDeletePercent = 60
+			})
+
+			It("deletes them", func() {
+				_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), false, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+				Expect(fakeEgressStore.DeleteCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Context("when a tombstone store is configured", func() {
+		var fakeTombstoneStore *fakes.TombstoneStore
+
+		BeforeEach(func() {
+			fakeTombstoneStore = &fakes.TombstoneStore{}
+			policyCleaner.TombstoneStore = fakeTombstoneStore
+			policyCleaner.TombstoneRetention = 24 * time.Hour
+		})
+
+		It("deletes the stale policies along with recording them as tombstones", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTombstoneStore.DeleteWithTombstonesCallCount()).To(Equal(1))
+			_, tombstonePolicies, tombstoneEgressPolicies := fakeTombstoneStore.DeleteWithTombstonesArgsForCall(0)
+			Expect(tombstonePolicies).To(Equal(c2cPolicies[1:]))
+			Expect(tombstoneEgressPolicies).To(Equal(egressPolicies[2:]))
+
+			_, deleted := fakeStore.DeleteArgsForCall(0)
+			Expect(deleted).To(BeEmpty())
+			_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+			Expect(deletedGUIDs).To(BeEmpty())
+		})
+
+		It("purges tombstones older than the retention period", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeTombstoneStore.DeleteOlderThanCallCount()).To(Equal(1))
+			Expect(fakeTombstoneStore.DeleteOlderThanArgsForCall(0)).To(BeTemporally("~", time.Now().Add(-24*time.Hour), time.Minute))
+		})
+
+		Context("when there are expired policies", func() {
+			BeforeEach(func() {
+				past := time.Now().Add(-time.Minute)
+				fakeEgressStore.AllReturns(append(egressPolicies, store.EgressPolicy{
+					ID:        "expired-egress-policy-guid",
+					Source:    store.EgressSource{ID: "live-egress-app-guid", Type: "app"},
+					ExpiresAt: &past,
+				}), nil)
+			})
+
+			It("deletes them without recording them as tombstones", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+
+				_, _, tombstoneEgressPolicies := fakeTombstoneStore.DeleteWithTombstonesArgsForCall(0)
+				Expect(tombstoneEgressPolicies).To(Equal(egressPolicies[2:]))
+
+				_, deletedGUIDs := fakeEgressStore.DeleteArgsForCall(0)
+				Expect(deletedGUIDs).To(Equal([]string{"expired-egress-policy-guid"}))
+			})
+		})
+
+		Context("when running in dry run mode", func() {
+			It("does not record tombstones", func() {
+				_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), true, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTombstoneStore.DeleteWithTombstonesCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when deleting the policies with their tombstones fails", func() {
+			BeforeEach(func() {
+				fakeTombstoneStore.DeleteWithTombstonesReturns(errors.New("potato"))
+			})
+
+			It("does not delete the expired policies", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).To(MatchError("database write failed: potato"))
+				Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+				Expect(fakeEgressStore.DeleteCallCount()).To(Equal(0))
+				Expect(logger).To(gbytes.Say("store-delete-policies-with-tombstones-failed.*potato"))
+			})
+		})
+
+		Context("when purging the tombstones fails", func() {
+			BeforeEach(func() {
+				fakeTombstoneStore.DeleteOlderThanReturns(0, errors.New("potato"))
+			})
+
+			It("logs the error and still succeeds", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger).To(gbytes.Say("store-purge-tombstones-failed.*potato"))
+			})
+		})
+	})
+
+	Context("when a tag store is configured", func() {
+		var fakeTagStore *fakes.TagStore
+
+		BeforeEach(func() {
+			fakeTagStore = &fakes.TagStore{}
+			fakeTagStore.TagsReturns([]store.Tag{
+				{ID: "live-guid", Tag: "01", Type: "app"},
+				{ID: "dead-guid", Tag: "02", Type: "app"},
+				{ID: "dead-tag-only-guid", Tag: "03", Type: "app"},
+				{ID: "live-tag-only-guid", Tag: "04", Type: "app"},
+				{ID: "router-guid", Tag: "05", Type: "router"},
+			}, nil)
+			fakeTagStore.ReleaseTagsReturns(2, nil)
+			policyCleaner.TagStore = fakeTagStore
+
+			fakeCCClient.GetLiveAppGUIDsStub = func(_ context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
+				liveGUIDs := make(map[string]struct{})
+				for _, guid := range appGUIDs {
+					if guid == "live-guid" || guid == "live-egress-app-guid" || guid == "live-tag-only-guid" {
+						liveGUIDs[guid] = struct{}{}
+					}
+				}
+				return liveGUIDs, nil
+			}
+		})
+
+		It("releases the app tags of dead apps without remaining policies", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeCCClient.GetLiveAppGUIDsCallCount()).To(Equal(3))
+			_, token, guids := fakeCCClient.GetLiveAppGUIDsArgsForCall(2)
+			Expect(token).To(Equal("valid-token"))
+			Expect(guids).To(Equal([]string{"dead-guid", "dead-tag-only-guid", "live-tag-only-guid"}))
+
+			Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+			Expect(fakeTagStore.ReleaseTagsArgsForCall(0)).To(Equal([]string{"dead-guid", "dead-tag-only-guid"}))
+
+			Expect(logger).To(gbytes.Say("reclaimed-tags.*total_tags\":2"))
+			Expect(fakeMetrics.IncrementCounterCallCount()).To(Equal(2))
+			Expect(fakeMetrics.IncrementCounterArgsForCall(0)).To(Equal("TagsReclaimed"))
+		})
+
+		Context("when running in dry run mode", func() {
+			It("does not release tags", func() {
+				_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), true, false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTagStore.TagsCallCount()).To(Equal(0))
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when no tags belong to dead apps", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagsReturns([]store.Tag{
+					{ID: "live-guid", Tag: "01", Type: "app"},
+				}, nil)
+			})
+
+			It("does not release tags", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the dead apps exceed the delete threshold", func() {
+			BeforeEach(func() {
+				policyCleaner.MaxDeletePercent = 10
+				policyCleaner.TombstoneRetention = 0
+			})
+
+			It("does not release tags unless confirmed", func() {
+				_, _, err := policyCleaner.CleanupStalePolicies(context.Background(), false, true)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+
+				fakeTagStore.ReleaseTagsReturns(0, nil)
+				fakeStore.AllReturns(c2cPolicies[:1], nil)
+				fakeEgressStore.AllReturns(egressPolicies[:2], nil)
+				_, _, err = policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+				Expect(logger).To(gbytes.Say("reclaim-tags-threshold-exceeded.*refusing to release 2 of 5 tags"))
+			})
+		})
+
+		Context("when listing the tags fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagsReturns(nil, errors.New("potato"))
+			})
+
+			It("logs the error and still succeeds", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger).To(gbytes.Say("store-list-tags-failed.*potato"))
+			})
+		})
+
+		Context("when checking the apps in cloud controller fails", func() {
+			BeforeEach(func() {
+				fakeCCClient.GetLiveAppGUIDsStub = func(_ context.Context, token string, appGUIDs []string) (map[string]struct{}, error) {
+					if fakeCCClient.GetLiveAppGUIDsCallCount() == 3 {
+						return nil, errors.New("potato")
+					}
+					return map[string]struct{}{"live-guid": {}, "live-egress-app-guid": {}}, nil
+				}
+			})
+
+			It("does not release any tags", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(0))
+				Expect(logger).To(gbytes.Say("cc-get-app-guids-failed.*potato"))
+			})
+		})
+
+		Context("when releasing the tags fails", func() {
+			BeforeEach(func() {
+				fakeTagStore.ReleaseTagsReturns(0, errors.New("potato"))
+			})
+
+			It("logs the error and still succeeds", func() {
+				_, _, err := policyCleaner.DeleteStalePolicies()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(logger).To(gbytes.Say("store-release-tags-failed.*potato"))
+			})
+		})
+	})
+
+	Context("when there are no egress policies with an org source", func() {
+		It("does not query cloud controller for orgs", func() {
+			_, _, err := policyCleaner.DeleteStalePolicies()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeCCClient.GetLiveOrgGUIDsCallCount()).To(Equal(0))
+		})
+	})
+
 	It("returns a helpful error when get live space guids call fails", func() {
 		fakeCCClient.GetLiveSpaceGUIDsReturns(nil, errors.New("yankee"))
 
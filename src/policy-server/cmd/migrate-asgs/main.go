@@ -0,0 +1,135 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"lib/common"
+	"lib/nonmutualtls"
+
+	"policy-server/asg_importer"
+	"policy-server/cc_client"
+	"policy-server/config"
+	"policy-server/store"
+	"policy-server/uaa_client"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/lager/lagerflags"
+)
+
+const (
+	jobPrefix = "policy-server-migrate-asgs"
+	logPrefix = "cfnetworking"
+)
+
+func main() {
+	err := mainWithError()
+	if err != nil {
+		fmt.Printf("fatal error occured, %s", err)
+		os.Exit(1)
+	}
+}
+
+func mainWithError() error {
+	configFilePath := flag.String("config-file", "", "path to config file")
+	dryRun := flag.Bool("dry-run", false, "report the destinations and egress policies that would be created without creating them")
+	flag.Parse()
+
+	conf, err := config.New(*configFilePath)
+	if err != nil {
+		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
+	}
+
+	logger, _ := lagerflags.NewFromConfig(fmt.Sprintf("%s.%s", logPrefix, jobPrefix), common.GetLagerConfig())
+
+	var tlsConfig *tls.Config
+	if conf.SkipSSLValidation {
+		tlsConfig = &tls.Config{
+			InsecureSkipVerify: conf.SkipSSLValidation,
+		}
+	} else {
+		tlsConfig, err = nonmutualtls.NewClientTLSConfig(conf.UAACA, conf.CCCA)
+		if err != nil {
+			return fmt.Errorf("creating tls config: %s", err)
+		}
+	}
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+
+	uaaClient := &uaa_client.Client{
+		BaseURL:    fmt.Sprintf("%s:%d", conf.UAAURL, conf.UAAPort),
+		Name:       conf.UAAClient,
+		Secret:     conf.UAAClientSecret,
+		HTTPClient: httpClient,
+		Logger:     logger,
+	}
+
+	ccClient := &cc_client.Client{
+		JSONClient: cc_client.NewContextJSONClient(logger.Session("cc-json-client"), httpClient, conf.CCURL),
+		Logger:     logger,
+	}
+
+	logger.Info("getting db connection")
+	connectionPool, err := db.NewConnectionPool(
+		conf.Database,
+		conf.MaxOpenConnections,
+		conf.MaxIdleConnections,
+		time.Duration(conf.MaxConnectionsLifetimeSeconds)*time.Second,
+		logPrefix,
+		jobPrefix,
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("getting db connection: %s", err)
+	}
+	defer connectionPool.Close()
+
+	terminalsTable := &store.TerminalsTable{
+		Guids: &store.GuidGenerator{},
+	}
+	egressPolicyStore := &store.EgressPolicyStore{
+		EgressPolicyRepo: &store.EgressPolicyTable{
+			Conn:  connectionPool,
+			Guids: &store.GuidGenerator{},
+		},
+		TerminalsRepo: terminalsTable,
+		Conn:          connectionPool,
+	}
+	egressDestinationStore := &store.EgressDestinationStore{
+		Conn:                    connectionPool,
+		EgressDestinationRepo:   &store.EgressDestinationTable{},
+		TerminalsRepo:           terminalsTable,
+		DestinationMetadataRepo: &store.DestinationMetadataTable{},
+		EgressPolicyStore:       egressPolicyStore,
+	}
+
+	importer := &asg_importer.Importer{
+		Logger:            logger,
+		UAAClient:         uaaClient,
+		CCClient:          ccClient,
+		DestinationStore:  egressDestinationStore,
+		EgressPolicyStore: egressPolicyStore,
+	}
+
+	report, err := importer.Import(*dryRun)
+	if err != nil {
+		return fmt.Errorf("importing security groups: %s", err)
+	}
+
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling report: %s", err)
+	}
+	fmt.Println(string(reportBytes))
+
+	return nil
+}
@@ -2,9 +2,11 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"policy-server/config"
 	"policy-server/store"
+	"text/tabwriter"
 	"time"
 
 	"flag"
@@ -31,10 +33,38 @@ func main() {
 }
 
 func mainWithError() error {
-	conf := parseConfig()
+	conf, command, count := parseFlags()
 
 	logger, _ := lagerflags.NewFromConfig(fmt.Sprintf("%s.%s", logPrefix, jobPrefix), common.GetLagerConfig())
 
+	switch command {
+	case "", "up":
+		return migrateWithTimeout(logger, conf)
+	case "status":
+		return printStatus(logger, conf, os.Stdout)
+	case "down":
+		return rollback(logger, conf, count)
+	case "verify":
+		return verify(logger, conf, os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q, expected one of: up, status, down, verify", command)
+	}
+}
+
+func parseFlags() (*config.Config, string, int) {
+	configFilePath := flag.String("config-file", "", "path to config file")
+	count := flag.Int("count", 1, "number of migrations to roll back with the down command")
+	flag.Parse()
+
+	conf, err := config.New(*configFilePath)
+	if err != nil {
+		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
+	}
+
+	return conf, flag.Arg(0), *count
+}
+
+func migrateWithTimeout(logger lager.Logger, conf *config.Config) error {
 	doneChan := make(chan bool, 1)
 	go func() {
 		for {
@@ -57,19 +87,7 @@ func mainWithError() error {
 	}
 }
 
-func parseConfig() *config.Config {
-	configFilePath := flag.String("config-file", "", "path to config file")
-	flag.Parse()
-
-	conf, err := config.New(*configFilePath)
-	if err != nil {
-		log.Fatalf("%s.%s: could not read config file: %s", logPrefix, jobPrefix, err)
-	}
-
-	return conf
-}
-
-func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) error {
+func newConnectionPool(logger lager.Logger, conf *config.Config) (*db.ConnWrapper, error) {
 	logger.Info("getting migration db connection")
 	dbConn, err := db.NewConnectionPool(
 		conf.Database,
@@ -81,21 +99,33 @@ func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) err
 		logger,
 	)
 	if err != nil {
-		return fmt.Errorf("getting migration db connection: %s", err)
+		return nil, fmt.Errorf("getting migration db connection: %s", err)
 	}
-
-	defer dbConn.Close()
-
 	logger.Info("migration db connection retrieved")
+	return dbConn, nil
+}
 
-	migrator := &migrations.Migrator{
+func newMigrator(logger lager.Logger, dbConn *db.ConnWrapper) *migrations.Migrator {
+	return &migrations.Migrator{
 		MigrateAdapter: &migrations.MigrateAdapter{},
 		MigrationsProvider: &migrations.MigrationsProvider{
 			Store: &store.MigrationsStore{
 				DBConn: dbConn,
 			},
 		},
+		Logger: logger,
 	}
+}
+
+func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) error {
+	dbConn, err := newConnectionPool(logger, conf)
+	if err != nil {
+		return err
+	}
+
+	defer dbConn.Close()
+
+	migrator := newMigrator(logger, dbConn)
 
 	tagPopulator := &store.TagPopulator{DBConnection: dbConn}
 
@@ -115,3 +145,80 @@ func migrateAndPopulateGroupsTable(logger lager.Logger, conf *config.Config) err
 
 	return nil
 }
+
+func printStatus(logger lager.Logger, conf *config.Config, out io.Writer) error {
+	dbConn, err := newConnectionPool(logger, conf)
+	if err != nil {
+		return err
+	}
+
+	defer dbConn.Close()
+
+	statuses, err := newMigrator(logger, dbConn).MigrationStatus(dbConn.DriverName(), dbConn)
+	if err != nil {
+		return fmt.Errorf("migration status: %s", err)
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tAPPLIED AT\tREVERSIBLE")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.UTC().Format(time.RFC3339)
+		}
+		reversible := "no"
+		if status.Reversible {
+			reversible = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", status.Id, state, appliedAt, reversible)
+	}
+	return w.Flush()
+}
+
+func rollback(logger lager.Logger, conf *config.Config, count int) error {
+	dbConn, err := newConnectionPool(logger, conf)
+	if err != nil {
+		return err
+	}
+
+	defer dbConn.Close()
+
+	logger.Info("rolling back migrations", lager.Data{"count": count})
+	numRolledBack, err := newMigrator(logger, dbConn).RollbackMigrations(dbConn.DriverName(), dbConn, count)
+	if err != nil {
+		return fmt.Errorf("rollback migrations: %s", err)
+	}
+	logger.Info("finished rolling back migrations", lager.Data{"num-migrations-rolled-back": numRolledBack})
+
+	return nil
+}
+
+func verify(logger lager.Logger, conf *config.Config, out io.Writer) error {
+	dbConn, err := newConnectionPool(logger, conf)
+	if err != nil {
+		return err
+	}
+
+	defer dbConn.Close()
+
+	verifier := &migrations.SchemaVerifier{
+		Migrator:   newMigrator(logger, dbConn),
+		DB:         dbConn,
+		DriverName: dbConn.DriverName(),
+	}
+
+	differences, err := verifier.Verify()
+	if err != nil {
+		return fmt.Errorf("verify schema: %s", err)
+	}
+
+	for _, difference := range differences {
+		fmt.Fprintln(out, difference)
+	}
+	if len(differences) > 0 {
+		return fmt.Errorf("schema drift detected: %d differences", len(differences))
+	}
+
+	fmt.Fprintln(out, "schema matches expected migrations")
+	return nil
+}
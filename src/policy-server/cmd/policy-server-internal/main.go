@@ -5,6 +5,9 @@ import (
 	"flag"
 	"fmt"
 	"lib/common"
+	"lib/poller"
+	"lib/prometheus"
+	"lib/tracing"
 	"log"
 	"net/http"
 	"os"
@@ -14,6 +17,7 @@ import (
 	"policy-server/config"
 	"policy-server/handlers"
 	"policy-server/store"
+	"policy-server/store/migrations"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 	"code.cloudfoundry.org/cf-networking-helpers/httperror"
@@ -68,8 +72,43 @@ func main() {
 		log.Fatalf(err.Error())
 	}
 
+	transactionTracker := &store.TransactionTracker{Database: connectionPool}
+	var readConnection store.Database = transactionTracker
+	var readReplicaPool *db.ConnWrapper
+	var readReplicaPoller ifrit.Runner
+	if conf.ReadReplicaDatabase != nil {
+		readReplicaPool, err = db.NewConnectionPool(
+			*conf.ReadReplicaDatabase,
+			conf.MaxOpenConnections,
+			conf.MaxIdleConnections,
+			time.Duration(conf.MaxConnectionsLifetimeSeconds)*time.Second,
+			logPrefix,
+			jobPrefix,
+			logger,
+		)
+		if err != nil {
+			log.Fatalf("%s.%s: connecting to read replica: %s", logPrefix, jobPrefix, err)
+		}
+
+		readReplica := &store.ReadReplica{
+			Primary:      transactionTracker,
+			Replica:      readReplicaPool,
+			MaxStaleness: time.Duration(conf.ReadReplicaMaxStalenessSeconds) * time.Second,
+		}
+		if err := readReplica.Check(); err != nil {
+			logger.Error("read-replica-check", err)
+		}
+		readConnection = readReplica
+
+		readReplicaPoller = &poller.Poller{
+			Logger:          logger.Session("read-replica-poller"),
+			PollInterval:    time.Duration(conf.ReadReplicaCheckIntervalSeconds) * time.Second,
+			SingleCycleFunc: readReplica.Check,
+		}
+	}
+
 	dataStore := store.New(
-		connectionPool,
+		readConnection,
 		&store.GroupTable{},
 		&store.DestinationTable{},
 		&store.PolicyTable{},
@@ -78,19 +117,35 @@ func main() {
 
 	egressDataStore := &store.EgressPolicyStore{
 		EgressPolicyRepo: &store.EgressPolicyTable{
-			Conn:  connectionPool,
+			Conn:  readConnection,
 			Guids: &store.GuidGenerator{},
 		},
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
+	tagDataStore := store.NewTagStore(readConnection, &store.GroupTable{}, conf.TagLength)
 
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
+	var prometheusRegistry *prometheus.Registry
+	if conf.PrometheusListenPort > 0 {
+		prometheusRegistry = prometheus.NewRegistry("policy_server_internal")
+	}
+
+	metricsSender := &prometheus.MetricsSender{
+		Sender: &metrics.MetricsSender{
+			Logger: logger.Session("time-metric-emitter"),
+		},
+		Registry: prometheusRegistry,
+	}
+
+	var tracer *tracing.Tracer
+	if conf.TracingOTLPEndpoint != "" {
+		tracer = common.InitTracer(logger, conf.TracingOTLPEndpoint, jobPrefix)
 	}
 
 	wrappedStore := &store.MetricsWrapper{
-		Store:         dataStore,
+		Store: &store.TracingWrapper{
+			Store:  dataStore,
+			Tracer: tracer,
+		},
 		TagStore:      tagDataStore,
 		MetricsSender: metricsSender,
 	}
@@ -108,17 +163,45 @@ func main() {
 	internalPoliciesHandlerV1 := handlers.NewPoliciesIndexInternal(logger, wrappedStore,
 		wrappedEgressStore, policyCollectionWriter, errorResponse, conf.EnforceExperimentalDynamicEgressPolicies)
 
+	var policySnapshotPoller ifrit.Runner
+	if conf.PolicySnapshotPollIntervalSeconds > 0 {
+		policyCache := &store.PolicySnapshotCache{
+			Store:         wrappedStore,
+			RevisionStore: store.NewRevisionStore(readConnection),
+			MaxAge:        time.Duration(conf.PolicySnapshotMaxAgeSeconds) * time.Second,
+		}
+		if conf.EnforceExperimentalDynamicEgressPolicies {
+			policyCache.EgressStore = wrappedEgressStore
+		}
+		internalPoliciesHandlerV1.PolicyCache = policyCache
+
+		policySnapshotPoller = &poller.Poller{
+			Logger:          logger.Session("policy-snapshot-poller"),
+			PollInterval:    time.Duration(conf.PolicySnapshotPollIntervalSeconds) * time.Second,
+			SingleCycleFunc: policyCache.Refresh,
+		}
+	}
+
 	createTagsHandlerV1 := &handlers.TagsCreate{
 		Store:         wrappedStore,
 		ErrorResponse: errorResponse,
 	}
 
-	metricsWrap := func(name string, handler http.Handler) http.Handler {
+	routeTimeouts := map[string]time.Duration{}
+	for route, seconds := range conf.RouteRequestTimeouts {
+		routeTimeouts[route] = time.Duration(seconds) * time.Second
+	}
+	timeoutWrapper := &handlers.TimeoutWrapper{
+		Timeout:       time.Duration(conf.RequestTimeout) * time.Second,
+		RouteTimeouts: routeTimeouts,
+	}
+
+	metricsWrap := func(route, name string, handler http.Handler) http.Handler {
 		metricsWrapper := middleware.MetricWrapper{
 			Name:          name,
 			MetricsSender: metricsSender,
 		}
-		return metricsWrapper.Wrap(handler)
+		return tracer.Wrap(name, metricsWrapper.Wrap(timeoutWrapper.WrapRoute(route, handler)))
 	}
 
 	logWrapper := middleware.LogWrapper{
@@ -134,7 +217,8 @@ func main() {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore, connectionPool)
+	metricSources := common.InitMetricSources(wrappedStore, connectionPool)
+	metricsEmitter := common.InitMetricsEmitter(logger, metricSources)
 
 	internalRoutes := rata.Routes{
 		{Name: "internal_policies", Method: "GET", Path: "/networking/:version/internal/policies"},
@@ -142,8 +226,8 @@ func main() {
 	}
 
 	internalHandlers := rata.Handlers{
-		"internal_policies": metricsWrap("InternalPolicies", logWrap(internalPoliciesHandlerV1)),
-		"create_tags":       metricsWrap("CreateTags", logWrap(createTagsHandlerV1)),
+		"internal_policies": metricsWrap("internal_policies", "InternalPolicies", logWrap(internalPoliciesHandlerV1)),
+		"create_tags":       metricsWrap("create_tags", "CreateTags", logWrap(createTagsHandlerV1)),
 	}
 
 	tlsConfig, err := mutualtls.NewServerTLSConfig(conf.ServerCertFile, conf.ServerKeyFile, conf.CACertFile)
@@ -151,32 +235,72 @@ func main() {
 		log.Fatalf("%s.%s: mutual tls config: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
-	internalServer := common.InitServer(logger, tlsConfig, conf.ListenHost, conf.InternalListenPort, internalHandlers, internalRoutes)
+	drain := &common.Drain{
+		Wait:    time.Duration(conf.DrainWaitSeconds) * time.Second,
+		Timeout: time.Duration(conf.DrainTimeoutSeconds) * time.Second,
+	}
+	internalServer := common.InitServer(logger, tlsConfig, conf.ListenHost, conf.InternalListenPort, internalHandlers, internalRoutes, drain)
 	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
 
 	uptimeHandler := &handlers.UptimeHandler{
 		StartTime: time.Now(),
 	}
-	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	healthHandler := handlers.NewHealth(wrappedStore, drain, errorResponse)
+
+	schemaVerifier := &migrations.SchemaVerifier{
+		Migrator: &migrations.Migrator{
+			MigrateAdapter: &migrations.MigrateAdapter{},
+			MigrationsProvider: &migrations.MigrationsProvider{
+				Store: &store.MigrationsStore{
+					DBConn: connectionPool,
+				},
+			},
+		},
+		DB:         connectionPool,
+		DriverName: connectionPool.DriverName(),
+	}
+	schemaHealthHandler := handlers.NewSchemaHealth(schemaVerifier, marshal.MarshalFunc(json.Marshal), errorResponse)
 
 	healthRoutes := rata.Routes{
 		{Name: "uptime", Method: "GET", Path: "/"},
 		{Name: "health", Method: "GET", Path: "/health"},
+		{Name: "schema_health", Method: "GET", Path: "/health/schema"},
 	}
 
 	healthHandlers := rata.Handlers{
-		"uptime": metricsWrap("Uptime", logWrap(uptimeHandler)),
-		"health": metricsWrap("Health", logWrap(healthHandler)),
+		"uptime":        metricsWrap("uptime", "Uptime", logWrap(uptimeHandler)),
+		"health":        metricsWrap("health", "Health", logWrap(healthHandler)),
+		"schema_health": metricsWrap("schema_health", "SchemaHealth", logWrap(schemaHealthHandler)),
 	}
 
 	healthCheckServer := common.InitServer(logger, nil, conf.ListenHost,
-		conf.HealthCheckPort, healthHandlers, healthRoutes)
+		conf.HealthCheckPort, healthHandlers, healthRoutes, drain)
 
-	members := grouper.Members{
+	// the ordered group stops its members in reverse, so the tracer starts
+	// first to flush the spans of requests the servers finish while draining
+	members := grouper.Members{}
+	if tracer != nil {
+		members = append(members, grouper.Member{Name: "tracer", Runner: tracer})
+	}
+	members = append(members, grouper.Members{
 		{"metrics-emitter", metricsEmitter},
 		{"internal-http-server", internalServer},
 		{"debug-server", debugServer},
 		{"health-check-server", healthCheckServer},
+	}...)
+
+	if policySnapshotPoller != nil {
+		members = append(members, grouper.Member{Name: "policy-snapshot-poller", Runner: policySnapshotPoller})
+	}
+
+	if readReplicaPoller != nil {
+		members = append(members, grouper.Member{Name: "read-replica-poller", Runner: readReplicaPoller})
+	}
+
+	if prometheusRegistry != nil {
+		prometheusRegistry.AddSources(metricSources...)
+		prometheusServer := prometheus.NewServer(fmt.Sprintf("%s:%d", conf.PrometheusListenAddress, conf.PrometheusListenPort), prometheusRegistry)
+		members = append(members, grouper.Member{Name: "prometheus-server", Runner: prometheusServer})
 	}
 
 	logger.Info("starting internal server", lager.Data{"listen-address": conf.ListenHost, "port": conf.InternalListenPort})
@@ -185,9 +309,15 @@ func main() {
 	monitor := ifrit.Invoke(sigmon.New(group))
 
 	err = <-monitor.Wait()
+	if waitErr := transactionTracker.Wait(drain.Deadline()); waitErr != nil {
+		logger.Error("drain-transactions", waitErr)
+	}
 	if connectionPool != nil {
 		connectionPool.Close()
 	}
+	if readReplicaPool != nil {
+		readReplicaPool.Close()
+	}
 	if err != nil {
 		logger.Error("exited-with-failure", err)
 		os.Exit(1)
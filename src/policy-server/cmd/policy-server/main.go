@@ -13,6 +13,8 @@ import (
 	"lib/common"
 	"lib/nonmutualtls"
 	"lib/poller"
+	"lib/prometheus"
+	"lib/tracing"
 
 	"policy-server/adapter"
 	"policy-server/api"
@@ -21,13 +23,14 @@ import (
 	"policy-server/cleaner"
 	"policy-server/config"
 	"policy-server/handlers"
+	"policy-server/health"
 	psmiddleware "policy-server/middleware"
 	"policy-server/store"
+	"policy-server/store/migrations"
 	"policy-server/uaa_client"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 	"code.cloudfoundry.org/cf-networking-helpers/httperror"
-	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/cf-networking-helpers/marshal"
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"code.cloudfoundry.org/cf-networking-helpers/middleware"
@@ -83,12 +86,18 @@ func main() {
 		},
 	}
 
+	var tracer *tracing.Tracer
+	if conf.TracingOTLPEndpoint != "" {
+		tracer = common.InitTracer(logger, conf.TracingOTLPEndpoint, jobPrefix)
+	}
+
 	uaaClient := &uaa_client.Client{
 		BaseURL:    fmt.Sprintf("%s:%d", conf.UAAURL, conf.UAAPort),
 		Name:       conf.UAAClient,
 		Secret:     conf.UAAClientSecret,
 		HTTPClient: httpClient,
 		Logger:     logger,
+		Tracer:     tracer,
 	}
 
 	whoamiHandler := &handlers.WhoAmIHandler{
@@ -119,20 +128,50 @@ func main() {
 
 	logger.Info("db connection retrieved", lager.Data{})
 
+	transactionTracker := &store.TransactionTracker{Database: connectionPool}
+	var readConnection store.Database = transactionTracker
+	var readReplicaPool *db.ConnWrapper
+	var readReplicaPoller ifrit.Runner
+	if conf.ReadReplicaDatabase != nil {
+		readReplicaPool, err = db.NewConnectionPool(
+			*conf.ReadReplicaDatabase,
+			conf.MaxOpenConnections,
+			conf.MaxIdleConnections,
+			time.Duration(conf.MaxConnectionsLifetimeSeconds)*time.Second,
+			logPrefix,
+			jobPrefix,
+			logger,
+		)
+		if err != nil {
+			log.Fatalf("%s.%s: connecting to read replica: %s", logPrefix, jobPrefix, err)
+		}
+
+		readReplica := &store.ReadReplica{
+			Primary:      transactionTracker,
+			Replica:      readReplicaPool,
+			MaxStaleness: time.Duration(conf.ReadReplicaMaxStalenessSeconds) * time.Second,
+		}
+		if err := readReplica.Check(); err != nil {
+			logger.Error("read-replica-check", err)
+		}
+		readConnection = readReplica
+		readReplicaPoller = initReadReplicaPoller(logger, conf, readReplica)
+	}
+
 	terminalsTable := &store.TerminalsTable{
 		Guids: &store.GuidGenerator{},
 	}
 	egressPolicyStore := &store.EgressPolicyStore{
 		EgressPolicyRepo: &store.EgressPolicyTable{
-			Conn:  connectionPool,
+			Conn:  readConnection,
 			Guids: &store.GuidGenerator{},
 		},
 		TerminalsRepo: terminalsTable,
-		Conn:          connectionPool,
+		Conn:          transactionTracker,
 	}
 
 	c2cPolicyStore := store.New(
-		connectionPool,
+		readConnection,
 		storeGroup,
 		destination,
 		policy,
@@ -143,14 +182,25 @@ func main() {
 		log.Fatalf("%s.%s: failed to construct datastore: %s", logPrefix, jobPrefix, err) // not tested
 	}
 
-	tagDataStore := store.NewTagStore(connectionPool, &store.GroupTable{}, conf.TagLength)
+	tagDataStore := store.NewTagStore(readConnection, &store.GroupTable{}, conf.TagLength)
+
+	var prometheusRegistry *prometheus.Registry
+	if conf.PrometheusListenPort > 0 {
+		prometheusRegistry = prometheus.NewRegistry("policy_server")
+	}
 
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
+	metricsSender := &prometheus.MetricsSender{
+		Sender: &metrics.MetricsSender{
+			Logger: logger.Session("time-metric-emitter"),
+		},
+		Registry: prometheusRegistry,
 	}
 
 	wrappedStore := &store.MetricsWrapper{
-		Store:         c2cPolicyStore,
+		Store: &store.TracingWrapper{
+			Store:  c2cPolicyStore,
+			Tracer: tracer,
+		},
 		TagStore:      tagDataStore,
 		MetricsSender: metricsSender,
 	}
@@ -160,8 +210,9 @@ func main() {
 	}
 
 	ccClient := &cc_client.Client{
-		JSONClient: json_client.New(logger.Session("cc-json-client"), httpClient, conf.CCURL),
+		JSONClient: cc_client.NewContextJSONClient(logger.Session("cc-json-client"), httpClient, conf.CCURL),
 		Logger:     logger,
+		Tracer:     tracer,
 	}
 
 	policyGuard := handlers.NewPolicyGuard(uaaClient, ccClient)
@@ -190,10 +241,11 @@ func main() {
 	}
 
 	egressDestinationStore := &store.EgressDestinationStore{
-		Conn: connectionPool,
+		Conn: transactionTracker,
 		EgressDestinationRepo:   &store.EgressDestinationTable{},
 		TerminalsRepo:           terminalsTable,
 		DestinationMetadataRepo: &store.DestinationMetadataTable{},
+		EgressPolicyStore:       egressPolicyStore,
 	}
 
 	destinationsIndexHandlerV1 := &handlers.DestinationsIndex{
@@ -217,6 +269,16 @@ func main() {
 		Logger:                  logger,
 	}
 
+	destinationUsageHandlerV1 := &handlers.DestinationUsage{
+		ErrorResponse:    errorResponse,
+		DestinationStore: egressDestinationStore,
+		Store:            egressPolicyStore,
+		UAAClient:        uaaClient,
+		CCClient:         ccClient,
+		Marshaler:        marshal.MarshalFunc(json.Marshal),
+		Logger:           logger,
+	}
+
 	egressPolicyValidator := &api.EgressValidator{
 		CCClient:         ccClient,
 		UAAClient:        uaaClient,
@@ -251,26 +313,98 @@ func main() {
 	}
 
 	policyCleaner := cleaner.NewPolicyCleaner(logger.Session("policy-cleaner"), wrappedStore, egressPolicyStore, uaaClient,
-		ccClient, 100, time.Duration(5)*time.Second)
+		ccClient, 100, time.Duration(5)*time.Second, metricsSender)
+	policyCleaner.DryRun = conf.CleanupDryRun
+	policyCleaner.MaxDeletePercent = conf.CleanupMaxDeletePercent
+	policyCleaner.TagStore = wrappedStore
+
+	tombstoneStore := store.NewTombstoneStore(transactionTracker, c2cPolicyStore, egressPolicyStore)
+	if conf.TombstoneRetentionPeriod > 0 {
+		policyCleaner.TombstoneStore = tombstoneStore
+		policyCleaner.TombstoneRetention = time.Duration(conf.TombstoneRetentionPeriod) * time.Second
+	}
+
+	eventCleaner := cleaner.NewEventCleaner(logger.Session("event-cleaner"), wrappedStore, egressPolicyStore, uaaClient,
+		ccClient, metricsSender)
+	eventCleaner.TombstoneStore = policyCleaner.TombstoneStore
+	eventCleaner.DryRun = conf.CleanupDryRun
+
+	tombstonesIndexHandler := &handlers.TombstonesIndex{
+		Store:         tombstoneStore,
+		Marshaler:     marshal.MarshalFunc(json.Marshal),
+		ErrorResponse: errorResponse,
+	}
+
+	tombstonesRestoreHandler := &handlers.TombstonesRestore{
+		TombstoneStore: tombstoneStore,
+		Unmarshaler:    marshal.UnmarshalFunc(json.Unmarshal),
+		Marshaler:      marshal.MarshalFunc(json.Marshal),
+		ErrorResponse:  errorResponse,
+	}
 
 	policyCollectionWriter := api.NewPolicyCollectionWriter(marshal.MarshalFunc(json.Marshal))
 	policiesCleanupHandler := handlers.NewPoliciesCleanup(policyCollectionWriter, policyCleaner, errorResponse)
 
 	tagsIndexHandler := handlers.NewTagsIndex(wrappedStore, marshal.MarshalFunc(json.Marshal), errorResponse)
 
-	healthHandler := handlers.NewHealth(wrappedStore, errorResponse)
+	statsStore := store.NewStatsStore(readConnection, conf.TagLength)
+	statsIndexHandler := &handlers.StatsIndex{
+		Store:         statsStore,
+		UAAClient:     uaaClient,
+		CCClient:      ccClient,
+		Marshaler:     marshal.MarshalFunc(json.Marshal),
+		ErrorResponse: errorResponse,
+	}
+
+	drain := &common.Drain{
+		Wait:    time.Duration(conf.DrainWaitSeconds) * time.Second,
+		Timeout: time.Duration(conf.DrainTimeoutSeconds) * time.Second,
+	}
+	healthHandler := handlers.NewHealth(wrappedStore, drain, errorResponse)
+
+	migrator := &migrations.Migrator{
+		MigrateAdapter: &migrations.MigrateAdapter{},
+		MigrationsProvider: &migrations.MigrationsProvider{
+			Store: &store.MigrationsStore{
+				DBConn: connectionPool,
+			},
+		},
+	}
+	healthChecks := []health.Check{
+		health.NewDatabaseCheck(wrappedStore, connectionPool, conf.MaxOpenConnections, conf.MaxIdleConnections),
+		health.NewMigrationsCheck(migrator, connectionPool, connectionPool.DriverName()),
+		health.NewUAACheck(uaaClient),
+		health.NewCCCheck(uaaClient, ccClient),
+		health.NewCleanerCheck(policyCleaner, 2*time.Duration(conf.CleanupInterval)*time.Second),
+		health.NewPolicyCountsCheck(statsStore),
+	}
+	healthDetailedHandler := handlers.NewHealthDetailed(healthChecks, time.Duration(conf.RequestTimeout)*time.Second,
+		marshal.MarshalFunc(json.Marshal), errorResponse)
 
 	checkVersionWrapper := &handlers.CheckVersionWrapper{
 		ErrorResponse: errorResponse,
 		RataAdapter:   adapter.RataAdapter{},
 	}
 
-	metricsWrap := func(name string, handler http.Handler) http.Handler {
+	untimedMetricsWrap := func(name string, handler http.Handler) http.Handler {
 		metricsWrapper := middleware.MetricWrapper{
 			Name:          name,
 			MetricsSender: metricsSender,
 		}
-		return metricsWrapper.Wrap(handler)
+		return tracer.Wrap(name, metricsWrapper.Wrap(handler))
+	}
+
+	routeTimeouts := map[string]time.Duration{}
+	for route, seconds := range conf.RouteRequestTimeouts {
+		routeTimeouts[route] = time.Duration(seconds) * time.Second
+	}
+	timeoutWrapper := &handlers.TimeoutWrapper{
+		Timeout:       time.Duration(conf.RequestTimeout) * time.Second,
+		RouteTimeouts: routeTimeouts,
+	}
+
+	metricsWrap := func(route, name string, handler http.Handler) http.Handler {
+		return untimedMetricsWrap(name, timeoutWrapper.WrapRoute(route, handler))
 	}
 
 	logWrapper := middleware.LogWrapper{
@@ -312,6 +446,7 @@ func main() {
 		{Name: "uptime", Method: "GET", Path: "/"},
 		{Name: "uptime", Method: "GET", Path: "/networking"},
 		{Name: "health", Method: "GET", Path: "/health"},
+		{Name: "health_detailed", Method: "GET", Path: "/health/detailed"},
 		{Name: "whoami", Method: "GET", Path: "/networking/:version/external/whoami"},
 		{Name: "create_policies", Method: "POST", Path: "/networking/:version/external/policies"},
 		{Name: "delete_policies", Method: "POST", Path: "/networking/:version/external/policies/delete"},
@@ -319,11 +454,15 @@ func main() {
 		{Name: "destinations_index", Method: "GET", Path: "/networking/:version/external/destinations"},
 		{Name: "destinations_create", Method: "POST", Path: "/networking/:version/external/destinations"},
 		{Name: "destination_delete", Method: "DELETE", Path: "/networking/:version/external/destinations/:id"},
+		{Name: "destination_usage", Method: "GET", Path: "/networking/:version/external/destinations/:id/usage"},
 		{Name: "egress_policies_index", Method: "GET", Path: "/networking/:version/external/egress_policies"},
 		{Name: "egress_policies_create", Method: "POST", Path: "/networking/:version/external/egress_policies"},
 		{Name: "egress_policies_delete", Method: "DELETE", Path: "/networking/:version/external/egress_policies/:id"},
 		{Name: "cleanup", Method: "POST", Path: "/networking/:version/external/policies/cleanup"},
 		{Name: "tags_index", Method: "GET", Path: "/networking/:version/external/tags"},
+		{Name: "tombstones_index", Method: "GET", Path: "/networking/:version/external/tombstones"},
+		{Name: "tombstones_restore", Method: "POST", Path: "/networking/:version/external/tombstones/restore"},
+		{Name: "stats_index", Method: "GET", Path: "/networking/:version/external/stats"},
 	}
 
 	corsMiddleware := psmiddleware.CORS{}
@@ -340,43 +479,60 @@ func main() {
 	externalHandlers := rata.Handlers{
 		"options": corsOptionsWrapper(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		})),
-		"uptime": corsOptionsWrapper(metricsWrap("Uptime", logWrap(uptimeHandler))),
-		"health": corsOptionsWrapper(metricsWrap("Health", logWrap(healthHandler))),
+		"uptime":          corsOptionsWrapper(metricsWrap("uptime", "Uptime", logWrap(uptimeHandler))),
+		"health":          corsOptionsWrapper(metricsWrap("health", "Health", logWrap(healthHandler))),
+		"health_detailed": corsOptionsWrapper(metricsWrap("health_detailed", "HealthDetailed", logWrap(authAdminWrap(healthDetailedHandler)))),
 
-		"create_policies": corsOptionsWrapper(metricsWrap("CreatePolicies",
+		"create_policies": corsOptionsWrapper(metricsWrap("create_policies", "CreatePolicies",
 			logWrap(versionWrap(authWriteWrap(createPolicyHandlerV1), authWriteWrap(createPolicyHandlerV0))))),
 
-		"delete_policies": corsOptionsWrapper(metricsWrap("DeletePolicies",
+		"delete_policies": corsOptionsWrapper(metricsWrap("delete_policies", "DeletePolicies",
 			logWrap(versionWrap(authWriteWrap(deletePolicyHandlerV1), authWriteWrap(deletePolicyHandlerV0))))),
 
-		"policies_index": corsOptionsWrapper(metricsWrap("PoliciesIndex",
+		"policies_index": corsOptionsWrapper(metricsWrap("policies_index", "PoliciesIndex",
 			logWrap(versionWrap(authWriteWrap(policiesIndexHandlerV1), authWriteWrap(policiesIndexHandlerV0))))),
 
-		"destinations_index": corsOptionsWrapper(metricsWrap("DestinationsIndex",
+		"destinations_index": corsOptionsWrapper(metricsWrap("destinations_index", "DestinationsIndex",
 			logWrap(versionWrap(authAdminWrap(destinationsIndexHandlerV1), authAdminWrap(destinationsIndexHandlerV1))))),
 
-		"destinations_create": corsOptionsWrapper(metricsWrap("DestinationsCreate",
+		"destinations_create": corsOptionsWrapper(metricsWrap("destinations_create", "DestinationsCreate",
 			logWrap(authAdminWrap(createDestinationsHandlerV1)))),
 
-		"destination_delete": corsOptionsWrapper(metricsWrap("DestinationDelete",
+		"destination_delete": corsOptionsWrapper(metricsWrap("destination_delete", "DestinationDelete",
 			logWrap(authAdminWrap(deleteDestinationHandlerV1)))),
 
-		"egress_policies_index": corsOptionsWrapper(metricsWrap("EgressPoliciesIndex",
+		"destination_usage": corsOptionsWrapper(metricsWrap("destination_usage", "DestinationUsage",
+			logWrap(authAdminWrap(destinationUsageHandlerV1)))),
+
+		"egress_policies_index": corsOptionsWrapper(metricsWrap("egress_policies_index", "EgressPoliciesIndex",
 			logWrap(authAdminWrap(indexEgressPolicyHandlerV1)))),
 
-		"egress_policies_create": corsOptionsWrapper(metricsWrap("EgressPoliciesCreate",
+		"egress_policies_create": corsOptionsWrapper(metricsWrap("egress_policies_create", "EgressPoliciesCreate",
 			logWrap(authAdminWrap(createEgressPolicyHandlerV1)))),
 
-		"egress_policies_delete": corsOptionsWrapper(metricsWrap("EgressPoliciesDelete",
+		"egress_policies_delete": corsOptionsWrapper(metricsWrap("egress_policies_delete", "EgressPoliciesDelete",
 			logWrap(authAdminWrap(deleteEgressPolicyHandlerV1)))),
 
-		"cleanup": corsOptionsWrapper(metricsWrap("Cleanup",
+		// cleanup checks every app with a policy against Cloud Controller, so
+		// it is not held to the request timeout.
+		"cleanup": corsOptionsWrapper(untimedMetricsWrap("Cleanup",
 			logWrap(versionWrap(authAdminWrap(policiesCleanupHandler), authAdminWrap(policiesCleanupHandler))))),
 
-		"tags_index": corsOptionsWrapper(metricsWrap("TagsIndex",
+		"tags_index": corsOptionsWrapper(metricsWrap("tags_index", "TagsIndex",
 			logWrap(versionWrap(authAdminWrap(tagsIndexHandler), authAdminWrap(tagsIndexHandler))))),
 
-		"whoami": corsOptionsWrapper(metricsWrap("WhoAmI",
+		"tombstones_index": corsOptionsWrapper(metricsWrap("tombstones_index", "TombstonesIndex",
+			logWrap(authAdminWrap(tombstonesIndexHandler)))),
+
+		"tombstones_restore": corsOptionsWrapper(metricsWrap("tombstones_restore", "TombstonesRestore",
+			logWrap(authAdminWrap(tombstonesRestoreHandler)))),
+
+		"stats_index": corsOptionsWrapper(metricsWrap("stats_index", "StatsIndex",
+			logWrap(checkVersionWrapper.CheckVersion(map[string]http.Handler{
+				"v1": authAdminWrap(statsIndexHandler),
+			})))),
+
+		"whoami": corsOptionsWrapper(metricsWrap("whoami", "WhoAmI",
 			logWrap(versionWrap(authAdminWrap(whoamiHandler), authAdminWrap(whoamiHandler))))),
 	}
 
@@ -385,16 +541,34 @@ func main() {
 		log.Fatalf("%s.%s: initializing dropsonde: %s", logPrefix, jobPrefix, err)
 	}
 
-	metricsEmitter := common.InitMetricsEmitter(logger, wrappedStore, connectionPool)
-	externalServer := common.InitServer(logger, nil, conf.ListenHost, conf.ListenPort, externalHandlers, externalRoutesWithOptions)
+	metricSources := common.InitMetricSources(wrappedStore, connectionPool)
+	metricsEmitter := common.InitMetricsEmitter(logger, metricSources)
+	externalServer := common.InitServer(logger, nil, conf.ListenHost, conf.ListenPort, externalHandlers, externalRoutesWithOptions, drain)
 	policyPoller := initPoller(logger, conf, policyCleaner)
 	debugServer := debugserver.Runner(fmt.Sprintf("%s:%d", conf.DebugServerHost, conf.DebugServerPort), reconfigurableSink)
 
-	members := grouper.Members{
+	// the ordered group stops its members in reverse, so the tracer starts
+	// first to flush the spans of requests the servers finish while draining
+	members := grouper.Members{}
+	if tracer != nil {
+		members = append(members, grouper.Member{Name: "tracer", Runner: tracer})
+	}
+	members = append(members, grouper.Members{
 		{"metrics_emitter", metricsEmitter},
 		{"http_server", externalServer},
 		{"policy-cleaner-poller", policyPoller},
 		{"debug-server", debugServer},
+	}...)
+	if conf.CleanupEventPollInterval > 0 {
+		members = append(members, grouper.Member{Name: "policy-cleaner-event-poller", Runner: initEventPoller(logger, conf, eventCleaner)})
+	}
+	if readReplicaPoller != nil {
+		members = append(members, grouper.Member{Name: "read-replica-poller", Runner: readReplicaPoller})
+	}
+	if prometheusRegistry != nil {
+		prometheusRegistry.AddSources(metricSources...)
+		prometheusServer := prometheus.NewServer(fmt.Sprintf("%s:%d", conf.PrometheusListenAddress, conf.PrometheusListenPort), prometheusRegistry)
+		members = append(members, grouper.Member{Name: "prometheus-server", Runner: prometheusServer})
 	}
 
 	logger.Info("starting external server", lager.Data{"listen-address": conf.ListenHost, "port": conf.ListenPort})
@@ -403,9 +577,15 @@ func main() {
 	monitor := ifrit.Invoke(sigmon.New(group))
 
 	err = <-monitor.Wait()
+	if waitErr := transactionTracker.Wait(drain.Deadline()); waitErr != nil {
+		logger.Error("drain-transactions", waitErr)
+	}
 	if connectionPool != nil {
 		connectionPool.Close()
 	}
+	if readReplicaPool != nil {
+		readReplicaPool.Close()
+	}
 	if err != nil {
 		logger.Error("exited-with-failure", err)
 		os.Exit(1)
@@ -423,3 +603,19 @@ func initPoller(logger lager.Logger, conf *config.Config, policyCleaner *cleaner
 		SingleCycleFunc: policyCleaner.DeleteStalePoliciesWrapper,
 	}
 }
+
+func initEventPoller(logger lager.Logger, conf *config.Config, eventCleaner *cleaner.EventCleaner) ifrit.Runner {
+	return &poller.Poller{
+		Logger:          logger.Session("policy-cleaner-event-poller"),
+		PollInterval:    time.Duration(conf.CleanupEventPollInterval) * time.Second,
+		SingleCycleFunc: eventCleaner.DeletePoliciesForDeletedResources,
+	}
+}
+
+func initReadReplicaPoller(logger lager.Logger, conf *config.Config, readReplica *store.ReadReplica) ifrit.Runner {
+	return &poller.Poller{
+		Logger:          logger.Session("read-replica-poller"),
+		PollInterval:    time.Duration(conf.ReadReplicaCheckIntervalSeconds) * time.Second,
+		SingleCycleFunc: readReplica.Check,
+	}
+}
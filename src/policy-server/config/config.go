@@ -2,6 +2,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 
@@ -10,38 +11,63 @@ import (
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
 
+// DefaultPrometheusListenAddress keeps the unauthenticated metrics endpoint
+// off the network unless an operator chooses otherwise.
+const DefaultPrometheusListenAddress = "127.0.0.1"
+
 type Config struct {
-	ListenHost                      string    `json:"listen_host" validate:"nonzero"`
-	ListenPort                      int       `json:"listen_port" validate:"nonzero"`
-	LogPrefix                       string    `json:"log_prefix" validate:"nonzero"`
-	DebugServerHost                 string    `json:"debug_server_host" validate:"nonzero"`
-	DebugServerPort                 int       `json:"debug_server_port" validate:"nonzero"`
-	UAAClient                       string    `json:"uaa_client" validate:"nonzero"`
-	UAAClientSecret                 string    `json:"uaa_client_secret" validate:"nonzero"`
-	UAACA                           string    `json:"uaa_ca"`
-	UAAURL                          string    `json:"uaa_url" validate:"nonzero"`
-	UAAPort                         int       `json:"uaa_port" validate:"nonzero"`
-	CCURL                           string    `json:"cc_url" validate:"nonzero"`
-	CCCA                            string    `json:"cc_ca_cert" validate:"nonzero"`
-	SkipSSLValidation               bool      `json:"skip_ssl_validation"`
-	Database                        db.Config `json:"database" validate:"nonzero"`
-	DatabaseMigrationTimeout        int       `json:"database_migration_timeout" validate:"min=1"`
-	TagLength                       int       `json:"tag_length" validate:"nonzero"`
-	MetronAddress                   string    `json:"metron_address" validate:"nonzero"`
-	LogLevel                        string    `json:"log_level"`
-	CleanupInterval                 int       `json:"cleanup_interval" validate:"min=1"`
-	CCAppRequestChunkSize           int       `json:"cc_app_request_chunk_size"`
-	RequestTimeout                  int       `json:"request_timeout" validate:"min=1"`
-	MaxPolicies                     int       `json:"max_policies" validate:"min=1"`
-	EnableSpaceDeveloperSelfService bool      `json:"enable_space_developer_self_service"`
-	AllowedCORSDomains              []string  `json:"allowed_cors_domains"`
-	MaxIdleConnections              int       `json:"max_idle_connections" validate:"min=0"`
-	MaxOpenConnections              int       `json:"max_open_connections" validate:"min=0"`
-	MaxConnectionsLifetimeSeconds   int       `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	ListenHost                      string         `json:"listen_host" validate:"nonzero"`
+	ListenPort                      int            `json:"listen_port" validate:"nonzero"`
+	LogPrefix                       string         `json:"log_prefix" validate:"nonzero"`
+	DebugServerHost                 string         `json:"debug_server_host" validate:"nonzero"`
+	DebugServerPort                 int            `json:"debug_server_port" validate:"nonzero"`
+	UAAClient                       string         `json:"uaa_client" validate:"nonzero"`
+	UAAClientSecret                 string         `json:"uaa_client_secret" validate:"nonzero"`
+	UAACA                           string         `json:"uaa_ca"`
+	UAAURL                          string         `json:"uaa_url" validate:"nonzero"`
+	UAAPort                         int            `json:"uaa_port" validate:"nonzero"`
+	CCURL                           string         `json:"cc_url" validate:"nonzero"`
+	CCCA                            string         `json:"cc_ca_cert" validate:"nonzero"`
+	SkipSSLValidation               bool           `json:"skip_ssl_validation"`
+	Database                        db.Config      `json:"database" validate:"nonzero"`
+	DatabaseMigrationTimeout        int            `json:"database_migration_timeout" validate:"min=1"`
+	TagLength                       int            `json:"tag_length" validate:"nonzero"`
+	MetronAddress                   string         `json:"metron_address" validate:"nonzero"`
+	LogLevel                        string         `json:"log_level"`
+	CleanupInterval                 int            `json:"cleanup_interval" validate:"min=1"`
+	CleanupDryRun                   bool           `json:"cleanup_dry_run"`
+	CleanupMaxDeletePercent         int            `json:"cleanup_max_delete_percent" validate:"min=0,max=100"`
+	TombstoneRetentionPeriod        int            `json:"tombstone_retention_period" validate:"min=0"`
+	CleanupEventPollInterval        int            `json:"cleanup_event_poll_interval" validate:"min=0"`
+	CCAppRequestChunkSize           int            `json:"cc_app_request_chunk_size"`
+	RequestTimeout                  int            `json:"request_timeout" validate:"min=1"`
+	RouteRequestTimeouts            map[string]int `json:"route_request_timeouts"`
+	MaxPolicies                     int            `json:"max_policies" validate:"min=1"`
+	EnableSpaceDeveloperSelfService bool           `json:"enable_space_developer_self_service"`
+	AllowedCORSDomains              []string       `json:"allowed_cors_domains"`
+	MaxIdleConnections              int            `json:"max_idle_connections" validate:"min=0"`
+	MaxOpenConnections              int            `json:"max_open_connections" validate:"min=0"`
+	MaxConnectionsLifetimeSeconds   int            `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	ReadReplicaDatabase             *db.Config     `json:"read_replica_database"`
+	ReadReplicaCheckIntervalSeconds int            `json:"read_replica_check_interval_seconds" validate:"min=0"`
+	ReadReplicaMaxStalenessSeconds  int            `json:"read_replica_max_staleness_seconds" validate:"min=0"`
+	PrometheusListenAddress         string         `json:"prometheus_listen_address"`
+	PrometheusListenPort            int            `json:"prometheus_listen_port" validate:"min=0"`
+	TracingOTLPEndpoint             string         `json:"tracing_otlp_endpoint"`
+	DrainWaitSeconds                int            `json:"drain_wait_seconds" validate:"min=0"`
+	DrainTimeoutSeconds             int            `json:"drain_timeout_seconds" validate:"min=0"`
 }
 
 func (c *Config) Validate() error {
-	return validator.Validate(c)
+	err := validator.Validate(c)
+	if err != nil {
+		return err
+	}
+	err = validateRouteRequestTimeouts(c.RouteRequestTimeouts)
+	if err != nil {
+		return err
+	}
+	return validateReadReplica(c.ReadReplicaDatabase, c.ReadReplicaCheckIntervalSeconds, c.ReadReplicaMaxStalenessSeconds)
 }
 
 func New(path string) (*Config, error) {
@@ -56,9 +82,39 @@ func New(path string) (*Config, error) {
 		return nil, fmt.Errorf("parsing config: %s", err)
 	}
 
+	if cfg.PrometheusListenAddress == "" {
+		cfg.PrometheusListenAddress = DefaultPrometheusListenAddress
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return &cfg, fmt.Errorf("invalid config: %s", err)
 	}
 
 	return &cfg, nil
 }
+
+// validateReadReplica checks the read replica settings, which are only
+// required when a read replica database is configured.
+func validateReadReplica(replica *db.Config, checkIntervalSeconds, maxStalenessSeconds int) error {
+	if replica == nil {
+		return nil
+	}
+	if checkIntervalSeconds < 1 {
+		return errors.New("ReadReplicaCheckIntervalSeconds: must be at least 1 when a read replica is configured")
+	}
+	if maxStalenessSeconds < 1 {
+		return errors.New("ReadReplicaMaxStalenessSeconds: must be at least 1 when a read replica is configured")
+	}
+	return nil
+}
+
+// validateRouteRequestTimeouts checks the timeouts, in seconds, that
+// override RequestTimeout for the named routes.
+func validateRouteRequestTimeouts(timeouts map[string]int) error {
+	for route, seconds := range timeouts {
+		if seconds < 1 {
+			return fmt.Errorf("RouteRequestTimeouts.%s: less than min", route)
+		}
+	}
+	return nil
+}
@@ -60,10 +60,20 @@ var _ = Describe("Config", func() {
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
 					"cleanup_interval": 2,
+					"cleanup_dry_run": true,
+					"cleanup_max_delete_percent": 25,
+					"tombstone_retention_period": 86400,
+					"cleanup_event_poll_interval": 10,
 					"request_timeout": 5,
+					"route_request_timeouts": {"policies_index": 30},
 					"max_policies": 3,
 					"enable_space_developer_self_service": true,
-					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"]
+					"allowed_cors_domains": ["https://foo.bar", "https://bar.foo"],
+					"prometheus_listen_address": "10.0.0.1",
+					"prometheus_listen_port": 9100,
+					"tracing_otlp_endpoint": "http://127.0.0.1:4318",
+					"drain_wait_seconds": 5,
+					"drain_timeout_seconds": 30
 				}`)
 				c, err := config.New(file.Name())
 				Expect(err).NotTo(HaveOccurred())
@@ -97,13 +107,23 @@ var _ = Describe("Config", func() {
 				Expect(c.MetronAddress).To(Equal("http://1.2.3.4:9999"))
 				Expect(c.LogLevel).To(Equal("debug"))
 				Expect(c.CleanupInterval).To(Equal(2))
+				Expect(c.CleanupDryRun).To(BeTrue())
+				Expect(c.CleanupMaxDeletePercent).To(Equal(25))
+				Expect(c.TombstoneRetentionPeriod).To(Equal(86400))
+				Expect(c.CleanupEventPollInterval).To(Equal(10))
 				Expect(c.RequestTimeout).To(Equal(5))
+				Expect(c.RouteRequestTimeouts).To(Equal(map[string]int{"policies_index": 30}))
 				Expect(c.MaxPolicies).To(Equal(3))
 				Expect(c.EnableSpaceDeveloperSelfService).To(BeTrue())
 				Expect(c.AllowedCORSDomains).To(Equal([]string{
 					"https://foo.bar",
 					"https://bar.foo",
 				}))
+				Expect(c.PrometheusListenAddress).To(Equal("10.0.0.1"))
+				Expect(c.PrometheusListenPort).To(Equal(9100))
+				Expect(c.TracingOTLPEndpoint).To(Equal("http://127.0.0.1:4318"))
+				Expect(c.DrainWaitSeconds).To(Equal(5))
+				Expect(c.DrainTimeoutSeconds).To(Equal(30))
 			})
 		})
 
@@ -333,6 +353,28 @@ var _ = Describe("Config", func() {
 				})
 			})
 
+			Context("when the prometheus listen address is not set", func() {
+				BeforeEach(func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+				It("listens on the loopback address", func() {
+					c, err := config.New(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.PrometheusListenAddress).To(Equal("127.0.0.1"))
+				})
+			})
+
+			Context("when a route request timeout is less than 1", func() {
+				BeforeEach(func() {
+					allData["route_request_timeouts"] = map[string]int{"some_route": 0}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+				It("returns an error", func() {
+					_, err = config.New(file.Name())
+					Expect(err).To(MatchError("invalid config: RouteRequestTimeouts.some_route: less than min"))
+				})
+			})
+
 			Context("when the config file is missing a database_name", func() {
 				BeforeEach(func() {
 					delete(allData["database"].(map[string]interface{}), "database_name")
@@ -343,6 +385,68 @@ var _ = Describe("Config", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
+
+			Context("when a read replica is configured", func() {
+				BeforeEach(func() {
+					allData["read_replica_database"] = map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.2",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					}
+					allData["read_replica_check_interval_seconds"] = 1
+					allData["read_replica_max_staleness_seconds"] = 5
+				})
+
+				It("returns the read replica config", func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+					c, err := config.New(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.ReadReplicaDatabase).NotTo(BeNil())
+					Expect(c.ReadReplicaDatabase.Host).To(Equal("127.0.0.2"))
+					Expect(c.ReadReplicaCheckIntervalSeconds).To(Equal(1))
+					Expect(c.ReadReplicaMaxStalenessSeconds).To(Equal(5))
+				})
+
+				Context("when the read replica is missing a host", func() {
+					It("returns an error", func() {
+						delete(allData["read_replica_database"].(map[string]interface{}), "host")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.New(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaDatabase.Host: zero value"))
+					})
+				})
+
+				Context("when the check interval is not set", func() {
+					It("returns an error", func() {
+						delete(allData, "read_replica_check_interval_seconds")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.New(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaCheckIntervalSeconds: must be at least 1 when a read replica is configured"))
+					})
+				})
+
+				Context("when the max staleness is not set", func() {
+					It("returns an error", func() {
+						delete(allData, "read_replica_max_staleness_seconds")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.New(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaMaxStalenessSeconds: must be at least 1 when a read replica is configured"))
+					})
+				})
+			})
+
+			Context("when no read replica is configured", func() {
+				It("does not require the read replica settings", func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+					c, err := config.New(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.ReadReplicaDatabase).To(BeNil())
+				})
+			})
 		})
 	})
 })
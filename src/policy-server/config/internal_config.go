@@ -11,28 +11,47 @@ import (
 )
 
 type InternalConfig struct {
-	LogPrefix                                string    `json:"log_prefix" validate:"nonzero"`
-	ListenHost                               string    `json:"listen_host" validate:"nonzero"`
-	InternalListenPort                       int       `json:"internal_listen_port" validate:"nonzero"`
-	DebugServerHost                          string    `json:"debug_server_host" validate:"nonzero"`
-	DebugServerPort                          int       `json:"debug_server_port" validate:"nonzero"`
-	HealthCheckPort                          int       `json:"health_check_port" validate:"nonzero"`
-	CACertFile                               string    `json:"ca_cert_file" validate:"nonzero"`
-	ServerCertFile                           string    `json:"server_cert_file" validate:"nonzero"`
-	ServerKeyFile                            string    `json:"server_key_file" validate:"nonzero"`
-	Database                                 db.Config `json:"database" validate:"nonzero"`
-	TagLength                                int       `json:"tag_length" validate:"nonzero"`
-	MetronAddress                            string    `json:"metron_address" validate:"nonzero"`
-	LogLevel                                 string    `json:"log_level"`
-	RequestTimeout                           int       `json:"request_timeout" validate:"min=1"`
-	MaxIdleConnections                       int       `json:"max_idle_connections" validate:"min=0"`
-	MaxOpenConnections                       int       `json:"max_open_connections" validate:"min=0"`
-	MaxConnectionsLifetimeSeconds            int       `json:"connections_max_lifetime_seconds" validate:"min=0"`
-	EnforceExperimentalDynamicEgressPolicies bool      `json:"enforce_experimental_dynamic_egress_policies"`
+	LogPrefix                                string         `json:"log_prefix" validate:"nonzero"`
+	ListenHost                               string         `json:"listen_host" validate:"nonzero"`
+	InternalListenPort                       int            `json:"internal_listen_port" validate:"nonzero"`
+	DebugServerHost                          string         `json:"debug_server_host" validate:"nonzero"`
+	DebugServerPort                          int            `json:"debug_server_port" validate:"nonzero"`
+	HealthCheckPort                          int            `json:"health_check_port" validate:"nonzero"`
+	CACertFile                               string         `json:"ca_cert_file" validate:"nonzero"`
+	ServerCertFile                           string         `json:"server_cert_file" validate:"nonzero"`
+	ServerKeyFile                            string         `json:"server_key_file" validate:"nonzero"`
+	Database                                 db.Config      `json:"database" validate:"nonzero"`
+	TagLength                                int            `json:"tag_length" validate:"nonzero"`
+	MetronAddress                            string         `json:"metron_address" validate:"nonzero"`
+	LogLevel                                 string         `json:"log_level"`
+	RequestTimeout                           int            `json:"request_timeout" validate:"min=1"`
+	RouteRequestTimeouts                     map[string]int `json:"route_request_timeouts"`
+	MaxIdleConnections                       int            `json:"max_idle_connections" validate:"min=0"`
+	MaxOpenConnections                       int            `json:"max_open_connections" validate:"min=0"`
+	MaxConnectionsLifetimeSeconds            int            `json:"connections_max_lifetime_seconds" validate:"min=0"`
+	EnforceExperimentalDynamicEgressPolicies bool           `json:"enforce_experimental_dynamic_egress_policies"`
+	PolicySnapshotPollIntervalSeconds        int            `json:"policy_snapshot_poll_interval_seconds" validate:"min=0"`
+	PolicySnapshotMaxAgeSeconds              int            `json:"policy_snapshot_max_age_seconds" validate:"min=0"`
+	ReadReplicaDatabase                      *db.Config     `json:"read_replica_database"`
+	ReadReplicaCheckIntervalSeconds          int            `json:"read_replica_check_interval_seconds" validate:"min=0"`
+	ReadReplicaMaxStalenessSeconds           int            `json:"read_replica_max_staleness_seconds" validate:"min=0"`
+	PrometheusListenAddress                  string         `json:"prometheus_listen_address"`
+	PrometheusListenPort                     int            `json:"prometheus_listen_port" validate:"min=0"`
+	TracingOTLPEndpoint                      string         `json:"tracing_otlp_endpoint"`
+	DrainWaitSeconds                         int            `json:"drain_wait_seconds" validate:"min=0"`
+	DrainTimeoutSeconds                      int            `json:"drain_timeout_seconds" validate:"min=0"`
 }
 
 func (c *InternalConfig) Validate() error {
-	return validator.Validate(c)
+	err := validator.Validate(c)
+	if err != nil {
+		return err
+	}
+	err = validateRouteRequestTimeouts(c.RouteRequestTimeouts)
+	if err != nil {
+		return err
+	}
+	return validateReadReplica(c.ReadReplicaDatabase, c.ReadReplicaCheckIntervalSeconds, c.ReadReplicaMaxStalenessSeconds)
 }
 
 func NewInternal(path string) (*InternalConfig, error) {
@@ -47,6 +66,10 @@ func NewInternal(path string) (*InternalConfig, error) {
 		return nil, fmt.Errorf("parsing config: %s", err)
 	}
 
+	if cfg.PrometheusListenAddress == "" {
+		cfg.PrometheusListenAddress = DefaultPrometheusListenAddress
+	}
+
 	if err := cfg.Validate(); err != nil {
 		return &cfg, fmt.Errorf("invalid config: %s", err)
 	}
@@ -52,7 +52,15 @@ var _ = Describe("InternalConfig", func() {
 					"metron_address": "http://1.2.3.4:9999",
 					"log_level": "debug",
 					"request_timeout": 5,
-					"enforce_experimental_dynamic_egress_policies": true
+					"route_request_timeouts": {"internal_policies": 10},
+					"enforce_experimental_dynamic_egress_policies": true,
+					"policy_snapshot_poll_interval_seconds": 1,
+					"policy_snapshot_max_age_seconds": 60,
+					"prometheus_listen_address": "10.0.0.2",
+					"prometheus_listen_port": 9101,
+					"tracing_otlp_endpoint": "http://127.0.0.1:4318",
+					"drain_wait_seconds": 5,
+					"drain_timeout_seconds": 30
 				}`)
 				c, err := config.NewInternal(file.Name())
 				Expect(err).NotTo(HaveOccurred())
@@ -76,10 +84,18 @@ var _ = Describe("InternalConfig", func() {
 				Expect(c.MetronAddress).To(Equal("http://1.2.3.4:9999"))
 				Expect(c.LogLevel).To(Equal("debug"))
 				Expect(c.RequestTimeout).To(Equal(5))
+				Expect(c.RouteRequestTimeouts).To(Equal(map[string]int{"internal_policies": 10}))
 				Expect(c.MaxIdleConnections).To(Equal(4))
 				Expect(c.MaxOpenConnections).To(Equal(5))
 				Expect(c.MaxConnectionsLifetimeSeconds).To(Equal(45))
 				Expect(c.EnforceExperimentalDynamicEgressPolicies).To(Equal(true))
+				Expect(c.PolicySnapshotPollIntervalSeconds).To(Equal(1))
+				Expect(c.PolicySnapshotMaxAgeSeconds).To(Equal(60))
+				Expect(c.PrometheusListenAddress).To(Equal("10.0.0.2"))
+				Expect(c.PrometheusListenPort).To(Equal(9101))
+				Expect(c.TracingOTLPEndpoint).To(Equal("http://127.0.0.1:4318"))
+				Expect(c.DrainWaitSeconds).To(Equal(5))
+				Expect(c.DrainTimeoutSeconds).To(Equal(30))
 			})
 		})
 
@@ -297,6 +313,28 @@ var _ = Describe("InternalConfig", func() {
 				})
 			})
 
+			Context("when the prometheus listen address is not set", func() {
+				BeforeEach(func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+				It("listens on the loopback address", func() {
+					c, err := config.NewInternal(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.PrometheusListenAddress).To(Equal("127.0.0.1"))
+				})
+			})
+
+			Context("when a route request timeout is less than 1", func() {
+				BeforeEach(func() {
+					allData["route_request_timeouts"] = map[string]int{"some_route": 0}
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+				})
+				It("returns an error", func() {
+					_, err = config.NewInternal(file.Name())
+					Expect(err).To(MatchError("invalid config: RouteRequestTimeouts.some_route: less than min"))
+				})
+			})
+
 			Context("when the config file is missing a database_name", func() {
 				BeforeEach(func() {
 					delete(allData["database"].(map[string]interface{}), "database_name")
@@ -307,6 +345,68 @@ var _ = Describe("InternalConfig", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 			})
+
+			Context("when a read replica is configured", func() {
+				BeforeEach(func() {
+					allData["read_replica_database"] = map[string]interface{}{
+						"type":          "mysql",
+						"user":          "root",
+						"password":      "password",
+						"host":          "127.0.0.2",
+						"port":          3306,
+						"timeout":       5,
+						"database_name": "network_policy",
+					}
+					allData["read_replica_check_interval_seconds"] = 1
+					allData["read_replica_max_staleness_seconds"] = 5
+				})
+
+				It("returns the read replica config", func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+					c, err := config.NewInternal(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.ReadReplicaDatabase).NotTo(BeNil())
+					Expect(c.ReadReplicaDatabase.Host).To(Equal("127.0.0.2"))
+					Expect(c.ReadReplicaCheckIntervalSeconds).To(Equal(1))
+					Expect(c.ReadReplicaMaxStalenessSeconds).To(Equal(5))
+				})
+
+				Context("when the read replica is missing a host", func() {
+					It("returns an error", func() {
+						delete(allData["read_replica_database"].(map[string]interface{}), "host")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.NewInternal(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaDatabase.Host: zero value"))
+					})
+				})
+
+				Context("when the check interval is not set", func() {
+					It("returns an error", func() {
+						delete(allData, "read_replica_check_interval_seconds")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.NewInternal(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaCheckIntervalSeconds: must be at least 1 when a read replica is configured"))
+					})
+				})
+
+				Context("when the max staleness is not set", func() {
+					It("returns an error", func() {
+						delete(allData, "read_replica_max_staleness_seconds")
+						Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+						_, err = config.NewInternal(file.Name())
+						Expect(err).To(MatchError("invalid config: ReadReplicaMaxStalenessSeconds: must be at least 1 when a read replica is configured"))
+					})
+				})
+			})
+
+			Context("when no read replica is configured", func() {
+				It("does not require the read replica settings", func() {
+					Expect(json.NewEncoder(file).Encode(allData)).To(Succeed())
+					c, err := config.NewInternal(file.Name())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(c.ReadReplicaDatabase).To(BeNil())
+				})
+			})
 		})
 	})
 })
@@ -26,7 +26,7 @@ type http_handler interface {
 }
 
 type UAAClient interface {
-	CheckToken(token string) (uaa_client.CheckTokenResponse, error)
+	CheckToken(ctx context.Context, token string) (uaa_client.CheckTokenResponse, error)
 }
 
 type Authenticator struct {
@@ -70,7 +70,7 @@ func (a *Authenticator) Wrap(handle http.Handler) http.Handler {
 		token := authorization[0]
 		token = strings.TrimPrefix(token, "Bearer ")
 		token = strings.TrimPrefix(token, "bearer ")
-		tokenData, err := a.Client.CheckToken(token)
+		tokenData, err := a.Client.CheckToken(req.Context(), token)
 		if err != nil {
 			a.ErrorResponse.Forbidden(logger, w, err, "failed to verify token with uaa")
 			return
@@ -99,7 +99,8 @@ var _ = Describe("Authentication middleware", func() {
 		Expect(unprotectedCallCount).To(Equal(1))
 
 		Expect(uaaClient.CheckTokenCallCount()).To(Equal(1))
-		Expect(uaaClient.CheckTokenArgsForCall(0)).To(Equal("correct-token"))
+		_, token := uaaClient.CheckTokenArgsForCall(0)
+		Expect(token).To(Equal("correct-token"))
 	})
 
 	Context("when the logger isn't on the request", func() {
@@ -147,7 +148,8 @@ var _ = Describe("Authentication middleware", func() {
 			Expect(unprotectedCallCount).To(Equal(1))
 
 			Expect(uaaClient.CheckTokenCallCount()).To(Equal(1))
-			Expect(uaaClient.CheckTokenArgsForCall(0)).To(Equal("correct-token"))
+			_, token := uaaClient.CheckTokenArgsForCall(0)
+			Expect(token).To(Equal("correct-token"))
 
 		})
 	})
@@ -10,6 +10,7 @@ import (
 //go:generate counterfeiter -o fakes/egress_destination_store_deleter.go --fake-name EgressDestinationStoreDeleter . EgressDestinationStoreDeleter
 type EgressDestinationStoreDeleter interface {
 	Delete(string) (store.EgressDestination, error)
+	DeleteWithPolicies(string) (store.EgressDestination, []store.EgressPolicy, error)
 }
 
 type DestinationDelete struct {
@@ -23,7 +24,17 @@ func (d *DestinationDelete) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	guid := req.URL.Query().Get(":id")
 	logger := getLogger(req)
 
-	deletedDestination, err := d.EgressDestinationStore.Delete(guid)
+	var deletedDestination store.EgressDestination
+	var err error
+	if req.URL.Query().Get("cascade") == "true" {
+		var deletedPolicies []store.EgressPolicy
+		deletedDestination, deletedPolicies, err = d.EgressDestinationStore.DeleteWithPolicies(guid)
+		if err == nil {
+			logger.Info("deleted-bound-egress-policies", lager.Data{"destination": guid, "total_egress_policies": len(deletedPolicies)})
+		}
+	} else {
+		deletedDestination, err = d.EgressDestinationStore.Delete(guid)
+	}
 	if err != nil {
 		switch err.(type) {
 		case store.ForeignKeyError:
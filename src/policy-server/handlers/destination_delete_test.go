@@ -76,6 +76,31 @@ var _ = Describe("DestinationDelete", func() {
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
 	})
 
+	Context("when cascade is requested", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = ":id=destguid&cascade=true"
+			fakeStore.DeleteWithPoliciesReturns(deletedDestination, []store.EgressPolicy{{ID: "some-policy-guid"}}, nil)
+		})
+
+		It("deletes the destination along with its bound egress policies", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.DeleteCallCount()).To(Equal(0))
+			Expect(fakeStore.DeleteWithPoliciesCallCount()).To(Equal(1))
+			Expect(fakeStore.DeleteWithPoliciesArgsForCall(0)).To(Equal("destguid"))
+			Expect(fakeMarshaller.AsBytesArgsForCall(0)).To(Equal([]store.EgressDestination{deletedDestination}))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+		})
+
+		It("returns an internal server error when the store fails", func() {
+			fakeStore.DeleteWithPoliciesReturns(store.EgressDestination{}, nil, errors.New("can't delete"))
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error deleting egress destination"}`))
+		})
+	})
+
 	Context("when the store returns an error", func() {
 		It("returns bad request when the store returns foreign key violation", func() {
 			fakeStore.DeleteReturns(store.EgressDestination{}, store.NewForeignKeyError(errors.New("egress dest in use")))
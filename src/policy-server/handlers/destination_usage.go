@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/egress_destination_getter.go --fake-name EgressDestinationGetter . egressDestinationGetter
+type egressDestinationGetter interface {
+	GetByGUID(guid ...string) ([]store.EgressDestination, error)
+}
+
+type DestinationUsage struct {
+	ErrorResponse    errorResponse
+	DestinationStore egressDestinationGetter
+	Store            egressPolicyStore
+	UAAClient        uaaClient
+	CCClient         ccClient
+	Marshaler        marshal.Marshaler
+	Logger           lager.Logger
+}
+
+func (d *DestinationUsage) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	guid := req.URL.Query().Get(":id")
+	logger := getLogger(req)
+
+	destinations, err := d.DestinationStore.GetByGUID(guid)
+	if err != nil {
+		d.ErrorResponse.InternalServerError(logger, w, err, "error getting egress destination")
+		return
+	}
+	if len(destinations) == 0 {
+		d.ErrorResponse.BadRequest(logger, w, errors.New("destination not found"), "destination not found: "+guid)
+		return
+	}
+
+	policies, err := d.Store.GetByFilter(req.Context(), store.EgressPolicyFilter{DestinationIDs: []string{guid}})
+	if err != nil {
+		d.ErrorResponse.InternalServerError(logger, w, err, "error listing egress policies")
+		return
+	}
+
+	names, err := d.sourceNames(req.Context(), policies)
+	if err != nil {
+		d.ErrorResponse.InternalServerError(logger, w, err, "error resolving egress policy sources")
+		return
+	}
+
+	apiPolicies := []api.EgressPolicy{}
+	for _, policy := range policies {
+		apiPolicies = append(apiPolicies, api.EgressPolicy{
+			ID: policy.ID,
+			Source: &api.EgressSource{
+				ID:   policy.Source.ID,
+				Type: policy.Source.Type,
+				Name: names[policy.Source.Type][policy.Source.ID],
+			},
+			Destination: &api.EgressDestination{
+				GUID: policy.Destination.GUID,
+			},
+			Action:    policy.Action,
+			ExpiresAt: policy.ExpiresAt,
+		})
+	}
+
+	usageResponse := struct {
+		TotalEgressPolicies int                `json:"total_egress_policies"`
+		EgressPolicies      []api.EgressPolicy `json:"egress_policies"`
+	}{len(apiPolicies), apiPolicies}
+	responseBytes, err := d.Marshaler.Marshal(usageResponse)
+	if err != nil {
+		d.ErrorResponse.InternalServerError(logger, w, err, "error serializing response")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func (d *DestinationUsage) sourceNames(ctx context.Context, policies []store.EgressPolicy) (map[string]map[string]string, error) {
+	sourceGUIDs := map[string][]string{}
+	for _, policy := range policies {
+		if policy.Source.Type != "default" {
+			sourceGUIDs[policy.Source.Type] = append(sourceGUIDs[policy.Source.Type], policy.Source.ID)
+		}
+	}
+
+	names := map[string]map[string]string{}
+	if len(sourceGUIDs) == 0 {
+		return names, nil
+	}
+
+	token, err := d.UAAClient.GetToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	names["app"], err = d.CCClient.GetAppNames(ctx, token, sourceGUIDs["app"])
+	if err != nil {
+		return nil, err
+	}
+
+	names["space"], err = d.CCClient.GetSpaceNames(ctx, token, sourceGUIDs["space"])
+	if err != nil {
+		return nil, err
+	}
+
+	names["org"], err = d.CCClient.GetOrgNames(ctx, token, sourceGUIDs["org"])
+	if err != nil {
+		return nil, err
+	}
+
+	return names, nil
+}
@@ -0,0 +1,195 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+
+	"code.cloudfoundry.org/cf-networking-helpers/httperror"
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DestinationUsage", func() {
+	var (
+		request           *http.Request
+		handler           *handlers.DestinationUsage
+		resp              *httptest.ResponseRecorder
+		fakeMetricsSender *storeFakes.MetricsSender
+		fakeStore         *fakes.EgressPolicyStore
+		fakeUAAClient     *fakes.UAAClient
+		fakeCCClient      *fakes.CCClient
+		fakeDestinations  *fakes.EgressDestinationGetter
+		logger            *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/destinations/destguid/usage", nil)
+		Expect(err).NotTo(HaveOccurred())
+		request.URL.RawQuery = ":id=destguid"
+
+		fakeStore = &fakes.EgressPolicyStore{}
+		fakeStore.GetByFilterReturns([]store.EgressPolicy{
+			{
+				ID:          "policy-1",
+				Source:      store.EgressSource{ID: "app-guid", Type: "app"},
+				Destination: store.EgressDestination{GUID: "destguid"},
+				Action:      "allow",
+			},
+			{
+				ID:          "policy-2",
+				Source:      store.EgressSource{ID: "space-guid", Type: "space"},
+				Destination: store.EgressDestination{GUID: "destguid"},
+				Action:      "deny",
+			},
+			{
+				ID:          "policy-3",
+				Source:      store.EgressSource{Type: "default"},
+				Destination: store.EgressDestination{GUID: "destguid"},
+				Action:      "allow",
+			},
+		}, nil)
+
+		fakeDestinations = &fakes.EgressDestinationGetter{}
+		fakeDestinations.GetByGUIDReturns([]store.EgressDestination{{GUID: "destguid", Name: "some-destination"}}, nil)
+
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+
+		fakeCCClient = &fakes.CCClient{}
+		fakeCCClient.GetAppNamesReturns(map[string]string{"app-guid": "some-app"}, nil)
+		fakeCCClient.GetSpaceNamesReturns(map[string]string{"space-guid": "some-space"}, nil)
+		fakeCCClient.GetOrgNamesReturns(map[string]string{}, nil)
+
+		logger = lagertest.NewTestLogger("test")
+		fakeMetricsSender = &storeFakes.MetricsSender{}
+
+		handler = &handlers.DestinationUsage{
+			ErrorResponse: &httperror.ErrorResponse{
+				MetricsSender: fakeMetricsSender,
+			},
+			DestinationStore: fakeDestinations,
+			Store:            fakeStore,
+			UAAClient:        fakeUAAClient,
+			CCClient:         fakeCCClient,
+			Marshaler:        marshal.MarshalFunc(json.Marshal),
+			Logger:           logger,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("lists the egress policies bound to the destination with their source names", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeDestinations.GetByGUIDCallCount()).To(Equal(1))
+		Expect(fakeDestinations.GetByGUIDArgsForCall(0)).To(Equal([]string{"destguid"}))
+
+		Expect(fakeStore.GetByFilterCallCount()).To(Equal(1))
+		_, passedFilter := fakeStore.GetByFilterArgsForCall(0)
+		Expect(passedFilter).To(Equal(store.EgressPolicyFilter{DestinationIDs: []string{"destguid"}}))
+
+		_, token, appGUIDs := fakeCCClient.GetAppNamesArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+		Expect(appGUIDs).To(Equal([]string{"app-guid"}))
+		_, _, spaceGUIDs := fakeCCClient.GetSpaceNamesArgsForCall(0)
+		Expect(spaceGUIDs).To(Equal([]string{"space-guid"}))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.String()).To(MatchJSON(`{
+			"total_egress_policies": 3,
+			"egress_policies": [
+				{"id": "policy-1", "source": {"id": "app-guid", "type": "app", "name": "some-app"}, "destination": {"id": "destguid"}, "action": "allow"},
+				{"id": "policy-2", "source": {"id": "space-guid", "type": "space", "name": "some-space"}, "destination": {"id": "destguid"}, "action": "deny"},
+				{"id": "policy-3", "source": {"id": "", "type": "default"}, "destination": {"id": "destguid"}, "action": "allow"}
+			]
+		}`))
+	})
+
+	Context("when the destination is not in use", func() {
+		BeforeEach(func() {
+			fakeStore.GetByFilterReturns([]store.EgressPolicy{}, nil)
+		})
+
+		It("returns an empty list without calling CC", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(MatchJSON(`{"total_egress_policies": 0, "egress_policies": []}`))
+			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the destination does not exist", func() {
+		BeforeEach(func() {
+			fakeDestinations.GetByGUIDReturns([]store.EgressDestination{}, nil)
+		})
+
+		It("returns a bad request without listing policies", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "destination not found: destguid"}`))
+			Expect(fakeStore.GetByFilterCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when getting the destination fails", func() {
+		BeforeEach(func() {
+			fakeDestinations.GetByGUIDReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an internal server error", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error getting egress destination"}`))
+		})
+	})
+
+	Context("when the store fails", func() {
+		BeforeEach(func() {
+			fakeStore.GetByFilterReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an internal server error", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error listing egress policies"}`))
+		})
+	})
+
+	Context("when getting a uaa token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("potato"))
+		})
+
+		It("returns an internal server error", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error resolving egress policy sources"}`))
+		})
+	})
+
+	Context("when resolving names from CC fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceNamesReturns(nil, errors.New("potato"))
+		})
+
+		It("returns an internal server error", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "error resolving egress policy sources"}`))
+		})
+	})
+})
@@ -22,10 +22,24 @@ type EgressDestinationMarshaller interface {
 //go:generate counterfeiter -o fakes/egress_destination_store_lister.go --fake-name EgressDestinationStoreLister . EgressDestinationStoreLister
 type EgressDestinationStoreLister interface {
 	All() ([]store.EgressDestination, error)
+	GetByFilter(filter store.EgressDestinationFilter) ([]store.EgressDestination, error)
 }
 
 func (d *DestinationsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	egressDestinations, err := d.EgressDestinationStore.All()
+	queryValues := req.URL.Query()
+	filter := store.EgressDestinationFilter{
+		IDs:       parseQueryList(queryValues, "id"),
+		Names:     parseQueryList(queryValues, "name"),
+		Protocols: parseQueryList(queryValues, "protocol"),
+	}
+
+	var egressDestinations []store.EgressDestination
+	var err error
+	if len(filter.IDs) == 0 && len(filter.Names) == 0 && len(filter.Protocols) == 0 {
+		egressDestinations, err = d.EgressDestinationStore.All()
+	} else {
+		egressDestinations, err = d.EgressDestinationStore.GetByFilter(filter)
+	}
 	if err != nil {
 		d.ErrorResponse.InternalServerError(d.Logger, w, err, "error getting egress destinations")
 		return
@@ -78,6 +78,42 @@ var _ = Describe("Destinations index handler", func() {
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
 	})
 
+	Context("when filters are provided", func() {
+		var filteredDestinations []store.EgressDestination
+
+		BeforeEach(func() {
+			filteredDestinations = []store.EgressDestination{
+				{GUID: "dest-1", Name: "dest-name"},
+			}
+			fakeStore.GetByFilterReturns(filteredDestinations, nil)
+
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v1/external/destinations?id=dest-1,dest-2&name=dest-name&protocol=tcp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the destinations matching the filter", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeStore.GetByFilterCallCount()).To(Equal(1))
+			Expect(fakeStore.GetByFilterArgsForCall(0)).To(Equal(store.EgressDestinationFilter{
+				IDs:       []string{"dest-1", "dest-2"},
+				Names:     []string{"dest-name"},
+				Protocols: []string{"tcp"},
+			}))
+			Expect(fakeMapper.AsBytesArgsForCall(0)).To(Equal(filteredDestinations))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("returns an error when the store returns an error", func() {
+			fakeStore.GetByFilterReturns(nil, errors.New("things went askew"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error getting egress destinations"}`))
+		})
+	})
+
 	It("returns an error when the store returns an error", func() {
 		fakeStore.AllReturns(nil, errors.New("things went askew"))
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"policy-server/store"
@@ -11,7 +12,7 @@ import (
 
 //go:generate counterfeiter -o fakes/egress_policy_mapper.go --fake-name EgressPolicyMapper . egressPolicyMapper
 type egressPolicyMapper interface {
-	AsStoreEgressPolicy(bytes []byte) ([]store.EgressPolicy, error)
+	AsStoreEgressPolicy(ctx context.Context, bytes []byte) ([]store.EgressPolicy, error)
 	AsBytes(storeEgressPolicies []store.EgressPolicy) ([]byte, error)
 	AsBytesWithPopulatedDestinations(storeEgressPolicies []store.EgressPolicy) ([]byte, error)
 }
@@ -30,13 +31,13 @@ func (e *EgressPolicyCreate) ServeHTTP(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	storeEgressPolicies, err := e.Mapper.AsStoreEgressPolicy(requestBytes)
+	storeEgressPolicies, err := e.Mapper.AsStoreEgressPolicy(req.Context(), requestBytes)
 	if err != nil {
 		e.ErrorResponse.BadRequest(e.Logger, w, err, fmt.Sprintf("error parsing egress policies: %s", err))
 		return
 	}
 
-	createdPolicies, err := e.Store.Create(storeEgressPolicies)
+	createdPolicies, err := e.Store.Create(req.Context(), storeEgressPolicies)
 	if err != nil {
 		e.ErrorResponse.InternalServerError(e.Logger, w, err, "error creating egress policy")
 		return
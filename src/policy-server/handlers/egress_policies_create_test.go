@@ -104,11 +104,11 @@ var _ = Describe("EgressPoliciesCreate", func() {
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 		Expect(fakeMapper.AsStoreEgressPolicyCallCount()).To(Equal(1))
-		policies := fakeMapper.AsStoreEgressPolicyArgsForCall(0)
+		_, policies := fakeMapper.AsStoreEgressPolicyArgsForCall(0)
 		Expect(string(policies)).To(Equal(requestBody))
 
 		Expect(fakeStore.CreateCallCount()).To(Equal(1))
-		storePolicies := fakeStore.CreateArgsForCall(0)
+		_, storePolicies := fakeStore.CreateArgsForCall(0)
 		Expect(storePolicies).To(Equal(expectedStoreEgressPolicies))
 
 		Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
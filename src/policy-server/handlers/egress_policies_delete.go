@@ -16,7 +16,7 @@ type EgressPolicyDelete struct {
 func (e *EgressPolicyDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	guid := req.URL.Query().Get(":id")
 
-	deletedPolicies, err := e.Store.Delete(guid)
+	deletedPolicies, err := e.Store.Delete(req.Context(), guid)
 	if err != nil {
 		e.ErrorResponse.InternalServerError(e.Logger, w, err, "error deleting egress policy")
 		return
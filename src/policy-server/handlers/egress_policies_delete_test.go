@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"net/http"
@@ -85,7 +86,7 @@ var _ = Describe("EgressPoliciesDelete", func() {
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-		guidToBeDeleted := fakeStore.DeleteArgsForCall(0)
+		_, guidToBeDeleted := fakeStore.DeleteArgsForCall(0)
 		Expect(guidToBeDeleted).To(ConsistOf("abc-123"))
 
 		Expect(fakeMapper.AsBytesCallCount()).To(Equal(1))
@@ -94,6 +95,18 @@ var _ = Describe("EgressPoliciesDelete", func() {
 		Expect(resp.Code).To(Equal(http.StatusOK))
 	})
 
+	It("deletes the policy within the request context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		request = request.WithContext(ctx)
+
+		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+		Expect(fakeStore.DeleteCallCount()).To(Equal(1))
+		passedCtx, _ := fakeStore.DeleteArgsForCall(0)
+		Expect(passedCtx.Err()).To(Equal(context.Canceled))
+	})
+
 	It("returns a response that includes the deleted policy", func() {
 		MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
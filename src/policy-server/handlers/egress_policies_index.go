@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"net/url"
+	"policy-server/store"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 )
@@ -14,7 +18,23 @@ type EgressPolicyIndex struct {
 }
 
 func (e *EgressPolicyIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
-	policies, err := e.Store.All()
+	filter := parseEgressPolicyFilter(req.URL.Query())
+
+	for _, sourceType := range filter.SourceTypes {
+		if sourceType != "app" && sourceType != "space" && sourceType != "org" && sourceType != "default" {
+			e.ErrorResponse.BadRequest(e.Logger, w, errors.New("invalid source type"), "source_type must be app, space, org or default")
+			return
+		}
+	}
+
+	var policies []store.EgressPolicy
+	var err error
+	if isEmptyEgressPolicyFilter(filter) {
+		policies, err = e.Store.All(req.Context())
+	} else {
+		policies, err = e.Store.GetByFilter(req.Context(), filter)
+	}
+
 	if err != nil {
 		e.ErrorResponse.InternalServerError(e.Logger, w, err, "error listing egress policies")
 		return
@@ -29,3 +49,30 @@ func (e *EgressPolicyIndex) ServeHTTP(w http.ResponseWriter, req *http.Request)
 	w.WriteHeader(http.StatusOK)
 	w.Write(bytes)
 }
+
+func parseEgressPolicyFilter(queryValues url.Values) store.EgressPolicyFilter {
+	return store.EgressPolicyFilter{
+		SourceIDs:        parseQueryList(queryValues, "source_id"),
+		SourceTypes:      parseQueryList(queryValues, "source_type"),
+		DestinationIDs:   parseQueryList(queryValues, "destination_id"),
+		DestinationNames: parseQueryList(queryValues, "destination_name"),
+		Protocols:        parseQueryList(queryValues, "protocol"),
+	}
+}
+
+func isEmptyEgressPolicyFilter(filter store.EgressPolicyFilter) bool {
+	return len(filter.SourceIDs) == 0 &&
+		len(filter.SourceTypes) == 0 &&
+		len(filter.DestinationIDs) == 0 &&
+		len(filter.DestinationNames) == 0 &&
+		len(filter.Protocols) == 0
+}
+
+func parseQueryList(queryValues url.Values, key string) []string {
+	var values []string
+	valueList, ok := queryValues[key]
+	if ok {
+		values = strings.Split(valueList[0], ",")
+	}
+	return values
+}
@@ -106,6 +106,76 @@ var _ = Describe("EgressPoliciesIndex", func() {
 		Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error listing egress policies"}`))
 	})
 
+	Context("when filters are provided", func() {
+		var filteredPolicies []store.EgressPolicy
+
+		BeforeEach(func() {
+			filteredPolicies = []store.EgressPolicy{
+				{
+					ID: "def-456",
+				},
+			}
+			fakeStore.GetByFilterReturns(filteredPolicies, nil)
+
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v1/external/egress_policies?source_id=app-1,app-2&source_type=app&destination_id=dest-1&destination_name=dest-name&protocol=tcp,udp", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the egress policies matching the filter", func() {
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeStore.GetByFilterCallCount()).To(Equal(1))
+			_, passedFilter := fakeStore.GetByFilterArgsForCall(0)
+			Expect(passedFilter).To(Equal(store.EgressPolicyFilter{
+				SourceIDs:        []string{"app-1", "app-2"},
+				SourceTypes:      []string{"app"},
+				DestinationIDs:   []string{"dest-1"},
+				DestinationNames: []string{"dest-name"},
+				Protocols:        []string{"tcp", "udp"},
+			}))
+
+			Expect(fakeMapper.AsBytesWithPopulatedDestinationsArgsForCall(0)).To(Equal(filteredPolicies))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+
+		It("only sets the provided filters", func() {
+			var err error
+			request, err = http.NewRequest("GET", "/networking/v1/external/egress_policies?destination_name=dest-name", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+
+			_, passedFilter := fakeStore.GetByFilterArgsForCall(0)
+			Expect(passedFilter).To(Equal(store.EgressPolicyFilter{
+				DestinationNames: []string{"dest-name"},
+			}))
+		})
+
+		It("returns an error when the store returns an error", func() {
+			fakeStore.GetByFilterReturns(nil, errors.New("can't filter"))
+			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "error listing egress policies"}`))
+		})
+
+		Context("when the source type is invalid", func() {
+			BeforeEach(func() {
+				var err error
+				request, err = http.NewRequest("GET", "/networking/v1/external/egress_policies?source_type=app,potato", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a bad request", func() {
+				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
+				Expect(fakeStore.GetByFilterCallCount()).To(Equal(0))
+				Expect(resp.Code).To(Equal(http.StatusBadRequest))
+				Expect(resp.Body.Bytes()).To(MatchJSON(`{"error": "source_type must be app, space, org or default"}`))
+			})
+		})
+	})
+
 	It("returns an error the mapper cannot serialize the output", func() {
 		fakeMapper.AsBytesWithPopulatedDestinationsReturns(nil, errors.New("didn't go well"))
 
@@ -2,14 +2,16 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/api"
 	"sync"
 )
 
 type CCClient struct {
-	GetAppSpacesStub        func(token string, appGUIDs []string) (map[string]string, error)
+	GetAppSpacesStub        func(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
 	getAppSpacesMutex       sync.RWMutex
 	getAppSpacesArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
 	}
@@ -21,9 +23,10 @@ type CCClient struct {
 		result1 map[string]string
 		result2 error
 	}
-	GetSpaceStub        func(token, spaceGUID string) (*api.Space, error)
+	GetSpaceStub        func(ctx context.Context, token, spaceGUID string) (*api.Space, error)
 	getSpaceMutex       sync.RWMutex
 	getSpaceArgsForCall []struct {
+		ctx       context.Context
 		token     string
 		spaceGUID string
 	}
@@ -35,9 +38,10 @@ type CCClient struct {
 		result1 *api.Space
 		result2 error
 	}
-	GetSpaceGUIDsStub        func(token string, appGUIDs []string) ([]string, error)
+	GetSpaceGUIDsStub        func(ctx context.Context, token string, appGUIDs []string) ([]string, error)
 	getSpaceGUIDsMutex       sync.RWMutex
 	getSpaceGUIDsArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
 	}
@@ -49,9 +53,10 @@ type CCClient struct {
 		result1 []string
 		result2 error
 	}
-	GetUserSpaceStub        func(token, userGUID string, spaces api.Space) (*api.Space, error)
+	GetUserSpaceStub        func(ctx context.Context, token, userGUID string, spaces api.Space) (*api.Space, error)
 	getUserSpaceMutex       sync.RWMutex
 	getUserSpaceArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		userGUID string
 		spaces   api.Space
@@ -64,9 +69,10 @@ type CCClient struct {
 		result1 *api.Space
 		result2 error
 	}
-	GetUserSpacesStub        func(token, userGUID string) (map[string]struct{}, error)
+	GetUserSpacesStub        func(ctx context.Context, token, userGUID string) (map[string]struct{}, error)
 	getUserSpacesMutex       sync.RWMutex
 	getUserSpacesArgsForCall []struct {
+		ctx      context.Context
 		token    string
 		userGUID string
 	}
@@ -78,11 +84,71 @@ type CCClient struct {
 		result1 map[string]struct{}
 		result2 error
 	}
+	GetAppNamesStub        func(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
+	getAppNamesMutex       sync.RWMutex
+	getAppNamesArgsForCall []struct {
+		ctx      context.Context
+		token    string
+		appGUIDs []string
+	}
+	getAppNamesReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getAppNamesReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetSpaceNamesStub        func(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error)
+	getSpaceNamesMutex       sync.RWMutex
+	getSpaceNamesArgsForCall []struct {
+		ctx        context.Context
+		token      string
+		spaceGUIDs []string
+	}
+	getSpaceNamesReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getSpaceNamesReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetSpaceOrgsStub        func(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error)
+	getSpaceOrgsMutex       sync.RWMutex
+	getSpaceOrgsArgsForCall []struct {
+		ctx        context.Context
+		token      string
+		spaceGUIDs []string
+	}
+	getSpaceOrgsReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getSpaceOrgsReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
+	GetOrgNamesStub        func(ctx context.Context, token string, orgGUIDs []string) (map[string]string, error)
+	getOrgNamesMutex       sync.RWMutex
+	getOrgNamesArgsForCall []struct {
+		ctx      context.Context
+		token    string
+		orgGUIDs []string
+	}
+	getOrgNamesReturns struct {
+		result1 map[string]string
+		result2 error
+	}
+	getOrgNamesReturnsOnCall map[int]struct {
+		result1 map[string]string
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *CCClient) GetAppSpaces(token string, appGUIDs []string) (map[string]string, error) {
+func (fake *CCClient) GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
 	var appGUIDsCopy []string
 	if appGUIDs != nil {
 		appGUIDsCopy = make([]string, len(appGUIDs))
@@ -91,13 +157,14 @@ func (fake *CCClient) GetAppSpaces(token string, appGUIDs []string) (map[string]
 	fake.getAppSpacesMutex.Lock()
 	ret, specificReturn := fake.getAppSpacesReturnsOnCall[len(fake.getAppSpacesArgsForCall)]
 	fake.getAppSpacesArgsForCall = append(fake.getAppSpacesArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetAppSpaces", []interface{}{token, appGUIDsCopy})
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetAppSpaces", []interface{}{ctx, token, appGUIDsCopy})
 	fake.getAppSpacesMutex.Unlock()
 	if fake.GetAppSpacesStub != nil {
-		return fake.GetAppSpacesStub(token, appGUIDs)
+		return fake.GetAppSpacesStub(ctx, token, appGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -111,10 +178,10 @@ func (fake *CCClient) GetAppSpacesCallCount() int {
 	return len(fake.getAppSpacesArgsForCall)
 }
 
-func (fake *CCClient) GetAppSpacesArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetAppSpacesArgsForCall(i int) (context.Context, string, []string) {
 	fake.getAppSpacesMutex.RLock()
 	defer fake.getAppSpacesMutex.RUnlock()
-	return fake.getAppSpacesArgsForCall[i].token, fake.getAppSpacesArgsForCall[i].appGUIDs
+	return fake.getAppSpacesArgsForCall[i].ctx, fake.getAppSpacesArgsForCall[i].token, fake.getAppSpacesArgsForCall[i].appGUIDs
 }
 
 func (fake *CCClient) GetAppSpacesReturns(result1 map[string]string, result2 error) {
@@ -139,17 +206,18 @@ func (fake *CCClient) GetAppSpacesReturnsOnCall(i int, result1 map[string]string
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetSpace(token string, spaceGUID string) (*api.Space, error) {
+func (fake *CCClient) GetSpace(ctx context.Context, token string, spaceGUID string) (*api.Space, error) {
 	fake.getSpaceMutex.Lock()
 	ret, specificReturn := fake.getSpaceReturnsOnCall[len(fake.getSpaceArgsForCall)]
 	fake.getSpaceArgsForCall = append(fake.getSpaceArgsForCall, struct {
+		ctx       context.Context
 		token     string
 		spaceGUID string
-	}{token, spaceGUID})
-	fake.recordInvocation("GetSpace", []interface{}{token, spaceGUID})
+	}{ctx, token, spaceGUID})
+	fake.recordInvocation("GetSpace", []interface{}{ctx, token, spaceGUID})
 	fake.getSpaceMutex.Unlock()
 	if fake.GetSpaceStub != nil {
-		return fake.GetSpaceStub(token, spaceGUID)
+		return fake.GetSpaceStub(ctx, token, spaceGUID)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -163,10 +231,10 @@ func (fake *CCClient) GetSpaceCallCount() int {
 	return len(fake.getSpaceArgsForCall)
 }
 
-func (fake *CCClient) GetSpaceArgsForCall(i int) (string, string) {
+func (fake *CCClient) GetSpaceArgsForCall(i int) (context.Context, string, string) {
 	fake.getSpaceMutex.RLock()
 	defer fake.getSpaceMutex.RUnlock()
-	return fake.getSpaceArgsForCall[i].token, fake.getSpaceArgsForCall[i].spaceGUID
+	return fake.getSpaceArgsForCall[i].ctx, fake.getSpaceArgsForCall[i].token, fake.getSpaceArgsForCall[i].spaceGUID
 }
 
 func (fake *CCClient) GetSpaceReturns(result1 *api.Space, result2 error) {
@@ -191,7 +259,7 @@ func (fake *CCClient) GetSpaceReturnsOnCall(i int, result1 *api.Space, result2 e
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error) {
+func (fake *CCClient) GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error) {
 	var appGUIDsCopy []string
 	if appGUIDs != nil {
 		appGUIDsCopy = make([]string, len(appGUIDs))
@@ -200,13 +268,14 @@ func (fake *CCClient) GetSpaceGUIDs(token string, appGUIDs []string) ([]string,
 	fake.getSpaceGUIDsMutex.Lock()
 	ret, specificReturn := fake.getSpaceGUIDsReturnsOnCall[len(fake.getSpaceGUIDsArgsForCall)]
 	fake.getSpaceGUIDsArgsForCall = append(fake.getSpaceGUIDsArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		appGUIDs []string
-	}{token, appGUIDsCopy})
-	fake.recordInvocation("GetSpaceGUIDs", []interface{}{token, appGUIDsCopy})
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetSpaceGUIDs", []interface{}{ctx, token, appGUIDsCopy})
 	fake.getSpaceGUIDsMutex.Unlock()
 	if fake.GetSpaceGUIDsStub != nil {
-		return fake.GetSpaceGUIDsStub(token, appGUIDs)
+		return fake.GetSpaceGUIDsStub(ctx, token, appGUIDs)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -220,10 +289,10 @@ func (fake *CCClient) GetSpaceGUIDsCallCount() int {
 	return len(fake.getSpaceGUIDsArgsForCall)
 }
 
-func (fake *CCClient) GetSpaceGUIDsArgsForCall(i int) (string, []string) {
+func (fake *CCClient) GetSpaceGUIDsArgsForCall(i int) (context.Context, string, []string) {
 	fake.getSpaceGUIDsMutex.RLock()
 	defer fake.getSpaceGUIDsMutex.RUnlock()
-	return fake.getSpaceGUIDsArgsForCall[i].token, fake.getSpaceGUIDsArgsForCall[i].appGUIDs
+	return fake.getSpaceGUIDsArgsForCall[i].ctx, fake.getSpaceGUIDsArgsForCall[i].token, fake.getSpaceGUIDsArgsForCall[i].appGUIDs
 }
 
 func (fake *CCClient) GetSpaceGUIDsReturns(result1 []string, result2 error) {
@@ -248,18 +317,19 @@ func (fake *CCClient) GetSpaceGUIDsReturnsOnCall(i int, result1 []string, result
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetUserSpace(token string, userGUID string, spaces api.Space) (*api.Space, error) {
+func (fake *CCClient) GetUserSpace(ctx context.Context, token string, userGUID string, spaces api.Space) (*api.Space, error) {
 	fake.getUserSpaceMutex.Lock()
 	ret, specificReturn := fake.getUserSpaceReturnsOnCall[len(fake.getUserSpaceArgsForCall)]
 	fake.getUserSpaceArgsForCall = append(fake.getUserSpaceArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		userGUID string
 		spaces   api.Space
-	}{token, userGUID, spaces})
-	fake.recordInvocation("GetUserSpace", []interface{}{token, userGUID, spaces})
+	}{ctx, token, userGUID, spaces})
+	fake.recordInvocation("GetUserSpace", []interface{}{ctx, token, userGUID, spaces})
 	fake.getUserSpaceMutex.Unlock()
 	if fake.GetUserSpaceStub != nil {
-		return fake.GetUserSpaceStub(token, userGUID, spaces)
+		return fake.GetUserSpaceStub(ctx, token, userGUID, spaces)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -273,10 +343,10 @@ func (fake *CCClient) GetUserSpaceCallCount() int {
 	return len(fake.getUserSpaceArgsForCall)
 }
 
-func (fake *CCClient) GetUserSpaceArgsForCall(i int) (string, string, api.Space) {
+func (fake *CCClient) GetUserSpaceArgsForCall(i int) (context.Context, string, string, api.Space) {
 	fake.getUserSpaceMutex.RLock()
 	defer fake.getUserSpaceMutex.RUnlock()
-	return fake.getUserSpaceArgsForCall[i].token, fake.getUserSpaceArgsForCall[i].userGUID, fake.getUserSpaceArgsForCall[i].spaces
+	return fake.getUserSpaceArgsForCall[i].ctx, fake.getUserSpaceArgsForCall[i].token, fake.getUserSpaceArgsForCall[i].userGUID, fake.getUserSpaceArgsForCall[i].spaces
 }
 
 func (fake *CCClient) GetUserSpaceReturns(result1 *api.Space, result2 error) {
@@ -301,17 +371,18 @@ func (fake *CCClient) GetUserSpaceReturnsOnCall(i int, result1 *api.Space, resul
 	}{result1, result2}
 }
 
-func (fake *CCClient) GetUserSpaces(token string, userGUID string) (map[string]struct{}, error) {
+func (fake *CCClient) GetUserSpaces(ctx context.Context, token string, userGUID string) (map[string]struct{}, error) {
 	fake.getUserSpacesMutex.Lock()
 	ret, specificReturn := fake.getUserSpacesReturnsOnCall[len(fake.getUserSpacesArgsForCall)]
 	fake.getUserSpacesArgsForCall = append(fake.getUserSpacesArgsForCall, struct {
+		ctx      context.Context
 		token    string
 		userGUID string
-	}{token, userGUID})
-	fake.recordInvocation("GetUserSpaces", []interface{}{token, userGUID})
+	}{ctx, token, userGUID})
+	fake.recordInvocation("GetUserSpaces", []interface{}{ctx, token, userGUID})
 	fake.getUserSpacesMutex.Unlock()
 	if fake.GetUserSpacesStub != nil {
-		return fake.GetUserSpacesStub(token, userGUID)
+		return fake.GetUserSpacesStub(ctx, token, userGUID)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -325,10 +396,10 @@ func (fake *CCClient) GetUserSpacesCallCount() int {
 	return len(fake.getUserSpacesArgsForCall)
 }
 
-func (fake *CCClient) GetUserSpacesArgsForCall(i int) (string, string) {
+func (fake *CCClient) GetUserSpacesArgsForCall(i int) (context.Context, string, string) {
 	fake.getUserSpacesMutex.RLock()
 	defer fake.getUserSpacesMutex.RUnlock()
-	return fake.getUserSpacesArgsForCall[i].token, fake.getUserSpacesArgsForCall[i].userGUID
+	return fake.getUserSpacesArgsForCall[i].ctx, fake.getUserSpacesArgsForCall[i].token, fake.getUserSpacesArgsForCall[i].userGUID
 }
 
 func (fake *CCClient) GetUserSpacesReturns(result1 map[string]struct{}, result2 error) {
@@ -353,6 +424,238 @@ func (fake *CCClient) GetUserSpacesReturnsOnCall(i int, result1 map[string]struc
 	}{result1, result2}
 }
 
+func (fake *CCClient) GetAppNames(ctx context.Context, token string, appGUIDs []string) (map[string]string, error) {
+	var appGUIDsCopy []string
+	if appGUIDs != nil {
+		appGUIDsCopy = make([]string, len(appGUIDs))
+		copy(appGUIDsCopy, appGUIDs)
+	}
+	fake.getAppNamesMutex.Lock()
+	ret, specificReturn := fake.getAppNamesReturnsOnCall[len(fake.getAppNamesArgsForCall)]
+	fake.getAppNamesArgsForCall = append(fake.getAppNamesArgsForCall, struct {
+		ctx      context.Context
+		token    string
+		appGUIDs []string
+	}{ctx, token, appGUIDsCopy})
+	fake.recordInvocation("GetAppNames", []interface{}{ctx, token, appGUIDsCopy})
+	fake.getAppNamesMutex.Unlock()
+	if fake.GetAppNamesStub != nil {
+		return fake.GetAppNamesStub(ctx, token, appGUIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getAppNamesReturns.result1, fake.getAppNamesReturns.result2
+}
+
+func (fake *CCClient) GetAppNamesCallCount() int {
+	fake.getAppNamesMutex.RLock()
+	defer fake.getAppNamesMutex.RUnlock()
+	return len(fake.getAppNamesArgsForCall)
+}
+
+func (fake *CCClient) GetAppNamesArgsForCall(i int) (context.Context, string, []string) {
+	fake.getAppNamesMutex.RLock()
+	defer fake.getAppNamesMutex.RUnlock()
+	return fake.getAppNamesArgsForCall[i].ctx, fake.getAppNamesArgsForCall[i].token, fake.getAppNamesArgsForCall[i].appGUIDs
+}
+
+func (fake *CCClient) GetAppNamesReturns(result1 map[string]string, result2 error) {
+	fake.GetAppNamesStub = nil
+	fake.getAppNamesReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetAppNamesReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.GetAppNamesStub = nil
+	if fake.getAppNamesReturnsOnCall == nil {
+		fake.getAppNamesReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getAppNamesReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetSpaceNames(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error) {
+	var spaceGUIDsCopy []string
+	if spaceGUIDs != nil {
+		spaceGUIDsCopy = make([]string, len(spaceGUIDs))
+		copy(spaceGUIDsCopy, spaceGUIDs)
+	}
+	fake.getSpaceNamesMutex.Lock()
+	ret, specificReturn := fake.getSpaceNamesReturnsOnCall[len(fake.getSpaceNamesArgsForCall)]
+	fake.getSpaceNamesArgsForCall = append(fake.getSpaceNamesArgsForCall, struct {
+		ctx        context.Context
+		token      string
+		spaceGUIDs []string
+	}{ctx, token, spaceGUIDsCopy})
+	fake.recordInvocation("GetSpaceNames", []interface{}{ctx, token, spaceGUIDsCopy})
+	fake.getSpaceNamesMutex.Unlock()
+	if fake.GetSpaceNamesStub != nil {
+		return fake.GetSpaceNamesStub(ctx, token, spaceGUIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getSpaceNamesReturns.result1, fake.getSpaceNamesReturns.result2
+}
+
+func (fake *CCClient) GetSpaceNamesCallCount() int {
+	fake.getSpaceNamesMutex.RLock()
+	defer fake.getSpaceNamesMutex.RUnlock()
+	return len(fake.getSpaceNamesArgsForCall)
+}
+
+func (fake *CCClient) GetSpaceNamesArgsForCall(i int) (context.Context, string, []string) {
+	fake.getSpaceNamesMutex.RLock()
+	defer fake.getSpaceNamesMutex.RUnlock()
+	return fake.getSpaceNamesArgsForCall[i].ctx, fake.getSpaceNamesArgsForCall[i].token, fake.getSpaceNamesArgsForCall[i].spaceGUIDs
+}
+
+func (fake *CCClient) GetSpaceNamesReturns(result1 map[string]string, result2 error) {
+	fake.GetSpaceNamesStub = nil
+	fake.getSpaceNamesReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetSpaceNamesReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.GetSpaceNamesStub = nil
+	if fake.getSpaceNamesReturnsOnCall == nil {
+		fake.getSpaceNamesReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getSpaceNamesReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetSpaceOrgs(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error) {
+	var spaceGUIDsCopy []string
+	if spaceGUIDs != nil {
+		spaceGUIDsCopy = make([]string, len(spaceGUIDs))
+		copy(spaceGUIDsCopy, spaceGUIDs)
+	}
+	fake.getSpaceOrgsMutex.Lock()
+	ret, specificReturn := fake.getSpaceOrgsReturnsOnCall[len(fake.getSpaceOrgsArgsForCall)]
+	fake.getSpaceOrgsArgsForCall = append(fake.getSpaceOrgsArgsForCall, struct {
+		ctx        context.Context
+		token      string
+		spaceGUIDs []string
+	}{ctx, token, spaceGUIDsCopy})
+	fake.recordInvocation("GetSpaceOrgs", []interface{}{ctx, token, spaceGUIDsCopy})
+	fake.getSpaceOrgsMutex.Unlock()
+	if fake.GetSpaceOrgsStub != nil {
+		return fake.GetSpaceOrgsStub(ctx, token, spaceGUIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getSpaceOrgsReturns.result1, fake.getSpaceOrgsReturns.result2
+}
+
+func (fake *CCClient) GetSpaceOrgsCallCount() int {
+	fake.getSpaceOrgsMutex.RLock()
+	defer fake.getSpaceOrgsMutex.RUnlock()
+	return len(fake.getSpaceOrgsArgsForCall)
+}
+
+func (fake *CCClient) GetSpaceOrgsArgsForCall(i int) (context.Context, string, []string) {
+	fake.getSpaceOrgsMutex.RLock()
+	defer fake.getSpaceOrgsMutex.RUnlock()
+	return fake.getSpaceOrgsArgsForCall[i].ctx, fake.getSpaceOrgsArgsForCall[i].token, fake.getSpaceOrgsArgsForCall[i].spaceGUIDs
+}
+
+func (fake *CCClient) GetSpaceOrgsReturns(result1 map[string]string, result2 error) {
+	fake.GetSpaceOrgsStub = nil
+	fake.getSpaceOrgsReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetSpaceOrgsReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.GetSpaceOrgsStub = nil
+	if fake.getSpaceOrgsReturnsOnCall == nil {
+		fake.getSpaceOrgsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getSpaceOrgsReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetOrgNames(ctx context.Context, token string, orgGUIDs []string) (map[string]string, error) {
+	var orgGUIDsCopy []string
+	if orgGUIDs != nil {
+		orgGUIDsCopy = make([]string, len(orgGUIDs))
+		copy(orgGUIDsCopy, orgGUIDs)
+	}
+	fake.getOrgNamesMutex.Lock()
+	ret, specificReturn := fake.getOrgNamesReturnsOnCall[len(fake.getOrgNamesArgsForCall)]
+	fake.getOrgNamesArgsForCall = append(fake.getOrgNamesArgsForCall, struct {
+		ctx      context.Context
+		token    string
+		orgGUIDs []string
+	}{ctx, token, orgGUIDsCopy})
+	fake.recordInvocation("GetOrgNames", []interface{}{ctx, token, orgGUIDsCopy})
+	fake.getOrgNamesMutex.Unlock()
+	if fake.GetOrgNamesStub != nil {
+		return fake.GetOrgNamesStub(ctx, token, orgGUIDs)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getOrgNamesReturns.result1, fake.getOrgNamesReturns.result2
+}
+
+func (fake *CCClient) GetOrgNamesCallCount() int {
+	fake.getOrgNamesMutex.RLock()
+	defer fake.getOrgNamesMutex.RUnlock()
+	return len(fake.getOrgNamesArgsForCall)
+}
+
+func (fake *CCClient) GetOrgNamesArgsForCall(i int) (context.Context, string, []string) {
+	fake.getOrgNamesMutex.RLock()
+	defer fake.getOrgNamesMutex.RUnlock()
+	return fake.getOrgNamesArgsForCall[i].ctx, fake.getOrgNamesArgsForCall[i].token, fake.getOrgNamesArgsForCall[i].orgGUIDs
+}
+
+func (fake *CCClient) GetOrgNamesReturns(result1 map[string]string, result2 error) {
+	fake.GetOrgNamesStub = nil
+	fake.getOrgNamesReturns = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CCClient) GetOrgNamesReturnsOnCall(i int, result1 map[string]string, result2 error) {
+	fake.GetOrgNamesStub = nil
+	if fake.getOrgNamesReturnsOnCall == nil {
+		fake.getOrgNamesReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+			result2 error
+		})
+	}
+	fake.getOrgNamesReturnsOnCall[i] = struct {
+		result1 map[string]string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CCClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -366,6 +669,14 @@ func (fake *CCClient) Invocations() map[string][][]interface{} {
 	defer fake.getUserSpaceMutex.RUnlock()
 	fake.getUserSpacesMutex.RLock()
 	defer fake.getUserSpacesMutex.RUnlock()
+	fake.getAppNamesMutex.RLock()
+	defer fake.getAppNamesMutex.RUnlock()
+	fake.getSpaceNamesMutex.RLock()
+	defer fake.getSpaceNamesMutex.RUnlock()
+	fake.getSpaceOrgsMutex.RLock()
+	defer fake.getSpaceOrgsMutex.RUnlock()
+	fake.getOrgNamesMutex.RLock()
+	defer fake.getOrgNamesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -21,6 +21,21 @@ type EgressDestinationStoreDeleter struct {
 		result1 store.EgressDestination
 		result2 error
 	}
+	DeleteWithPoliciesStub        func(string) (store.EgressDestination, []store.EgressPolicy, error)
+	deleteWithPoliciesMutex       sync.RWMutex
+	deleteWithPoliciesArgsForCall []struct {
+		arg1 string
+	}
+	deleteWithPoliciesReturns struct {
+		result1 store.EgressDestination
+		result2 []store.EgressPolicy
+		result3 error
+	}
+	deleteWithPoliciesReturnsOnCall map[int]struct {
+		result1 store.EgressDestination
+		result2 []store.EgressPolicy
+		result3 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -76,11 +91,67 @@ func (fake *EgressDestinationStoreDeleter) DeleteReturnsOnCall(i int, result1 st
 	}{result1, result2}
 }
 
+func (fake *EgressDestinationStoreDeleter) DeleteWithPolicies(arg1 string) (store.EgressDestination, []store.EgressPolicy, error) {
+	fake.deleteWithPoliciesMutex.Lock()
+	ret, specificReturn := fake.deleteWithPoliciesReturnsOnCall[len(fake.deleteWithPoliciesArgsForCall)]
+	fake.deleteWithPoliciesArgsForCall = append(fake.deleteWithPoliciesArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DeleteWithPolicies", []interface{}{arg1})
+	fake.deleteWithPoliciesMutex.Unlock()
+	if fake.DeleteWithPoliciesStub != nil {
+		return fake.DeleteWithPoliciesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	return fake.deleteWithPoliciesReturns.result1, fake.deleteWithPoliciesReturns.result2, fake.deleteWithPoliciesReturns.result3
+}
+
+func (fake *EgressDestinationStoreDeleter) DeleteWithPoliciesCallCount() int {
+	fake.deleteWithPoliciesMutex.RLock()
+	defer fake.deleteWithPoliciesMutex.RUnlock()
+	return len(fake.deleteWithPoliciesArgsForCall)
+}
+
+func (fake *EgressDestinationStoreDeleter) DeleteWithPoliciesArgsForCall(i int) string {
+	fake.deleteWithPoliciesMutex.RLock()
+	defer fake.deleteWithPoliciesMutex.RUnlock()
+	return fake.deleteWithPoliciesArgsForCall[i].arg1
+}
+
+func (fake *EgressDestinationStoreDeleter) DeleteWithPoliciesReturns(result1 store.EgressDestination, result2 []store.EgressPolicy, result3 error) {
+	fake.DeleteWithPoliciesStub = nil
+	fake.deleteWithPoliciesReturns = struct {
+		result1 store.EgressDestination
+		result2 []store.EgressPolicy
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *EgressDestinationStoreDeleter) DeleteWithPoliciesReturnsOnCall(i int, result1 store.EgressDestination, result2 []store.EgressPolicy, result3 error) {
+	fake.DeleteWithPoliciesStub = nil
+	if fake.deleteWithPoliciesReturnsOnCall == nil {
+		fake.deleteWithPoliciesReturnsOnCall = make(map[int]struct {
+			result1 store.EgressDestination
+			result2 []store.EgressPolicy
+			result3 error
+		})
+	}
+	fake.deleteWithPoliciesReturnsOnCall[i] = struct {
+		result1 store.EgressDestination
+		result2 []store.EgressPolicy
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *EgressDestinationStoreDeleter) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.deleteWithPoliciesMutex.RLock()
+	defer fake.deleteWithPoliciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -19,6 +19,19 @@ type EgressDestinationStoreLister struct {
 		result1 []store.EgressDestination
 		result2 error
 	}
+	GetByFilterStub        func(filter store.EgressDestinationFilter) ([]store.EgressDestination, error)
+	getByFilterMutex       sync.RWMutex
+	getByFilterArgsForCall []struct {
+		filter store.EgressDestinationFilter
+	}
+	getByFilterReturns struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	getByFilterReturnsOnCall map[int]struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -66,11 +79,64 @@ func (fake *EgressDestinationStoreLister) AllReturnsOnCall(i int, result1 []stor
 	}{result1, result2}
 }
 
+func (fake *EgressDestinationStoreLister) GetByFilter(filter store.EgressDestinationFilter) ([]store.EgressDestination, error) {
+	fake.getByFilterMutex.Lock()
+	ret, specificReturn := fake.getByFilterReturnsOnCall[len(fake.getByFilterArgsForCall)]
+	fake.getByFilterArgsForCall = append(fake.getByFilterArgsForCall, struct {
+		filter store.EgressDestinationFilter
+	}{filter})
+	fake.recordInvocation("GetByFilter", []interface{}{filter})
+	fake.getByFilterMutex.Unlock()
+	if fake.GetByFilterStub != nil {
+		return fake.GetByFilterStub(filter)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getByFilterReturns.result1, fake.getByFilterReturns.result2
+}
+
+func (fake *EgressDestinationStoreLister) GetByFilterCallCount() int {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return len(fake.getByFilterArgsForCall)
+}
+
+func (fake *EgressDestinationStoreLister) GetByFilterArgsForCall(i int) store.EgressDestinationFilter {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return fake.getByFilterArgsForCall[i].filter
+}
+
+func (fake *EgressDestinationStoreLister) GetByFilterReturns(result1 []store.EgressDestination, result2 error) {
+	fake.GetByFilterStub = nil
+	fake.getByFilterReturns = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationStoreLister) GetByFilterReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.GetByFilterStub = nil
+	if fake.getByFilterReturnsOnCall == nil {
+		fake.getByFilterReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressDestination
+			result2 error
+		})
+	}
+	fake.getByFilterReturnsOnCall[i] = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressDestinationStoreLister) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.allMutex.RLock()
 	defer fake.allMutex.RUnlock()
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -2,14 +2,16 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type EgressPolicyMapper struct {
-	AsStoreEgressPolicyStub        func(bytes []byte) ([]store.EgressPolicy, error)
+	AsStoreEgressPolicyStub        func(ctx context.Context, bytes []byte) ([]store.EgressPolicy, error)
 	asStoreEgressPolicyMutex       sync.RWMutex
 	asStoreEgressPolicyArgsForCall []struct {
+		ctx   context.Context
 		bytes []byte
 	}
 	asStoreEgressPolicyReturns struct {
@@ -50,7 +52,7 @@ type EgressPolicyMapper struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyMapper) AsStoreEgressPolicy(bytes []byte) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyMapper) AsStoreEgressPolicy(ctx context.Context, bytes []byte) ([]store.EgressPolicy, error) {
 	var bytesCopy []byte
 	if bytes != nil {
 		bytesCopy = make([]byte, len(bytes))
@@ -59,12 +61,13 @@ func (fake *EgressPolicyMapper) AsStoreEgressPolicy(bytes []byte) ([]store.Egres
 	fake.asStoreEgressPolicyMutex.Lock()
 	ret, specificReturn := fake.asStoreEgressPolicyReturnsOnCall[len(fake.asStoreEgressPolicyArgsForCall)]
 	fake.asStoreEgressPolicyArgsForCall = append(fake.asStoreEgressPolicyArgsForCall, struct {
+		ctx   context.Context
 		bytes []byte
-	}{bytesCopy})
-	fake.recordInvocation("AsStoreEgressPolicy", []interface{}{bytesCopy})
+	}{ctx, bytesCopy})
+	fake.recordInvocation("AsStoreEgressPolicy", []interface{}{ctx, bytesCopy})
 	fake.asStoreEgressPolicyMutex.Unlock()
 	if fake.AsStoreEgressPolicyStub != nil {
-		return fake.AsStoreEgressPolicyStub(bytes)
+		return fake.AsStoreEgressPolicyStub(ctx, bytes)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -78,10 +81,10 @@ func (fake *EgressPolicyMapper) AsStoreEgressPolicyCallCount() int {
 	return len(fake.asStoreEgressPolicyArgsForCall)
 }
 
-func (fake *EgressPolicyMapper) AsStoreEgressPolicyArgsForCall(i int) []byte {
+func (fake *EgressPolicyMapper) AsStoreEgressPolicyArgsForCall(i int) (context.Context, []byte) {
 	fake.asStoreEgressPolicyMutex.RLock()
 	defer fake.asStoreEgressPolicyMutex.RUnlock()
-	return fake.asStoreEgressPolicyArgsForCall[i].bytes
+	return fake.asStoreEgressPolicyArgsForCall[i].ctx, fake.asStoreEgressPolicyArgsForCall[i].bytes
 }
 
 func (fake *EgressPolicyMapper) AsStoreEgressPolicyReturns(result1 []store.EgressPolicy, result2 error) {
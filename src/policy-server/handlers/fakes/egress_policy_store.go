@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type EgressPolicyStore struct {
-	AllStub        func() ([]store.EgressPolicy, error)
+	AllStub        func(ctx context.Context) ([]store.EgressPolicy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		ctx context.Context
+	}
+	allReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -18,22 +21,38 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	GetBySourceGuidsStub        func(ids []string) ([]store.EgressPolicy, error)
-	getBySourceGuidsMutex       sync.RWMutex
-	getBySourceGuidsArgsForCall []struct {
+	GetBySourceGuidsWithDefaultStub        func(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
+	getBySourceGuidsWithDefaultMutex       sync.RWMutex
+	getBySourceGuidsWithDefaultArgsForCall []struct {
+		ctx context.Context
 		ids []string
 	}
-	getBySourceGuidsReturns struct {
+	getBySourceGuidsWithDefaultReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	getBySourceGuidsReturnsOnCall map[int]struct {
+	getBySourceGuidsWithDefaultReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	GetByFilterStub        func(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error)
+	getByFilterMutex       sync.RWMutex
+	getByFilterArgsForCall []struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}
+	getByFilterReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	CreateStub        func(egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
+	getByFilterReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	CreateStub        func(ctx context.Context, egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
+		ctx            context.Context
 		egressPolicies []store.EgressPolicy
 	}
 	createReturns struct {
@@ -44,9 +63,10 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	DeleteStub        func(guids ...string) ([]store.EgressPolicy, error)
+	DeleteStub        func(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
+		ctx   context.Context
 		guids []string
 	}
 	deleteReturns struct {
@@ -61,14 +81,16 @@ type EgressPolicyStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyStore) All() ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) All(ctx context.Context) ([]store.EgressPolicy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("All", []interface{}{ctx})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(ctx)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -82,6 +104,12 @@ func (fake *EgressPolicyStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *EgressPolicyStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].ctx
+}
+
 func (fake *EgressPolicyStore) AllReturns(result1 []store.EgressPolicy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -104,63 +132,116 @@ func (fake *EgressPolicyStore) AllReturnsOnCall(i int, result1 []store.EgressPol
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuids(ids []string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]store.EgressPolicy, error) {
 	var idsCopy []string
 	if ids != nil {
 		idsCopy = make([]string, len(ids))
 		copy(idsCopy, ids)
 	}
-	fake.getBySourceGuidsMutex.Lock()
-	ret, specificReturn := fake.getBySourceGuidsReturnsOnCall[len(fake.getBySourceGuidsArgsForCall)]
-	fake.getBySourceGuidsArgsForCall = append(fake.getBySourceGuidsArgsForCall, struct {
+	fake.getBySourceGuidsWithDefaultMutex.Lock()
+	ret, specificReturn := fake.getBySourceGuidsWithDefaultReturnsOnCall[len(fake.getBySourceGuidsWithDefaultArgsForCall)]
+	fake.getBySourceGuidsWithDefaultArgsForCall = append(fake.getBySourceGuidsWithDefaultArgsForCall, struct {
+		ctx context.Context
 		ids []string
-	}{idsCopy})
-	fake.recordInvocation("GetBySourceGuids", []interface{}{idsCopy})
-	fake.getBySourceGuidsMutex.Unlock()
-	if fake.GetBySourceGuidsStub != nil {
-		return fake.GetBySourceGuidsStub(ids)
+	}{ctx, idsCopy})
+	fake.recordInvocation("GetBySourceGuidsWithDefault", []interface{}{ctx, idsCopy})
+	fake.getBySourceGuidsWithDefaultMutex.Unlock()
+	if fake.GetBySourceGuidsWithDefaultStub != nil {
+		return fake.GetBySourceGuidsWithDefaultStub(ctx, ids)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBySourceGuidsWithDefaultReturns.result1, fake.getBySourceGuidsWithDefaultReturns.result2
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultCallCount() int {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return len(fake.getBySourceGuidsWithDefaultArgsForCall)
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultArgsForCall(i int) (context.Context, []string) {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return fake.getBySourceGuidsWithDefaultArgsForCall[i].ctx, fake.getBySourceGuidsWithDefaultArgsForCall[i].ids
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	fake.getBySourceGuidsWithDefaultReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	if fake.getBySourceGuidsWithDefaultReturnsOnCall == nil {
+		fake.getBySourceGuidsWithDefaultReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getBySourceGuidsWithDefaultReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyStore) GetByFilter(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error) {
+	fake.getByFilterMutex.Lock()
+	ret, specificReturn := fake.getByFilterReturnsOnCall[len(fake.getByFilterArgsForCall)]
+	fake.getByFilterArgsForCall = append(fake.getByFilterArgsForCall, struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}{ctx, filter})
+	fake.recordInvocation("GetByFilter", []interface{}{ctx, filter})
+	fake.getByFilterMutex.Unlock()
+	if fake.GetByFilterStub != nil {
+		return fake.GetByFilterStub(ctx, filter)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getBySourceGuidsReturns.result1, fake.getBySourceGuidsReturns.result2
+	return fake.getByFilterReturns.result1, fake.getByFilterReturns.result2
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuidsCallCount() int {
-	fake.getBySourceGuidsMutex.RLock()
-	defer fake.getBySourceGuidsMutex.RUnlock()
-	return len(fake.getBySourceGuidsArgsForCall)
+func (fake *EgressPolicyStore) GetByFilterCallCount() int {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return len(fake.getByFilterArgsForCall)
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuidsArgsForCall(i int) []string {
-	fake.getBySourceGuidsMutex.RLock()
-	defer fake.getBySourceGuidsMutex.RUnlock()
-	return fake.getBySourceGuidsArgsForCall[i].ids
+func (fake *EgressPolicyStore) GetByFilterArgsForCall(i int) (context.Context, store.EgressPolicyFilter) {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return fake.getByFilterArgsForCall[i].ctx, fake.getByFilterArgsForCall[i].filter
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuidsReturns(result1 []store.EgressPolicy, result2 error) {
-	fake.GetBySourceGuidsStub = nil
-	fake.getBySourceGuidsReturns = struct {
+func (fake *EgressPolicyStore) GetByFilterReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	fake.getByFilterReturns = struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuidsReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
-	fake.GetBySourceGuidsStub = nil
-	if fake.getBySourceGuidsReturnsOnCall == nil {
-		fake.getBySourceGuidsReturnsOnCall = make(map[int]struct {
+func (fake *EgressPolicyStore) GetByFilterReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	if fake.getByFilterReturnsOnCall == nil {
+		fake.getByFilterReturnsOnCall = make(map[int]struct {
 			result1 []store.EgressPolicy
 			result2 error
 		})
 	}
-	fake.getBySourceGuidsReturnsOnCall[i] = struct {
+	fake.getByFilterReturnsOnCall[i] = struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) Create(egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) Create(ctx context.Context, egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error) {
 	var egressPoliciesCopy []store.EgressPolicy
 	if egressPolicies != nil {
 		egressPoliciesCopy = make([]store.EgressPolicy, len(egressPolicies))
@@ -169,12 +250,13 @@ func (fake *EgressPolicyStore) Create(egressPolicies []store.EgressPolicy) ([]st
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+		ctx            context.Context
 		egressPolicies []store.EgressPolicy
-	}{egressPoliciesCopy})
-	fake.recordInvocation("Create", []interface{}{egressPoliciesCopy})
+	}{ctx, egressPoliciesCopy})
+	fake.recordInvocation("Create", []interface{}{ctx, egressPoliciesCopy})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(egressPolicies)
+		return fake.CreateStub(ctx, egressPolicies)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -188,10 +270,10 @@ func (fake *EgressPolicyStore) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *EgressPolicyStore) CreateArgsForCall(i int) []store.EgressPolicy {
+func (fake *EgressPolicyStore) CreateArgsForCall(i int) (context.Context, []store.EgressPolicy) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].egressPolicies
+	return fake.createArgsForCall[i].ctx, fake.createArgsForCall[i].egressPolicies
 }
 
 func (fake *EgressPolicyStore) CreateReturns(result1 []store.EgressPolicy, result2 error) {
@@ -216,16 +298,17 @@ func (fake *EgressPolicyStore) CreateReturnsOnCall(i int, result1 []store.Egress
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) Delete(guids ...string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		ctx   context.Context
 		guids []string
-	}{guids})
-	fake.recordInvocation("Delete", []interface{}{guids})
+	}{ctx, guids})
+	fake.recordInvocation("Delete", []interface{}{ctx, guids})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(guids...)
+		return fake.DeleteStub(ctx, guids...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -239,10 +322,10 @@ func (fake *EgressPolicyStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *EgressPolicyStore) DeleteArgsForCall(i int) []string {
+func (fake *EgressPolicyStore) DeleteArgsForCall(i int) (context.Context, []string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].guids
+	return fake.deleteArgsForCall[i].ctx, fake.deleteArgsForCall[i].guids
 }
 
 func (fake *EgressPolicyStore) DeleteReturns(result1 []store.EgressPolicy, result2 error) {
@@ -272,8 +355,10 @@ func (fake *EgressPolicyStore) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.allMutex.RLock()
 	defer fake.allMutex.RUnlock()
-	fake.getBySourceGuidsMutex.RLock()
-	defer fake.getBySourceGuidsMutex.RUnlock()
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	fake.deleteMutex.RLock()
@@ -2,20 +2,25 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type PolicyCleaner struct {
-	DeleteStalePoliciesStub        func() ([]store.Policy, []store.EgressPolicy, error)
-	deleteStalePoliciesMutex       sync.RWMutex
-	deleteStalePoliciesArgsForCall []struct{}
-	deleteStalePoliciesReturns     struct {
+	CleanupStalePoliciesStub        func(ctx context.Context, dryRun, confirmed bool) ([]store.Policy, []store.EgressPolicy, error)
+	cleanupStalePoliciesMutex       sync.RWMutex
+	cleanupStalePoliciesArgsForCall []struct {
+		ctx       context.Context
+		dryRun    bool
+		confirmed bool
+	}
+	cleanupStalePoliciesReturns struct {
 		result1 []store.Policy
 		result2 []store.EgressPolicy
 		result3 error
 	}
-	deleteStalePoliciesReturnsOnCall map[int]struct {
+	cleanupStalePoliciesReturnsOnCall map[int]struct {
 		result1 []store.Policy
 		result2 []store.EgressPolicy
 		result3 error
@@ -24,46 +29,56 @@ type PolicyCleaner struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyCleaner) DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error) {
-	fake.deleteStalePoliciesMutex.Lock()
-	ret, specificReturn := fake.deleteStalePoliciesReturnsOnCall[len(fake.deleteStalePoliciesArgsForCall)]
-	fake.deleteStalePoliciesArgsForCall = append(fake.deleteStalePoliciesArgsForCall, struct{}{})
-	fake.recordInvocation("DeleteStalePolicies", []interface{}{})
-	fake.deleteStalePoliciesMutex.Unlock()
-	if fake.DeleteStalePoliciesStub != nil {
-		return fake.DeleteStalePoliciesStub()
+func (fake *PolicyCleaner) CleanupStalePolicies(ctx context.Context, dryRun bool, confirmed bool) ([]store.Policy, []store.EgressPolicy, error) {
+	fake.cleanupStalePoliciesMutex.Lock()
+	ret, specificReturn := fake.cleanupStalePoliciesReturnsOnCall[len(fake.cleanupStalePoliciesArgsForCall)]
+	fake.cleanupStalePoliciesArgsForCall = append(fake.cleanupStalePoliciesArgsForCall, struct {
+		ctx       context.Context
+		dryRun    bool
+		confirmed bool
+	}{ctx, dryRun, confirmed})
+	fake.recordInvocation("CleanupStalePolicies", []interface{}{ctx, dryRun, confirmed})
+	fake.cleanupStalePoliciesMutex.Unlock()
+	if fake.CleanupStalePoliciesStub != nil {
+		return fake.CleanupStalePoliciesStub(ctx, dryRun, confirmed)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
 	}
-	return fake.deleteStalePoliciesReturns.result1, fake.deleteStalePoliciesReturns.result2, fake.deleteStalePoliciesReturns.result3
+	return fake.cleanupStalePoliciesReturns.result1, fake.cleanupStalePoliciesReturns.result2, fake.cleanupStalePoliciesReturns.result3
+}
+
+func (fake *PolicyCleaner) CleanupStalePoliciesCallCount() int {
+	fake.cleanupStalePoliciesMutex.RLock()
+	defer fake.cleanupStalePoliciesMutex.RUnlock()
+	return len(fake.cleanupStalePoliciesArgsForCall)
 }
 
-func (fake *PolicyCleaner) DeleteStalePoliciesCallCount() int {
-	fake.deleteStalePoliciesMutex.RLock()
-	defer fake.deleteStalePoliciesMutex.RUnlock()
-	return len(fake.deleteStalePoliciesArgsForCall)
+func (fake *PolicyCleaner) CleanupStalePoliciesArgsForCall(i int) (context.Context, bool, bool) {
+	fake.cleanupStalePoliciesMutex.RLock()
+	defer fake.cleanupStalePoliciesMutex.RUnlock()
+	return fake.cleanupStalePoliciesArgsForCall[i].ctx, fake.cleanupStalePoliciesArgsForCall[i].dryRun, fake.cleanupStalePoliciesArgsForCall[i].confirmed
 }
 
-func (fake *PolicyCleaner) DeleteStalePoliciesReturns(result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
-	fake.DeleteStalePoliciesStub = nil
-	fake.deleteStalePoliciesReturns = struct {
+func (fake *PolicyCleaner) CleanupStalePoliciesReturns(result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.CleanupStalePoliciesStub = nil
+	fake.cleanupStalePoliciesReturns = struct {
 		result1 []store.Policy
 		result2 []store.EgressPolicy
 		result3 error
 	}{result1, result2, result3}
 }
 
-func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
-	fake.DeleteStalePoliciesStub = nil
-	if fake.deleteStalePoliciesReturnsOnCall == nil {
-		fake.deleteStalePoliciesReturnsOnCall = make(map[int]struct {
+func (fake *PolicyCleaner) CleanupStalePoliciesReturnsOnCall(i int, result1 []store.Policy, result2 []store.EgressPolicy, result3 error) {
+	fake.CleanupStalePoliciesStub = nil
+	if fake.cleanupStalePoliciesReturnsOnCall == nil {
+		fake.cleanupStalePoliciesReturnsOnCall = make(map[int]struct {
 			result1 []store.Policy
 			result2 []store.EgressPolicy
 			result3 error
 		})
 	}
-	fake.deleteStalePoliciesReturnsOnCall[i] = struct {
+	fake.cleanupStalePoliciesReturnsOnCall[i] = struct {
 		result1 []store.Policy
 		result2 []store.EgressPolicy
 		result3 error
@@ -73,8 +88,8 @@ func (fake *PolicyCleaner) DeleteStalePoliciesReturnsOnCall(i int, result1 []sto
 func (fake *PolicyCleaner) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.deleteStalePoliciesMutex.RLock()
-	defer fake.deleteStalePoliciesMutex.RUnlock()
+	fake.cleanupStalePoliciesMutex.RLock()
+	defer fake.cleanupStalePoliciesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
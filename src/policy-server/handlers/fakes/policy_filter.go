@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type PolicyFilter struct {
-	FilterPoliciesStub        func(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
+	FilterPoliciesStub        func(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
 	filterPoliciesMutex       sync.RWMutex
 	filterPoliciesArgsForCall []struct {
+		ctx       context.Context
 		policies  []store.Policy
 		userToken uaa_client.CheckTokenResponse
 	}
@@ -26,7 +28,7 @@ type PolicyFilter struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+func (fake *PolicyFilter) FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
 	var policiesCopy []store.Policy
 	if policies != nil {
 		policiesCopy = make([]store.Policy, len(policies))
@@ -35,13 +37,14 @@ func (fake *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_
 	fake.filterPoliciesMutex.Lock()
 	ret, specificReturn := fake.filterPoliciesReturnsOnCall[len(fake.filterPoliciesArgsForCall)]
 	fake.filterPoliciesArgsForCall = append(fake.filterPoliciesArgsForCall, struct {
+		ctx       context.Context
 		policies  []store.Policy
 		userToken uaa_client.CheckTokenResponse
-	}{policiesCopy, userToken})
-	fake.recordInvocation("FilterPolicies", []interface{}{policiesCopy, userToken})
+	}{ctx, policiesCopy, userToken})
+	fake.recordInvocation("FilterPolicies", []interface{}{ctx, policiesCopy, userToken})
 	fake.filterPoliciesMutex.Unlock()
 	if fake.FilterPoliciesStub != nil {
-		return fake.FilterPoliciesStub(policies, userToken)
+		return fake.FilterPoliciesStub(ctx, policies, userToken)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,10 +58,10 @@ func (fake *PolicyFilter) FilterPoliciesCallCount() int {
 	return len(fake.filterPoliciesArgsForCall)
 }
 
-func (fake *PolicyFilter) FilterPoliciesArgsForCall(i int) ([]store.Policy, uaa_client.CheckTokenResponse) {
+func (fake *PolicyFilter) FilterPoliciesArgsForCall(i int) (context.Context, []store.Policy, uaa_client.CheckTokenResponse) {
 	fake.filterPoliciesMutex.RLock()
 	defer fake.filterPoliciesMutex.RUnlock()
-	return fake.filterPoliciesArgsForCall[i].policies, fake.filterPoliciesArgsForCall[i].userToken
+	return fake.filterPoliciesArgsForCall[i].ctx, fake.filterPoliciesArgsForCall[i].policies, fake.filterPoliciesArgsForCall[i].userToken
 }
 
 func (fake *PolicyFilter) FilterPoliciesReturns(result1 []store.Policy, result2 error) {
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type PolicyGuard struct {
-	CheckAccessStub        func(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccessStub        func(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	checkAccessMutex       sync.RWMutex
 	checkAccessArgsForCall []struct {
+		ctx       context.Context
 		policies  []store.Policy
 		tokenData uaa_client.CheckTokenResponse
 	}
@@ -37,7 +39,7 @@ type PolicyGuard struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyGuard) CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error) {
+func (fake *PolicyGuard) CheckAccess(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error) {
 	var policiesCopy []store.Policy
 	if policies != nil {
 		policiesCopy = make([]store.Policy, len(policies))
@@ -46,13 +48,14 @@ func (fake *PolicyGuard) CheckAccess(policies []store.Policy, tokenData uaa_clie
 	fake.checkAccessMutex.Lock()
 	ret, specificReturn := fake.checkAccessReturnsOnCall[len(fake.checkAccessArgsForCall)]
 	fake.checkAccessArgsForCall = append(fake.checkAccessArgsForCall, struct {
+		ctx       context.Context
 		policies  []store.Policy
 		tokenData uaa_client.CheckTokenResponse
-	}{policiesCopy, tokenData})
-	fake.recordInvocation("CheckAccess", []interface{}{policiesCopy, tokenData})
+	}{ctx, policiesCopy, tokenData})
+	fake.recordInvocation("CheckAccess", []interface{}{ctx, policiesCopy, tokenData})
 	fake.checkAccessMutex.Unlock()
 	if fake.CheckAccessStub != nil {
-		return fake.CheckAccessStub(policies, tokenData)
+		return fake.CheckAccessStub(ctx, policies, tokenData)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -66,10 +69,10 @@ func (fake *PolicyGuard) CheckAccessCallCount() int {
 	return len(fake.checkAccessArgsForCall)
 }
 
-func (fake *PolicyGuard) CheckAccessArgsForCall(i int) ([]store.Policy, uaa_client.CheckTokenResponse) {
+func (fake *PolicyGuard) CheckAccessArgsForCall(i int) (context.Context, []store.Policy, uaa_client.CheckTokenResponse) {
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	return fake.checkAccessArgsForCall[i].policies, fake.checkAccessArgsForCall[i].tokenData
+	return fake.checkAccessArgsForCall[i].ctx, fake.checkAccessArgsForCall[i].policies, fake.checkAccessArgsForCall[i].tokenData
 }
 
 func (fake *PolicyGuard) CheckAccessReturns(result1 bool, result2 error) {
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type PolicyStore struct {
-	CreateStub        func([]store.Policy) error
+	CreateStub        func(context.Context, []store.Policy) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 []store.Policy
+		arg1 context.Context
+		arg2 []store.Policy
 	}
 	createReturns struct {
 		result1 error
@@ -18,10 +20,11 @@ type PolicyStore struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteStub        func([]store.Policy) error
+	DeleteStub        func(context.Context, []store.Policy) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 []store.Policy
+		arg1 context.Context
+		arg2 []store.Policy
 	}
 	deleteReturns struct {
 		result1 error
@@ -29,9 +32,10 @@ type PolicyStore struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ByGuidsStub        func(srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error)
+	ByGuidsStub        func(ctx context.Context, srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error)
 	byGuidsMutex       sync.RWMutex
 	byGuidsArgsForCall []struct {
+		ctx       context.Context
 		srcGuids  []string
 		dstGuids  []string
 		srcAndDst bool
@@ -48,21 +52,22 @@ type PolicyStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyStore) Create(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *PolicyStore) Create(arg1 context.Context, arg2 []store.Policy) error {
+	var arg2Copy []store.Policy
+	if arg2 != nil {
+		arg2Copy = make([]store.Policy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Create", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.Policy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2Copy})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1)
+		return fake.CreateStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -76,10 +81,10 @@ func (fake *PolicyStore) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *PolicyStore) CreateArgsForCall(i int) []store.Policy {
+func (fake *PolicyStore) CreateArgsForCall(i int) (context.Context, []store.Policy) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1
+	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2
 }
 
 func (fake *PolicyStore) CreateReturns(result1 error) {
@@ -101,21 +106,22 @@ func (fake *PolicyStore) CreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *PolicyStore) Delete(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *PolicyStore) Delete(arg1 context.Context, arg2 []store.Policy) error {
+	var arg2Copy []store.Policy
+	if arg2 != nil {
+		arg2Copy = make([]store.Policy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Delete", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.Policy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2Copy})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1)
+		return fake.DeleteStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -129,10 +135,10 @@ func (fake *PolicyStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *PolicyStore) DeleteArgsForCall(i int) []store.Policy {
+func (fake *PolicyStore) DeleteArgsForCall(i int) (context.Context, []store.Policy) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
 }
 
 func (fake *PolicyStore) DeleteReturns(result1 error) {
@@ -154,7 +160,7 @@ func (fake *PolicyStore) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *PolicyStore) ByGuids(srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error) {
+func (fake *PolicyStore) ByGuids(ctx context.Context, srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error) {
 	var srcGuidsCopy []string
 	if srcGuids != nil {
 		srcGuidsCopy = make([]string, len(srcGuids))
@@ -168,14 +174,15 @@ func (fake *PolicyStore) ByGuids(srcGuids []string, dstGuids []string, srcAndDst
 	fake.byGuidsMutex.Lock()
 	ret, specificReturn := fake.byGuidsReturnsOnCall[len(fake.byGuidsArgsForCall)]
 	fake.byGuidsArgsForCall = append(fake.byGuidsArgsForCall, struct {
+		ctx       context.Context
 		srcGuids  []string
 		dstGuids  []string
 		srcAndDst bool
-	}{srcGuidsCopy, dstGuidsCopy, srcAndDst})
-	fake.recordInvocation("ByGuids", []interface{}{srcGuidsCopy, dstGuidsCopy, srcAndDst})
+	}{ctx, srcGuidsCopy, dstGuidsCopy, srcAndDst})
+	fake.recordInvocation("ByGuids", []interface{}{ctx, srcGuidsCopy, dstGuidsCopy, srcAndDst})
 	fake.byGuidsMutex.Unlock()
 	if fake.ByGuidsStub != nil {
-		return fake.ByGuidsStub(srcGuids, dstGuids, srcAndDst)
+		return fake.ByGuidsStub(ctx, srcGuids, dstGuids, srcAndDst)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -189,10 +196,10 @@ func (fake *PolicyStore) ByGuidsCallCount() int {
 	return len(fake.byGuidsArgsForCall)
 }
 
-func (fake *PolicyStore) ByGuidsArgsForCall(i int) ([]string, []string, bool) {
+func (fake *PolicyStore) ByGuidsArgsForCall(i int) (context.Context, []string, []string, bool) {
 	fake.byGuidsMutex.RLock()
 	defer fake.byGuidsMutex.RUnlock()
-	return fake.byGuidsArgsForCall[i].srcGuids, fake.byGuidsArgsForCall[i].dstGuids, fake.byGuidsArgsForCall[i].srcAndDst
+	return fake.byGuidsArgsForCall[i].ctx, fake.byGuidsArgsForCall[i].srcGuids, fake.byGuidsArgsForCall[i].dstGuids, fake.byGuidsArgsForCall[i].srcAndDst
 }
 
 func (fake *PolicyStore) ByGuidsReturns(result1 []store.Policy, result2 error) {
@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type QuotaGuard struct {
-	CheckAccessStub        func(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccessStub        func(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	checkAccessMutex       sync.RWMutex
 	checkAccessArgsForCall []struct {
+		ctx       context.Context
 		policies  []store.Policy
 		tokenData uaa_client.CheckTokenResponse
 	}
@@ -26,7 +28,7 @@ type QuotaGuard struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *QuotaGuard) CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error) {
+func (fake *QuotaGuard) CheckAccess(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error) {
 	var policiesCopy []store.Policy
 	if policies != nil {
 		policiesCopy = make([]store.Policy, len(policies))
@@ -35,13 +37,14 @@ func (fake *QuotaGuard) CheckAccess(policies []store.Policy, tokenData uaa_clien
 	fake.checkAccessMutex.Lock()
 	ret, specificReturn := fake.checkAccessReturnsOnCall[len(fake.checkAccessArgsForCall)]
 	fake.checkAccessArgsForCall = append(fake.checkAccessArgsForCall, struct {
+		ctx       context.Context
 		policies  []store.Policy
 		tokenData uaa_client.CheckTokenResponse
-	}{policiesCopy, tokenData})
-	fake.recordInvocation("CheckAccess", []interface{}{policiesCopy, tokenData})
+	}{ctx, policiesCopy, tokenData})
+	fake.recordInvocation("CheckAccess", []interface{}{ctx, policiesCopy, tokenData})
 	fake.checkAccessMutex.Unlock()
 	if fake.CheckAccessStub != nil {
-		return fake.CheckAccessStub(policies, tokenData)
+		return fake.CheckAccessStub(ctx, policies, tokenData)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -55,10 +58,10 @@ func (fake *QuotaGuard) CheckAccessCallCount() int {
 	return len(fake.checkAccessArgsForCall)
 }
 
-func (fake *QuotaGuard) CheckAccessArgsForCall(i int) ([]store.Policy, uaa_client.CheckTokenResponse) {
+func (fake *QuotaGuard) CheckAccessArgsForCall(i int) (context.Context, []store.Policy, uaa_client.CheckTokenResponse) {
 	fake.checkAccessMutex.RLock()
 	defer fake.checkAccessMutex.RUnlock()
-	return fake.checkAccessArgsForCall[i].policies, fake.checkAccessArgsForCall[i].tokenData
+	return fake.checkAccessArgsForCall[i].ctx, fake.checkAccessArgsForCall[i].policies, fake.checkAccessArgsForCall[i].tokenData
 }
 
 func (fake *QuotaGuard) CheckAccessReturns(result1 bool, result2 error) {
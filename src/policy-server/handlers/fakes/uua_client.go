@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/uaa_client"
 	"sync"
 )
 
 type UAAClient struct {
-	GetTokenStub        func() (string, error)
+	GetTokenStub        func(context.Context) (string, error)
 	getTokenMutex       sync.RWMutex
-	getTokenArgsForCall []struct{}
-	getTokenReturns     struct {
+	getTokenArgsForCall []struct {
+		arg1 context.Context
+	}
+	getTokenReturns struct {
 		result1 string
 		result2 error
 	}
@@ -18,10 +21,11 @@ type UAAClient struct {
 		result1 string
 		result2 error
 	}
-	CheckTokenStub        func(string) (uaa_client.CheckTokenResponse, error)
+	CheckTokenStub        func(context.Context, string) (uaa_client.CheckTokenResponse, error)
 	checkTokenMutex       sync.RWMutex
 	checkTokenArgsForCall []struct {
-		arg1 string
+		arg1 context.Context
+		arg2 string
 	}
 	checkTokenReturns struct {
 		result1 uaa_client.CheckTokenResponse
@@ -35,14 +39,16 @@ type UAAClient struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *UAAClient) GetToken() (string, error) {
+func (fake *UAAClient) GetToken(arg1 context.Context) (string, error) {
 	fake.getTokenMutex.Lock()
 	ret, specificReturn := fake.getTokenReturnsOnCall[len(fake.getTokenArgsForCall)]
-	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct{}{})
-	fake.recordInvocation("GetToken", []interface{}{})
+	fake.getTokenArgsForCall = append(fake.getTokenArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("GetToken", []interface{}{arg1})
 	fake.getTokenMutex.Unlock()
 	if fake.GetTokenStub != nil {
-		return fake.GetTokenStub()
+		return fake.GetTokenStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -56,6 +62,12 @@ func (fake *UAAClient) GetTokenCallCount() int {
 	return len(fake.getTokenArgsForCall)
 }
 
+func (fake *UAAClient) GetTokenArgsForCall(i int) context.Context {
+	fake.getTokenMutex.RLock()
+	defer fake.getTokenMutex.RUnlock()
+	return fake.getTokenArgsForCall[i].arg1
+}
+
 func (fake *UAAClient) GetTokenReturns(result1 string, result2 error) {
 	fake.GetTokenStub = nil
 	fake.getTokenReturns = struct {
@@ -78,16 +90,17 @@ func (fake *UAAClient) GetTokenReturnsOnCall(i int, result1 string, result2 erro
 	}{result1, result2}
 }
 
-func (fake *UAAClient) CheckToken(arg1 string) (uaa_client.CheckTokenResponse, error) {
+func (fake *UAAClient) CheckToken(arg1 context.Context, arg2 string) (uaa_client.CheckTokenResponse, error) {
 	fake.checkTokenMutex.Lock()
 	ret, specificReturn := fake.checkTokenReturnsOnCall[len(fake.checkTokenArgsForCall)]
 	fake.checkTokenArgsForCall = append(fake.checkTokenArgsForCall, struct {
-		arg1 string
-	}{arg1})
-	fake.recordInvocation("CheckToken", []interface{}{arg1})
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("CheckToken", []interface{}{arg1, arg2})
 	fake.checkTokenMutex.Unlock()
 	if fake.CheckTokenStub != nil {
-		return fake.CheckTokenStub(arg1)
+		return fake.CheckTokenStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -101,10 +114,10 @@ func (fake *UAAClient) CheckTokenCallCount() int {
 	return len(fake.checkTokenArgsForCall)
 }
 
-func (fake *UAAClient) CheckTokenArgsForCall(i int) string {
+func (fake *UAAClient) CheckTokenArgsForCall(i int) (context.Context, string) {
 	fake.checkTokenMutex.RLock()
 	defer fake.checkTokenMutex.RUnlock()
-	return fake.checkTokenArgsForCall[i].arg1
+	return fake.checkTokenArgsForCall[i].arg1, fake.checkTokenArgsForCall[i].arg2
 }
 
 func (fake *UAAClient) CheckTokenReturns(result1 uaa_client.CheckTokenResponse, result2 error) {
@@ -5,14 +5,23 @@ import (
 	"policy-server/store"
 )
 
+//go:generate counterfeiter -o fakes/drain.go --fake-name Drain . drain
+type drain interface {
+	Draining() bool
+}
+
+// Health fails while the server is draining, so that load balancers stop
+// sending it requests before it stops accepting them.
 type Health struct {
 	Store         store.Store
+	Drain         drain
 	ErrorResponse errorResponse
 }
 
-func NewHealth(store store.Store, errorResponse errorResponse) *Health {
+func NewHealth(store store.Store, drain drain, errorResponse errorResponse) *Health {
 	return &Health{
 		Store:         store,
+		Drain:         drain,
 		ErrorResponse: errorResponse,
 	}
 }
@@ -20,7 +29,14 @@ func NewHealth(store store.Store, errorResponse errorResponse) *Health {
 func (h *Health) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("health")
-	err := h.Store.CheckDatabase()
+	if h.Drain != nil && h.Drain.Draining() {
+		logger.Info("draining")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"error": "draining"}`))
+		return
+	}
+
+	err := h.Store.CheckDatabase(req.Context())
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check database failed")
 		return
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"policy-server/health"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+type checkResult struct {
+	Name      string      `json:"name"`
+	Healthy   bool        `json:"healthy"`
+	LatencyMs float64     `json:"latency_ms"`
+	Error     string      `json:"error,omitempty"`
+	Details   interface{} `json:"details,omitempty"`
+}
+
+// HealthDetailed runs every check in parallel and reports the status and
+// latency of each, for operators diagnosing an unhealthy policy server. It
+// responds 503 when any check fails. Unlike Health it is too expensive to
+// be polled by load balancers.
+type HealthDetailed struct {
+	Checks        []health.Check
+	Timeout       time.Duration
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func NewHealthDetailed(checks []health.Check, timeout time.Duration, marshaler marshal.Marshaler, errorResponse errorResponse) *HealthDetailed {
+	return &HealthDetailed{
+		Checks:        checks,
+		Timeout:       timeout,
+		Marshaler:     marshaler,
+		ErrorResponse: errorResponse,
+	}
+}
+
+func (h *HealthDetailed) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("health-detailed")
+
+	ctx, cancel := context.WithTimeout(req.Context(), h.Timeout)
+	defer cancel()
+
+	results := make([]checkResult, len(h.Checks))
+	done := make(chan struct{})
+	for i, check := range h.Checks {
+		go func(i int, check health.Check) {
+			results[i] = h.run(ctx, check)
+			done <- struct{}{}
+		}(i, check)
+	}
+	for range h.Checks {
+		<-done
+	}
+
+	healthy := true
+	for _, result := range results {
+		if !result.Healthy {
+			healthy = false
+			logger.Info("check-failed", lager.Data{"check": result.Name, "error": result.Error})
+		}
+	}
+
+	response := struct {
+		Healthy bool          `json:"healthy"`
+		Checks  []checkResult `json:"checks"`
+	}{healthy, results}
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshalling failed")
+		return
+	}
+
+	if healthy {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	w.Write(responseBytes)
+}
+
+// run gives up on a check when ctx expires, since not every dependency
+// honours cancellation. The check keeps running in the background.
+func (h *HealthDetailed) run(ctx context.Context, check health.Check) checkResult {
+	type outcome struct {
+		details interface{}
+		err     error
+	}
+
+	start := time.Now()
+	outcomes := make(chan outcome, 1)
+	go func() {
+		details, err := check.Run(ctx)
+		outcomes <- outcome{details, err}
+	}()
+
+	var o outcome
+	select {
+	case o = <-outcomes:
+	case <-ctx.Done():
+		o.err = fmt.Errorf("timed out after %s", h.Timeout)
+	}
+
+	result := checkResult{
+		Name:      check.Name,
+		Healthy:   o.err == nil,
+		LatencyMs: float64(time.Since(start)) / float64(time.Millisecond),
+		Details:   o.details,
+	}
+	if o.err != nil {
+		result.Error = o.err.Error()
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/health"
+	"time"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Health detailed handler", func() {
+	var (
+		handler           *handlers.HealthDetailed
+		request           *http.Request
+		fakeErrorResponse *fakes.ErrorResponse
+		marshaler         *hfakes.Marshaler
+		resp              *httptest.ResponseRecorder
+		logger            *lagertest.TestLogger
+		checks            []health.Check
+	)
+
+	response := func() map[string]interface{} {
+		var body map[string]interface{}
+		Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+		return body
+	}
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/health/detailed", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		checks = []health.Check{{
+			Name: "database",
+			Run: func(context.Context) (interface{}, error) {
+				return map[string]int{"open_connections": 2}, nil
+			},
+		}, {
+			Name: "uaa",
+			Run: func(context.Context) (interface{}, error) {
+				return nil, nil
+			},
+		}}
+
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+		resp = httptest.NewRecorder()
+		logger = lagertest.NewTestLogger("test-logger")
+	})
+
+	JustBeforeEach(func() {
+		handler = handlers.NewHealthDetailed(checks, 100*time.Millisecond, marshaler, fakeErrorResponse)
+	})
+
+	It("reports every check with its latency and returns a 200", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		body := response()
+		Expect(body["healthy"]).To(BeTrue())
+
+		results := body["checks"].([]interface{})
+		Expect(results).To(HaveLen(2))
+
+		database := results[0].(map[string]interface{})
+		Expect(database["name"]).To(Equal("database"))
+		Expect(database["healthy"]).To(BeTrue())
+		Expect(database["latency_ms"]).To(BeNumerically(">=", 0))
+		Expect(database["details"]).To(Equal(map[string]interface{}{"open_connections": 2.0}))
+		Expect(database).NotTo(HaveKey("error"))
+
+		uaa := results[1].(map[string]interface{})
+		Expect(uaa["name"]).To(Equal("uaa"))
+		Expect(uaa).NotTo(HaveKey("details"))
+	})
+
+	Context("when a check fails", func() {
+		BeforeEach(func() {
+			checks[1].Run = func(context.Context) (interface{}, error) {
+				return nil, errors.New("banana")
+			}
+		})
+
+		It("reports the error, logs it and returns a 503", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			body := response()
+			Expect(body["healthy"]).To(BeFalse())
+
+			results := body["checks"].([]interface{})
+			Expect(results[0].(map[string]interface{})["healthy"]).To(BeTrue())
+			Expect(results[1].(map[string]interface{})["healthy"]).To(BeFalse())
+			Expect(results[1].(map[string]interface{})["error"]).To(Equal("banana"))
+
+			Expect(logger).To(gbytes.Say("health-detailed.check-failed.*check.*uaa.*banana"))
+		})
+	})
+
+	Context("when a check does not finish within the timeout", func() {
+		var unblock chan struct{}
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+			checks[1].Run = func(context.Context) (interface{}, error) {
+				<-unblock
+				return nil, nil
+			}
+		})
+
+		AfterEach(func() {
+			close(unblock)
+		})
+
+		It("reports it as timed out", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			results := response()["checks"].([]interface{})
+			Expect(results[1].(map[string]interface{})["error"]).To(Equal("timed out after 100ms"))
+		})
+	})
+
+	Context("when marshalling fails", func() {
+		BeforeEach(func() {
+			marshaler.MarshalReturns(nil, errors.New("potato"))
+			marshaler.MarshalStub = nil
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("marshalling failed"))
+		})
+	})
+})
@@ -20,6 +20,7 @@ var _ = Describe("Health handler", func() {
 		handler           *handlers.Health
 		request           *http.Request
 		fakeStore         *storeFakes.Store
+		fakeDrain         *fakes.Drain
 		fakeErrorResponse *fakes.ErrorResponse
 		resp              *httptest.ResponseRecorder
 		logger            *lagertest.TestLogger
@@ -32,10 +33,12 @@ var _ = Describe("Health handler", func() {
 		Expect(err).NotTo(HaveOccurred())
 
 		fakeStore = &storeFakes.Store{}
+		fakeDrain = &fakes.Drain{}
 		fakeErrorResponse = &fakes.ErrorResponse{}
 
 		handler = &handlers.Health{
 			Store:         fakeStore,
+			Drain:         fakeDrain,
 			ErrorResponse: fakeErrorResponse,
 		}
 		resp = httptest.NewRecorder()
@@ -82,4 +85,31 @@ var _ = Describe("Health handler", func() {
 			Expect(description).To(Equal("check database failed"))
 		})
 	})
+
+	Context("when the server is draining", func() {
+		BeforeEach(func() {
+			fakeDrain.DrainingReturns(true)
+		})
+
+		It("returns a 503 without checking the database", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.CheckDatabaseCallCount()).To(Equal(0))
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(MatchJSON(`{"error": "draining"}`))
+		})
+	})
+
+	Context("when no drain is configured", func() {
+		BeforeEach(func() {
+			handler.Drain = nil
+		})
+
+		It("checks the database", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.CheckDatabaseCallCount()).To(Equal(1))
+			Expect(resp.Code).To(Equal(http.StatusOK))
+		})
+	})
 })
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"policy-server/api"
+	"policy-server/cleaner"
 	"policy-server/store"
 
 	"code.cloudfoundry.org/lager"
@@ -10,7 +12,7 @@ import (
 
 //go:generate counterfeiter -o fakes/policy_cleaner.go --fake-name PolicyCleaner . policyCleaner
 type policyCleaner interface {
-	DeleteStalePolicies() ([]store.Policy, []store.EgressPolicy, error)
+	CleanupStalePolicies(ctx context.Context, dryRun, confirmed bool) ([]store.Policy, []store.EgressPolicy, error)
 }
 
 //go:generate counterfeiter -o fakes/error_response.go --fake-name ErrorResponse . errorResponse
@@ -40,9 +42,17 @@ func (h *PoliciesCleanup) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	logger := getLogger(req)
 	logger = logger.Session("cleanup-policies")
 
-	c2cPolicies, egressPolicies, err := h.PolicyCleaner.DeleteStalePolicies()
+	dryRun := req.URL.Query().Get("dry_run") == "true"
+	confirmed := req.URL.Query().Get("confirm") == "true"
+
+	c2cPolicies, egressPolicies, err := h.PolicyCleaner.CleanupStalePolicies(req.Context(), dryRun, confirmed)
 	if err != nil {
-		h.ErrorResponse.InternalServerError(logger, w, err, "policies cleanup failed")
+		switch err.(type) {
+		case cleaner.ThresholdExceededError:
+			h.ErrorResponse.BadRequest(logger, w, err, "policies cleanup exceeds the delete threshold, retry with confirm=true")
+		default:
+			h.ErrorResponse.InternalServerError(logger, w, err, "policies cleanup failed")
+		}
 		return
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"policy-server/cleaner"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
 	"policy-server/store"
@@ -80,7 +81,7 @@ var _ = Describe("PoliciesCleanup", func() {
 			ErrorResponse:          fakeErrorResponse,
 		}
 
-		fakePolicyCleaner.DeleteStalePoliciesReturns(policies, egressPolicies, nil)
+		fakePolicyCleaner.CleanupStalePoliciesReturns(policies, egressPolicies, nil)
 		fakePolicyCollectionWriter.AsBytesReturns([]byte("some-bytes"), nil)
 		resp = httptest.NewRecorder()
 		request, _ = http.NewRequest("POST", "/networking/v0/external/policies/cleanup", nil)
@@ -89,7 +90,10 @@ var _ = Describe("PoliciesCleanup", func() {
 	It("Cleans up stale policies for deleted apps", func() {
 		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
-		Expect(fakePolicyCleaner.DeleteStalePoliciesCallCount()).To(Equal(1))
+		Expect(fakePolicyCleaner.CleanupStalePoliciesCallCount()).To(Equal(1))
+		_, dryRun, confirmed := fakePolicyCleaner.CleanupStalePoliciesArgsForCall(0)
+		Expect(dryRun).To(BeFalse())
+		Expect(confirmed).To(BeFalse())
 		Expect(fakePolicyCollectionWriter.AsBytesCallCount()).To(Equal(1))
 
 		policiesArg, egressPoliciesArg := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
@@ -109,9 +113,58 @@ var _ = Describe("PoliciesCleanup", func() {
 		})
 	})
 
+	Context("when dry_run=true is passed", func() {
+		BeforeEach(func() {
+			request, _ = http.NewRequest("POST", "/networking/v0/external/policies/cleanup?dry_run=true", nil)
+		})
+
+		It("asks the cleaner for a dry run", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			_, dryRun, confirmed := fakePolicyCleaner.CleanupStalePoliciesArgsForCall(0)
+			Expect(dryRun).To(BeTrue())
+			Expect(confirmed).To(BeFalse())
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.String()).To(Equal(`some-bytes`))
+		})
+	})
+
+	Context("when confirm=true is passed", func() {
+		BeforeEach(func() {
+			request, _ = http.NewRequest("POST", "/networking/v0/external/policies/cleanup?confirm=true", nil)
+		})
+
+		It("confirms the cleanup", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			_, dryRun, confirmed := fakePolicyCleaner.CleanupStalePoliciesArgsForCall(0)
+			Expect(dryRun).To(BeFalse())
+			Expect(confirmed).To(BeTrue())
+		})
+	})
+
+	Context("when the cleanup exceeds the delete threshold", func() {
+		BeforeEach(func() {
+			fakePolicyCleaner.CleanupStalePoliciesReturns(nil, nil, cleaner.ThresholdExceededError{Stale: 4, Total: 7, Limit: 50})
+		})
+
+		It("calls the bad request handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+
+			l, w, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("refusing to delete 4 of 7 policies, more than the cleanup limit of 50%"))
+			Expect(description).To(Equal("policies cleanup exceeds the delete threshold, retry with confirm=true"))
+		})
+	})
+
 	Context("When deleting the policies fails", func() {
 		BeforeEach(func() {
-			fakePolicyCleaner.DeleteStalePoliciesReturns(policies, egressPolicies, errors.New("potato"))
+			fakePolicyCleaner.CleanupStalePoliciesReturns(policies, egressPolicies, errors.New("potato"))
 		})
 
 		It("calls the internal server error handler", func() {
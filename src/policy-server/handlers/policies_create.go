@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -14,20 +15,20 @@ import (
 
 //go:generate counterfeiter -o fakes/policy_guard.go --fake-name PolicyGuard . policyGuard
 type policyGuard interface {
-	CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccess(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
 	IsNetworkAdmin(userToken uaa_client.CheckTokenResponse) bool
 }
 
 //go:generate counterfeiter -o fakes/quota_guard.go --fake-name QuotaGuard . quotaGuard
 type quotaGuard interface {
-	CheckAccess(policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
+	CheckAccess(ctx context.Context, policies []store.Policy, tokenData uaa_client.CheckTokenResponse) (bool, error)
 }
 
 //go:generate counterfeiter -o fakes/policy_store.go --fake-name PolicyStore . policyStore
 type policyStore interface {
-	Create([]store.Policy) error
-	Delete([]store.Policy) error
-	ByGuids(srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error)
+	Create(context.Context, []store.Policy) error
+	Delete(context.Context, []store.Policy) error
+	ByGuids(ctx context.Context, srcGuids []string, dstGuids []string, srcAndDst bool) ([]store.Policy, error)
 }
 
 type PoliciesCreate struct {
@@ -66,7 +67,7 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	authorized, err := h.PolicyGuard.CheckAccess(policies, tokenData)
+	authorized, err := h.PolicyGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check access failed")
 		return
@@ -77,7 +78,7 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	authorized, err = h.QuotaGuard.CheckAccess(policies, tokenData)
+	authorized, err = h.QuotaGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check quota failed")
 		return
@@ -88,7 +89,7 @@ func (h *PoliciesCreate) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err = h.Store.Create(policies)
+	err = h.Store.Create(req.Context(), policies)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database create failed")
 		return
@@ -105,11 +105,12 @@ var _ = Describe("PoliciesCreate", func() {
 			Expect(fakeMapper.AsStorePolicyArgsForCall(0)).To(Equal([]byte(requestBody)))
 
 			Expect(fakePolicyGuard.CheckAccessCallCount()).To(Equal(1))
-			policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
+			_, policies, token := fakePolicyGuard.CheckAccessArgsForCall(0)
 			Expect(policies).To(Equal(expectedPolicies))
 			Expect(token).To(Equal(tokenData))
 			Expect(fakeStore.CreateCallCount()).To(Equal(1))
-			Expect(fakeStore.CreateArgsForCall(0)).To(Equal(expectedPolicies))
+			_, createdPolicies := fakeStore.CreateArgsForCall(0)
+			Expect(createdPolicies).To(Equal(expectedPolicies))
 			Expect(resp.Code).To(Equal(http.StatusOK))
 			Expect(resp.Body.String()).To(MatchJSON("{}"))
 		}
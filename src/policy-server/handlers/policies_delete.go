@@ -44,7 +44,7 @@ func (h *PoliciesDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	authorized, err := h.PolicyGuard.CheckAccess(policies, tokenData)
+	authorized, err := h.PolicyGuard.CheckAccess(req.Context(), policies, tokenData)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "check access failed")
 		return
@@ -55,7 +55,7 @@ func (h *PoliciesDelete) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	err = h.Store.Delete(policies)
+	err = h.Store.Delete(req.Context(), policies)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "database delete failed")
 		return
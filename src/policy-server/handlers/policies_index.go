@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"policy-server/api"
@@ -14,7 +15,7 @@ import (
 
 //go:generate counterfeiter -o fakes/policy_filter.go --fake-name PolicyFilter . policyFilter
 type policyFilter interface {
-	FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
+	FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error)
 }
 
 //go:generate counterfeiter -o fakes/database.go --fake-name Db . database
@@ -53,15 +54,15 @@ func (h *PoliciesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	var storePolicies []store.Policy
 	var err error
 	if len(ids) > 0 {
-		storePolicies, err = h.Store.ByGuids(ids, ids, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), ids, ids, false)
 	} else if len(sourceIDs) > 0 && len(destIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids(sourceIDs, destIDs, true)
+		storePolicies, err = h.Store.ByGuids(req.Context(), sourceIDs, destIDs, true)
 	} else if len(sourceIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids(sourceIDs, []string{}, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), sourceIDs, []string{}, false)
 	} else if len(destIDs) > 0 {
-		storePolicies, err = h.Store.ByGuids([]string{}, destIDs, false)
+		storePolicies, err = h.Store.ByGuids(req.Context(), []string{}, destIDs, false)
 	} else {
-		storePolicies, err = h.Store.All()
+		storePolicies, err = h.Store.All(req.Context())
 	}
 
 	if err != nil {
@@ -69,7 +70,7 @@ func (h *PoliciesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	policies, err := h.PolicyFilter.FilterPolicies(storePolicies, userToken)
+	policies, err := h.PolicyFilter.FilterPolicies(req.Context(), storePolicies, userToken)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "filter policies failed")
 		return
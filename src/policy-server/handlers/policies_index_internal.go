@@ -1,29 +1,40 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"net/url"
 	"policy-server/api"
 	"policy-server/store"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
 )
 
 //go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
 type egressPolicyStore interface {
-	All() ([]store.EgressPolicy, error)
-	GetBySourceGuids(ids []string) ([]store.EgressPolicy, error)
-	Create(egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
-	Delete(guids ...string) ([]store.EgressPolicy, error)
+	All(ctx context.Context) ([]store.EgressPolicy, error)
+	GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
+	GetByFilter(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error)
+	Create(ctx context.Context, egressPolicies []store.EgressPolicy) ([]store.EgressPolicy, error)
+	Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
 }
 
+//go:generate counterfeiter -o fakes/policy_cache.go --fake-name PolicyCache . policyCache
+type policyCache interface {
+	Policies(ids []string) ([]store.Policy, []store.EgressPolicy, error)
+}
+
+// PoliciesIndexInternal serves the policies polled by the agents. When a
+// PolicyCache is set it serves them from memory instead of the stores.
 type PoliciesIndexInternal struct {
 	Logger                                   lager.Logger
 	Store                                    store.Store
 	PolicyCollectionWriter                   api.PolicyCollectionWriter
 	ErrorResponse                            errorResponse
 	EgressStore                              egressPolicyStore
+	PolicyCache                              policyCache
 	EnforceExperimentalDynamicEgressPolicies bool
 }
 
@@ -47,31 +58,46 @@ func (h *PoliciesIndexInternal) ServeHTTP(w http.ResponseWriter, req *http.Reque
 	ids := parseIds(queryValues)
 
 	var policies []store.Policy
+	var egressPolicies []store.EgressPolicy
 	var err error
-	if len(ids) == 0 {
-		policies, err = h.Store.All()
+	if h.PolicyCache != nil {
+		policies, egressPolicies, err = h.PolicyCache.Policies(ids)
+		if err != nil {
+			h.ErrorResponse.InternalServerError(logger, w, err, "policy snapshot read failed")
+			return
+		}
+		if !h.EnforceExperimentalDynamicEgressPolicies {
+			egressPolicies = nil
+		}
 	} else {
-		policies, err = h.Store.ByGuids(ids, ids, false)
-	}
-
-	if err != nil {
-		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
-		return
-	}
-
-	var egressPolicies []store.EgressPolicy
-	if h.EnforceExperimentalDynamicEgressPolicies {
 		if len(ids) == 0 {
-			egressPolicies, err = h.EgressStore.All()
+			policies, err = h.Store.All(req.Context())
 		} else {
-			egressPolicies, err = h.EgressStore.GetBySourceGuids(ids)
+			policies, err = h.Store.ByGuids(req.Context(), ids, ids, false)
 		}
+
 		if err != nil {
-			h.ErrorResponse.InternalServerError(logger, w, err, "egress database read failed")
+			h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
 			return
 		}
+
+		if h.EnforceExperimentalDynamicEgressPolicies {
+			if len(ids) == 0 {
+				egressPolicies, err = h.EgressStore.All(req.Context())
+			} else {
+				egressPolicies, err = h.EgressStore.GetBySourceGuidsWithDefault(req.Context(), ids)
+			}
+			if err != nil {
+				h.ErrorResponse.InternalServerError(logger, w, err, "egress database read failed")
+				return
+			}
+		}
 	}
 
+	now := time.Now()
+	policies = unexpiredPolicies(policies, now)
+	egressPolicies = unexpiredEgressPolicies(egressPolicies, now)
+
 	bytes, err := h.PolicyCollectionWriter.AsBytes(policies, egressPolicies)
 	if err != nil {
 		h.ErrorResponse.InternalServerError(logger, w, err, "map policies as bytes failed")
@@ -82,6 +108,34 @@ func (h *PoliciesIndexInternal) ServeHTTP(w http.ResponseWriter, req *http.Reque
 	w.Write(bytes)
 }
 
+func unexpiredPolicies(policies []store.Policy, now time.Time) []store.Policy {
+	if policies == nil {
+		return nil
+	}
+
+	unexpired := make([]store.Policy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.ExpiresAt == nil || policy.ExpiresAt.After(now) {
+			unexpired = append(unexpired, policy)
+		}
+	}
+	return unexpired
+}
+
+func unexpiredEgressPolicies(egressPolicies []store.EgressPolicy, now time.Time) []store.EgressPolicy {
+	if egressPolicies == nil {
+		return nil
+	}
+
+	unexpired := make([]store.EgressPolicy, 0, len(egressPolicies))
+	for _, egressPolicy := range egressPolicies {
+		if egressPolicy.ExpiresAt == nil || egressPolicy.ExpiresAt.After(now) {
+			unexpired = append(unexpired, egressPolicy)
+		}
+	}
+	return unexpired
+}
+
 func parseIds(queryValues url.Values) []string {
 	var ids []string
 	idList, ok := queryValues["id"]
@@ -13,6 +13,7 @@ import (
 	"code.cloudfoundry.org/lager"
 
 	"policy-server/store"
+	"time"
 
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
@@ -82,7 +83,7 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		fakeStore = &storeFakes.Store{}
 		fakeStore.AllReturns(allPolicies, nil)
 		fakeEgressStore = &fakes.EgressPolicyStore{}
-		fakeEgressStore.GetBySourceGuidsReturns(allEgressPolicies, nil)
+		fakeEgressStore.GetBySourceGuidsWithDefaultReturns(allEgressPolicies, nil)
 		fakeStore.ByGuidsReturns(byGuidsPolicies, nil)
 		fakePolicyCollectionWriter.AsBytesReturns(expectedResponseBody, nil)
 		logger = lagertest.NewTestLogger("test")
@@ -109,12 +110,12 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
 		Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-		Expect(fakeEgressStore.GetBySourceGuidsCallCount()).To(Equal(1))
-		srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+		Expect(fakeEgressStore.GetBySourceGuidsWithDefaultCallCount()).To(Equal(1))
+		_, srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 		Expect(srcGuids).To(Equal([]string{"some-app-guid"}))
 		Expect(dstGuids).To(Equal([]string{"some-app-guid"}))
 		Expect(inSourceAndDest).To(BeFalse())
-		guids := fakeEgressStore.GetBySourceGuidsArgsForCall(0)
+		_, guids := fakeEgressStore.GetBySourceGuidsWithDefaultArgsForCall(0)
 		Expect(guids).To(Equal([]string{"some-app-guid"}))
 		Expect(resp.Code).To(Equal(http.StatusOK))
 		Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
@@ -147,12 +148,136 @@ var _ = Describe("PoliciesIndexInternal", func() {
 			Expect(err).NotTo(HaveOccurred())
 			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
-			Expect(fakeEgressStore.GetBySourceGuidsCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.GetBySourceGuidsWithDefaultCallCount()).To(Equal(0))
 			_, passedEgressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
 			Expect(passedEgressPolicies).To(BeNil())
 		})
 	})
 
+	Context("when some policies have expired", func() {
+		var (
+			livePolicy, expiredPolicy             store.Policy
+			liveEgressPolicy, expiredEgressPolicy store.EgressPolicy
+		)
+
+		BeforeEach(func() {
+			past := time.Now().Add(-time.Hour)
+			future := time.Now().Add(time.Hour)
+
+			livePolicy = store.Policy{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Ports: store.Ports{Start: 8080, End: 8080}},
+				ExpiresAt:   &future,
+			}
+			expiredPolicy = store.Policy{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Ports: store.Ports{Start: 9090, End: 9090}},
+				ExpiresAt:   &past,
+			}
+			liveEgressPolicy = store.EgressPolicy{
+				ID:     "live-egress-policy",
+				Source: store.EgressSource{ID: "some-app-guid"},
+			}
+			expiredEgressPolicy = store.EgressPolicy{
+				ID:        "expired-egress-policy",
+				Source:    store.EgressSource{ID: "some-app-guid"},
+				ExpiresAt: &past,
+			}
+
+			fakeStore.ByGuidsReturns([]store.Policy{livePolicy, expiredPolicy}, nil)
+			fakeEgressStore.GetBySourceGuidsWithDefaultReturns([]store.EgressPolicy{liveEgressPolicy, expiredEgressPolicy}, nil)
+		})
+
+		It("excludes the expired policies", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			passedPolicies, passedEgressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(passedPolicies).To(Equal([]store.Policy{livePolicy}))
+			Expect(passedEgressPolicies).To(Equal([]store.EgressPolicy{liveEgressPolicy}))
+		})
+	})
+
+	Context("when a policy cache is set", func() {
+		var (
+			fakePolicyCache *fakes.PolicyCache
+			cachedPolicies  []store.Policy
+			cachedEgress    []store.EgressPolicy
+		)
+
+		BeforeEach(func() {
+			cachedPolicies = []store.Policy{{
+				Source:      store.Source{ID: "cached-app-guid"},
+				Destination: store.Destination{ID: "cached-other-app-guid", Protocol: "tcp", Ports: store.Ports{Start: 8080, End: 8080}},
+			}}
+			cachedEgress = []store.EgressPolicy{{
+				ID:     "cached-egress-policy",
+				Source: store.EgressSource{ID: "cached-app-guid"},
+			}}
+
+			fakePolicyCache = &fakes.PolicyCache{}
+			fakePolicyCache.PoliciesReturns(cachedPolicies, cachedEgress, nil)
+			handler.PolicyCache = fakePolicyCache
+		})
+
+		It("serves the policies from the cache without reading the stores", func() {
+			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=cached-app-guid,another-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
+
+			Expect(fakePolicyCache.PoliciesCallCount()).To(Equal(1))
+			Expect(fakePolicyCache.PoliciesArgsForCall(0)).To(Equal([]string{"cached-app-guid", "another-guid"}))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(0))
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.GetBySourceGuidsWithDefaultCallCount()).To(Equal(0))
+			Expect(fakeEgressStore.AllCallCount()).To(Equal(0))
+
+			passedPolicies, passedEgressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+			Expect(passedPolicies).To(Equal(cachedPolicies))
+			Expect(passedEgressPolicies).To(Equal(cachedEgress))
+		})
+
+		Context("when enforce experimental dynamic egress policies is off", func() {
+			BeforeEach(func() {
+				handler.EnforceExperimentalDynamicEgressPolicies = false
+			})
+
+			It("doesn't return egress policies", func() {
+				request, err := http.NewRequest("GET", "/networking/v0/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				_, passedEgressPolicies := fakePolicyCollectionWriter.AsBytesArgsForCall(0)
+				Expect(passedEgressPolicies).To(BeNil())
+			})
+		})
+
+		Context("when reading from the cache fails", func() {
+			BeforeEach(func() {
+				fakePolicyCache.PoliciesReturns(nil, nil, errors.New("banana"))
+			})
+
+			It("calls the internal server error handler", func() {
+				request, err := http.NewRequest("GET", "/networking/v0/internal/policies", nil)
+				Expect(err).NotTo(HaveOccurred())
+				MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+				Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+				l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+				Expect(l).To(Equal(expectedLogger))
+				Expect(w).To(Equal(resp))
+				Expect(err).To(MatchError("banana"))
+				Expect(description).To(Equal("policy snapshot read failed"))
+			})
+		})
+	})
+
 	Context("when the logger isn't on the request context", func() {
 		It("still works", func() {
 			request, err := http.NewRequest("GET", "/networking/v0/internal/policies?id=some-app-guid", nil)
@@ -257,9 +382,9 @@ var _ = Describe("PoliciesIndexInternal", func() {
 		})
 	})
 
-	Context("when egressStore.GetBySourceGuidsReturns() throws an error", func() {
+	Context("when egressStore.GetBySourceGuidsWithDefault() throws an error", func() {
 		BeforeEach(func() {
-			fakeEgressStore.GetBySourceGuidsReturns(nil, errors.New("banana"))
+			fakeEgressStore.GetBySourceGuidsWithDefaultReturns(nil, errors.New("banana"))
 		})
 
 		It("calls the internal server error handler", func() {
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -148,7 +149,7 @@ var _ = Describe("Policies index handler", func() {
 
 		fakeErrorResponse = &fakes.ErrorResponse{}
 		fakePolicyFilter = &fakes.PolicyFilter{}
-		fakePolicyFilter.FilterPoliciesStub = func(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+		fakePolicyFilter.FilterPoliciesStub = func(_ context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
 			return filteredPolicies, nil
 		}
 		fakeMapper = &apifakes.PolicyMapper{}
@@ -199,7 +200,7 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
 
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			_, filterToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, _, filterToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(filterToken).To(Equal(uaa_client.CheckTokenResponse{}))
 			Expect(resp.Code).To(Equal(http.StatusOK))
 			Expect(resp.Body.Bytes()).To(Equal(expectedResponseBody))
@@ -239,12 +240,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid"}))
 			Expect(destGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid"}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -258,12 +259,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{""}))
 				Expect(destGuids).To(Equal([]string{""}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 
@@ -283,12 +284,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{}))
 			Expect(destGuids).To(ConsistOf([]string{"not-a-real-app-guid", "some-other-app-guid"}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -302,12 +303,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{}))
 				Expect(destGuids).To(Equal([]string{""}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 				Expect(resp.Code).To(Equal(http.StatusOK))
@@ -326,12 +327,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "yet-another-app-guid", "some-other-app-guid"}))
 			Expect(destGuids).To(ConsistOf([]string{}))
 			Expect(inSourceAndDest).To(BeFalse())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
@@ -345,12 +346,12 @@ var _ = Describe("Policies index handler", func() {
 
 				MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 				Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-				srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+				_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 				Expect(srcGuids).To(Equal([]string{""}))
 				Expect(destGuids).To(Equal([]string{}))
 				Expect(inSourceAndDest).To(BeFalse())
 				Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-				policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+				_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 				Expect(policies).To(Equal(byGuidsAPIPolicies))
 				Expect(userToken).To(Equal(token))
 
@@ -370,12 +371,12 @@ var _ = Describe("Policies index handler", func() {
 			MakeRequestWithLoggerAndAuth(handler.ServeHTTP, resp, request, logger, token)
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, srcGuids, destGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(srcGuids).To(ConsistOf([]string{"some-app-guid", "meow"}))
 			Expect(destGuids).To(ConsistOf([]string{"not-a-real-app-guid", "some-other-app-guid"}))
 			Expect(inSourceAndDest).To(BeTrue())
 			Expect(fakePolicyFilter.FilterPoliciesCallCount()).To(Equal(1))
-			policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
+			_, policies, userToken := fakePolicyFilter.FilterPoliciesArgsForCall(0)
 			Expect(policies).To(Equal(byGuidsAPIPolicies))
 			Expect(userToken).To(Equal(token))
 			Expect(resp.Code).To(Equal(http.StatusOK))
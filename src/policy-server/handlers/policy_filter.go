@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/api"
 	"policy-server/store"
@@ -9,17 +10,21 @@ import (
 
 //go:generate counterfeiter -o fakes/uua_client.go --fake-name UAAClient . uaaClient
 type uaaClient interface {
-	GetToken() (string, error)
-	CheckToken(string) (uaa_client.CheckTokenResponse, error)
+	GetToken(context.Context) (string, error)
+	CheckToken(context.Context, string) (uaa_client.CheckTokenResponse, error)
 }
 
 //go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
 type ccClient interface {
-	GetAppSpaces(token string, appGUIDs []string) (map[string]string, error)
-	GetSpace(token, spaceGUID string) (*api.Space, error)
-	GetSpaceGUIDs(token string, appGUIDs []string) ([]string, error)
-	GetUserSpace(token, userGUID string, spaces api.Space) (*api.Space, error)
-	GetUserSpaces(token, userGUID string) (map[string]struct{}, error)
+	GetAppSpaces(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
+	GetSpace(ctx context.Context, token, spaceGUID string) (*api.Space, error)
+	GetSpaceGUIDs(ctx context.Context, token string, appGUIDs []string) ([]string, error)
+	GetUserSpace(ctx context.Context, token, userGUID string, spaces api.Space) (*api.Space, error)
+	GetUserSpaces(ctx context.Context, token, userGUID string) (map[string]struct{}, error)
+	GetAppNames(ctx context.Context, token string, appGUIDs []string) (map[string]string, error)
+	GetSpaceNames(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error)
+	GetSpaceOrgs(ctx context.Context, token string, spaceGUIDs []string) (map[string]string, error)
+	GetOrgNames(ctx context.Context, token string, orgGUIDs []string) (map[string]string, error)
 }
 
 type PolicyFilter struct {
@@ -36,14 +41,14 @@ func NewPolicyFilter(uaaClient uaaClient, ccClient ccClient, chunkSize int) *Pol
 	}
 }
 
-func (f *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
+func (f *PolicyFilter) FilterPolicies(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) ([]store.Policy, error) {
 	for _, scope := range userToken.Scope {
 		if scope == "network.admin" {
 			return policies, nil
 		}
 	}
 
-	token, err := f.UAAClient.GetToken()
+	token, err := f.UAAClient.GetToken(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("getting token: %s", err)
 	}
@@ -53,7 +58,7 @@ func (f *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_cli
 
 	appSpacesList := []map[string]string{}
 	for _, chunk := range appGuidChunks {
-		spaces, err := f.CCClient.GetAppSpaces(token, chunk)
+		spaces, err := f.CCClient.GetAppSpaces(ctx, token, chunk)
 		if err != nil {
 			return nil, fmt.Errorf("getting app spaces: %s", err)
 		}
@@ -62,7 +67,7 @@ func (f *PolicyFilter) FilterPolicies(policies []store.Policy, userToken uaa_cli
 
 	appSpaces := flatten(appSpacesList)
 
-	userSpaces, err := f.CCClient.GetUserSpaces(token, userToken.UserID)
+	userSpaces, err := f.CCClient.GetUserSpaces(ctx, token, userToken.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("getting user spaces: %s", err)
 	}
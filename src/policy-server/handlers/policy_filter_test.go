@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/handlers"
 	"policy-server/handlers/fakes"
@@ -72,19 +73,19 @@ var _ = Describe("PolicyFilter", func() {
 
 	Describe("FilterPolicies", func() {
 		It("filters the policies by the spaces the user can access", func() {
-			filteredPolicies, err := policyFilter.FilterPolicies(policies, tokenData)
+			filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
 
-			token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+			_, token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(appGUIDs).To(ConsistOf([]string{"app-guid-1", "app-guid-2", "app-guid-3", "app-guid-4"}))
 
 			Expect(fakeCCClient.GetUserSpacesCallCount()).To(Equal(1))
 
-			token, userGUID := fakeCCClient.GetUserSpacesArgsForCall(0)
+			_, token, userGUID := fakeCCClient.GetUserSpacesArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 
@@ -107,7 +108,7 @@ var _ = Describe("PolicyFilter", func() {
 			})
 
 			It("returns a non-null, but empty, slice of policies", func() {
-				filteredPolicies, err := policyFilter.FilterPolicies(policies, tokenData)
+				filteredPolicies, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(filteredPolicies).NotTo(BeNil())
 				Expect(filteredPolicies).To(HaveLen(0))
@@ -121,7 +122,7 @@ var _ = Describe("PolicyFilter", func() {
 				}
 			})
 			It("returns all policies without making extra calls to UAA or CC", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 				Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(0))
@@ -135,7 +136,7 @@ var _ = Describe("PolicyFilter", func() {
 				fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting app spaces: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -146,7 +147,7 @@ var _ = Describe("PolicyFilter", func() {
 				fakeCCClient.GetUserSpacesReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting user spaces: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -157,7 +158,7 @@ var _ = Describe("PolicyFilter", func() {
 				fakeUAAClient.GetTokenReturns("", errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting token: banana"))
 				Expect(filtered).To(BeNil())
 			})
@@ -168,7 +169,7 @@ var _ = Describe("PolicyFilter", func() {
 				policyFilter.ChunkSize = 1
 			})
 			It("chunks the guids and makes multiple requests to CC", func() {
-				filtered, err := policyFilter.FilterPolicies(policies, tokenData)
+				filtered, err := policyFilter.FilterPolicies(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				expected := []store.Policy{
@@ -186,7 +187,7 @@ var _ = Describe("PolicyFilter", func() {
 
 				var appGUIDs []string
 				for i := 0; i < 4; i++ {
-					_, guids := fakeCCClient.GetAppSpacesArgsForCall(i)
+					_, _, guids := fakeCCClient.GetAppSpacesArgsForCall(i)
 					appGUIDs = append(appGUIDs, guids...)
 				}
 				Expect(appGUIDs).To(ConsistOf("app-guid-1",
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
 	"policy-server/uaa_client"
@@ -18,31 +19,31 @@ func NewPolicyGuard(uaaClient uaaClient, ccClient ccClient) *PolicyGuard {
 	}
 }
 
-func (g *PolicyGuard) CheckAccess(policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
+func (g *PolicyGuard) CheckAccess(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
 	for _, scope := range userToken.Scope {
 		if scope == "network.admin" {
 			return true, nil
 		}
 	}
 
-	token, err := g.UAAClient.GetToken()
+	token, err := g.UAAClient.GetToken(ctx)
 	if err != nil {
 		return false, fmt.Errorf("getting token: %s", err)
 	}
 
-	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(token, uniqueAppGUIDs(policies))
+	spaceGUIDs, err := g.CCClient.GetSpaceGUIDs(ctx, token, uniqueAppGUIDs(policies))
 	if err != nil {
 		return false, fmt.Errorf("getting space guids: %s", err)
 	}
 	for _, guid := range spaceGUIDs {
-		space, err := g.CCClient.GetSpace(token, guid)
+		space, err := g.CCClient.GetSpace(ctx, token, guid)
 		if err != nil {
 			return false, fmt.Errorf("getting space with guid %s: %s", guid, err)
 		}
 		if space == nil {
 			return false, nil
 		}
-		userSpace, err := g.CCClient.GetUserSpace(token, userToken.UserID, *space)
+		userSpace, err := g.CCClient.GetUserSpace(ctx, token, userToken.UserID, *space)
 		if err != nil {
 			return false, fmt.Errorf("getting space with guid %s: %s", guid, err)
 		}
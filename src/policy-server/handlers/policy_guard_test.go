@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/api"
 	"policy-server/handlers"
@@ -71,7 +72,7 @@ var _ = Describe("PolicyGuard", func() {
 
 		fakeUAAClient.GetTokenReturns("policy-server-token", nil)
 		fakeCCClient.GetSpaceGUIDsReturns(spaceGUIDs, nil)
-		fakeCCClient.GetSpaceStub = func(token, spaceGUID string) (*api.Space, error) {
+		fakeCCClient.GetSpaceStub = func(_ context.Context, token, spaceGUID string) (*api.Space, error) {
 			switch spaceGUID {
 			case "space-guid-1":
 				{
@@ -91,7 +92,7 @@ var _ = Describe("PolicyGuard", func() {
 				}
 			}
 		}
-		fakeCCClient.GetUserSpaceStub = func(token, userGUID string, space api.Space) (*api.Space, error) {
+		fakeCCClient.GetUserSpaceStub = func(_ context.Context, token, userGUID string, space api.Space) (*api.Space, error) {
 			switch space {
 			case space1:
 				{
@@ -144,33 +145,33 @@ var _ = Describe("PolicyGuard", func() {
 
 	Describe("CheckAccess", func() {
 		It("checks that the user can access all apps references in policies", func() {
-			authorized, err := policyGuard.CheckAccess(policies, tokenData)
+			authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
 			Expect(fakeCCClient.GetSpaceGUIDsCallCount()).To(Equal(1))
-			token, appGUIDs := fakeCCClient.GetSpaceGUIDsArgsForCall(0)
+			_, token, appGUIDs := fakeCCClient.GetSpaceGUIDsArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(appGUIDs).To(ConsistOf([]string{"some-app-guid", "some-other-guid", "yet-another-guid"}))
 			Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(3))
-			token, guid := fakeCCClient.GetSpaceArgsForCall(0)
+			_, token, guid := fakeCCClient.GetSpaceArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-1"))
-			token, guid = fakeCCClient.GetSpaceArgsForCall(1)
+			_, token, guid = fakeCCClient.GetSpaceArgsForCall(1)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-2"))
-			token, guid = fakeCCClient.GetSpaceArgsForCall(2)
+			_, token, guid = fakeCCClient.GetSpaceArgsForCall(2)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(guid).To(Equal("space-guid-3"))
 			Expect(fakeCCClient.GetUserSpaceCallCount()).To(Equal(3))
-			token, userGUID, checkUserSpace := fakeCCClient.GetUserSpaceArgsForCall(0)
+			_, token, userGUID, checkUserSpace := fakeCCClient.GetUserSpaceArgsForCall(0)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space1))
-			token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(1)
+			_, token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(1)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space2))
-			token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(2)
+			_, token, userGUID, checkUserSpace = fakeCCClient.GetUserSpaceArgsForCall(2)
 			Expect(token).To(Equal("policy-server-token"))
 			Expect(userGUID).To(Equal("some-developer-guid"))
 			Expect(checkUserSpace).To(Equal(space3))
@@ -184,7 +185,7 @@ var _ = Describe("PolicyGuard", func() {
 				}
 			})
 			It("returns successfully without making extra calls to UAA or CC", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
 				Expect(fakeCCClient.GetSpaceCallCount()).To(Equal(0))
@@ -198,7 +199,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetSpaceReturns(nil, nil)
 			})
 			It("returns false", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(authorized).To(BeFalse())
 			})
@@ -209,7 +210,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetUserSpaceReturns(nil, nil)
 			})
 			It("returns false", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(authorized).To(BeFalse())
 			})
@@ -220,7 +221,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeUAAClient.GetTokenReturns("", errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting token: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -231,7 +232,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetSpaceGUIDsReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting space guids: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -242,7 +243,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetSpaceReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting space with guid space-guid-1: banana"))
 				Expect(authorized).To(BeFalse())
 			})
@@ -253,7 +254,7 @@ var _ = Describe("PolicyGuard", func() {
 				fakeCCClient.GetUserSpaceReturns(nil, errors.New("banana"))
 			})
 			It("returns a useful error", func() {
-				authorized, err := policyGuard.CheckAccess(policies, tokenData)
+				authorized, err := policyGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting space with guid space-guid-1: banana"))
 				Expect(authorized).To(BeFalse())
 			})
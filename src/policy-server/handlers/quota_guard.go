@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"policy-server/store"
 	"policy-server/uaa_client"
@@ -18,7 +19,7 @@ func NewQuotaGuard(store policyStore, maxPolicies int) *QuotaGuard {
 	}
 }
 
-func (g *QuotaGuard) CheckAccess(policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
+func (g *QuotaGuard) CheckAccess(ctx context.Context, policies []store.Policy, userToken uaa_client.CheckTokenResponse) (bool, error) {
 	for _, scope := range userToken.Scope {
 		if scope == "network.admin" {
 			return true, nil
@@ -27,7 +28,7 @@ func (g *QuotaGuard) CheckAccess(policies []store.Policy, userToken uaa_client.C
 
 	appGuids := uniqueAppGUIDs(policies)
 	toAddSourceCounts := sourceCounts(policies, appGuids)
-	sourcePolicies, err := g.Store.ByGuids(appGuids, []string{}, false)
+	sourcePolicies, err := g.Store.ByGuids(ctx, appGuids, []string{}, false)
 	if err != nil {
 		return false, fmt.Errorf("getting policies: %s", err)
 	}
@@ -1,6 +1,7 @@
 package handlers_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/handlers"
 	"policy-server/store"
@@ -48,7 +49,7 @@ var _ = Describe("QuotaGuard", func() {
 	Context("when the user is not an admin", func() {
 		Context("when the additional policies do not exceed the quota", func() {
 			It("allows policy creation", func() {
-				authorized, err := quotaGuard.CheckAccess(policies, tokenData)
+				authorized, err := quotaGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(authorized).To(BeTrue())
@@ -68,7 +69,7 @@ var _ = Describe("QuotaGuard", func() {
 				}, nil)
 			})
 			It("does not allow policy creation", func() {
-				authorized, err := quotaGuard.CheckAccess(policies, tokenData)
+				authorized, err := quotaGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(authorized).To(BeFalse())
@@ -79,7 +80,7 @@ var _ = Describe("QuotaGuard", func() {
 				fakeStore.ByGuidsReturns([]store.Policy{}, errors.New("banana"))
 			})
 			It("returns an error", func() {
-				_, err := quotaGuard.CheckAccess(policies, tokenData)
+				_, err := quotaGuard.CheckAccess(context.Background(), policies, tokenData)
 				Expect(err).To(MatchError("getting policies: banana"))
 			})
 
@@ -104,7 +105,7 @@ var _ = Describe("QuotaGuard", func() {
 			}, nil)
 		})
 		It("allows policy creation beyond the max policies", func() {
-			authorized, err := quotaGuard.CheckAccess(policies, tokenData)
+			authorized, err := quotaGuard.CheckAccess(context.Background(), policies, tokenData)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(authorized).To(BeTrue())
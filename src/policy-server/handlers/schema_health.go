@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter -o fakes/schema_verifier.go --fake-name SchemaVerifier . schemaVerifier
+type schemaVerifier interface {
+	Verify() ([]string, error)
+}
+
+// SchemaHealth reports whether the database schema has drifted from the
+// schema the migrations produce. It responds 503 when differences are found.
+type SchemaHealth struct {
+	SchemaVerifier schemaVerifier
+	Marshaler      marshal.Marshaler
+	ErrorResponse  errorResponse
+}
+
+func NewSchemaHealth(schemaVerifier schemaVerifier, marshaler marshal.Marshaler, errorResponse errorResponse) *SchemaHealth {
+	return &SchemaHealth{
+		SchemaVerifier: schemaVerifier,
+		Marshaler:      marshaler,
+		ErrorResponse:  errorResponse,
+	}
+}
+
+func (h *SchemaHealth) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("schema-health")
+	differences, err := h.SchemaVerifier.Verify()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "schema verification failed")
+		return
+	}
+	if differences == nil {
+		differences = []string{}
+	}
+
+	response := struct {
+		Healthy     bool     `json:"healthy"`
+		Differences []string `json:"differences"`
+	}{len(differences) == 0, differences}
+	responseBytes, err := h.Marshaler.Marshal(response)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "marshalling failed")
+		return
+	}
+
+	if len(differences) > 0 {
+		logger.Info("schema-drift-detected", lager.Data{"differences": differences})
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.Write(responseBytes)
+}
@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Schema health handler", func() {
+	var (
+		handler            *handlers.SchemaHealth
+		request            *http.Request
+		fakeSchemaVerifier *fakes.SchemaVerifier
+		fakeErrorResponse  *fakes.ErrorResponse
+		marshaler          *hfakes.Marshaler
+		resp               *httptest.ResponseRecorder
+		logger             *lagertest.TestLogger
+		expectedLogger     lager.Logger
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/health/schema", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeSchemaVerifier = &fakes.SchemaVerifier{}
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		handler = handlers.NewSchemaHealth(fakeSchemaVerifier, marshaler, fakeErrorResponse)
+		resp = httptest.NewRecorder()
+
+		logger = lagertest.NewTestLogger("test-logger")
+		expectedLogger = lager.NewLogger("test-logger").Session("schema-health")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+	})
+
+	It("returns a 200 when the schema matches", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeSchemaVerifier.VerifyCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body.String()).To(MatchJSON(`{"healthy": true, "differences": []}`))
+	})
+
+	Context("when the schema has drifted", func() {
+		BeforeEach(func() {
+			fakeSchemaVerifier.VerifyReturns([]string{"unexpected column policies.dba_note"}, nil)
+		})
+
+		It("returns a 503 listing the differences", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusServiceUnavailable))
+			Expect(resp.Body.String()).To(MatchJSON(`{"healthy": false, "differences": ["unexpected column policies.dba_note"]}`))
+			Expect(logger).To(gbytes.Say("schema-health.schema-drift-detected.*unexpected column policies.dba_note"))
+		})
+	})
+
+	Context("when verifying the schema fails", func() {
+		BeforeEach(func() {
+			fakeSchemaVerifier.VerifyReturns(nil, errors.New("pineapple"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("pineapple"))
+			Expect(description).To(Equal("schema verification failed"))
+		})
+	})
+
+	Context("when marshalling fails", func() {
+		BeforeEach(func() {
+			marshaler.MarshalReturns(nil, errors.New("banana"))
+			marshaler.MarshalStub = nil
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("marshalling failed"))
+		})
+	})
+})
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+)
+
+const defaultStatsTop = 10
+
+// StatsIndex reports policy statistics for capacity planning. The top query
+// parameter sets how many of the apps, sources and destinations with the
+// most policies are listed. The spaces and orgs of the source apps are looked
+// up in Cloud Controller to count the c2c policies per space and org.
+type StatsIndex struct {
+	Store         store.StatsStore
+	UAAClient     uaaClient
+	CCClient      ccClient
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func (h *StatsIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("index-stats")
+
+	top := defaultStatsTop
+	if topParam := req.URL.Query().Get("top"); topParam != "" {
+		var err error
+		top, err = strconv.Atoi(topParam)
+		if err != nil || top < 0 {
+			h.ErrorResponse.BadRequest(logger, w, errors.New("invalid top"), "top must be a non-negative integer")
+			return
+		}
+	}
+
+	stats, err := h.Store.Stats()
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	apps, err := h.appSpaces(req.Context(), stats.FanOut)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "getting app spaces failed")
+		return
+	}
+
+	stats.PoliciesPerSpace, stats.PoliciesPerOrg, err = h.Store.SpaceCounts(apps)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	responseBytes, err := h.Marshaler.Marshal(api.MapStoreStats(stats, top))
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database marshalling failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+// appSpaces looks up the space and org of every app that is the source of a
+// policy. Apps, or their spaces, that no longer exist are left out.
+func (h *StatsIndex) appSpaces(ctx context.Context, sources []store.GUIDCount) ([]store.AppSpace, error) {
+	if len(sources) == 0 {
+		return []store.AppSpace{}, nil
+	}
+
+	appGUIDs := []string{}
+	for _, source := range sources {
+		appGUIDs = append(appGUIDs, source.GUID)
+	}
+
+	token, err := h.UAAClient.GetToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	spaces, err := h.CCClient.GetAppSpaces(ctx, token, appGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceGUIDSet := map[string]struct{}{}
+	for _, spaceGUID := range spaces {
+		if spaceGUID != "" {
+			spaceGUIDSet[spaceGUID] = struct{}{}
+		}
+	}
+	spaceGUIDs := []string{}
+	for spaceGUID := range spaceGUIDSet {
+		spaceGUIDs = append(spaceGUIDs, spaceGUID)
+	}
+
+	orgs, err := h.CCClient.GetSpaceOrgs(ctx, token, spaceGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	apps := []store.AppSpace{}
+	for _, appGUID := range appGUIDs {
+		spaceGUID := spaces[appGUID]
+		orgGUID := orgs[spaceGUID]
+		if spaceGUID == "" || orgGUID == "" {
+			continue
+		}
+		apps = append(apps, store.AppSpace{
+			AppGUID:   appGUID,
+			SpaceGUID: spaceGUID,
+			OrgGUID:   orgGUID,
+		})
+	}
+	return apps, nil
+}
@@ -0,0 +1,289 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/api"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stats index handler", func() {
+	var (
+		request           *http.Request
+		handler           *handlers.StatsIndex
+		resp              *httptest.ResponseRecorder
+		fakeStore         *storeFakes.StatsStore
+		fakeUAAClient     *fakes.UAAClient
+		fakeCCClient      *fakes.CCClient
+		fakeErrorResponse *fakes.ErrorResponse
+		logger            *lagertest.TestLogger
+		expectedLogger    lager.Logger
+		marshaler         *hfakes.Marshaler
+	)
+
+	BeforeEach(func() {
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/stats", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakeStore = &storeFakes.StatsStore{}
+		fakeStore.StatsReturns(store.Stats{
+			TotalPolicies: 2,
+			FanOut: []store.GUIDCount{
+				{GUID: "app-a", Count: 2},
+			},
+			FanIn: []store.GUIDCount{
+				{GUID: "app-b", Count: 1},
+				{GUID: "app-c", Count: 1},
+			},
+			Tags: store.TagStats{TagLength: 1, Max: 255, Populated: 255, Used: 3, UsedByType: map[string]int{"app": 3}},
+		}, nil)
+		fakeStore.SpaceCountsReturns(
+			[]store.GUIDCount{{GUID: "space-1", Count: 2}},
+			[]store.GUIDCount{{GUID: "org-1", Count: 2}},
+			nil,
+		)
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+		fakeCCClient = &fakes.CCClient{}
+		fakeCCClient.GetAppSpacesReturns(map[string]string{"app-a": "space-1"}, nil)
+		fakeCCClient.GetSpaceOrgsReturns(map[string]string{"space-1": "org-1"}, nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("index-stats")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		handler = &handlers.StatsIndex{
+			Store:         fakeStore,
+			UAAClient:     fakeUAAClient,
+			CCClient:      fakeCCClient,
+			Marshaler:     marshaler,
+			ErrorResponse: fakeErrorResponse,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("returns the stats", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeStore.StatsCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+
+		var body api.Stats
+		Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.TotalPolicies).To(Equal(2))
+		Expect(body.FanOut.Top).To(Equal([]api.GUIDCount{{GUID: "app-a", Policies: 2}}))
+		Expect(body.FanIn.Count).To(Equal(2))
+		Expect(body.Tags.Used).To(Equal(3))
+	})
+
+	It("counts the policies per space and org of the source apps", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(1))
+		_, token, appGUIDs := fakeCCClient.GetAppSpacesArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+		Expect(appGUIDs).To(Equal([]string{"app-a"}))
+
+		Expect(fakeCCClient.GetSpaceOrgsCallCount()).To(Equal(1))
+		_, token, spaceGUIDs := fakeCCClient.GetSpaceOrgsArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+		Expect(spaceGUIDs).To(Equal([]string{"space-1"}))
+
+		Expect(fakeStore.SpaceCountsCallCount()).To(Equal(1))
+		Expect(fakeStore.SpaceCountsArgsForCall(0)).To(Equal([]store.AppSpace{
+			{AppGUID: "app-a", SpaceGUID: "space-1", OrgGUID: "org-1"},
+		}))
+
+		var body api.Stats
+		Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.PoliciesPerSpace.Top).To(Equal([]api.GUIDCount{{GUID: "space-1", Policies: 2}}))
+		Expect(body.PoliciesPerOrg.Top).To(Equal([]api.GUIDCount{{GUID: "org-1", Policies: 2}}))
+	})
+
+	Context("when a source app or its space no longer exists", func() {
+		BeforeEach(func() {
+			fakeStore.StatsReturns(store.Stats{
+				FanOut: []store.GUIDCount{
+					{GUID: "app-a", Count: 1},
+					{GUID: "deleted-app", Count: 1},
+					{GUID: "app-in-deleted-space", Count: 1},
+				},
+			}, nil)
+			fakeCCClient.GetAppSpacesReturns(map[string]string{
+				"app-a":                "space-1",
+				"app-in-deleted-space": "deleted-space",
+			}, nil)
+		})
+
+		It("leaves the app out", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(fakeStore.SpaceCountsArgsForCall(0)).To(Equal([]store.AppSpace{
+				{AppGUID: "app-a", SpaceGUID: "space-1", OrgGUID: "org-1"},
+			}))
+		})
+	})
+
+	Context("when there are no c2c policies", func() {
+		BeforeEach(func() {
+			fakeStore.StatsReturns(store.Stats{}, nil)
+		})
+
+		It("does not call Cloud Controller", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(0))
+			Expect(fakeCCClient.GetAppSpacesCallCount()).To(Equal(0))
+			Expect(fakeStore.SpaceCountsArgsForCall(0)).To(BeEmpty())
+		})
+	})
+
+	Context("when getting the UAA token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("getting app spaces failed"))
+		})
+	})
+
+	Context("when getting the app spaces fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetAppSpacesReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("getting app spaces failed"))
+		})
+	})
+
+	Context("when getting the space orgs fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.GetSpaceOrgsReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("getting app spaces failed"))
+		})
+	})
+
+	Context("when counting the policies per space fails", func() {
+		BeforeEach(func() {
+			fakeStore.SpaceCountsReturns(nil, nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when top is set", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "top=1"
+		})
+
+		It("lists that many entries", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			var body api.Stats
+			Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.FanIn.Top).To(Equal([]api.GUIDCount{{GUID: "app-b", Policies: 1}}))
+		})
+	})
+
+	Context("when top is invalid", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "top=banana"
+		})
+
+		It("calls the bad request handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.StatsCallCount()).To(Equal(0))
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+
+			l, w, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("invalid top"))
+			Expect(description).To(Equal("top must be a non-negative integer"))
+		})
+	})
+
+	Context("when the store throws an error", func() {
+		BeforeEach(func() {
+			fakeStore.StatsReturns(store.Stats{}, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when the stats cannot be marshaled", func() {
+		BeforeEach(func() {
+			marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+				return nil, errors.New("potato")
+			}
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("potato"))
+			Expect(description).To(Equal("database marshalling failed"))
+		})
+	})
+})
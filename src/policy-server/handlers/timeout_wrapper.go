@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// TimeoutWrapper gives each request a deadline, so that the database queries
+// and Cloud Controller and UAA calls made on its behalf are cancelled once it
+// has run for longer than Timeout, or than the entry in RouteTimeouts for the
+// route it was wrapped for.
+type TimeoutWrapper struct {
+	Timeout       time.Duration
+	RouteTimeouts map[string]time.Duration
+}
+
+func (t *TimeoutWrapper) Wrap(handler http.Handler) http.Handler {
+	return withTimeout(t.Timeout, handler)
+}
+
+// WrapRoute is Wrap using the timeout configured for the named route.
+func (t *TimeoutWrapper) WrapRoute(name string, handler http.Handler) http.Handler {
+	timeout, ok := t.RouteTimeouts[name]
+	if !ok {
+		timeout = t.Timeout
+	}
+	return withTimeout(timeout, handler)
+}
+
+func withTimeout(timeout time.Duration, handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+		handler.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TimeoutWrapper", func() {
+	var (
+		wrapper    *handlers.TimeoutWrapper
+		request    *http.Request
+		resp       *httptest.ResponseRecorder
+		requestCtx context.Context
+		inner      http.Handler
+	)
+
+	BeforeEach(func() {
+		wrapper = &handlers.TimeoutWrapper{Timeout: time.Minute}
+
+		var err error
+		request, err = http.NewRequest("GET", "/some/path", nil)
+		Expect(err).NotTo(HaveOccurred())
+		resp = httptest.NewRecorder()
+
+		inner = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			requestCtx = req.Context()
+			w.WriteHeader(http.StatusTeapot)
+		})
+	})
+
+	It("serves the request with a deadline of the timeout", func() {
+		wrapper.Wrap(inner).ServeHTTP(resp, request)
+
+		Expect(resp.Code).To(Equal(http.StatusTeapot))
+		deadline, ok := requestCtx.Deadline()
+		Expect(ok).To(BeTrue())
+		Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+	})
+
+	It("keeps the values of the request context", func() {
+		request = request.WithContext(context.WithValue(request.Context(), "some-key", "some-value"))
+
+		wrapper.Wrap(inner).ServeHTTP(resp, request)
+
+		Expect(requestCtx.Value("some-key")).To(Equal("some-value"))
+	})
+
+	It("cancels the context once the request has been served", func() {
+		wrapper.Wrap(inner).ServeHTTP(resp, request)
+
+		Expect(requestCtx.Err()).To(Equal(context.Canceled))
+	})
+
+	Describe("WrapRoute", func() {
+		BeforeEach(func() {
+			wrapper.RouteTimeouts = map[string]time.Duration{"slow_route": time.Hour}
+		})
+
+		It("uses the timeout configured for the route", func() {
+			wrapper.WrapRoute("slow_route", inner).ServeHTTP(resp, request)
+
+			Expect(resp.Code).To(Equal(http.StatusTeapot))
+			deadline, ok := requestCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Hour), time.Second))
+		})
+
+		It("falls back to the timeout for routes without their own", func() {
+			wrapper.WrapRoute("other_route", inner).ServeHTTP(resp, request)
+
+			deadline, ok := requestCtx.Deadline()
+			Expect(ok).To(BeTrue())
+			Expect(deadline).To(BeTemporally("~", time.Now().Add(time.Minute), time.Second))
+		})
+	})
+
+	Context("when the timeout passes while serving the request", func() {
+		BeforeEach(func() {
+			wrapper.Timeout = 10 * time.Millisecond
+			inner = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				<-req.Context().Done()
+				requestCtx = req.Context()
+			})
+		})
+
+		It("cancels the request context", func() {
+			wrapper.Wrap(inner).ServeHTTP(resp, request)
+
+			Expect(requestCtx.Err()).To(Equal(context.DeadlineExceeded))
+		})
+	})
+})
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"net/http"
+
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+)
+
+type TombstonesIndex struct {
+	Store         store.TombstoneStore
+	Marshaler     marshal.Marshaler
+	ErrorResponse errorResponse
+}
+
+func (h *TombstonesIndex) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("index-tombstones")
+
+	var (
+		tombstones []store.Tombstone
+		err        error
+	)
+	guids := parseQueryList(req.URL.Query(), "id")
+	if guids == nil {
+		tombstones, err = h.Store.All()
+	} else {
+		tombstones, err = h.Store.ByGuids(guids)
+	}
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database read failed")
+		return
+	}
+
+	tombstonesResponse := struct {
+		TotalTombstones int             `json:"total_tombstones"`
+		Tombstones      []api.Tombstone `json:"tombstones"`
+	}{len(tombstones), api.MapStoreTombstones(tombstones)}
+	responseBytes, err := h.Marshaler.Marshal(tombstonesResponse)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database marshalling failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
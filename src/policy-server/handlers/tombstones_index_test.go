@@ -0,0 +1,175 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"time"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tombstones index handler", func() {
+	var (
+		tombstones        []store.Tombstone
+		request           *http.Request
+		handler           *handlers.TombstonesIndex
+		resp              *httptest.ResponseRecorder
+		fakeStore         *storeFakes.TombstoneStore
+		fakeErrorResponse *fakes.ErrorResponse
+		logger            *lagertest.TestLogger
+		expectedLogger    lager.Logger
+		marshaler         *hfakes.Marshaler
+	)
+
+	BeforeEach(func() {
+		deletedAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		tombstones = []store.Tombstone{{
+			ID:   1,
+			Type: "c2c",
+			Policy: &store.Policy{
+				Source: store.Source{ID: "app-a"},
+				Destination: store.Destination{
+					ID:       "app-b",
+					Protocol: "tcp",
+					Port:     8080,
+					Ports:    store.Ports{Start: 8080, End: 8080},
+				},
+			},
+			DeletedAt: deletedAt,
+		}, {
+			ID:   2,
+			Type: "egress",
+			EgressPolicy: &store.EgressPolicy{
+				ID:          "egress-guid",
+				Source:      store.EgressSource{ID: "app-c", Type: "app"},
+				Destination: store.EgressDestination{GUID: "destination-guid"},
+				Action:      "allow",
+			},
+			DeletedAt: deletedAt,
+		}}
+
+		var err error
+		request, err = http.NewRequest("GET", "/networking/v1/external/tombstones", nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakeStore = &storeFakes.TombstoneStore{}
+		fakeStore.AllReturns(tombstones, nil)
+		fakeStore.ByGuidsReturns(tombstones[:1], nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("index-tombstones")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		handler = &handlers.TombstonesIndex{
+			Store:         fakeStore,
+			Marshaler:     marshaler,
+			ErrorResponse: fakeErrorResponse,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("returns all the tombstones", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeStore.AllCallCount()).To(Equal(1))
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		Expect(resp.Body).To(MatchJSON(`{
+			"total_tombstones": 2,
+			"tombstones": [
+				{
+					"id": 1,
+					"type": "c2c",
+					"deleted_at": "2020-01-02T03:04:05Z",
+					"policy": {
+						"source": {"id": "app-a"},
+						"destination": {"id": "app-b", "protocol": "tcp", "ports": {"start": 8080, "end": 8080}}
+					}
+				},
+				{
+					"id": 2,
+					"type": "egress",
+					"deleted_at": "2020-01-02T03:04:05Z",
+					"egress_policy": {
+						"id": "egress-guid",
+						"source": {"id": "app-c", "type": "app"},
+						"destination": {"id": "destination-guid"},
+						"action": "allow"
+					}
+				}
+			]
+		}`))
+	})
+
+	Context("when filtering by app guids", func() {
+		BeforeEach(func() {
+			request.URL.RawQuery = "id=app-a,app-b"
+		})
+
+		It("returns the tombstones for those apps", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeStore.AllCallCount()).To(Equal(0))
+			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
+			Expect(fakeStore.ByGuidsArgsForCall(0)).To(Equal([]string{"app-a", "app-b"}))
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			var body struct {
+				TotalTombstones int `json:"total_tombstones"`
+			}
+			Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.TotalTombstones).To(Equal(1))
+		})
+	})
+
+	Context("when the store throws an error", func() {
+		BeforeEach(func() {
+			fakeStore.AllReturns(nil, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database read failed"))
+		})
+	})
+
+	Context("when the tombstones cannot be marshaled", func() {
+		BeforeEach(func() {
+			marshaler.MarshalStub = func(interface{}) ([]byte, error) {
+				return nil, errors.New("grapes")
+			}
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+
+			_, _, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(err).To(MatchError("grapes"))
+			Expect(description).To(Equal("database marshalling failed"))
+		})
+	})
+})
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+
+	"policy-server/api"
+	"policy-server/store"
+
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+)
+
+// TombstonesRestore recreates the policies of tombstones. Tombstones whose
+// policy conflicts with the current policies are skipped and reported.
+type TombstonesRestore struct {
+	TombstoneStore store.TombstoneStore
+	Unmarshaler    marshal.Unmarshaler
+	Marshaler      marshal.Marshaler
+	ErrorResponse  errorResponse
+}
+
+func (h *TombstonesRestore) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger := getLogger(req)
+	logger = logger.Session("restore-tombstones")
+
+	bodyBytes, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, "invalid request body")
+		return
+	}
+
+	var payload struct {
+		IDs []int `json:"ids"`
+	}
+	err = h.Unmarshaler.Unmarshal(bodyBytes, &payload)
+	if err != nil {
+		h.ErrorResponse.BadRequest(logger, w, err, "invalid values passed to API")
+		return
+	}
+	if len(payload.IDs) == 0 {
+		err = errors.New("missing tombstone ids")
+		h.ErrorResponse.BadRequest(logger, w, err, err.Error())
+		return
+	}
+
+	restore, err := h.TombstoneStore.Restore(req.Context(), payload.IDs...)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database restore failed")
+		return
+	}
+
+	logger.Info("restored-tombstones", lager.Data{
+		"ids":         tombstoneIDs(restore.Restored),
+		"skipped_ids": tombstoneIDs(restore.Skipped),
+	})
+
+	restoreResponse := struct {
+		TotalTombstones   int             `json:"total_tombstones"`
+		Tombstones        []api.Tombstone `json:"tombstones"`
+		SkippedTombstones []api.Tombstone `json:"skipped_tombstones"`
+	}{len(restore.Restored), api.MapStoreTombstones(restore.Restored), api.MapStoreTombstones(restore.Skipped)}
+	responseBytes, err := h.Marshaler.Marshal(restoreResponse)
+	if err != nil {
+		h.ErrorResponse.InternalServerError(logger, w, err, "database marshalling failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+	w.Write(responseBytes)
+}
+
+func tombstoneIDs(tombstones []store.Tombstone) []int {
+	ids := []int{}
+	for _, tombstone := range tombstones {
+		ids = append(ids, tombstone.ID)
+	}
+	return ids
+}
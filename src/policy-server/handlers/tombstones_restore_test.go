@@ -0,0 +1,168 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"policy-server/handlers"
+	"policy-server/handlers/fakes"
+	"policy-server/store"
+	storeFakes "policy-server/store/fakes"
+	"strings"
+
+	hfakes "code.cloudfoundry.org/cf-networking-helpers/fakes"
+	"code.cloudfoundry.org/cf-networking-helpers/marshal"
+	"code.cloudfoundry.org/lager"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Tombstones restore handler", func() {
+	var (
+		tombstones         []store.Tombstone
+		request            *http.Request
+		handler            *handlers.TombstonesRestore
+		resp               *httptest.ResponseRecorder
+		fakeTombstoneStore *storeFakes.TombstoneStore
+		fakeErrorResponse  *fakes.ErrorResponse
+		logger             *lagertest.TestLogger
+		expectedLogger     lager.Logger
+		marshaler          *hfakes.Marshaler
+	)
+
+	BeforeEach(func() {
+		tombstones = []store.Tombstone{{
+			ID:   1,
+			Type: "c2c",
+			Policy: &store.Policy{
+				Source:      store.Source{ID: "app-a"},
+				Destination: store.Destination{ID: "app-b", Protocol: "tcp", Ports: store.Ports{Start: 8080, End: 8080}},
+			},
+		}, {
+			ID:   2,
+			Type: "egress",
+			EgressPolicy: &store.EgressPolicy{
+				Source:      store.EgressSource{ID: "app-c", Type: "app"},
+				Destination: store.EgressDestination{GUID: "destination-guid"},
+				Action:      "allow",
+			},
+		}}
+
+		var err error
+		request, err = http.NewRequest("POST", "/networking/v1/external/tombstones/restore", bytes.NewBuffer([]byte(`{"ids": [1, 2]}`)))
+		Expect(err).NotTo(HaveOccurred())
+
+		marshaler = &hfakes.Marshaler{}
+		marshaler.MarshalStub = json.Marshal
+
+		fakeTombstoneStore = &storeFakes.TombstoneStore{}
+		fakeTombstoneStore.RestoreReturns(store.TombstoneRestore{Restored: tombstones, Skipped: []store.Tombstone{}}, nil)
+		fakeErrorResponse = &fakes.ErrorResponse{}
+		logger = lagertest.NewTestLogger("test")
+		expectedLogger = lager.NewLogger("test").Session("restore-tombstones")
+
+		testSink := lagertest.NewTestSink()
+		expectedLogger.RegisterSink(testSink)
+		expectedLogger.RegisterSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG))
+		handler = &handlers.TombstonesRestore{
+			TombstoneStore: fakeTombstoneStore,
+			Unmarshaler:    marshal.UnmarshalFunc(json.Unmarshal),
+			Marshaler:      marshaler,
+			ErrorResponse:  fakeErrorResponse,
+		}
+		resp = httptest.NewRecorder()
+	})
+
+	It("restores the tombstones", func() {
+		MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+		Expect(fakeTombstoneStore.RestoreCallCount()).To(Equal(1))
+		_, ids := fakeTombstoneStore.RestoreArgsForCall(0)
+		Expect(ids).To(Equal([]int{1, 2}))
+
+		Expect(resp.Code).To(Equal(http.StatusOK))
+		var body struct {
+			TotalTombstones   int               `json:"total_tombstones"`
+			Tombstones        []json.RawMessage `json:"tombstones"`
+			SkippedTombstones []json.RawMessage `json:"skipped_tombstones"`
+		}
+		Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+		Expect(body.TotalTombstones).To(Equal(2))
+		Expect(body.Tombstones).To(HaveLen(2))
+		Expect(body.SkippedTombstones).To(BeEmpty())
+	})
+
+	Context("when some tombstones conflict with the current policies", func() {
+		BeforeEach(func() {
+			fakeTombstoneStore.RestoreReturns(store.TombstoneRestore{Restored: tombstones[:1], Skipped: tombstones[1:]}, nil)
+		})
+
+		It("reports them as skipped", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(resp.Code).To(Equal(http.StatusOK))
+			var body struct {
+				TotalTombstones   int `json:"total_tombstones"`
+				SkippedTombstones []struct {
+					ID int `json:"id"`
+				} `json:"skipped_tombstones"`
+			}
+			Expect(json.Unmarshal(resp.Body.Bytes(), &body)).To(Succeed())
+			Expect(body.TotalTombstones).To(Equal(1))
+			Expect(body.SkippedTombstones).To(HaveLen(1))
+			Expect(body.SkippedTombstones[0].ID).To(Equal(2))
+		})
+	})
+
+	Context("when the request body is invalid", func() {
+		BeforeEach(func() {
+			request.Body = ioutil.NopCloser(strings.NewReader(`{"ids": "potato"}`))
+		})
+
+		It("calls the bad request handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			_, _, _, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(description).To(Equal("invalid values passed to API"))
+			Expect(fakeTombstoneStore.RestoreCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when no ids are given", func() {
+		BeforeEach(func() {
+			request.Body = ioutil.NopCloser(strings.NewReader(`{"ids": []}`))
+		})
+
+		It("calls the bad request handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.BadRequestCallCount()).To(Equal(1))
+			_, _, err, description := fakeErrorResponse.BadRequestArgsForCall(0)
+			Expect(err).To(MatchError("missing tombstone ids"))
+			Expect(description).To(Equal("missing tombstone ids"))
+		})
+	})
+
+	Context("when restoring the tombstones fails", func() {
+		BeforeEach(func() {
+			fakeTombstoneStore.RestoreReturns(store.TombstoneRestore{}, errors.New("banana"))
+		})
+
+		It("calls the internal server error handler", func() {
+			MakeRequestWithLogger(handler.ServeHTTP, resp, request, logger)
+
+			Expect(fakeErrorResponse.InternalServerErrorCallCount()).To(Equal(1))
+			l, w, err, description := fakeErrorResponse.InternalServerErrorArgsForCall(0)
+			Expect(l).To(Equal(expectedLogger))
+			Expect(w).To(Equal(resp))
+			Expect(err).To(MatchError("banana"))
+			Expect(description).To(Equal("database restore failed"))
+		})
+	})
+})
@@ -0,0 +1,169 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/migrations"
+	"time"
+)
+
+// Check is a single dependency reported by the detailed health endpoint.
+// Run returns details to include in the report, and an error when the
+// dependency is unhealthy.
+type Check struct {
+	Name string
+	Run  func(context.Context) (interface{}, error)
+}
+
+//go:generate counterfeiter -o fakes/database.go --fake-name Database . database
+type database interface {
+	CheckDatabase(context.Context) error
+}
+
+//go:generate counterfeiter -o fakes/connection_pool.go --fake-name ConnectionPool . connectionPool
+type connectionPool interface {
+	OpenConnections() int
+}
+
+func NewDatabaseCheck(db database, pool connectionPool, maxOpenConnections, maxIdleConnections int) Check {
+	return Check{
+		Name: "database",
+		Run: func(ctx context.Context) (interface{}, error) {
+			details := map[string]int{
+				"open_connections":     pool.OpenConnections(),
+				"max_open_connections": maxOpenConnections,
+				"max_idle_connections": maxIdleConnections,
+			}
+			return details, db.CheckDatabase(ctx)
+		},
+	}
+}
+
+//go:generate counterfeiter -o fakes/migration_statuser.go --fake-name MigrationStatuser . migrationStatuser
+type migrationStatuser interface {
+	MigrationStatus(driverName string, migrationDb migrations.MigrationDb) ([]migrations.MigrationStatus, error)
+}
+
+// NewMigrationsCheck is unhealthy while any known migration has not been
+// applied, e.g. while a deploy is rolling out a new schema.
+func NewMigrationsCheck(migrator migrationStatuser, migrationDb migrations.MigrationDb, driverName string) Check {
+	return Check{
+		Name: "migrations",
+		Run: func(ctx context.Context) (interface{}, error) {
+			statuses, err := migrator.MigrationStatus(driverName, migrationDb)
+			if err != nil {
+				return nil, err
+			}
+
+			details := struct {
+				Applied       int      `json:"applied"`
+				LatestApplied string   `json:"latest_applied"`
+				Pending       []string `json:"pending"`
+			}{Pending: []string{}}
+			for _, status := range statuses {
+				if status.Applied {
+					details.Applied++
+					details.LatestApplied = status.Id
+				} else {
+					details.Pending = append(details.Pending, status.Id)
+				}
+			}
+
+			if len(details.Pending) > 0 {
+				return details, fmt.Errorf("%d migrations have not been applied", len(details.Pending))
+			}
+			return details, nil
+		},
+	}
+}
+
+//go:generate counterfeiter -o fakes/uaa_client.go --fake-name UAAClient . uaaClient
+type uaaClient interface {
+	GetToken(context.Context) (string, error)
+}
+
+func NewUAACheck(uaaClient uaaClient) Check {
+	return Check{
+		Name: "uaa",
+		Run: func(ctx context.Context) (interface{}, error) {
+			_, err := uaaClient.GetToken(ctx)
+			return nil, err
+		},
+	}
+}
+
+//go:generate counterfeiter -o fakes/cc_client.go --fake-name CCClient . ccClient
+type ccClient interface {
+	Ping(context.Context, string) error
+}
+
+// NewCCCheck needs a UAA token to reach Cloud Controller, so it is also
+// unhealthy when UAA is.
+func NewCCCheck(uaaClient uaaClient, ccClient ccClient) Check {
+	return Check{
+		Name: "cloud_controller",
+		Run: func(ctx context.Context) (interface{}, error) {
+			token, err := uaaClient.GetToken(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("get UAA token failed: %s", err)
+			}
+			return nil, ccClient.Ping(ctx, token)
+		},
+	}
+}
+
+//go:generate counterfeiter -o fakes/cleaner.go --fake-name Cleaner . cleaner
+type cleaner interface {
+	LastSuccessfulRun() time.Time
+}
+
+// NewCleanerCheck is unhealthy when the policy cleaner has not completed a
+// run within maxAge, counting from when the check was created if it has
+// never completed one.
+func NewCleanerCheck(cleaner cleaner, maxAge time.Duration) Check {
+	created := time.Now()
+	return Check{
+		Name: "policy_cleaner",
+		Run: func(ctx context.Context) (interface{}, error) {
+			details := struct {
+				LastSuccessfulRun *time.Time `json:"last_successful_run"`
+			}{}
+
+			since := created
+			if lastRun := cleaner.LastSuccessfulRun(); !lastRun.IsZero() {
+				details.LastSuccessfulRun = &lastRun
+				since = lastRun
+			}
+
+			if time.Since(since) > maxAge {
+				return details, fmt.Errorf("no successful run in the last %s", maxAge)
+			}
+			return details, nil
+		},
+	}
+}
+
+//go:generate counterfeiter -o fakes/policy_counter.go --fake-name PolicyCounter . policyCounter
+type policyCounter interface {
+	PolicyCounts(context.Context) (store.PolicyCounts, error)
+}
+
+// NewPolicyCountsCheck reports how many c2c and egress policies there are.
+// The database counts them, so the check stays cheap however many there are.
+func NewPolicyCountsCheck(counter policyCounter) Check {
+	return Check{
+		Name: "policy_counts",
+		Run: func(ctx context.Context) (interface{}, error) {
+			counts, err := counter.PolicyCounts(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("count policies: %s", err)
+			}
+
+			return map[string]int{
+				"c2c":    counts.C2C,
+				"egress": counts.Egress,
+			}, nil
+		},
+	}
+}
@@ -0,0 +1,254 @@
+package health_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"policy-server/health"
+	"policy-server/health/fakes"
+	"policy-server/store"
+	"policy-server/store/migrations"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NewDatabaseCheck", func() {
+	var (
+		fakeDatabase       *fakes.Database
+		fakeConnectionPool *fakes.ConnectionPool
+		check              health.Check
+	)
+
+	BeforeEach(func() {
+		fakeDatabase = &fakes.Database{}
+		fakeConnectionPool = &fakes.ConnectionPool{}
+		fakeConnectionPool.OpenConnectionsReturns(3)
+		check = health.NewDatabaseCheck(fakeDatabase, fakeConnectionPool, 200, 10)
+	})
+
+	It("checks the database and reports the pool stats", func() {
+		Expect(check.Name).To(Equal("database"))
+
+		details, err := check.Run(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakeDatabase.CheckDatabaseCallCount()).To(Equal(1))
+		Expect(details).To(Equal(map[string]int{
+			"open_connections":     3,
+			"max_open_connections": 200,
+			"max_idle_connections": 10,
+		}))
+	})
+
+	Context("when the database check fails", func() {
+		BeforeEach(func() {
+			fakeDatabase.CheckDatabaseReturns(errors.New("banana"))
+		})
+
+		It("returns the error along with the pool stats", func() {
+			details, err := check.Run(context.Background())
+			Expect(err).To(MatchError("banana"))
+			Expect(details).To(HaveKeyWithValue("open_connections", 3))
+		})
+	})
+})
+
+var _ = Describe("NewMigrationsCheck", func() {
+	var (
+		fakeMigrator *fakes.MigrationStatuser
+		check        health.Check
+	)
+
+	BeforeEach(func() {
+		fakeMigrator = &fakes.MigrationStatuser{}
+		fakeMigrator.MigrationStatusReturns([]migrations.MigrationStatus{
+			{Id: "1", Applied: true},
+			{Id: "2", Applied: true},
+		}, nil)
+		check = health.NewMigrationsCheck(fakeMigrator, nil, "mysql")
+	})
+
+	It("reports the latest applied migration", func() {
+		Expect(check.Name).To(Equal("migrations"))
+
+		details, err := check.Run(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(toJSON(details)).To(MatchJSON(`{"applied": 2, "latest_applied": "2", "pending": []}`))
+
+		driverName, _ := fakeMigrator.MigrationStatusArgsForCall(0)
+		Expect(driverName).To(Equal("mysql"))
+	})
+
+	Context("when migrations are pending", func() {
+		BeforeEach(func() {
+			fakeMigrator.MigrationStatusReturns([]migrations.MigrationStatus{
+				{Id: "1", Applied: true},
+				{Id: "2", Applied: false},
+			}, nil)
+		})
+
+		It("is unhealthy", func() {
+			details, err := check.Run(context.Background())
+			Expect(err).To(MatchError("1 migrations have not been applied"))
+			Expect(toJSON(details)).To(MatchJSON(`{"applied": 1, "latest_applied": "1", "pending": ["2"]}`))
+		})
+	})
+
+	Context("when getting the migration status fails", func() {
+		BeforeEach(func() {
+			fakeMigrator.MigrationStatusReturns(nil, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			_, err := check.Run(context.Background())
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+})
+
+var _ = Describe("NewUAACheck", func() {
+	It("gets a token from UAA", func() {
+		fakeUAAClient := &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("", errors.New("banana"))
+
+		check := health.NewUAACheck(fakeUAAClient)
+		Expect(check.Name).To(Equal("uaa"))
+
+		_, err := check.Run(context.Background())
+		Expect(err).To(MatchError("banana"))
+		Expect(fakeUAAClient.GetTokenCallCount()).To(Equal(1))
+	})
+})
+
+var _ = Describe("NewCCCheck", func() {
+	var (
+		fakeUAAClient *fakes.UAAClient
+		fakeCCClient  *fakes.CCClient
+		check         health.Check
+	)
+
+	BeforeEach(func() {
+		fakeUAAClient = &fakes.UAAClient{}
+		fakeUAAClient.GetTokenReturns("some-token", nil)
+		fakeCCClient = &fakes.CCClient{}
+		check = health.NewCCCheck(fakeUAAClient, fakeCCClient)
+	})
+
+	It("pings cloud controller with a UAA token", func() {
+		Expect(check.Name).To(Equal("cloud_controller"))
+
+		_, err := check.Run(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, token := fakeCCClient.PingArgsForCall(0)
+		Expect(token).To(Equal("some-token"))
+	})
+
+	Context("when the ping fails", func() {
+		BeforeEach(func() {
+			fakeCCClient.PingReturns(errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			_, err := check.Run(context.Background())
+			Expect(err).To(MatchError("banana"))
+		})
+	})
+
+	Context("when getting a token fails", func() {
+		BeforeEach(func() {
+			fakeUAAClient.GetTokenReturns("", errors.New("banana"))
+		})
+
+		It("does not ping cloud controller", func() {
+			_, err := check.Run(context.Background())
+			Expect(err).To(MatchError("get UAA token failed: banana"))
+			Expect(fakeCCClient.PingCallCount()).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("NewCleanerCheck", func() {
+	var fakeCleaner *fakes.Cleaner
+
+	BeforeEach(func() {
+		fakeCleaner = &fakes.Cleaner{}
+	})
+
+	It("reports the last successful run", func() {
+		lastRun := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+		fakeCleaner.LastSuccessfulRunReturns(lastRun)
+
+		check := health.NewCleanerCheck(fakeCleaner, time.Since(lastRun)+time.Hour)
+		Expect(check.Name).To(Equal("policy_cleaner"))
+
+		details, err := check.Run(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(toJSON(details)).To(MatchJSON(`{"last_successful_run": "2020-01-02T03:04:05Z"}`))
+	})
+
+	Context("when the last successful run is too old", func() {
+		It("is unhealthy", func() {
+			fakeCleaner.LastSuccessfulRunReturns(time.Now().Add(-2 * time.Hour))
+
+			_, err := health.NewCleanerCheck(fakeCleaner, time.Hour).Run(context.Background())
+			Expect(err).To(MatchError("no successful run in the last 1h0m0s"))
+		})
+	})
+
+	Context("when the cleaner has not run yet", func() {
+		It("is healthy until maxAge has passed", func() {
+			details, err := health.NewCleanerCheck(fakeCleaner, time.Hour).Run(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(toJSON(details)).To(MatchJSON(`{"last_successful_run": null}`))
+
+			check := health.NewCleanerCheck(fakeCleaner, 10*time.Millisecond)
+			Eventually(func() error {
+				_, err := check.Run(context.Background())
+				return err
+			}).Should(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("NewPolicyCountsCheck", func() {
+	var (
+		fakePolicyCounter *fakes.PolicyCounter
+		check             health.Check
+	)
+
+	BeforeEach(func() {
+		fakePolicyCounter = &fakes.PolicyCounter{}
+		fakePolicyCounter.PolicyCountsReturns(store.PolicyCounts{C2C: 2, Egress: 1}, nil)
+		check = health.NewPolicyCountsCheck(fakePolicyCounter)
+	})
+
+	It("counts the c2c and egress policies", func() {
+		Expect(check.Name).To(Equal("policy_counts"))
+
+		details, err := check.Run(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(details).To(Equal(map[string]int{"c2c": 2, "egress": 1}))
+	})
+
+	It("counts within the check's context", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		_, err := check.Run(ctx)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fakePolicyCounter.PolicyCountsArgsForCall(0)).To(Equal(ctx))
+	})
+
+	Context("when counting the policies fails", func() {
+		BeforeEach(func() {
+			fakePolicyCounter.PolicyCountsReturns(store.PolicyCounts{}, errors.New("banana"))
+		})
+
+		It("returns the error", func() {
+			_, err := check.Run(context.Background())
+			Expect(err).To(MatchError("count policies: banana"))
+		})
+	})
+})
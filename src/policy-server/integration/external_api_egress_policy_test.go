@@ -132,9 +132,25 @@ var _ = Describe("External API Egress Policies", func() {
 					IPs:         []psclient.IPRange{{Start: "1.2.3.4", End: "1.2.3.5"}},
 					Ports:       []psclient.Port{{Start: 8080, End: 9090}},
 				},
+				Action: "allow",
 			},
 		))
 
+		By("filtering the egress policies")
+		egressPolicyList, err = client.ListEgressPoliciesWithFilter(token, psclient.EgressPolicyFilter{
+			SourceIDs:        []string{"live-app-1-guid"},
+			DestinationNames: []string{"tcp with ports"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressPolicyList.EgressPolicies).To(HaveLen(1))
+		Expect(egressPolicyList.EgressPolicies[0].GUID).To(Equal(policyGUID))
+
+		egressPolicyList, err = client.ListEgressPoliciesWithFilter(token, psclient.EgressPolicyFilter{
+			DestinationIDs: []string{createdDestinations[1].GUID},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressPolicyList.EgressPolicies).To(HaveLen(0))
+
 		deletedDestination, err := client.DeleteDestination(token, createdDestinations[0])
 		Expect(err).To(HaveOccurred(), "expected the delete to fail because this destination still has associated egress policy")
 		Expect(err).To(MatchError(ContainSubstring("destination is still in use")))
@@ -142,6 +158,7 @@ var _ = Describe("External API Egress Policies", func() {
 		deletedEgressPolicy, err := client.DeleteEgressPolicy(policyGUID, token)
 		Expect(err).NotTo(HaveOccurred())
 		somePolicy.GUID = policyGUID
+		somePolicy.Action = "allow"
 		Expect(somePolicy).To(Equal(deletedEgressPolicy))
 
 		egressPolicyList, err = client.ListEgressPolicies(token)
@@ -0,0 +1,105 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"policy-server/api"
+	"policy-server/config"
+	"policy-server/integration/helpers"
+	"strings"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/metrics"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/ports"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("External API Stats", func() {
+	var (
+		sessions          []*gexec.Session
+		conf              config.Config
+		policyServerConfs []config.Config
+		dbConf            db.Config
+
+		fakeMetron metrics.FakeMetron
+	)
+
+	BeforeEach(func() {
+		fakeMetron = metrics.NewFakeMetron()
+
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("external_api_stats_test_node_%d", ports.PickAPort())
+
+		template, _ := helpers.DefaultTestConfig(dbConf, fakeMetron.Address(), "fixtures")
+		policyServerConfs = configurePolicyServers(template, 1)
+		sessions = startPolicyServers(policyServerConfs)
+		conf = policyServerConfs[0]
+
+		body := strings.NewReader(`{ "policies": [
+			{"source": { "id": "some-app-guid" }, "destination": { "id": "some-other-app-guid", "protocol": "tcp", "ports": { "start": 8090, "end": 8090 } } },
+			{"source": { "id": "some-app-guid" }, "destination": { "id": "another-app-guid", "protocol": "udp", "ports": { "start": 6666, "end": 6666 } } },
+			{"source": { "id": "another-app-guid" }, "destination": { "id": "some-app-guid", "protocol": "tcp", "ports": { "start": 3333, "end": 3333 } } }
+			] }`)
+		resp := helpers.MakeAndDoRequest(
+			"POST",
+			fmt.Sprintf("http://%s:%d/networking/v1/external/policies", conf.ListenHost, conf.ListenPort),
+			nil,
+			body,
+		)
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+	})
+
+	AfterEach(func() {
+		stopPolicyServers(sessions, policyServerConfs)
+
+		Expect(fakeMetron.Close()).To(Succeed())
+	})
+
+	It("reports aggregate policy stats", func() {
+		resp := helpers.MakeAndDoRequest(
+			"GET",
+			fmt.Sprintf("http://%s:%d/networking/v1/external/stats?top=1", conf.ListenHost, conf.ListenPort),
+			nil,
+			nil,
+		)
+
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+		var stats api.Stats
+		Expect(json.NewDecoder(resp.Body).Decode(&stats)).To(Succeed())
+
+		Expect(stats.TotalPolicies).To(Equal(3))
+		Expect(stats.PoliciesPerApp.Count).To(Equal(3))
+		Expect(stats.PoliciesPerApp.Max).To(Equal(3))
+		Expect(stats.PoliciesPerApp.Top).To(Equal([]api.GUIDCount{{GUID: "some-app-guid", Policies: 3}}))
+		Expect(stats.FanOut.Top).To(Equal([]api.GUIDCount{{GUID: "some-app-guid", Policies: 2}}))
+		Expect(stats.FanIn.Count).To(Equal(3))
+		Expect(stats.Tags.TagLength).To(Equal(1))
+		Expect(stats.Tags.Max).To(Equal(255))
+		Expect(stats.Tags.Used).To(Equal(3))
+		Expect(stats.Tags.UsedByType).To(Equal(map[string]int{"app": 3}))
+
+		Eventually(fakeMetron.AllEvents, "5s").Should(ContainElement(
+			HaveName("StatsIndexRequestTime"),
+		))
+	})
+
+	It("is not available on v0", func() {
+		resp := helpers.MakeAndDoRequest(
+			"GET",
+			fmt.Sprintf("http://%s:%d/networking/v0/external/stats", conf.ListenHost, conf.ListenPort),
+			nil,
+			nil,
+		)
+
+		Expect(resp.StatusCode).To(Equal(http.StatusNotAcceptable))
+		responseString, err := ioutil.ReadAll(resp.Body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(responseString).To(ContainSubstring("api version"))
+	})
+})
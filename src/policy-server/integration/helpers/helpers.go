@@ -65,6 +65,30 @@ var MockCCServer = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWrite
 		return
 	}
 
+	if r.URL.Path == "/v3/organizations" {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fixtures.LiveOrgsPage2))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fixtures.LiveOrgsPage1))
+		return
+	}
+
+	if r.URL.Path == "/v3/security_groups" {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(fixtures.SecurityGroupsPage2))
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fixtures.SecurityGroupsPage1))
+		return
+	}
+
 	if r.URL.Path == "/v2/spaces/space-1-guid" {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(fixtures.Space1))
@@ -307,3 +331,26 @@ func RunMigrationsPreStartBinary(pathToMigrationBinary string, conf config.Confi
 	Expect(err).NotTo(HaveOccurred())
 	return session
 }
+
+func RunMigrationsCommand(pathToMigrationBinary string, conf config.Config, args ...string) *gexec.Session {
+	configFilePath := WriteConfigFile(conf)
+
+	startCmd := exec.Command(pathToMigrationBinary, append([]string{"-config-file", configFilePath}, args...)...)
+	session, err := gexec.Start(startCmd, GinkgoWriter, GinkgoWriter)
+	Expect(err).NotTo(HaveOccurred())
+	return session
+}
+
+func RunMigrateAsgsBinary(pathToMigrateAsgsBinary string, conf config.Config, dryRun bool) *gexec.Session {
+	configFilePath := WriteConfigFile(conf)
+
+	args := []string{"-config-file", configFilePath}
+	if dryRun {
+		args = append(args, "-dry-run")
+	}
+
+	startCmd := exec.Command(pathToMigrateAsgsBinary, args...)
+	session, err := gexec.Start(startCmd, GinkgoWriter, GinkgoWriter)
+	Expect(err).NotTo(HaveOccurred())
+	return session
+}
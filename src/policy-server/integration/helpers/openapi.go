@@ -0,0 +1,340 @@
+package helpers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// OpenAPISpec checks responses from the policy servers against the schemas in
+// an OpenAPI 3 document. It understands the subset of JSON schema used by
+// docs/openapi: type, properties, required, additionalProperties, items,
+// enum, nullable, $ref and the date-time format.
+type OpenAPISpec struct {
+	doc map[string]interface{}
+}
+
+func LoadOpenAPISpec(path string) (*OpenAPISpec, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read spec: %s", err)
+	}
+
+	var raw interface{}
+	err = yaml.Unmarshal(contents, &raw)
+	if err != nil {
+		return nil, fmt.Errorf("unmarshal spec: %s", err)
+	}
+
+	doc, ok := normalizeYAML(raw).(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("spec %s is not a yaml mapping", path)
+	}
+	if _, ok := doc["paths"].(map[string]interface{}); !ok {
+		return nil, fmt.Errorf("spec %s has no paths", path)
+	}
+	return &OpenAPISpec{doc: doc}, nil
+}
+
+// ValidateResponse checks that the status code of resp is documented for its
+// request's method and path, and that the body matches the documented
+// schema. The body is replaced so that callers can still read it.
+func (s *OpenAPISpec) ValidateResponse(resp *http.Response) error {
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read body: %s", err)
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	method := strings.ToLower(resp.Request.Method)
+	path := resp.Request.URL.Path
+	route := fmt.Sprintf("%s %s", resp.Request.Method, path)
+
+	pathItem, err := s.findPath(path)
+	if err != nil {
+		return err
+	}
+	operation, ok := pathItem[method].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s: method is not documented", route)
+	}
+
+	responses, _ := operation["responses"].(map[string]interface{})
+	response, ok := responses[strconv.Itoa(resp.StatusCode)]
+	if !ok {
+		response, ok = responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("%s: status %d is not documented", route, resp.StatusCode)
+	}
+	responseObject, err := s.resolve(response)
+	if err != nil {
+		return fmt.Errorf("%s: %s", route, err)
+	}
+
+	content, _ := responseObject["content"].(map[string]interface{})
+	if len(content) == 0 {
+		if len(bytes.TrimSpace(body)) != 0 {
+			return fmt.Errorf("%s: status %d is documented without a body, got %q", route, resp.StatusCode, body)
+		}
+		return nil
+	}
+
+	mediaType, ok := content["application/json"].(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value interface{}
+	err = decoder.Decode(&value)
+	if err != nil {
+		return fmt.Errorf("%s: body is not json: %s", route, err)
+	}
+
+	err = s.validate(mediaType["schema"], value, "$")
+	if err != nil {
+		return fmt.Errorf("%s: status %d: %s", route, resp.StatusCode, err)
+	}
+	return nil
+}
+
+// findPath returns the path item whose template matches path, preferring the
+// template with the fewest parameters.
+func (s *OpenAPISpec) findPath(path string) (map[string]interface{}, error) {
+	paths := s.doc["paths"].(map[string]interface{})
+	segments := strings.Split(path, "/")
+
+	var templates []string
+	for template := range paths {
+		templates = append(templates, template)
+	}
+	sort.Strings(templates)
+
+	var match map[string]interface{}
+	fewestParams := -1
+	for _, template := range templates {
+		templateSegments := strings.Split(template, "/")
+		if len(templateSegments) != len(segments) {
+			continue
+		}
+
+		params := 0
+		matched := true
+		for i, segment := range templateSegments {
+			if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+				params++
+				continue
+			}
+			if segment != segments[i] {
+				matched = false
+				break
+			}
+		}
+		if !matched || (fewestParams != -1 && params >= fewestParams) {
+			continue
+		}
+
+		pathItem, ok := paths[template].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("path %s is not a mapping", template)
+		}
+		match = pathItem
+		fewestParams = params
+	}
+
+	if match == nil {
+		return nil, fmt.Errorf("path %s is not documented", path)
+	}
+	return match, nil
+}
+
+// resolve follows a local $ref, such as "#/components/schemas/Policy".
+func (s *OpenAPISpec) resolve(node interface{}) (map[string]interface{}, error) {
+	object, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected a mapping, got %v", node)
+	}
+
+	for seen := 0; ; seen++ {
+		ref, ok := object["$ref"].(string)
+		if !ok {
+			return object, nil
+		}
+		if seen > 32 {
+			return nil, fmt.Errorf("too many references resolving %s", ref)
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("unsupported reference %s", ref)
+		}
+
+		var current interface{} = s.doc
+		for _, key := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			parent, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("unresolvable reference %s", ref)
+			}
+			current, ok = parent[key]
+			if !ok {
+				return nil, fmt.Errorf("unresolvable reference %s", ref)
+			}
+		}
+
+		object, ok = current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("reference %s is not a mapping", ref)
+		}
+	}
+}
+
+func (s *OpenAPISpec) validate(node interface{}, value interface{}, pointer string) error {
+	schema, err := s.resolve(node)
+	if err != nil {
+		return fmt.Errorf("%s: %s", pointer, err)
+	}
+
+	if value == nil {
+		if nullable, _ := schema["nullable"].(bool); nullable || schema["type"] == nil {
+			return nil
+		}
+		return fmt.Errorf("%s: is null", pointer)
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: %v is not one of %v", pointer, value, enum)
+		}
+	}
+
+	switch schema["type"] {
+	case nil:
+		return nil
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected object, got %v", pointer, value)
+		}
+		return s.validateObject(schema, object, pointer)
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("%s: expected array, got %v", pointer, value)
+		}
+		for i, item := range array {
+			err := s.validate(schema["items"], item, fmt.Sprintf("%s[%d]", pointer, i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s: expected string, got %v", pointer, value)
+		}
+		if schema["format"] == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				return fmt.Errorf("%s: %q is not a date-time", pointer, str)
+			}
+		}
+		return nil
+	case "integer":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fmt.Errorf("%s: expected integer, got %v", pointer, value)
+		}
+		if _, err := number.Int64(); err != nil {
+			return fmt.Errorf("%s: expected integer, got %s", pointer, number)
+		}
+		return nil
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			return fmt.Errorf("%s: expected number, got %v", pointer, value)
+		}
+		return nil
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s: expected boolean, got %v", pointer, value)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%s: unsupported schema type %v", pointer, schema["type"])
+	}
+}
+
+func (s *OpenAPISpec) validateObject(schema map[string]interface{}, object map[string]interface{}, pointer string) error {
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if _, ok := object[fmt.Sprint(name)]; !ok {
+			return fmt.Errorf("%s: missing required property %s", pointer, name)
+		}
+	}
+
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPointer := fmt.Sprintf("%s.%s", pointer, name)
+		if property, ok := properties[name]; ok {
+			err := s.validate(property, object[name], propertyPointer)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				return fmt.Errorf("%s: property is not documented", propertyPointer)
+			}
+		case map[string]interface{}:
+			err := s.validate(additional, object[name], propertyPointer)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeYAML converts the map[interface{}]interface{} values produced by
+// yaml.v2 into map[string]interface{}, to match decoded json.
+func normalizeYAML(node interface{}) interface{} {
+	switch n := node.(type) {
+	case map[interface{}]interface{}:
+		object := make(map[string]interface{}, len(n))
+		for key, value := range n {
+			object[fmt.Sprint(key)] = normalizeYAML(value)
+		}
+		return object
+	case []interface{}:
+		for i, value := range n {
+			n[i] = normalizeYAML(value)
+		}
+		return n
+	default:
+		return n
+	}
+}
@@ -24,12 +24,14 @@ var (
 	policyServerPath         string
 	policyServerInternalPath string
 	migrateDbPath            string
+	migrateAsgsPath          string
 )
 
 type policyServerPaths struct {
-	Internal  string
-	External  string
-	MigrateDb string
+	Internal    string
+	External    string
+	MigrateDb   string
+	MigrateAsgs string
 }
 
 var HaveName = func(name string) types.GomegaMatcher {
@@ -75,6 +77,11 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	fmt.Fprint(GinkgoWriter, "done")
 	Expect(err).NotTo(HaveOccurred())
 
+	fmt.Fprint(GinkgoWriter, "building migrate-asgs binary...")
+	paths.MigrateAsgs, err = gexec.Build("policy-server/cmd/migrate-asgs", "-race")
+	fmt.Fprint(GinkgoWriter, "done")
+	Expect(err).NotTo(HaveOccurred())
+
 	data, err := json.Marshal(paths)
 	Expect(err).NotTo(HaveOccurred())
 	return data
@@ -86,6 +93,7 @@ var _ = SynchronizedBeforeSuite(func() []byte {
 	policyServerPath = paths.External
 	policyServerInternalPath = paths.Internal
 	migrateDbPath = paths.MigrateDb
+	migrateAsgsPath = paths.MigrateAsgs
 
 	rand.Seed(ginkgoConfig.GinkgoConfig.RandomSeed + int64(GinkgoParallelNode()))
 })
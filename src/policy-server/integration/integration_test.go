@@ -1,8 +1,10 @@
 package integration_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	libtestsupport "lib/testsupport"
 	"net/http"
 	"os/exec"
 	"policy-server/config"
@@ -89,6 +91,44 @@ var _ = Describe("Integration", func() {
 				))
 			})
 
+			It("reports the status of every dependency on /health/detailed", func() {
+				resp := helpers.MakeAndDoRequest(
+					"GET",
+					fmt.Sprintf("http://%s:%d/health/detailed", conf.ListenHost, conf.ListenPort),
+					headers,
+					nil,
+				)
+
+				Expect(resp.StatusCode).To(Equal(http.StatusOK))
+				var body struct {
+					Healthy bool `json:"healthy"`
+					Checks  []struct {
+						Name    string `json:"name"`
+						Healthy bool   `json:"healthy"`
+					} `json:"checks"`
+				}
+				Expect(json.NewDecoder(resp.Body).Decode(&body)).To(Succeed())
+				Expect(body.Healthy).To(BeTrue())
+
+				var names []string
+				for _, check := range body.Checks {
+					Expect(check.Healthy).To(BeTrue(), check.Name)
+					names = append(names, check.Name)
+				}
+				Expect(names).To(Equal([]string{"database", "migrations", "uaa", "cloud_controller", "policy_cleaner", "policy_counts"}))
+			})
+
+			It("requires a network.admin token on /health/detailed", func() {
+				resp := helpers.MakeAndDoRequest(
+					"GET",
+					fmt.Sprintf("http://%s:%d/health/detailed", conf.ListenHost, conf.ListenPort),
+					nil,
+					nil,
+				)
+
+				Expect(resp.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
 			It("has a whoami endpoint", func() {
 				resp := helpers.MakeAndDoRequest(
 					"GET",
@@ -162,6 +202,173 @@ var _ = Describe("Integration", func() {
 		})
 	})
 
+	Context("with the prometheus listener enabled", func() {
+		var (
+			sessions          []*gexec.Session
+			conf              config.Config
+			policyServerConfs []config.Config
+			fakeMetron        metrics.FakeMetron
+		)
+
+		BeforeEach(func() {
+			fakeMetron = metrics.NewFakeMetron()
+
+			dbConf := testsupport.GetDBConfig()
+			dbConf.DatabaseName = fmt.Sprintf("integration_test_node_%d", ports.PickAPort())
+
+			template, _ := helpers.DefaultTestConfig(dbConf, fakeMetron.Address(), "fixtures")
+			template.PrometheusListenAddress = "127.0.0.1"
+			template.PrometheusListenPort = ports.PickAPort()
+			policyServerConfs = configurePolicyServers(template, 1)
+			sessions = startPolicyServers(policyServerConfs)
+			conf = policyServerConfs[0]
+		})
+
+		AfterEach(func() {
+			stopPolicyServers(sessions, policyServerConfs)
+
+			Expect(fakeMetron.Close()).To(Succeed())
+		})
+
+		It("serves request times and gauges on /metrics", func() {
+			resp := helpers.MakeAndDoRequest(
+				"GET",
+				fmt.Sprintf("http://%s:%d/networking/v0/external/whoami", conf.ListenHost, conf.ListenPort),
+				nil,
+				nil,
+			)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			resp = helpers.MakeAndDoRequest(
+				"GET",
+				fmt.Sprintf("http://%s:%d/metrics", conf.PrometheusListenAddress, conf.PrometheusListenPort),
+				nil,
+				nil,
+			)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			responseString, err := ioutil.ReadAll(resp.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(responseString)).To(ContainSubstring(`policy_server_request_duration_seconds_count{route="who_am_i"} 1`))
+			Expect(string(responseString)).To(ContainSubstring("policy_server_total_policies 0"))
+			Expect(string(responseString)).To(ContainSubstring("policy_server_uptime "))
+
+			Eventually(fakeMetron.AllEvents, "5s").Should(ContainElement(
+				HaveName("WhoAmIRequestTime"),
+			))
+		})
+	})
+
+	Context("with a drain wait configured", func() {
+		var (
+			sessions          []*gexec.Session
+			conf              config.Config
+			policyServerConfs []config.Config
+			fakeMetron        metrics.FakeMetron
+		)
+
+		BeforeEach(func() {
+			fakeMetron = metrics.NewFakeMetron()
+
+			dbConf := testsupport.GetDBConfig()
+			dbConf.DatabaseName = fmt.Sprintf("integration_test_node_%d", ports.PickAPort())
+
+			template, _ := helpers.DefaultTestConfig(dbConf, fakeMetron.Address(), "fixtures")
+			template.DrainWaitSeconds = 2
+			template.DrainTimeoutSeconds = 1
+			policyServerConfs = configurePolicyServers(template, 1)
+			sessions = startPolicyServers(policyServerConfs)
+			conf = policyServerConfs[0]
+		})
+
+		AfterEach(func() {
+			stopPolicyServers(sessions, policyServerConfs)
+
+			Expect(fakeMetron.Close()).To(Succeed())
+		})
+
+		It("fails the health check while draining before exiting", func() {
+			healthStatus := func() int {
+				resp := helpers.MakeAndDoRequest(
+					"GET",
+					fmt.Sprintf("http://%s:%d/health", conf.ListenHost, conf.ListenPort),
+					nil,
+					nil,
+				)
+				return resp.StatusCode
+			}
+			Expect(healthStatus()).To(Equal(http.StatusOK))
+
+			sessions[0].Interrupt()
+			Eventually(healthStatus).Should(Equal(http.StatusServiceUnavailable))
+			Consistently(sessions[0], "1s").ShouldNot(gexec.Exit())
+
+			Eventually(sessions[0], helpers.DEFAULT_TIMEOUT).Should(gexec.Exit(0))
+			Expect(sessions[0].Out).To(gbytes.Say("drained"))
+		})
+	})
+
+	Context("with tracing enabled", func() {
+		var (
+			sessions          []*gexec.Session
+			conf              config.Config
+			policyServerConfs []config.Config
+			fakeMetron        metrics.FakeMetron
+			collector         *libtestsupport.FakeCollector
+		)
+
+		BeforeEach(func() {
+			fakeMetron = metrics.NewFakeMetron()
+			collector = libtestsupport.NewFakeCollector()
+
+			dbConf := testsupport.GetDBConfig()
+			dbConf.DatabaseName = fmt.Sprintf("integration_test_node_%d", ports.PickAPort())
+
+			template, _ := helpers.DefaultTestConfig(dbConf, fakeMetron.Address(), "fixtures")
+			template.TracingOTLPEndpoint = collector.URL()
+			policyServerConfs = configurePolicyServers(template, 1)
+			sessions = startPolicyServers(policyServerConfs)
+			conf = policyServerConfs[0]
+		})
+
+		AfterEach(func() {
+			stopPolicyServers(sessions, policyServerConfs)
+
+			collector.Close()
+			Expect(fakeMetron.Close()).To(Succeed())
+		})
+
+		It("continues the caller's trace through the middleware, UAA and the store", func() {
+			traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+			resp := helpers.MakeAndDoRequest(
+				"GET",
+				fmt.Sprintf("http://%s:%d/networking/v1/external/policies", conf.ListenHost, conf.ListenPort),
+				map[string]string{"traceparent": "00-" + traceID + "-00f067aa0ba902b7-01"},
+				nil,
+			)
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+			spanNamesInTrace := func() []string {
+				var names []string
+				for _, span := range collector.Spans() {
+					if span.TraceID == traceID {
+						Expect(span.ServiceName).To(Equal("policy-server"))
+						names = append(names, span.Name)
+					}
+				}
+				return names
+			}
+			Eventually(spanNamesInTrace, "10s").Should(ContainElement("store.All"))
+			Expect(spanNamesInTrace()).To(ContainElement("PoliciesIndex"))
+			Expect(spanNamesInTrace()).To(ContainElement("uaa_client.CheckToken"))
+
+			for _, span := range collector.Spans() {
+				if span.Name == "PoliciesIndex" {
+					Expect(span.ParentSpanID).To(Equal("00f067aa0ba902b7"))
+				}
+			}
+		})
+	})
+
 	Context("when connection to the database times out", func() {
 		var (
 			session *gexec.Session
@@ -0,0 +1,89 @@
+package integration_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"policy-server/config"
+	"policy-server/integration/helpers"
+	"policy-server/psclient"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/metrics"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/ports"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Migrate ASGs Binary", func() {
+	var (
+		sessions          []*gexec.Session
+		policyServerConfs []config.Config
+		dbConf            db.Config
+		client            *psclient.Client
+		fakeMetron        metrics.FakeMetron
+	)
+
+	BeforeEach(func() {
+		fakeMetron = metrics.NewFakeMetron()
+
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("migrate_asgs_test_node_%d", ports.PickAPort())
+
+		template, _ := helpers.DefaultTestConfig(dbConf, fakeMetron.Address(), "fixtures")
+		policyServerConfs = configurePolicyServers(template, 1)
+		sessions = startPolicyServers(policyServerConfs)
+
+		conf := policyServerConfs[0]
+		client = psclient.NewClient(lagertest.NewTestLogger("psclient"), http.DefaultClient, fmt.Sprintf("http://%s:%d", conf.ListenHost, conf.ListenPort))
+	})
+
+	AfterEach(func() {
+		stopPolicyServers(sessions, policyServerConfs)
+
+		Expect(fakeMetron.Close()).To(Succeed())
+	})
+
+	It("imports the security groups from CC as egress destinations and policies", func() {
+		By("reporting what would be imported on a dry run")
+		session := helpers.RunMigrateAsgsBinary(migrateAsgsPath, policyServerConfs[0], true)
+		Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+		Expect(session.Out).To(gbytes.Say(`"dry_run": true`))
+
+		destinations, err := client.ListDestinations("valid-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(destinations).To(BeEmpty())
+
+		By("importing the security groups")
+		session = helpers.RunMigrateAsgsBinary(migrateAsgsPath, policyServerConfs[0], false)
+		Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+
+		destinations, err = client.ListDestinations("valid-token")
+		Expect(err).NotTo(HaveOccurred())
+		var names []string
+		for _, destination := range destinations {
+			names = append(names, destination.Name)
+		}
+		Expect(names).To(ConsistOf("public-networks-1", "public-networks-2", "public-networks-3", "public-networks-4", "dns-1"))
+
+		egressPolicies, err := client.ListEgressPolicies("valid-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressPolicies.EgressPolicies).To(HaveLen(10))
+
+		By("skipping everything that was already imported when run again")
+		session = helpers.RunMigrateAsgsBinary(migrateAsgsPath, policyServerConfs[0], false)
+		Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+
+		destinations, err = client.ListDestinations("valid-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(destinations).To(HaveLen(5))
+
+		egressPolicies, err = client.ListEgressPolicies("valid-token")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(egressPolicies.EgressPolicies).To(HaveLen(10))
+	})
+})
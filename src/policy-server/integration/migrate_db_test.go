@@ -16,6 +16,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
 )
 
@@ -62,6 +63,84 @@ var _ = Describe("Migrate DB Binary", func() {
 				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
 			})
 		})
+
+		Describe("status", func() {
+			It("prints applied and pending migrations", func() {
+				session := helpers.RunMigrationsCommand(migrateDbPath, conf, "status")
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+				Expect(session.Out).To(gbytes.Say(`ID\s+STATUS\s+APPLIED AT\s+REVERSIBLE`))
+				Expect(session.Out).To(gbytes.Say(`66\s+pending\s+-\s+yes`))
+
+				session = helpers.RunMigrationsPreStartBinary(migrateDbPath, conf)
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+
+				session = helpers.RunMigrationsCommand(migrateDbPath, conf, "status")
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+				Expect(session.Out).To(gbytes.Say(`56\s+applied\s+\S+\s+no`))
+				Expect(session.Out).To(gbytes.Say(`66\s+applied\s+\S+\s+yes`))
+			})
+		})
+
+		Describe("down", func() {
+			BeforeEach(func() {
+				session := helpers.RunMigrationsPreStartBinary(migrateDbPath, conf)
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+			})
+
+			It("rolls back the requested number of migrations", func() {
+				session := helpers.RunMigrationsCommand(migrateDbPath, conf, "-count", "2", "down")
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+
+				conn := createDbConn(dbConf)
+				defer conn.Close()
+
+				var migrationCount int
+				conn.QueryRow("SELECT COUNT(*) FROM gorp_migrations WHERE id IN ('67', '68')").Scan(&migrationCount)
+				Expect(migrationCount).To(Equal(0))
+
+				session = helpers.RunMigrationsPreStartBinary(migrateDbPath, conf)
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+				assertMigrationsSucceeded(conn, conf)
+			})
+
+			Context("when a migration cannot be rolled back", func() {
+				It("exits non-zero", func() {
+					session := helpers.RunMigrationsCommand(migrateDbPath, conf, "-count", "100", "down")
+					Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(1))
+					Expect(session.Out).To(gbytes.Say("cannot be rolled back"))
+				})
+			})
+		})
+
+		Describe("verify", func() {
+			BeforeEach(func() {
+				session := helpers.RunMigrationsPreStartBinary(migrateDbPath, conf)
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+			})
+
+			It("reports that the schema matches", func() {
+				session := helpers.RunMigrationsCommand(migrateDbPath, conf, "verify")
+				Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(0))
+				Expect(session.Out).To(gbytes.Say("schema matches expected migrations"))
+			})
+
+			Context("when the schema has been altered by hand", func() {
+				BeforeEach(func() {
+					conn := createDbConn(dbConf)
+					defer conn.Close()
+
+					_, err := conn.Exec("CREATE TABLE drift (id int)")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("prints the differences and exits non-zero", func() {
+					session := helpers.RunMigrationsCommand(migrateDbPath, conf, "verify")
+					Eventually(session.Wait(TimeoutShort)).Should(gexec.Exit(1))
+					Expect(session.Out).To(gbytes.Say("unexpected table drift"))
+					Expect(session.Out).To(gbytes.Say("schema drift detected: 1 differences"))
+				})
+			})
+		})
 	})
 
 	Context("when the db is not available", func() {
@@ -0,0 +1,245 @@
+package integration_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"policy-server/api"
+	"policy-server/config"
+	"policy-server/integration/helpers"
+	"strings"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/metrics"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport/ports"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("OpenAPI conformance", func() {
+	var (
+		sessions                  []*gexec.Session
+		conf                      config.Config
+		internalConf              config.InternalConfig
+		policyServerConfs         []config.Config
+		policyServerInternalConfs []config.InternalConfig
+		dbConf                    db.Config
+		tlsConfig                 *tls.Config
+
+		fakeMetron   metrics.FakeMetron
+		mockCCServer *helpers.ConfigurableMockCCServer
+
+		v0Spec *helpers.OpenAPISpec
+		v1Spec *helpers.OpenAPISpec
+	)
+
+	BeforeEach(func() {
+		var err error
+		v0Spec, err = helpers.LoadOpenAPISpec("../../../docs/openapi/v0.yaml")
+		Expect(err).NotTo(HaveOccurred())
+		v1Spec, err = helpers.LoadOpenAPISpec("../../../docs/openapi/v1.yaml")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeMetron = metrics.NewFakeMetron()
+
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("openapi_test_node_%d", ports.PickAPort())
+
+		mockCCServer = helpers.NewConfigurableMockCCServer()
+		mockCCServer.Start()
+		mockCCServer.AddApp("live-app-1-guid")
+		mockCCServer.AddApp("live-app-2-guid")
+
+		cert, err := tls.LoadX509KeyPair("fixtures/client.crt", "fixtures/client.key")
+		Expect(err).NotTo(HaveOccurred())
+		clientCACert, err := ioutil.ReadFile("fixtures/netman-ca.crt")
+		Expect(err).NotTo(HaveOccurred())
+		clientCertPool := x509.NewCertPool()
+		clientCertPool.AppendCertsFromPEM(clientCACert)
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			RootCAs:      clientCertPool,
+		}
+
+		template, internalTemplate := helpers.DefaultTestConfigWithCCServer(dbConf, fakeMetron.Address(), "fixtures", mockCCServer.URL())
+		template.TombstoneRetentionPeriod = 3600
+		policyServerConfs = configurePolicyServers(template, 1)
+		policyServerInternalConfs = configureInternalPolicyServers(internalTemplate, 1)
+		sessions = startPolicyAndInternalServers(policyServerConfs, policyServerInternalConfs)
+		conf = policyServerConfs[0]
+		internalConf = policyServerInternalConfs[0]
+	})
+
+	AfterEach(func() {
+		stopPolicyServers(sessions, policyServerConfs)
+
+		mockCCServer.Close()
+		Expect(fakeMetron.Close()).To(Succeed())
+	})
+
+	conforms := func(spec *helpers.OpenAPISpec, resp *http.Response) *http.Response {
+		ExpectWithOffset(1, spec.ValidateResponse(resp)).To(Succeed())
+		return resp
+	}
+
+	externalURL := func(version, route string) string {
+		return fmt.Sprintf("http://%s:%d/networking/%s/external/%s", conf.ListenHost, conf.ListenPort, version, route)
+	}
+
+	do := func(method, endpoint, body string) *http.Response {
+		return helpers.MakeAndDoRequest(method, endpoint, nil, strings.NewReader(body))
+	}
+
+	c2cPolicy := `{"policies": [
+		{"source": { "id": "live-app-1-guid" }, "destination": { "id": "live-app-2-guid", "protocol": "tcp", "ports": { "start": 8080, "end": 8080 } } }
+	]}`
+
+	Describe("the v1 external API", func() {
+		It("documents the uptime and health responses", func() {
+			for _, route := range []string{"/", "/networking", "/health", "/health/detailed"} {
+				resp := do("GET", fmt.Sprintf("http://%s:%d%s", conf.ListenHost, conf.ListenPort, route), "")
+				conforms(v1Spec, resp)
+			}
+		})
+
+		It("documents the c2c policy responses", func() {
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies"), c2cPolicy)).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "policies"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "policies?id=live-app-1-guid"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies/delete"), c2cPolicy)).StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("documents the egress destination and egress policy responses", func() {
+			resp := conforms(v1Spec, do("POST", externalURL("v1", "destinations"), `{"destinations": [
+				{"name": "dest-1", "description": "dest-1-desc", "protocol": "tcp", "ports": [{"start": 8080, "end": 8081}], "ips": [{"start": "10.27.1.1", "end": "10.27.1.2"}]}
+			]}`))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			var destinations api.DestinationsPayload
+			Expect(json.NewDecoder(resp.Body).Decode(&destinations)).To(Succeed())
+			Expect(destinations.EgressDestinations).To(HaveLen(1))
+			destinationGUID := destinations.EgressDestinations[0].GUID
+
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "destinations"), "")).StatusCode).To(Equal(http.StatusOK))
+
+			resp = conforms(v1Spec, do("POST", externalURL("v1", "egress_policies"), fmt.Sprintf(`{"egress_policies": [
+				{"source": {"id": "live-app-1-guid"}, "destination": {"id": %q}}
+			]}`, destinationGUID)))
+			Expect(resp.StatusCode).To(Equal(http.StatusCreated))
+			var egressPolicies struct {
+				EgressPolicies []api.EgressPolicy `json:"egress_policies"`
+			}
+			Expect(json.NewDecoder(resp.Body).Decode(&egressPolicies)).To(Succeed())
+			Expect(egressPolicies.EgressPolicies).To(HaveLen(1))
+
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "egress_policies"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "destinations/"+destinationGUID+"/usage"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("DELETE", externalURL("v1", "destinations/"+destinationGUID), "")).StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(conforms(v1Spec, do("DELETE", externalURL("v1", "egress_policies/"+egressPolicies.EgressPolicies[0].ID), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("DELETE", externalURL("v1", "destinations/"+destinationGUID), "")).StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("documents the cleanup and tombstone responses", func() {
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies"), `{"policies": [
+				{"source": { "id": "live-app-1-guid" }, "destination": { "id": "dead-app", "protocol": "tcp", "ports": { "start": 3333, "end": 3333 } } }
+			]}`)).StatusCode).To(Equal(http.StatusOK))
+
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies/cleanup?dry_run=true"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies/cleanup?confirm=true"), "")).StatusCode).To(Equal(http.StatusOK))
+
+			resp := conforms(v1Spec, do("GET", externalURL("v1", "tombstones"), ""))
+			Expect(resp.StatusCode).To(Equal(http.StatusOK))
+			var tombstones struct {
+				Tombstones []api.Tombstone `json:"tombstones"`
+			}
+			Expect(json.NewDecoder(resp.Body).Decode(&tombstones)).To(Succeed())
+			Expect(tombstones.Tombstones).To(HaveLen(1))
+
+			resp = do("POST", externalURL("v1", "tombstones/restore"), fmt.Sprintf(`{"ids": [%d]}`, tombstones.Tombstones[0].ID))
+			Expect(conforms(v1Spec, resp).StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("documents the admin responses", func() {
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies"), c2cPolicy)).StatusCode).To(Equal(http.StatusOK))
+
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "whoami"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "tags"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "stats?top=5"), "")).StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("documents the error responses", func() {
+			req, err := http.NewRequest("GET", externalURL("v1", "policies"), nil)
+			Expect(err).NotTo(HaveOccurred())
+			resp, err := http.DefaultClient.Do(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(conforms(v1Spec, resp).StatusCode).To(Equal(http.StatusUnauthorized))
+
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies"), `{`)).StatusCode).To(Equal(http.StatusBadRequest))
+			Expect(conforms(v1Spec, do("GET", externalURL("v1", "stats?top=-1"), "")).StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("the v1 internal API", func() {
+		It("documents the policies, tags and schema health responses", func() {
+			Expect(conforms(v1Spec, do("POST", externalURL("v1", "policies"), c2cPolicy)).StatusCode).To(Equal(http.StatusOK))
+
+			resp := helpers.MakeAndDoHTTPSRequest(
+				"GET",
+				fmt.Sprintf("https://%s:%d/networking/v1/internal/policies?id=live-app-1-guid", internalConf.ListenHost, internalConf.InternalListenPort),
+				nil,
+				tlsConfig,
+			)
+			Expect(conforms(v1Spec, resp).StatusCode).To(Equal(http.StatusOK))
+
+			resp = helpers.MakeAndDoHTTPSRequest(
+				"PUT",
+				fmt.Sprintf("https://%s:%d/networking/v1/internal/tags", internalConf.ListenHost, internalConf.InternalListenPort),
+				strings.NewReader(`{"id": "some-space-guid", "type": "space"}`),
+				tlsConfig,
+			)
+			Expect(conforms(v1Spec, resp).StatusCode).To(Equal(http.StatusOK))
+
+			for _, route := range []string{"/", "/health", "/health/schema"} {
+				resp, err := http.Get(fmt.Sprintf("http://%s:%d%s", internalConf.ListenHost, internalConf.HealthCheckPort, route))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(conforms(v1Spec, resp).StatusCode).To(Equal(http.StatusOK))
+			}
+		})
+	})
+
+	Describe("the v0 external API", func() {
+		v0Policy := `{"policies": [
+			{"source": { "id": "live-app-1-guid" }, "destination": { "id": "live-app-2-guid", "protocol": "tcp", "port": 8080 } }
+		]}`
+
+		It("documents the policy, tag and whoami responses", func() {
+			Expect(conforms(v0Spec, do("POST", externalURL("v0", "policies"), v0Policy)).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v0Spec, do("GET", externalURL("v0", "policies"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v0Spec, do("GET", externalURL("v0", "tags"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v0Spec, do("GET", externalURL("v0", "whoami"), "")).StatusCode).To(Equal(http.StatusOK))
+			Expect(conforms(v0Spec, do("POST", externalURL("v0", "policies/delete"), v0Policy)).StatusCode).To(Equal(http.StatusOK))
+		})
+
+		It("documents the error responses", func() {
+			Expect(conforms(v0Spec, do("POST", externalURL("v0", "policies"), `{`)).StatusCode).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Describe("the conformance check", func() {
+		It("rejects responses that drift from the spec", func() {
+			resp := do("GET", externalURL("v1", "policies"), "")
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(strings.NewReader(`{"total_policies": 0, "policies": [], "unexpected": true}`))
+			Expect(v1Spec.ValidateResponse(resp)).To(MatchError(ContainSubstring("$.unexpected: property is not documented")))
+
+			resp = do("GET", externalURL("v1", "policies"), "")
+			resp.StatusCode = http.StatusTeapot
+			Expect(v1Spec.ValidateResponse(resp)).To(MatchError(ContainSubstring("status 418 is not documented")))
+		})
+	})
+})
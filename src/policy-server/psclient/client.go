@@ -3,6 +3,8 @@ package psclient
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"code.cloudfoundry.org/cf-networking-helpers/json_client"
 	"code.cloudfoundry.org/lager"
@@ -41,6 +43,7 @@ type EgressPolicy struct {
 	GUID        string             `json:"id,omitempty"`
 	Source      EgressPolicySource `json:"source"`
 	Destination Destination        `json:"destination"`
+	Action      string             `json:"action,omitempty"`
 }
 
 type EgressPolicySource struct {
@@ -57,6 +60,14 @@ type EgressPolicyList struct {
 	EgressPolicies      []EgressPolicy `json:"egress_policies"`
 }
 
+type EgressPolicyFilter struct {
+	SourceIDs        []string
+	SourceTypes      []string
+	DestinationIDs   []string
+	DestinationNames []string
+	Protocols        []string
+}
+
 func NewClient(logger lager.Logger, httpClient json_client.HttpClient, baseURL string) *Client {
 	return &Client{
 		JsonClient: json_client.New(logger, httpClient, baseURL),
@@ -142,3 +153,31 @@ func (c *Client) ListEgressPolicies(token string) (EgressPolicyList, error) {
 
 	return response, nil
 }
+
+func (c *Client) ListEgressPoliciesWithFilter(token string, filter EgressPolicyFilter) (EgressPolicyList, error) {
+	values := url.Values{}
+	addFilterValues(values, "source_id", filter.SourceIDs)
+	addFilterValues(values, "source_type", filter.SourceTypes)
+	addFilterValues(values, "destination_id", filter.DestinationIDs)
+	addFilterValues(values, "destination_name", filter.DestinationNames)
+	addFilterValues(values, "protocol", filter.Protocols)
+
+	route := "/networking/v1/external/egress_policies"
+	if len(values) > 0 {
+		route = fmt.Sprintf("%s?%s", route, values.Encode())
+	}
+
+	var response EgressPolicyList
+	err := c.JsonClient.Do("GET", route, "", &response, "Bearer "+token)
+	if err != nil {
+		return EgressPolicyList{}, fmt.Errorf("list egress policies api call: %s", err)
+	}
+
+	return response, nil
+}
+
+func addFilterValues(values url.Values, key string, filterValues []string) {
+	if len(filterValues) > 0 {
+		values.Add(key, strings.Join(filterValues, ","))
+	}
+}
@@ -436,5 +436,36 @@ var _ = Describe("Client", func() {
 			_, err := client.ListEgressPolicies(token)
 			Expect(err).To(MatchError("list egress policies api call: failed to do"))
 		})
+
+		Context("when a filter is provided", func() {
+			It("passes the filter as query parameters", func() {
+				_, err := client.ListEgressPoliciesWithFilter(token, psclient.EgressPolicyFilter{
+					SourceIDs:        []string{"app-1", "app-2"},
+					DestinationNames: []string{"some-dest"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(jsonClient.DoCallCount()).To(Equal(1))
+				passedMethod, passedRoute, _, _, passedToken := jsonClient.DoArgsForCall(0)
+				Expect(passedMethod).To(Equal("GET"))
+				Expect(passedRoute).To(Equal("/networking/v1/external/egress_policies?destination_name=some-dest&source_id=app-1%2Capp-2"))
+				Expect(passedToken).To(Equal("Bearer some-token"))
+			})
+
+			It("omits the query string when the filter is empty", func() {
+				_, err := client.ListEgressPoliciesWithFilter(token, psclient.EgressPolicyFilter{})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, passedRoute, _, _, _ := jsonClient.DoArgsForCall(0)
+				Expect(passedRoute).To(Equal("/networking/v1/external/egress_policies"))
+			})
+
+			It("returns an error when the json client do fails", func() {
+				jsonClient.DoStub = nil
+				jsonClient.DoReturns(errors.New("failed to do"))
+				_, err := client.ListEgressPoliciesWithFilter(token, psclient.EgressPolicyFilter{SourceIDs: []string{"app-1"}})
+				Expect(err).To(MatchError("list egress policies api call: failed to do"))
+			})
+		})
 	})
 })
@@ -2,15 +2,18 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type ListStore struct {
-	AllStub        func() ([]store.Policy, error)
+	AllStub        func(context.Context) ([]store.Policy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		arg1 context.Context
+	}
+	allReturns struct {
 		result1 []store.Policy
 		result2 error
 	}
@@ -22,14 +25,16 @@ type ListStore struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *ListStore) All() ([]store.Policy, error) {
+func (fake *ListStore) All(arg1 context.Context) ([]store.Policy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -43,6 +48,12 @@ func (fake *ListStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *ListStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].arg1
+}
+
 func (fake *ListStore) AllReturns(result1 []store.Policy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
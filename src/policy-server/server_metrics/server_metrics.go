@@ -1,13 +1,15 @@
 package server_metrics
 
 import (
+	"context"
+
 	"code.cloudfoundry.org/cf-networking-helpers/metrics"
 	"policy-server/store"
 )
 
 //go:generate counterfeiter -o fakes/list_store.go --fake-name ListStore . listStore
 type listStore interface {
-	All() ([]store.Policy, error)
+	All(context.Context) ([]store.Policy, error)
 }
 
 func NewTotalPoliciesSource(lister listStore) metrics.MetricSource {
@@ -15,8 +17,38 @@ func NewTotalPoliciesSource(lister listStore) metrics.MetricSource {
 		Name: "totalPolicies",
 		Unit: "",
 		Getter: func() (float64, error) {
-			allPolicies, err := lister.All()
+			allPolicies, err := lister.All(context.Background())
 			return float64(len(allPolicies)), err
 		},
 	}
 }
+
+//go:generate counterfeiter -o fakes/tag_capacity_store.go --fake-name TagCapacityStore . tagCapacityStore
+type tagCapacityStore interface {
+	TagCapacity() (store.TagCapacity, error)
+}
+
+func NewFreeTagsSource(tagStore tagCapacityStore) metrics.MetricSource {
+	return metrics.MetricSource{
+		Name: "freeTags",
+		Unit: "",
+		Getter: func() (float64, error) {
+			capacity, err := tagStore.TagCapacity()
+			return float64(capacity.Free), err
+		},
+	}
+}
+
+func NewFreeTagsPercentSource(tagStore tagCapacityStore) metrics.MetricSource {
+	return metrics.MetricSource{
+		Name: "freeTagsPercent",
+		Unit: "percent",
+		Getter: func() (float64, error) {
+			capacity, err := tagStore.TagCapacity()
+			if err != nil || capacity.Total == 0 {
+				return 0, err
+			}
+			return float64(capacity.Free) * 100 / float64(capacity.Total), nil
+		},
+	}
+}
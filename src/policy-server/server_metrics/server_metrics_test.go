@@ -1,6 +1,7 @@
 package server_metrics_test
 
 import (
+	"errors"
 	"policy-server/server_metrics"
 	"policy-server/server_metrics/fakes"
 
@@ -56,3 +57,48 @@ var _ = Describe("NewTotalPoliciesSource", func() {
 		})
 	})
 })
+
+var _ = Describe("Tag capacity sources", func() {
+	var fakeTagStore *fakes.TagCapacityStore
+
+	BeforeEach(func() {
+		fakeTagStore = &fakes.TagCapacityStore{}
+		fakeTagStore.TagCapacityReturns(store.TagCapacity{Total: 200, Free: 50}, nil)
+	})
+
+	Describe("NewFreeTagsSource", func() {
+		It("returns the number of free tags", func() {
+			source := server_metrics.NewFreeTagsSource(fakeTagStore)
+			Expect(source.Name).To(Equal("freeTags"))
+			Expect(source.Unit).To(Equal(""))
+
+			value, err := source.Getter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(50.0))
+		})
+	})
+
+	Describe("NewFreeTagsPercentSource", func() {
+		It("returns the percentage of free tags", func() {
+			source := server_metrics.NewFreeTagsPercentSource(fakeTagStore)
+			Expect(source.Name).To(Equal("freeTagsPercent"))
+			Expect(source.Unit).To(Equal("percent"))
+
+			value, err := source.Getter()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(value).To(Equal(25.0))
+		})
+
+		Context("when the tag capacity cannot be read", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagCapacityReturns(store.TagCapacity{}, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				source := server_metrics.NewFreeTagsPercentSource(fakeTagStore)
+				_, err := source.Getter()
+				Expect(err).To(MatchError("potato"))
+			})
+		})
+	})
+})
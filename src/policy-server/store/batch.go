@@ -0,0 +1,67 @@
+package store
+
+import (
+	"fmt"
+	"policy-server/store/helpers"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+)
+
+// maxBatchSize bounds the number of rows written or looked up by a single
+// multi-row statement. It keeps the bind parameters of the widest batch
+// well inside the mysql and postgres limits.
+const maxBatchSize = 100
+
+// inBatches calls fn with consecutive [start, end) ranges covering count
+// items, each at most maxBatchSize long, stopping at the first error.
+func inBatches(count int, fn func(start, end int) error) error {
+	for start := 0; start < count; start += maxBatchSize {
+		end := start + maxBatchSize
+		if end > count {
+			end = count
+		}
+		err := fn(start, end)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func intsToInterfaceSlice(ints []int) []interface{} {
+	ifaces := make([]interface{}, len(ints))
+	for i, value := range ints {
+		ifaces[i] = value
+	}
+	return ifaces
+}
+
+// idsInUse runs query, a SELECT of a single id column with a %s
+// placeholder for the IN list, and returns which of ids it found.
+func idsInUse(tx db.Transaction, query string, ids []int) (map[int]bool, error) {
+	inUse := map[int]bool{}
+	err := inBatches(len(ids), func(start, end int) error {
+		rows, err := tx.Queryx(
+			tx.Rebind(fmt.Sprintf(query, helpers.QuestionMarks(end-start))),
+			intsToInterfaceSlice(ids[start:end])...,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int
+			err = rows.Scan(&id)
+			if err != nil {
+				return err
+			}
+			inUse[id] = true
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inUse, nil
+}
@@ -1,82 +1,141 @@
 package store
 
-import "code.cloudfoundry.org/cf-networking-helpers/db"
+import (
+	"policy-server/store/helpers"
+	"strings"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+)
 
 //go:generate counterfeiter -o fakes/destination_repo.go --fake-name DestinationRepo . DestinationRepo
 type DestinationRepo interface {
-	Create(db.Transaction, int, int, int, int, string) (int, error)
-	Delete(db.Transaction, int) error
-	GetID(db.Transaction, int, int, int, int, string) (int, error)
-	CountWhereGroupID(db.Transaction, int) (int, error)
+	CreateMany(db.Transaction, []DestinationKey) (map[DestinationKey]int, error)
+	DeleteMany(db.Transaction, []int) error
+	GetIDs(db.Transaction, []DestinationKey) (map[DestinationKey]int, error)
+	GroupIDsInUse(db.Transaction, []int) (map[int]bool, error)
+}
+
+// DestinationKey is the set of columns a destinations row is looked up by.
+type DestinationKey struct {
+	GroupID   int
+	Port      int
+	StartPort int
+	EndPort   int
+	Protocol  string
 }
 
 type DestinationTable struct {
 }
 
-func (d *DestinationTable) Create(tx db.Transaction, destinationGroupId, port, startPort, endPort int, protocol string) (int, error) {
-	dualStatement := ""
-	if tx.DriverName() == "mysql" {
-		dualStatement = " FROM DUAL "
+// CreateMany inserts the destinations that do not exist yet and returns
+// the id of every key.
+func (d *DestinationTable) CreateMany(tx db.Transaction, keys []DestinationKey) (map[DestinationKey]int, error) {
+	ids, err := d.GetIDs(tx, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []DestinationKey
+	seen := map[DestinationKey]bool{}
+	for _, key := range keys {
+		if _, ok := ids[key]; !ok && !seen[key] {
+			seen[key] = true
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	err = inBatches(len(missing), func(start, end int) error {
+		var values []string
+		var args []interface{}
+		for _, key := range missing[start:end] {
+			values = append(values, "(?, ?, ?, ?, ?)")
+			args = append(args, key.GroupID, key.Port, key.StartPort, key.EndPort, key.Protocol)
+		}
+		_, err := tx.Exec(tx.Rebind(`
+			INSERT INTO destinations (group_id, port, start_port, end_port, protocol)
+			VALUES `+strings.Join(values, ", ")),
+			args...,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	_, err := tx.Exec(tx.Rebind(`
-		INSERT INTO destinations (group_id, port, start_port, end_port, protocol)
-		SELECT ?, ?, ?, ?, ? `+dualStatement+`
-		WHERE
-		NOT EXISTS (
-			SELECT *
-			FROM destinations
-			WHERE group_id = ? AND port = ? AND start_port = ? AND end_port = ? AND protocol = ?
-		)`),
-		destinationGroupId,
-		port,
-		startPort,
-		endPort,
-		protocol,
-		destinationGroupId,
-		port,
-		startPort,
-		endPort,
-		protocol,
-	)
+	created, err := d.GetIDs(tx, missing)
 	if err != nil {
-		return -1, err
+		return nil, err
+	}
+	for key, id := range created {
+		ids[key] = id
 	}
-	id, err := d.GetID(tx, destinationGroupId, port, startPort, endPort, protocol)
-	return id, err
+	return ids, nil
 }
 
-func (d *DestinationTable) Delete(tx db.Transaction, id int) error {
-	_, err := tx.Exec(
-		tx.Rebind(`DELETE FROM destinations WHERE id = ?`),
-		id,
-	)
-	return err
+func (d *DestinationTable) DeleteMany(tx db.Transaction, ids []int) error {
+	return inBatches(len(ids), func(start, end int) error {
+		_, err := tx.Exec(
+			tx.Rebind(`DELETE FROM destinations WHERE id IN (`+helpers.QuestionMarks(end-start)+`)`),
+			intsToInterfaceSlice(ids[start:end])...,
+		)
+		return err
+	})
 }
 
-func (d *DestinationTable) GetID(tx db.Transaction, destinationGroupId, port, startPort, endPort int, protocol string) (int, error) {
-	var id int
+// GetIDs returns the ids of the keys that have a destinations row. Keys
+// without one are left out of the map.
+func (d *DestinationTable) GetIDs(tx db.Transaction, keys []DestinationKey) (map[DestinationKey]int, error) {
+	wanted := map[DestinationKey]bool{}
+	var groupIDs []int
+	seenGroup := map[int]bool{}
+	for _, key := range keys {
+		wanted[key] = true
+		if !seenGroup[key.GroupID] {
+			seenGroup[key.GroupID] = true
+			groupIDs = append(groupIDs, key.GroupID)
+		}
+	}
+
 	lockStatement := " FOR UPDATE "
 	if tx.DriverName() == "mysql" {
 		lockStatement = " LOCK IN SHARE MODE "
 	}
-	err := tx.QueryRow(tx.Rebind(`
-		SELECT id FROM destinations
-		WHERE group_id = ? AND port = ? AND start_port = ? AND end_port = ? AND protocol = ? `+lockStatement),
-		destinationGroupId,
-		port,
-		startPort,
-		endPort,
-		protocol,
-	).Scan(&id)
-	return id, err
+
+	ids := map[DestinationKey]int{}
+	err := inBatches(len(groupIDs), func(start, end int) error {
+		rows, err := tx.Queryx(tx.Rebind(`
+			SELECT id, group_id, port, start_port, end_port, protocol FROM destinations
+			WHERE group_id IN (`+helpers.QuestionMarks(end-start)+`) `+lockStatement),
+			intsToInterfaceSlice(groupIDs[start:end])...,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var id int
+			var key DestinationKey
+			err = rows.Scan(&id, &key.GroupID, &key.Port, &key.StartPort, &key.EndPort, &key.Protocol)
+			if err != nil {
+				return err
+			}
+			if wanted[key] {
+				ids[key] = id
+			}
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
 }
 
-func (d *DestinationTable) CountWhereGroupID(tx db.Transaction, groupId int) (int, error) {
-	var count int
-	err := tx.QueryRow(
-		tx.Rebind(`SELECT COUNT(*) FROM destinations WHERE group_id = ?`),
-		groupId,
-	).Scan(&count)
-	return count, err
+// GroupIDsInUse returns which of the group ids still have a destination.
+func (d *DestinationTable) GroupIDsInUse(tx db.Transaction, groupIDs []int) (map[int]bool, error) {
+	return idsInUse(tx, `SELECT DISTINCT group_id FROM destinations WHERE group_id IN (%s)`, groupIDs)
 }
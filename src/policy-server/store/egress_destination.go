@@ -2,6 +2,7 @@ package store
 
 import (
 	"fmt"
+	"policy-server/store/helpers"
 	"strings"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
@@ -20,7 +21,7 @@ func (e *EgressDestinationTable) All(tx db.Transaction) ([]EgressDestination, er
 }
 
 func (e *EgressDestinationTable) GetByGUID(tx db.Transaction, guids ...string) ([]EgressDestination, error) {
-	query := egressDestinationsQuery(`WHERE ip_ranges.terminal_guid IN (` + generateQuestionMarkString(len(guids)) + `)`)
+	query := egressDestinationsQuery(`WHERE ip_ranges.terminal_guid IN (` + helpers.QuestionMarks(len(guids)) + `)`)
 	rows, err := tx.Queryx(tx.Rebind(query), convertToInterfaceSlice(guids)...)
 	if err != nil {
 		return []EgressDestination{}, fmt.Errorf("running query: %s", err)
@@ -29,6 +30,38 @@ func (e *EgressDestinationTable) GetByGUID(tx db.Transaction, guids ...string) (
 	return convertRowsToEgressDestinations(rows)
 }
 
+func (e *EgressDestinationTable) GetByFilter(tx db.Transaction, filter EgressDestinationFilter) ([]EgressDestination, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.IDs) > 0 {
+		conditions = append(conditions, `ip_ranges.terminal_guid IN (`+helpers.QuestionMarks(len(filter.IDs))+`)`)
+		args = append(args, convertToInterfaceSlice(filter.IDs)...)
+	}
+
+	if len(filter.Names) > 0 {
+		conditions = append(conditions, `d_m.name IN (`+helpers.QuestionMarks(len(filter.Names))+`)`)
+		args = append(args, convertToInterfaceSlice(filter.Names)...)
+	}
+
+	if len(filter.Protocols) > 0 {
+		conditions = append(conditions, `ip_ranges.terminal_guid IN (SELECT terminal_guid FROM ip_ranges WHERE protocol IN (`+helpers.QuestionMarks(len(filter.Protocols))+`))`)
+		args = append(args, convertToInterfaceSlice(filter.Protocols)...)
+	}
+
+	var whereClause string
+	if len(conditions) > 0 {
+		whereClause = `WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	rows, err := tx.Queryx(tx.Rebind(egressDestinationsQuery(whereClause)), args...)
+	if err != nil {
+		return []EgressDestination{}, fmt.Errorf("running query: %s", err)
+	}
+	defer rows.Close()
+	return convertRowsToEgressDestinations(rows)
+}
+
 func (e *EgressDestinationTable) Delete(tx db.Transaction, guid string) error {
 	_, err := tx.Exec(tx.Rebind(`DELETE FROM ip_ranges WHERE terminal_guid = ?`), guid)
 	return err
@@ -137,14 +170,6 @@ func egressDestinationsQuery(whereClause string) string {
 		`ORDER BY ip_ranges.id`}, " ")
 }
 
-func generateQuestionMarkString(length int) string {
-	questionMarks := make([]string, length)
-	for i := 0; i < length; i++ {
-		questionMarks[i] = "?"
-	}
-	return strings.Join(questionMarks, ", ")
-}
-
 func convertToInterfaceSlice(slice []string) []interface{} {
 	ifaces := make([]interface{}, len(slice))
 	for i, value := range slice {
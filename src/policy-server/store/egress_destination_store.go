@@ -13,6 +13,7 @@ type egressDestinationRepo interface {
 	All(tx db.Transaction) ([]EgressDestination, error)
 	CreateIPRange(tx db.Transaction, destinationTerminalGUID, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
 	GetByGUID(tx db.Transaction, guid ...string) ([]EgressDestination, error)
+	GetByFilter(tx db.Transaction, filter EgressDestinationFilter) ([]EgressDestination, error)
 	Delete(tx db.Transaction, guid string) error
 }
 
@@ -22,11 +23,17 @@ type destinationMetadataRepo interface {
 	Delete(tx db.Transaction, terminalGUID string) error
 }
 
+//go:generate counterfeiter -o fakes/egress_policy_destination_deleter.go --fake-name EgressPolicyDestinationDeleter . egressPolicyDestinationDeleter
+type egressPolicyDestinationDeleter interface {
+	DeleteByDestinationWithTx(tx db.Transaction, destinationGUID string) ([]EgressPolicy, error)
+}
+
 type EgressDestinationStore struct {
 	Conn                    Database
 	EgressDestinationRepo   egressDestinationRepo
 	TerminalsRepo           terminalsRepo
 	DestinationMetadataRepo destinationMetadataRepo
+	EgressPolicyStore       egressPolicyDestinationDeleter
 }
 
 func (e *EgressDestinationStore) GetByGUID(guid ...string) ([]EgressDestination, error) {
@@ -47,50 +54,79 @@ func (e *EgressDestinationStore) All() ([]EgressDestination, error) {
 	return e.EgressDestinationRepo.All(tx)
 }
 
+func (e *EgressDestinationStore) GetByFilter(filter EgressDestinationFilter) ([]EgressDestination, error) {
+	tx, err := e.Conn.Beginx()
+	if err != nil {
+		return []EgressDestination{}, fmt.Errorf("egress destination store create transaction: %s", err)
+	}
+	defer tx.Rollback()
+	return e.EgressDestinationRepo.GetByFilter(tx, filter)
+}
+
 func (e *EgressDestinationStore) Delete(guid string) (EgressDestination, error) {
+	destination, _, err := e.delete(guid, false)
+	return destination, err
+}
+
+// DeleteWithPolicies deletes the destination along with every egress policy
+// bound to it in a single transaction.
+func (e *EgressDestinationStore) DeleteWithPolicies(guid string) (EgressDestination, []EgressPolicy, error) {
+	return e.delete(guid, true)
+}
+
+func (e *EgressDestinationStore) delete(guid string, cascade bool) (EgressDestination, []EgressPolicy, error) {
 	tx, err := e.Conn.Beginx()
 	if err != nil {
-		return EgressDestination{}, fmt.Errorf("egress destination store delete transaction: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete transaction: %s", err)
 	}
 
 	destinations, err := e.EgressDestinationRepo.GetByGUID(tx, guid)
 	if err != nil {
 		tx.Rollback()
-		return EgressDestination{}, fmt.Errorf("egress destination store get destination by guid: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store get destination by guid: %s", err)
+	}
+
+	deletedPolicies := []EgressPolicy{}
+	if cascade {
+		deletedPolicies, err = e.EgressPolicyStore.DeleteByDestinationWithTx(tx, guid)
+		if err != nil {
+			tx.Rollback()
+			return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete bound egress policies: %s", err)
+		}
 	}
 
 	err = e.EgressDestinationRepo.Delete(tx, guid)
 	if err != nil {
 		tx.Rollback()
-		return EgressDestination{}, fmt.Errorf("egress destination store delete destination: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete destination: %s", err)
 	}
 
 	err = e.DestinationMetadataRepo.Delete(tx, guid)
 	if err != nil {
 		tx.Rollback()
-		return EgressDestination{}, fmt.Errorf("egress destination store delete destination metadata: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete destination metadata: %s", err)
 	}
 
 	err = e.TerminalsRepo.Delete(tx, guid)
 	if err != nil {
 		tx.Rollback()
 		if isForeignKeyError(err) {
-			return EgressDestination{}, NewForeignKeyError(err)
+			return EgressDestination{}, []EgressPolicy{}, NewForeignKeyError(err)
 		}
-		return EgressDestination{}, fmt.Errorf("egress destination store delete destination terminal: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete destination terminal: %s", err)
 	}
 
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
-		return EgressDestination{}, fmt.Errorf("egress destination store delete destination commit: %s", err)
+		return EgressDestination{}, []EgressPolicy{}, fmt.Errorf("egress destination store delete destination commit: %s", err)
 	}
 
 	if len(destinations) > 0 {
-		return destinations[0], nil
+		return destinations[0], deletedPolicies, nil
 	}
 
-	return EgressDestination{}, nil
+	return EgressDestination{}, deletedPolicies, nil
 }
 
 func (e *EgressDestinationStore) Create(egressDestinations []EgressDestination) ([]EgressDestination, error) {
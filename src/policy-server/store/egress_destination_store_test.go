@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"policy-server/store"
@@ -86,6 +87,7 @@ var _ = Describe("EgressDestinationStore", func() {
 					EgressPolicyRepo: egressPolicyRepo,
 					Conn:             realDb,
 				}
+				egressDestinationsStore.EgressPolicyStore = egressPolicyStore
 
 				toBeCreatedDestinations = []store.EgressDestination{
 					{
@@ -226,7 +228,7 @@ var _ = Describe("EgressDestinationStore", func() {
 						},
 					}
 
-					_, err = egressPolicyStore.Create(toBeCreatedEgressPolicy)
+					_, err = egressPolicyStore.Create(context.Background(), toBeCreatedEgressPolicy)
 					Expect(err).NotTo(HaveOccurred())
 				})
 
@@ -235,6 +237,22 @@ var _ = Describe("EgressDestinationStore", func() {
 					_, ok := err.(store.ForeignKeyError)
 					Expect(ok).To(BeTrue(), "expected store.ForeignKeyError, got %v", err)
 				})
+
+				It("deletes the destination and the bound policies when deleting with policies", func() {
+					deletedDestination, deletedPolicies, err := egressDestinationsStore.DeleteWithPolicies(createdDestinations[0].GUID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(deletedDestination.GUID).To(Equal(createdDestinations[0].GUID))
+					Expect(deletedPolicies).To(HaveLen(1))
+					Expect(deletedPolicies[0].Source.ID).To(Equal("some-app-guid"))
+
+					policies, err := egressPolicyStore.All(context.Background())
+					Expect(err).NotTo(HaveOccurred())
+					Expect(policies).To(BeEmpty())
+
+					destinations, err := egressDestinationsStore.All()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(destinations).To(BeEmpty())
+				})
 			})
 		})
 	})
@@ -246,6 +264,7 @@ var _ = Describe("EgressDestinationStore", func() {
 			terminalsRepo           *fakes.TerminalsRepo
 			egressDestinationRepo   *fakes.EgressDestinationRepo
 			destinationMetadataRepo *fakes.DestinationMetadataRepo
+			egressPolicyStore       *fakes.EgressPolicyDestinationDeleter
 		)
 
 		BeforeEach(func() {
@@ -257,12 +276,14 @@ var _ = Describe("EgressDestinationStore", func() {
 			terminalsRepo = &fakes.TerminalsRepo{}
 			egressDestinationRepo = &fakes.EgressDestinationRepo{}
 			destinationMetadataRepo = &fakes.DestinationMetadataRepo{}
+			egressPolicyStore = &fakes.EgressPolicyDestinationDeleter{}
 
 			egressDestinationsStore = &store.EgressDestinationStore{
 				Conn: mockDB,
 				EgressDestinationRepo:   egressDestinationRepo,
 				DestinationMetadataRepo: destinationMetadataRepo,
 				TerminalsRepo:           terminalsRepo,
+				EgressPolicyStore:       egressPolicyStore,
 			}
 		})
 
@@ -495,6 +516,45 @@ var _ = Describe("EgressDestinationStore", func() {
 					Expect(err).To(MatchError("egress destination store delete destination commit: can't commit transaction"))
 				})
 			})
+
+			It("does not delete bound egress policies", func() {
+				_, err = egressDestinationsStore.Delete("a-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyStore.DeleteByDestinationWithTxCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("DeleteWithPolicies", func() {
+			It("deletes the bound egress policies in the same transaction", func() {
+				egressPolicyStore.DeleteByDestinationWithTxReturns([]store.EgressPolicy{{ID: "some-policy-guid"}}, nil)
+
+				_, deletedPolicies, err := egressDestinationsStore.DeleteWithPolicies("a-guid")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deletedPolicies).To(Equal([]store.EgressPolicy{{ID: "some-policy-guid"}}))
+
+				Expect(egressPolicyStore.DeleteByDestinationWithTxCallCount()).To(Equal(1))
+				passedTx, passedGUID := egressPolicyStore.DeleteByDestinationWithTxArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedGUID).To(Equal("a-guid"))
+				Expect(tx.CommitCallCount()).To(Equal(1))
+			})
+
+			Context("when deleting the bound egress policies fails", func() {
+				var err error
+				BeforeEach(func() {
+					egressPolicyStore.DeleteByDestinationWithTxReturns(nil, errors.New("can't delete policies"))
+					_, _, err = egressDestinationsStore.DeleteWithPolicies("a-guid")
+				})
+
+				It("rolls back the transaction", func() {
+					Expect(tx.RollbackCallCount()).To(Equal(1))
+					Expect(egressDestinationRepo.DeleteCallCount()).To(Equal(0))
+				})
+
+				It("returns an error", func() {
+					Expect(err).To(MatchError("egress destination store delete bound egress policies: can't delete policies"))
+				})
+			})
 		})
 	})
 })
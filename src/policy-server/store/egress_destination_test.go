@@ -202,5 +202,65 @@ var _ = Describe("EgressDestination", func() {
 				Expect(destinations[0].ICMPCode).To(Equal(-1))
 			})
 		})
+
+		Context("GetByFilter", func() {
+			It("filters by id", func() {
+				destinations, err := egressDestinationTable.GetByFilter(tx, store.EgressDestinationFilter{
+					IDs: []string{terminalIds[1]},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(1))
+				Expect(destinations[0].GUID).To(Equal(terminalIds[1]))
+			})
+
+			It("filters by name", func() {
+				destinations, err := egressDestinationTable.GetByFilter(tx, store.EgressDestinationFilter{
+					Names: []string{"dest name"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(1))
+				Expect(destinations[0].GUID).To(Equal(terminalIds[0]))
+				Expect(destinations[0].Name).To(Equal("dest name"))
+			})
+
+			It("filters by protocol", func() {
+				destinations, err := egressDestinationTable.GetByFilter(tx, store.EgressDestinationFilter{
+					Protocols: []string{"udp"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(1))
+				Expect(destinations[0].GUID).To(Equal(terminalIds[1]))
+			})
+
+			It("returns every rule of a destination when any of its rules matches the protocol", func() {
+				_, err = egressDestinationTable.CreateIPRange(tx, terminalIds[0], "3.3.3.3", "3.3.3.4", "udp", 53, 53, -1, -1)
+				Expect(err).NotTo(HaveOccurred())
+
+				destinations, err := egressDestinationTable.GetByFilter(tx, store.EgressDestinationFilter{
+					Protocols: []string{"udp"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(HaveLen(3))
+
+				Expect(destinations[0].GUID).To(Equal(terminalIds[0]))
+				Expect(destinations[0].Protocol).To(Equal("tcp"))
+				Expect(destinations[0].IPRanges).To(Equal([]store.IPRange{{Start: "1.1.1.1", End: "2.2.2.2"}}))
+
+				Expect(destinations[1].GUID).To(Equal(terminalIds[1]))
+
+				Expect(destinations[2].GUID).To(Equal(terminalIds[0]))
+				Expect(destinations[2].Protocol).To(Equal("udp"))
+				Expect(destinations[2].IPRanges).To(Equal([]store.IPRange{{Start: "3.3.3.3", End: "3.3.3.4"}}))
+			})
+
+			It("combines filters", func() {
+				destinations, err := egressDestinationTable.GetByFilter(tx, store.EgressDestinationFilter{
+					Names:     []string{"dest name"},
+					Protocols: []string{"udp"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(destinations).To(BeEmpty())
+			})
+		})
 	})
 })
@@ -1,9 +1,12 @@
 package store
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"policy-server/store/helpers"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
@@ -100,16 +103,18 @@ func (e *EgressPolicyTable) CreateIPRange(tx db.Transaction, destinationTerminal
 	return -1, fmt.Errorf("unknown driver: %s", driverName)
 }
 
-func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error) {
+func (e *EgressPolicyTable) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID, action string, expiresAt *time.Time) (string, error) {
 	guid := e.Guids.New()
 
 	_, err := tx.Exec(tx.Rebind(`
-			INSERT INTO egress_policies (guid, source_guid, destination_guid)
-			VALUES (?,?,?)
+			INSERT INTO egress_policies (guid, source_guid, destination_guid, action, expires_at)
+			VALUES (?,?,?,?,?)
 		`),
 		guid,
 		sourceTerminalGUID,
 		destinationTerminalGUID,
+		action,
+		utcTime(expiresAt),
 	)
 
 	if err != nil {
@@ -156,6 +161,78 @@ func (e *EgressPolicyTable) CreateSpace(tx db.Transaction, sourceTerminalGUID, s
 	return -1, fmt.Errorf("unknown driver: %s", driverName)
 }
 
+func (e *EgressPolicyTable) CreateOrg(tx db.Transaction, sourceTerminalGUID, orgGUID string) (int64, error) {
+	driverName := tx.DriverName()
+
+	if driverName == "mysql" {
+		result, err := tx.Exec(tx.Rebind(`
+			INSERT INTO orgs (terminal_guid, org_guid)
+			VALUES (?,?)
+		`),
+			sourceTerminalGUID,
+			orgGUID,
+		)
+		if err != nil {
+			return -1, err
+		}
+
+		return result.LastInsertId()
+	} else if driverName == "postgres" {
+		var id int64
+
+		err := tx.QueryRow(tx.Rebind(`
+			INSERT INTO orgs (terminal_guid, org_guid)
+			VALUES (?,?)
+			RETURNING id
+		`),
+			sourceTerminalGUID,
+			orgGUID,
+		).Scan(&id)
+
+		if err != nil {
+			return -1, fmt.Errorf("error inserting org: %s", err)
+		}
+
+		return id, nil
+	}
+	return -1, fmt.Errorf("unknown driver: %s", driverName)
+}
+
+func (e *EgressPolicyTable) CreateDefault(tx db.Transaction, sourceTerminalGUID string) (int64, error) {
+	driverName := tx.DriverName()
+
+	if driverName == "mysql" {
+		result, err := tx.Exec(tx.Rebind(`
+			INSERT INTO default_sources (terminal_guid)
+			VALUES (?)
+		`),
+			sourceTerminalGUID,
+		)
+		if err != nil {
+			return -1, err
+		}
+
+		return result.LastInsertId()
+	} else if driverName == "postgres" {
+		var id int64
+
+		err := tx.QueryRow(tx.Rebind(`
+			INSERT INTO default_sources (terminal_guid)
+			VALUES (?)
+			RETURNING id
+		`),
+			sourceTerminalGUID,
+		).Scan(&id)
+
+		if err != nil {
+			return -1, fmt.Errorf("error inserting default source: %s", err)
+		}
+
+		return id, nil
+	}
+	return -1, fmt.Errorf("unknown driver: %s", driverName)
+}
+
 func (e *EgressPolicyTable) DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error {
 	_, err := tx.Exec(tx.Rebind(`DELETE FROM egress_policies WHERE guid = ?`), egressPolicyGUID)
 	return err
@@ -176,6 +253,16 @@ func (e *EgressPolicyTable) DeleteSpace(tx db.Transaction, terminalGUID string)
 	return err
 }
 
+func (e *EgressPolicyTable) DeleteOrg(tx db.Transaction, terminalGUID string) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM orgs WHERE terminal_guid = ?`), terminalGUID)
+	return err
+}
+
+func (e *EgressPolicyTable) DeleteDefault(tx db.Transaction, terminalGUID string) error {
+	_, err := tx.Exec(tx.Rebind(`DELETE FROM default_sources WHERE terminal_guid = ?`), terminalGUID)
+	return err
+}
+
 func (e *EgressPolicyTable) IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error) {
 	var count int64
 	err := tx.QueryRow(tx.Rebind(`SELECT COUNT(guid) FROM egress_policies WHERE source_guid = ? OR destination_guid = ?`), terminalGUID, terminalGUID).Scan(&count)
@@ -192,7 +279,7 @@ func (e *EgressPolicyTable) GetByGUID(tx db.Transaction, guids ...string) ([]Egr
 
 	rows, err := tx.Queryx(tx.Rebind(
 		selectEgressPolicyQuery(`
-			WHERE egress_policies.guid IN (`+generateQuestionMarkString(len(guids))+`)
+			WHERE egress_policies.guid IN (`+helpers.QuestionMarks(len(guids))+`)
 			ORDER BY ip_ranges.id;`,
 		)),
 		convertToInterfaceSlice(guids)...)
@@ -203,6 +290,24 @@ func (e *EgressPolicyTable) GetByGUID(tx db.Transaction, guids ...string) ([]Egr
 	return e.convertRowsToEgressPolicies(rows)
 }
 
+func (e *EgressPolicyTable) GetByDestinationGUID(tx db.Transaction, destinationGUIDs ...string) ([]EgressPolicy, error) {
+	if len(destinationGUIDs) == 0 {
+		return []EgressPolicy{}, nil
+	}
+
+	rows, err := tx.Queryx(tx.Rebind(
+		selectEgressPolicyQuery(`
+			WHERE egress_policies.destination_guid IN (`+helpers.QuestionMarks(len(destinationGUIDs))+`)
+			ORDER BY ip_ranges.id;`,
+		)),
+		convertToInterfaceSlice(destinationGUIDs)...)
+	if err != nil {
+		return []EgressPolicy{}, err
+	}
+
+	return e.convertRowsToEgressPolicies(rows)
+}
+
 func (e *EgressPolicyTable) GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error) {
 	var guid string
 
@@ -235,8 +340,36 @@ func (e *EgressPolicyTable) GetTerminalBySpaceGUID(tx db.Transaction, spaceGUID
 	}
 }
 
-func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
-	rows, err := e.Conn.Query(selectEgressPolicyQuery())
+func (e *EgressPolicyTable) GetTerminalByOrgGUID(tx db.Transaction, orgGUID string) (string, error) {
+	var guid string
+
+	err := tx.QueryRow(tx.Rebind(`
+		SELECT terminal_guid FROM orgs WHERE org_guid = ?
+	`),
+		orgGUID,
+	).Scan(&guid)
+
+	if err != nil && err == sql.ErrNoRows {
+		return "", nil
+	} else {
+		return guid, err
+	}
+}
+
+func (e *EgressPolicyTable) GetDefaultTerminal(tx db.Transaction) (string, error) {
+	var guid string
+
+	err := tx.QueryRow(`SELECT terminal_guid FROM default_sources ORDER BY id LIMIT 1`).Scan(&guid)
+
+	if err != nil && err == sql.ErrNoRows {
+		return "", nil
+	} else {
+		return guid, err
+	}
+}
+
+func (e *EgressPolicyTable) GetAllPolicies(ctx context.Context) ([]EgressPolicy, error) {
+	rows, err := e.Conn.QueryContext(ctx, selectEgressPolicyQuery())
 	if err != nil {
 		return []EgressPolicy{}, err
 	}
@@ -244,14 +377,87 @@ func (e *EgressPolicyTable) GetAllPolicies() ([]EgressPolicy, error) {
 	return e.convertRowsToEgressPolicies(rows)
 }
 
-func (e *EgressPolicyTable) GetBySourceGuids(ids []string) ([]EgressPolicy, error) {
+func (e *EgressPolicyTable) GetBySourceGuids(ctx context.Context, ids []string) ([]EgressPolicy, error) {
+	return e.getBySourceGuids(ctx, ids, ``)
+}
+
+// GetBySourceGuidsWithDefault also returns the policies with the default
+// source, which apply to every app, so that they reach the policy agents along
+// with the policies of the apps themselves.
+func (e *EgressPolicyTable) GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]EgressPolicy, error) {
+	return e.getBySourceGuids(ctx, ids, `OR default_sources.terminal_guid IS NOT NULL`)
+}
 
+func (e *EgressPolicyTable) getBySourceGuids(ctx context.Context, ids []string, extraCondition string) ([]EgressPolicy, error) {
 	query := selectEgressPolicyQuery(fmt.Sprintf(`
-		WHERE apps.app_guid IN (%[1]s) OR spaces.space_guid IN (%[1]s)
-		ORDER BY ip_ranges.id;`, generateQuestionMarkString(len(ids))))
+		WHERE apps.app_guid IN (%[1]s) OR spaces.space_guid IN (%[1]s) OR orgs.org_guid IN (%[1]s)
+			%[2]s
+		ORDER BY ip_ranges.id;`, helpers.QuestionMarks(len(ids)), extraCondition))
+
+	args := convertToInterfaceSlice(ids)
+	args = append(args, convertToInterfaceSlice(ids)...)
+	args = append(args, convertToInterfaceSlice(ids)...)
+	rows, err := e.Conn.QueryContext(ctx, e.Conn.Rebind(query), args...)
+	if err != nil {
+		return []EgressPolicy{}, err
+	}
 
-	ids = append(ids, ids...)
-	rows, err := e.Conn.Query(e.Conn.Rebind(query), convertToInterfaceSlice(ids)...)
+	return e.convertRowsToEgressPolicies(rows)
+}
+
+func (e *EgressPolicyTable) GetByFilter(ctx context.Context, filter EgressPolicyFilter) ([]EgressPolicy, error) {
+	var conditions []string
+	var args []interface{}
+
+	if len(filter.SourceIDs) > 0 {
+		conditions = append(conditions, fmt.Sprintf(`(apps.app_guid IN (%[1]s) OR spaces.space_guid IN (%[1]s) OR orgs.org_guid IN (%[1]s))`,
+			helpers.QuestionMarks(len(filter.SourceIDs))))
+		args = append(args, convertToInterfaceSlice(filter.SourceIDs)...)
+		args = append(args, convertToInterfaceSlice(filter.SourceIDs)...)
+		args = append(args, convertToInterfaceSlice(filter.SourceIDs)...)
+	}
+
+	if len(filter.SourceTypes) > 0 {
+		var typeConditions []string
+		for _, sourceType := range filter.SourceTypes {
+			switch sourceType {
+			case "app":
+				typeConditions = append(typeConditions, `apps.app_guid IS NOT NULL`)
+			case "space":
+				typeConditions = append(typeConditions, `spaces.space_guid IS NOT NULL`)
+			case "org":
+				typeConditions = append(typeConditions, `orgs.org_guid IS NOT NULL`)
+			case "default":
+				typeConditions = append(typeConditions, `default_sources.terminal_guid IS NOT NULL`)
+			}
+		}
+		if len(typeConditions) > 0 {
+			conditions = append(conditions, `(`+strings.Join(typeConditions, ` OR `)+`)`)
+		}
+	}
+
+	if len(filter.DestinationIDs) > 0 {
+		conditions = append(conditions, `egress_policies.destination_guid IN (`+helpers.QuestionMarks(len(filter.DestinationIDs))+`)`)
+		args = append(args, convertToInterfaceSlice(filter.DestinationIDs)...)
+	}
+
+	if len(filter.DestinationNames) > 0 {
+		conditions = append(conditions, `destination_metadatas.name IN (`+helpers.QuestionMarks(len(filter.DestinationNames))+`)`)
+		args = append(args, convertToInterfaceSlice(filter.DestinationNames)...)
+	}
+
+	if len(filter.Protocols) > 0 {
+		conditions = append(conditions, `egress_policies.destination_guid IN (SELECT terminal_guid FROM ip_ranges WHERE protocol IN (`+helpers.QuestionMarks(len(filter.Protocols))+`))`)
+		args = append(args, convertToInterfaceSlice(filter.Protocols)...)
+	}
+
+	var whereClause string
+	if len(conditions) > 0 {
+		whereClause = `WHERE ` + strings.Join(conditions, ` AND `)
+	}
+
+	query := selectEgressPolicyQuery(whereClause, `ORDER BY ip_ranges.id`)
+	rows, err := e.Conn.QueryContext(ctx, e.Conn.Rebind(query), args...)
 	if err != nil {
 		return []EgressPolicy{}, err
 	}
@@ -268,6 +474,8 @@ func selectEgressPolicyQuery(extraClauses ...string) string {
 			COALESCE(destination_metadatas.description, ''),
 			apps.app_guid,
 			spaces.space_guid,
+			orgs.org_guid,
+			default_sources.terminal_guid,
 			ip_ranges.terminal_guid,
 			ip_ranges.protocol,
 			ip_ranges.start_ip,
@@ -275,10 +483,14 @@ func selectEgressPolicyQuery(extraClauses ...string) string {
 			ip_ranges.start_port,
 			ip_ranges.end_port,
 			ip_ranges.icmp_type,
-			ip_ranges.icmp_code
+			ip_ranges.icmp_code,
+			egress_policies.action,
+			egress_policies.expires_at
 		FROM egress_policies
 		LEFT OUTER JOIN apps ON (egress_policies.source_guid = apps.terminal_guid)
 		LEFT OUTER JOIN spaces ON (egress_policies.source_guid = spaces.terminal_guid)
+		LEFT OUTER JOIN orgs ON (egress_policies.source_guid = orgs.terminal_guid)
+		LEFT OUTER JOIN default_sources ON (egress_policies.source_guid = default_sources.terminal_guid)
 		LEFT OUTER JOIN ip_ranges ON (egress_policies.destination_guid = ip_ranges.terminal_guid)
 		LEFT OUTER JOIN destination_metadatas ON (egress_policies.destination_guid = destination_metadatas.terminal_guid)
 		%s;`, strings.Join(extraClauses, " "))
@@ -294,8 +506,10 @@ func (e *EgressPolicyTable) convertRowsToEgressPolicies(rows sqlRows) ([]EgressP
 	var foundPolicies []EgressPolicy
 	defer rows.Close()
 	for rows.Next() {
-		var egressPolicyGUID, sourceTerminalGUID, name, description, destinationGUID, sourceAppGUID, sourceSpaceGUID, protocol, startIP, endIP *string
+		var egressPolicyGUID, sourceTerminalGUID, name, description, destinationGUID, sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, sourceDefaultTerminalGUID, protocol, startIP, endIP *string
+		var action string
 		var startPort, endPort, icmpType, icmpCode int
+		var expiresAt *time.Time
 		err := rows.Scan(
 			&egressPolicyGUID,
 			&sourceTerminalGUID,
@@ -303,6 +517,8 @@ func (e *EgressPolicyTable) convertRowsToEgressPolicies(rows sqlRows) ([]EgressP
 			&description,
 			&sourceAppGUID,
 			&sourceSpaceGUID,
+			&sourceOrgGUID,
+			&sourceDefaultTerminalGUID,
 			&destinationGUID,
 			&protocol,
 			&startIP,
@@ -310,7 +526,9 @@ func (e *EgressPolicyTable) convertRowsToEgressPolicies(rows sqlRows) ([]EgressP
 			&startPort,
 			&endPort,
 			&icmpType,
-			&icmpCode)
+			&icmpCode,
+			&action,
+			&expiresAt)
 		if err != nil {
 			return foundPolicies, err
 		}
@@ -322,20 +540,24 @@ func (e *EgressPolicyTable) convertRowsToEgressPolicies(rows sqlRows) ([]EgressP
 			destinationGUID,
 			sourceAppGUID,
 			sourceSpaceGUID,
+			sourceOrgGUID,
+			sourceDefaultTerminalGUID,
 			protocol,
 			startIP,
 			endIP,
 			startPort,
 			endPort,
 			icmpType,
-			icmpCode))
+			icmpCode,
+			action,
+			expiresAt))
 	}
 	return foundPolicies, nil
 }
 
 func mapRowToEgressPolicy(egressPolicyGUID, sourceTerminalGUID, name, description, destinationGUID,
-	sourceAppGUID, sourceSpaceGUID, protocol, startIP, endIP *string,
-	startPort, endPort, icmpType, icmpCode int) EgressPolicy {
+	sourceAppGUID, sourceSpaceGUID, sourceOrgGUID, sourceDefaultTerminalGUID, protocol, startIP, endIP *string,
+	startPort, endPort, icmpType, icmpCode int, action string, expiresAt *time.Time) EgressPolicy {
 
 	var ports []Ports
 	if startPort != 0 && endPort != 0 {
@@ -350,6 +572,17 @@ func mapRowToEgressPolicy(egressPolicyGUID, sourceTerminalGUID, name, descriptio
 	var source EgressSource
 
 	switch {
+	case sourceDefaultTerminalGUID != nil:
+		source = EgressSource{
+			Type:         "default",
+			TerminalGUID: *sourceTerminalGUID,
+		}
+	case sourceOrgGUID != nil:
+		source = EgressSource{
+			ID:           *sourceOrgGUID,
+			Type:         "org",
+			TerminalGUID: *sourceTerminalGUID,
+		}
 	case sourceSpaceGUID != nil:
 		source = EgressSource{
 			ID:           *sourceSpaceGUID,
@@ -382,5 +615,7 @@ func mapRowToEgressPolicy(egressPolicyGUID, sourceTerminalGUID, name, descriptio
 			ICMPType: icmpType,
 			ICMPCode: icmpCode,
 		},
+		Action:    action,
+		ExpiresAt: expiresAt,
 	}
 }
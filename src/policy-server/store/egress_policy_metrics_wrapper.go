@@ -1,15 +1,18 @@
 package store
 
 import (
+	"context"
 	"time"
 )
 
 //go:generate counterfeiter -o fakes/egress_policy_store.go --fake-name EgressPolicyStore . egressPolicyStore
 type egressPolicyStore interface {
-	Create([]EgressPolicy) ([]EgressPolicy, error)
-	Delete(guids ...string) ([]EgressPolicy, error)
-	All() ([]EgressPolicy, error)
-	GetBySourceGuids(srcGuids []string) ([]EgressPolicy, error)
+	Create(context.Context, []EgressPolicy) ([]EgressPolicy, error)
+	Delete(ctx context.Context, guids ...string) ([]EgressPolicy, error)
+	All(context.Context) ([]EgressPolicy, error)
+	GetBySourceGuids(ctx context.Context, srcGuids []string) ([]EgressPolicy, error)
+	GetBySourceGuidsWithDefault(ctx context.Context, srcGuids []string) ([]EgressPolicy, error)
+	GetByFilter(ctx context.Context, filter EgressPolicyFilter) ([]EgressPolicy, error)
 }
 
 type EgressPolicyMetricsWrapper struct {
@@ -17,9 +20,9 @@ type EgressPolicyMetricsWrapper struct {
 	MetricsSender metricsSender
 }
 
-func (mw *EgressPolicyMetricsWrapper) Create(egressPolicies []EgressPolicy) ([]EgressPolicy, error) {
+func (mw *EgressPolicyMetricsWrapper) Create(ctx context.Context, egressPolicies []EgressPolicy) ([]EgressPolicy, error) {
 	startTime := time.Now()
-	policies, err := mw.Store.Create(egressPolicies)
+	policies, err := mw.Store.Create(ctx, egressPolicies)
 	createTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("EgressPolicyStoreCreateError")
@@ -30,9 +33,9 @@ func (mw *EgressPolicyMetricsWrapper) Create(egressPolicies []EgressPolicy) ([]E
 	return policies, err
 }
 
-func (mw *EgressPolicyMetricsWrapper) All() ([]EgressPolicy, error) {
+func (mw *EgressPolicyMetricsWrapper) All(ctx context.Context) ([]EgressPolicy, error) {
 	startTime := time.Now()
-	policies, err := mw.Store.All()
+	policies, err := mw.Store.All(ctx)
 	allTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("EgressPolicyStoreAllError")
@@ -43,9 +46,9 @@ func (mw *EgressPolicyMetricsWrapper) All() ([]EgressPolicy, error) {
 	return policies, err
 }
 
-func (mw *EgressPolicyMetricsWrapper) Delete(guids ...string) ([]EgressPolicy, error) {
+func (mw *EgressPolicyMetricsWrapper) Delete(ctx context.Context, guids ...string) ([]EgressPolicy, error) {
 	startTime := time.Now()
-	egressPolicies, err := mw.Store.Delete(guids...)
+	egressPolicies, err := mw.Store.Delete(ctx, guids...)
 	deleteTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("EgressPolicyStoreDeleteError")
@@ -56,9 +59,9 @@ func (mw *EgressPolicyMetricsWrapper) Delete(guids ...string) ([]EgressPolicy, e
 	return egressPolicies, err
 }
 
-func (mw *EgressPolicyMetricsWrapper) GetBySourceGuids(srcGuids []string) ([]EgressPolicy, error) {
+func (mw *EgressPolicyMetricsWrapper) GetBySourceGuids(ctx context.Context, srcGuids []string) ([]EgressPolicy, error) {
 	startTime := time.Now()
-	egressPolicies, err := mw.Store.GetBySourceGuids(srcGuids)
+	egressPolicies, err := mw.Store.GetBySourceGuids(ctx, srcGuids)
 	byGuidsTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("EgressPolicyStoreGetBySourceGuidsError")
@@ -68,3 +71,29 @@ func (mw *EgressPolicyMetricsWrapper) GetBySourceGuids(srcGuids []string) ([]Egr
 	}
 	return egressPolicies, err
 }
+
+func (mw *EgressPolicyMetricsWrapper) GetBySourceGuidsWithDefault(ctx context.Context, srcGuids []string) ([]EgressPolicy, error) {
+	startTime := time.Now()
+	egressPolicies, err := mw.Store.GetBySourceGuidsWithDefault(ctx, srcGuids)
+	byGuidsTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("EgressPolicyStoreGetBySourceGuidsWithDefaultError")
+		mw.MetricsSender.SendDuration("EgressPolicyStoreGetBySourceGuidsWithDefaultErrorTime", byGuidsTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("EgressPolicyStoreGetBySourceGuidsWithDefaultSuccessTime", byGuidsTimeDuration)
+	}
+	return egressPolicies, err
+}
+
+func (mw *EgressPolicyMetricsWrapper) GetByFilter(ctx context.Context, filter EgressPolicyFilter) ([]EgressPolicy, error) {
+	startTime := time.Now()
+	egressPolicies, err := mw.Store.GetByFilter(ctx, filter)
+	byFilterTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("EgressPolicyStoreGetByFilterError")
+		mw.MetricsSender.SendDuration("EgressPolicyStoreGetByFilterErrorTime", byFilterTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("EgressPolicyStoreGetByFilterSuccessTime", byFilterTimeDuration)
+	}
+	return egressPolicies, err
+}
@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/store"
 	"policy-server/store/fakes"
@@ -39,17 +40,17 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 		It("calls Create on the Store", func() {
 			createdPolicies := []store.EgressPolicy{{ID: "hi"}}
 			fakeStore.CreateReturns(createdPolicies, nil)
-			returnedPolicies, err := metricsWrapper.Create(policies)
+			returnedPolicies, err := metricsWrapper.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeStore.CreateCallCount()).To(Equal(1))
-			passedPolicies := fakeStore.CreateArgsForCall(0)
+			_, passedPolicies := fakeStore.CreateArgsForCall(0)
 			Expect(passedPolicies).To(Equal(policies))
 			Expect(returnedPolicies).To(Equal(createdPolicies))
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.Create(policies)
+			_, err := metricsWrapper.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -63,7 +64,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 			})
 
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.Create(policies)
+				_, err := metricsWrapper.Create(context.Background(), policies)
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -81,7 +82,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 			fakeStore.AllReturns(policies, nil)
 		})
 		It("returns the result of All on the Store", func() {
-			returnedPolicies, err := metricsWrapper.All()
+			returnedPolicies, err := metricsWrapper.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedPolicies).To(Equal(policies))
 
@@ -89,7 +90,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.All()
+			_, err := metricsWrapper.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -102,7 +103,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 				fakeStore.AllReturns(nil, errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.All()
+				_, err := metricsWrapper.All(context.Background())
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -121,17 +122,17 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 			fakeStore.GetBySourceGuidsReturns(policies, nil)
 		})
 		It("returns the result of GetBySourceGuids on the Store", func() {
-			returnedPolicies, err := metricsWrapper.GetBySourceGuids(srcGuids)
+			returnedPolicies, err := metricsWrapper.GetBySourceGuids(context.Background(), srcGuids)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedPolicies).To(Equal(policies))
 
 			Expect(fakeStore.GetBySourceGuidsCallCount()).To(Equal(1))
-			returnedSrcGuids := fakeStore.GetBySourceGuidsArgsForCall(0)
+			_, returnedSrcGuids := fakeStore.GetBySourceGuidsArgsForCall(0)
 			Expect(returnedSrcGuids).To(Equal(srcGuids))
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.GetBySourceGuids(srcGuids)
+			_, err := metricsWrapper.GetBySourceGuids(context.Background(), srcGuids)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -144,7 +145,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 				fakeStore.GetBySourceGuidsReturns(nil, errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.GetBySourceGuids(srcGuids)
+				_, err := metricsWrapper.GetBySourceGuids(context.Background(), srcGuids)
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -158,6 +159,93 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 		})
 	})
 
+	Describe("GetBySourceGuidsWithDefault", func() {
+		BeforeEach(func() {
+			fakeStore.GetBySourceGuidsWithDefaultReturns(policies, nil)
+		})
+		It("returns the result of GetBySourceGuidsWithDefault on the Store", func() {
+			returnedPolicies, err := metricsWrapper.GetBySourceGuidsWithDefault(context.Background(), srcGuids)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedPolicies).To(Equal(policies))
+
+			Expect(fakeStore.GetBySourceGuidsWithDefaultCallCount()).To(Equal(1))
+			_, returnedSrcGuids := fakeStore.GetBySourceGuidsWithDefaultArgsForCall(0)
+			Expect(returnedSrcGuids).To(Equal(srcGuids))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.GetBySourceGuidsWithDefault(context.Background(), srcGuids)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("EgressPolicyStoreGetBySourceGuidsWithDefaultSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeStore.GetBySourceGuidsWithDefaultReturns(nil, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.GetBySourceGuidsWithDefault(context.Background(), srcGuids)
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreGetBySourceGuidsWithDefaultError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("EgressPolicyStoreGetBySourceGuidsWithDefaultErrorTime"))
+
+			})
+		})
+	})
+
+	Describe("GetByFilter", func() {
+		var filter store.EgressPolicyFilter
+
+		BeforeEach(func() {
+			filter = store.EgressPolicyFilter{SourceIDs: srcGuids}
+			fakeStore.GetByFilterReturns(policies, nil)
+		})
+
+		It("returns the result of GetByFilter on the Store", func() {
+			returnedPolicies, err := metricsWrapper.GetByFilter(context.Background(), filter)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedPolicies).To(Equal(policies))
+
+			Expect(fakeStore.GetByFilterCallCount()).To(Equal(1))
+			_, passedFilter := fakeStore.GetByFilterArgsForCall(0)
+			Expect(passedFilter).To(Equal(filter))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.GetByFilter(context.Background(), filter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("EgressPolicyStoreGetByFilterSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeStore.GetByFilterReturns(nil, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.GetByFilter(context.Background(), filter)
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("EgressPolicyStoreGetByFilterError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("EgressPolicyStoreGetByFilterErrorTime"))
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		var egressPolicies []store.EgressPolicy
 		BeforeEach(func() {
@@ -166,17 +254,17 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 		})
 
 		It("calls Delete on the Store", func() {
-			policies, err := metricsWrapper.Delete("some-policy-guid")
+			policies, err := metricsWrapper.Delete(context.Background(), "some-policy-guid")
 			Expect(err).NotTo(HaveOccurred())
 			Expect(policies).To(Equal(egressPolicies))
 
 			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-			passedPolicies := fakeStore.DeleteArgsForCall(0)
+			_, passedPolicies := fakeStore.DeleteArgsForCall(0)
 			Expect(passedPolicies).To(ConsistOf("some-policy-guid"))
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.Delete("some-policy-guid")
+			_, err := metricsWrapper.Delete(context.Background(), "some-policy-guid")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -189,7 +277,7 @@ var _ = Describe("EgressPolicyMetricsWrapper", func() {
 				fakeStore.DeleteReturns(nil, errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.Delete("some-policy-guid")
+				_, err := metricsWrapper.Delete(context.Background(), "some-policy-guid")
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
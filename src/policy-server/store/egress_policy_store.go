@@ -1,7 +1,9 @@
 package store
 
 import (
+	"context"
 	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
@@ -10,17 +12,26 @@ import (
 type egressPolicyRepo interface {
 	CreateApp(tx db.Transaction, sourceTerminalGUID string, appGUID string) (int64, error)
 	CreateIPRange(tx db.Transaction, destinationTerminalGUID string, startIP, endIP, protocol string, startPort, endPort, icmpType, icmpCode int64) (int64, error)
-	CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error)
+	CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID, action string, expiresAt *time.Time) (string, error)
 	CreateSpace(tx db.Transaction, sourceTerminalGUID string, spaceGUID string) (int64, error)
+	CreateOrg(tx db.Transaction, sourceTerminalGUID string, orgGUID string) (int64, error)
+	CreateDefault(tx db.Transaction, sourceTerminalGUID string) (int64, error)
 	GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error)
 	GetTerminalBySpaceGUID(tx db.Transaction, appGUID string) (string, error)
-	GetAllPolicies() ([]EgressPolicy, error)
-	GetBySourceGuids(ids []string) ([]EgressPolicy, error)
+	GetTerminalByOrgGUID(tx db.Transaction, orgGUID string) (string, error)
+	GetDefaultTerminal(tx db.Transaction) (string, error)
+	GetAllPolicies(ctx context.Context) ([]EgressPolicy, error)
+	GetBySourceGuids(ctx context.Context, ids []string) ([]EgressPolicy, error)
+	GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]EgressPolicy, error)
+	GetByFilter(ctx context.Context, filter EgressPolicyFilter) ([]EgressPolicy, error)
 	GetByGUID(tx db.Transaction, ids ...string) ([]EgressPolicy, error)
+	GetByDestinationGUID(tx db.Transaction, destinationGUIDs ...string) ([]EgressPolicy, error)
 	DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error
 	DeleteIPRange(tx db.Transaction, ipRangeID int64) error
 	DeleteApp(tx db.Transaction, terminalID string) error
 	DeleteSpace(tx db.Transaction, spaceID string) error
+	DeleteOrg(tx db.Transaction, orgID string) error
+	DeleteDefault(tx db.Transaction, terminalID string) error
 	IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error)
 }
 
@@ -36,27 +47,65 @@ type EgressPolicyStore struct {
 	Conn             Database
 }
 
-func (e *EgressPolicyStore) Create(policies []EgressPolicy) ([]EgressPolicy, error) {
-	tx, err := e.Conn.Beginx()
+func (e *EgressPolicyStore) Create(ctx context.Context, policies []EgressPolicy) ([]EgressPolicy, error) {
+	tx, err := beginx(ctx, e.Conn)
 	if err != nil {
 		return nil, fmt.Errorf("create transaction: %s", err)
 	}
 
-	policies, err = e.createWithTx(tx, policies)
+	policies, err = e.CreateWithTx(tx, policies)
 	if err != nil {
 		return nil, rollback(tx, err)
 	}
 
-	return policies, commit(tx)
+	return policies, commitContext(ctx, tx)
 }
 
-func (e *EgressPolicyStore) createWithTx(tx db.Transaction, policies []EgressPolicy) ([]EgressPolicy, error) {
+func (e *EgressPolicyStore) CreateWithTx(tx db.Transaction, policies []EgressPolicy) ([]EgressPolicy, error) {
+	err := bumpRevision(tx)
+	if err != nil {
+		return nil, err
+	}
+
 	var createdPolicies []EgressPolicy
 	for _, policy := range policies {
 		var sourceTerminalGUID string
-		var err error
 
 		switch policy.Source.Type {
+		case "default":
+			sourceTerminalGUID, err = e.EgressPolicyRepo.GetDefaultTerminal(tx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get default terminal: %s", err)
+			}
+
+			if sourceTerminalGUID == "" {
+				sourceTerminalGUID, err = e.TerminalsRepo.Create(tx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create source terminal: %s", err)
+				}
+
+				_, err = e.EgressPolicyRepo.CreateDefault(tx, sourceTerminalGUID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create default source: %s", err)
+				}
+			}
+		case "org":
+			sourceTerminalGUID, err = e.EgressPolicyRepo.GetTerminalByOrgGUID(tx, policy.Source.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get terminal by org guid: %s", err)
+			}
+
+			if sourceTerminalGUID == "" {
+				sourceTerminalGUID, err = e.TerminalsRepo.Create(tx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create source terminal: %s", err)
+				}
+
+				_, err = e.EgressPolicyRepo.CreateOrg(tx, sourceTerminalGUID, policy.Source.ID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to create org: %s", err)
+				}
+			}
 		case "space":
 			sourceTerminalGUID, err = e.EgressPolicyRepo.GetTerminalBySpaceGUID(tx, policy.Source.ID)
 			if err != nil {
@@ -93,7 +142,11 @@ func (e *EgressPolicyStore) createWithTx(tx db.Transaction, policies []EgressPol
 			}
 		}
 
-		createdPolicyGUID, err := e.EgressPolicyRepo.CreateEgressPolicy(tx, sourceTerminalGUID, policy.Destination.GUID)
+		if policy.Action == "" {
+			policy.Action = "allow"
+		}
+
+		createdPolicyGUID, err := e.EgressPolicyRepo.CreateEgressPolicy(tx, sourceTerminalGUID, policy.Destination.GUID, policy.Action, policy.ExpiresAt)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create egress policy: %s", err)
 		}
@@ -103,24 +156,44 @@ func (e *EgressPolicyStore) createWithTx(tx db.Transaction, policies []EgressPol
 
 		createdPolicies = append(createdPolicies, policy)
 	}
+
 	return createdPolicies, nil
 }
 
-func (e *EgressPolicyStore) Delete(egressPolicyGUIDs ...string) ([]EgressPolicy, error) {
-	tx, err := e.Conn.Beginx()
+func (e *EgressPolicyStore) Delete(ctx context.Context, egressPolicyGUIDs ...string) ([]EgressPolicy, error) {
+	tx, err := beginx(ctx, e.Conn)
 	if err != nil {
 		return []EgressPolicy{}, fmt.Errorf("create transaction: %s", err)
 	}
 
-	egressPolicies, err := e.deleteWithTx(tx, egressPolicyGUIDs...)
+	egressPolicies, err := e.DeleteWithTx(tx, egressPolicyGUIDs...)
 	if err != nil {
 		return []EgressPolicy{}, rollback(tx, err)
 	}
 
-	return egressPolicies, commit(tx)
+	return egressPolicies, commitContext(ctx, tx)
 }
 
-func (e *EgressPolicyStore) deleteWithTx(tx db.Transaction, egressPolicyGUIDs ...string) ([]EgressPolicy, error) {
+func (e *EgressPolicyStore) DeleteByDestinationWithTx(tx db.Transaction, destinationGUID string) ([]EgressPolicy, error) {
+	boundPolicies, err := e.EgressPolicyRepo.GetByDestinationGUID(tx, destinationGUID)
+	if err != nil {
+		return []EgressPolicy{}, fmt.Errorf("failed to find egress policies by destination: %s", err)
+	}
+
+	var boundPolicyGUIDs []string
+	for _, policy := range boundPolicies {
+		boundPolicyGUIDs = append(boundPolicyGUIDs, policy.ID)
+	}
+
+	return e.DeleteWithTx(tx, boundPolicyGUIDs...)
+}
+
+func (e *EgressPolicyStore) DeleteWithTx(tx db.Transaction, egressPolicyGUIDs ...string) ([]EgressPolicy, error) {
+	err := bumpRevision(tx)
+	if err != nil {
+		return []EgressPolicy{}, err
+	}
+
 	egressPolicies, err := e.EgressPolicyRepo.GetByGUID(tx, egressPolicyGUIDs...)
 	if err != nil {
 		return []EgressPolicy{}, fmt.Errorf("failed to find egress policy: %s", err)
@@ -156,6 +229,20 @@ func (e *EgressPolicyStore) deleteWithTx(tx db.Transaction, egressPolicyGUIDs ..
 				}
 			}
 
+			if egressPolicy.Source.Type == "org" {
+				err = e.EgressPolicyRepo.DeleteOrg(tx, egressPolicy.Source.TerminalGUID)
+				if err != nil {
+					return []EgressPolicy{}, fmt.Errorf("failed to delete source org: %s", err)
+				}
+			}
+
+			if egressPolicy.Source.Type == "default" {
+				err = e.EgressPolicyRepo.DeleteDefault(tx, egressPolicy.Source.TerminalGUID)
+				if err != nil {
+					return []EgressPolicy{}, fmt.Errorf("failed to delete default source: %s", err)
+				}
+			}
+
 			err = e.TerminalsRepo.Delete(tx, egressPolicy.Source.TerminalGUID)
 			if err != nil {
 				return []EgressPolicy{}, fmt.Errorf("failed to delete source terminal: %s", err)
@@ -166,14 +253,30 @@ func (e *EgressPolicyStore) deleteWithTx(tx db.Transaction, egressPolicyGUIDs ..
 	return egressPolicies, nil
 }
 
-func (e *EgressPolicyStore) All() ([]EgressPolicy, error) {
-	return e.EgressPolicyRepo.GetAllPolicies()
+func (e *EgressPolicyStore) All(ctx context.Context) ([]EgressPolicy, error) {
+	return e.EgressPolicyRepo.GetAllPolicies(ctx)
+}
+
+func (e *EgressPolicyStore) GetBySourceGuids(ctx context.Context, ids []string) ([]EgressPolicy, error) {
+	policies, err := e.EgressPolicyRepo.GetBySourceGuids(ctx, ids)
+	if err != nil {
+		return []EgressPolicy{}, fmt.Errorf("failed to get policies by guids: %s", err)
+	}
+	return policies, nil
 }
 
-func (e *EgressPolicyStore) GetBySourceGuids(ids []string) ([]EgressPolicy, error) {
-	policies, err := e.EgressPolicyRepo.GetBySourceGuids(ids)
+func (e *EgressPolicyStore) GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]EgressPolicy, error) {
+	policies, err := e.EgressPolicyRepo.GetBySourceGuidsWithDefault(ctx, ids)
 	if err != nil {
 		return []EgressPolicy{}, fmt.Errorf("failed to get policies by guids: %s", err)
 	}
 	return policies, nil
 }
+
+func (e *EgressPolicyStore) GetByFilter(ctx context.Context, filter EgressPolicyFilter) ([]EgressPolicy, error) {
+	policies, err := e.EgressPolicyRepo.GetByFilter(ctx, filter)
+	if err != nil {
+		return []EgressPolicy{}, fmt.Errorf("failed to get policies by filter: %s", err)
+	}
+	return policies, nil
+}
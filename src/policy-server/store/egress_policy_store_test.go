@@ -1,9 +1,11 @@
 package store_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/store"
 	"policy-server/store/fakes"
+	"time"
 
 	dbfakes "code.cloudfoundry.org/cf-networking-helpers/db/fakes"
 
@@ -21,6 +23,7 @@ var _ = Describe("EgressPolicyStore", func() {
 		tx             *dbfakes.Transaction
 		egressPolicies []store.EgressPolicy
 		spacePolicy    store.EgressPolicy
+		orgPolicy      store.EgressPolicy
 	)
 
 	BeforeEach(func() {
@@ -35,7 +38,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			Conn:             mockDb,
 		}
 
-		mockDb.BeginxReturns(tx, nil)
+		mockDb.BeginTxxReturns(tx, nil)
 
 		egressPolicies = []store.EgressPolicy{
 			{
@@ -66,6 +69,16 @@ var _ = Describe("EgressPolicyStore", func() {
 			},
 		}
 
+		orgPolicy = store.EgressPolicy{
+			Source: store.EgressSource{
+				Type: "org",
+				ID:   "org-guid",
+			},
+			Destination: store.EgressDestination{
+				GUID: "some-destination-guid",
+			},
+		}
+
 		egressPolicyRepo.GetTerminalByAppGUIDReturns("", nil)
 	})
 
@@ -76,7 +89,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			egressPolicyRepo.CreateEgressPolicyReturnsOnCall(0, "some-egress-policy-guid-1", nil)
 			egressPolicyRepo.CreateEgressPolicyReturnsOnCall(1, "some-egress-policy-guid-2", nil)
 
-			createdPolicies, err := egressPolicyStore.Create(egressPolicies)
+			createdPolicies, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateEgressPolicyCallCount()).To(Equal(2))
 			Expect(createdPolicies).To(HaveLen(2))
@@ -90,6 +103,7 @@ var _ = Describe("EgressPolicyStore", func() {
 					Destination: store.EgressDestination{
 						GUID: "some-destination-guid",
 					},
+					Action: "allow",
 				},
 				{
 					ID: "some-egress-policy-guid-2",
@@ -100,49 +114,83 @@ var _ = Describe("EgressPolicyStore", func() {
 					Destination: store.EgressDestination{
 						GUID: "some-destination-guid-2",
 					},
+					Action: "allow",
 				},
 			}))
 
-			argTx, sourceID, destinationID := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			argTx, sourceID, destinationID, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-terminal-app-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid"))
 
-			argTx, sourceID, destinationID = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			argTx, sourceID, destinationID, _, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-terminal-space-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid-2"))
 		})
 
+		It("bumps the policies revision in the same transaction", func() {
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tx.ExecCallCount()).To(Equal(1))
+			query, _ := tx.ExecArgsForCall(0)
+			Expect(query).To(Equal(`UPDATE policies_info SET revision = revision + 1 WHERE id = 1`))
+		})
+
+		Context("when bumping the policies revision fails", func() {
+			It("rolls back and returns an error", func() {
+				tx.ExecReturns(nil, errors.New("potato"))
+
+				_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
+				Expect(err).To(MatchError("bumping policies revision: potato"))
+				Expect(tx.RollbackCallCount()).To(Equal(1))
+				Expect(tx.CommitCallCount()).To(Equal(0))
+			})
+		})
+
 		It("returns an error when the database connection can't begin a transaction", func() {
-			mockDb.BeginxReturns(nil, errors.New("potato"))
-			_, err := egressPolicyStore.Create(egressPolicies)
+			mockDb.BeginTxxReturns(nil, errors.New("potato"))
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("create transaction: potato"))
 		})
 
+		It("begins the transaction with the request context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			_, err := egressPolicyStore.Create(ctx, egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockDb.BeginTxxCallCount()).To(Equal(1))
+			txCtx, opts := mockDb.BeginTxxArgsForCall(0)
+			Expect(txCtx).To(Equal(ctx))
+			Expect(opts).To(BeNil())
+			Expect(mockDb.BeginxCallCount()).To(Equal(0))
+		})
+
 		It("starts/commits transaction", func() {
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(mockDb.BeginxCallCount()).To(Equal(1))
+			Expect(mockDb.BeginTxxCallCount()).To(Equal(1))
 			Expect(tx.CommitCallCount()).To(Equal(1))
 		})
 
 		It("returns an error when begin transaction fails", func() {
-			mockDb.BeginxReturns(nil, errors.New("failed to begin"))
-			_, err := egressPolicyStore.Create(egressPolicies)
+			mockDb.BeginTxxReturns(nil, errors.New("failed to begin"))
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("create transaction: failed to begin"))
 		})
 
 		It("returns an error when commit transaction fails", func() {
 			tx.CommitReturns(errors.New("failed to commit"))
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("commit transaction: failed to commit"))
 		})
 
-		It("rollsback the tx when the createWithTx fails", func() {
+		It("rollsback the tx when the CreateWithTx fails", func() {
 			egressPolicyRepo.CreateAppReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("failed to create source app: OMG WHY DID THIS FAIL"))
 			Expect(tx.RollbackCallCount()).To(Equal(1))
 		})
@@ -150,7 +198,7 @@ var _ = Describe("EgressPolicyStore", func() {
 		It("returns an error when CreateTerminal fails", func() {
 			terminalsRepo.CreateReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("failed to create source terminal: OMG WHY DID THIS FAIL"))
 		})
 
@@ -158,7 +206,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			terminalsRepo.CreateReturnsOnCall(0, "some-term-guid", nil)
 			terminalsRepo.CreateReturnsOnCall(1, "some-term-guid-2", nil)
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(2))
@@ -178,14 +226,14 @@ var _ = Describe("EgressPolicyStore", func() {
 		It("returns an error when the CreateApp fails", func() {
 			egressPolicyRepo.CreateAppReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("failed to create source app: OMG WHY DID THIS FAIL"))
 		})
 
 		It("creates a space with a sourceTerminalGUID", func() {
 			egressPolicyRepo.GetTerminalBySpaceGUIDReturns("", nil)
 			terminalsRepo.CreateReturns("some-term-guid", nil)
-			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{spacePolicy})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateSpaceCallCount()).To(Equal(1))
 			Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(0))
@@ -199,45 +247,75 @@ var _ = Describe("EgressPolicyStore", func() {
 			terminalsRepo.CreateReturnsOnCall(0, "some-app-guid", nil)
 			terminalsRepo.CreateReturnsOnCall(1, "some-space-guid", nil)
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateEgressPolicyCallCount()).To(Equal(2))
 
-			argTx, sourceID, destinationID := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			argTx, sourceID, destinationID, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-app-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid"))
 
-			argTx, sourceID, destinationID = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			argTx, sourceID, destinationID, _, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
 			Expect(argTx).To(Equal(tx))
 			Expect(sourceID).To(Equal("some-space-guid"))
 			Expect(destinationID).To(Equal("some-destination-guid-2"))
 		})
 
+		It("passes the expiry through to the egress policy repo", func() {
+			expiresAt := time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)
+			egressPolicies[0].ExpiresAt = &expiresAt
+
+			createdPolicies, err := egressPolicyStore.Create(context.Background(), egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, _, passedExpiresAt := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			Expect(passedExpiresAt).To(Equal(&expiresAt))
+			_, _, _, _, passedExpiresAt = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			Expect(passedExpiresAt).To(BeNil())
+
+			Expect(createdPolicies[0].ExpiresAt).To(Equal(&expiresAt))
+		})
+
+		It("passes the action through to the egress policy repo, defaulting to allow", func() {
+			egressPolicies[0].Action = "deny"
+
+			createdPolicies, err := egressPolicyStore.Create(context.Background(), egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, _, _, passedAction, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			Expect(passedAction).To(Equal("deny"))
+			_, _, _, passedAction, _ = egressPolicyRepo.CreateEgressPolicyArgsForCall(1)
+			Expect(passedAction).To(Equal("allow"))
+
+			Expect(createdPolicies[0].Action).To(Equal("deny"))
+			Expect(createdPolicies[1].Action).To(Equal("allow"))
+		})
+
 		It("returns an error when the CreateEgressPolicy fails", func() {
 			egressPolicyRepo.CreateEgressPolicyReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("failed to create egress policy: OMG WHY DID THIS FAIL"))
 		})
 
 		It("uses the existing app terminal id when it exists", func() {
 			egressPolicyRepo.GetTerminalByAppGUIDReturns("66", nil)
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(0))
-			_, sourceID, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(sourceID).To(Equal("66"))
 		})
 
 		It("uses the existing space terminal id when it exists", func() {
 			egressPolicyRepo.GetTerminalBySpaceGUIDReturns("55", nil)
 
-			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{spacePolicy})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicyRepo.CreateSpaceCallCount()).To(Equal(0))
-			_, sourceID, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
 			Expect(sourceID).To(Equal("55"))
 		})
 
@@ -245,7 +323,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			egressPolicyRepo.GetTerminalBySpaceGUIDReturns("", nil)
 			terminalsRepo.CreateReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{spacePolicy})
 			Expect(err).To(MatchError("failed to create source terminal: OMG WHY DID THIS FAIL"))
 		})
 
@@ -253,21 +331,112 @@ var _ = Describe("EgressPolicyStore", func() {
 			egressPolicyRepo.GetTerminalBySpaceGUIDReturns("", nil)
 			egressPolicyRepo.CreateSpaceReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{spacePolicy})
 			Expect(err).To(MatchError("failed to create space: OMG WHY DID THIS FAIL"))
 		})
 
 		It("returns an error when the GetTerminalBySpaceGUID fails", func() {
 			egressPolicyRepo.GetTerminalBySpaceGUIDReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create([]store.EgressPolicy{spacePolicy})
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{spacePolicy})
 			Expect(err).To(MatchError("failed to get terminal by space guid: OMG WHY DID THIS FAIL"))
 		})
 
+		It("creates an org with a sourceTerminalGUID", func() {
+			egressPolicyRepo.GetTerminalByOrgGUIDReturns("", nil)
+			terminalsRepo.CreateReturns("some-term-guid", nil)
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{orgPolicy})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(egressPolicyRepo.CreateOrgCallCount()).To(Equal(1))
+			Expect(egressPolicyRepo.CreateSpaceCallCount()).To(Equal(0))
+			Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(0))
+			argTx, argSourceTerminalGUID, argOrgGUID := egressPolicyRepo.CreateOrgArgsForCall(0)
+			Expect(argTx).To(Equal(tx))
+			Expect(argSourceTerminalGUID).To(Equal("some-term-guid"))
+			Expect(argOrgGUID).To(Equal("org-guid"))
+		})
+
+		It("uses the existing org terminal id when it exists", func() {
+			egressPolicyRepo.GetTerminalByOrgGUIDReturns("44", nil)
+
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{orgPolicy})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(egressPolicyRepo.CreateOrgCallCount()).To(Equal(0))
+			_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+			Expect(sourceID).To(Equal("44"))
+		})
+
+		It("returns an error when the CreateOrg fails", func() {
+			egressPolicyRepo.GetTerminalByOrgGUIDReturns("", nil)
+			egressPolicyRepo.CreateOrgReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
+
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{orgPolicy})
+			Expect(err).To(MatchError("failed to create org: OMG WHY DID THIS FAIL"))
+		})
+
+		It("returns an error when the GetTerminalByOrgGUID fails", func() {
+			egressPolicyRepo.GetTerminalByOrgGUIDReturns("", errors.New("OMG WHY DID THIS FAIL"))
+
+			_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{orgPolicy})
+			Expect(err).To(MatchError("failed to get terminal by org guid: OMG WHY DID THIS FAIL"))
+		})
+
+		Context("when the source is the default source", func() {
+			var defaultPolicy store.EgressPolicy
+
+			BeforeEach(func() {
+				defaultPolicy = store.EgressPolicy{
+					Source: store.EgressSource{
+						Type: "default",
+					},
+					Destination: store.EgressDestination{
+						GUID: "some-destination-guid",
+					},
+				}
+			})
+
+			It("creates the default source with a sourceTerminalGUID", func() {
+				egressPolicyRepo.GetDefaultTerminalReturns("", nil)
+				terminalsRepo.CreateReturns("some-term-guid", nil)
+
+				_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{defaultPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.CreateDefaultCallCount()).To(Equal(1))
+				Expect(egressPolicyRepo.CreateAppCallCount()).To(Equal(0))
+				argTx, argSourceTerminalGUID := egressPolicyRepo.CreateDefaultArgsForCall(0)
+				Expect(argTx).To(Equal(tx))
+				Expect(argSourceTerminalGUID).To(Equal("some-term-guid"))
+			})
+
+			It("uses the existing default terminal when it exists", func() {
+				egressPolicyRepo.GetDefaultTerminalReturns("33", nil)
+
+				_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{defaultPolicy})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(egressPolicyRepo.CreateDefaultCallCount()).To(Equal(0))
+				_, sourceID, _, _, _ := egressPolicyRepo.CreateEgressPolicyArgsForCall(0)
+				Expect(sourceID).To(Equal("33"))
+			})
+
+			It("returns an error when the CreateDefault fails", func() {
+				egressPolicyRepo.CreateDefaultReturns(-1, errors.New("OMG WHY DID THIS FAIL"))
+
+				_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{defaultPolicy})
+				Expect(err).To(MatchError("failed to create default source: OMG WHY DID THIS FAIL"))
+			})
+
+			It("returns an error when the GetDefaultTerminal fails", func() {
+				egressPolicyRepo.GetDefaultTerminalReturns("", errors.New("OMG WHY DID THIS FAIL"))
+
+				_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{defaultPolicy})
+				Expect(err).To(MatchError("failed to get default terminal: OMG WHY DID THIS FAIL"))
+			})
+		})
+
 		It("returns an error when the GetTerminalByAppGUID fails", func() {
 			egressPolicyRepo.GetTerminalByAppGUIDReturns("", errors.New("OMG WHY DID THIS FAIL"))
 
-			_, err := egressPolicyStore.Create(egressPolicies)
+			_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
 			Expect(err).To(MatchError("failed to get terminal by app guid: OMG WHY DID THIS FAIL"))
 		})
 	})
@@ -329,14 +498,14 @@ var _ = Describe("EgressPolicyStore", func() {
 		})
 
 		It("returns an error when beginning a transaction fails", func() {
-			mockDb.BeginxReturns(nil, errors.New("failed to create tx"))
-			_, err := egressPolicyStore.Delete(egressPolicyGUID)
+			mockDb.BeginTxxReturns(nil, errors.New("failed to create tx"))
+			_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 			Expect(err).To(HaveOccurred())
 			Expect(err).To(MatchError("create transaction: failed to create tx"))
 		})
 
 		It("deletes the egress policies and returns the deleted egress policies", func() {
-			egressPolicies, err := egressPolicyStore.Delete(egressPolicyGUID, egressPolicyGUID2)
+			egressPolicies, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID, egressPolicyGUID2)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(egressPolicies).To(Equal(expectedEgressPolicies))
 
@@ -374,6 +543,25 @@ var _ = Describe("EgressPolicyStore", func() {
 			Expect(passedSourceTerminalGUID).To(Equal(srcTerminalGUID2))
 		})
 
+		It("bumps the policies revision in the same transaction", func() {
+			_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID, egressPolicyGUID2)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tx.ExecCallCount()).To(Equal(1))
+			query, _ := tx.ExecArgsForCall(0)
+			Expect(query).To(Equal(`UPDATE policies_info SET revision = revision + 1 WHERE id = 1`))
+		})
+
+		Context("when bumping the policies revision fails", func() {
+			It("returns an error", func() {
+				tx.ExecReturns(nil, errors.New("potato"))
+
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
+				Expect(err).To(MatchError("bumping policies revision: potato"))
+				Expect(tx.RollbackCallCount()).To(Equal(1))
+			})
+		})
+
 		Context("when the EgressPolicyRepo.DeleteSpace fails", func() {
 			BeforeEach(func() {
 				srcGUID = "some-space-guid"
@@ -396,18 +584,98 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to delete source space: ther's a bug"))
 			})
 		})
 
+		Context("when the source is an org", func() {
+			BeforeEach(func() {
+				expectedEgressPolicies = []store.EgressPolicy{
+					{
+						ID: egressPolicyGUID,
+						Source: store.EgressSource{
+							TerminalGUID: srcTerminalGUID,
+							ID:           "some-org-guid",
+							Type:         "org",
+						},
+						Destination: store.EgressDestination{
+							GUID: destTerminalGUID,
+						},
+					},
+				}
+				egressPolicyRepo.GetByGUIDReturns(expectedEgressPolicies, nil)
+			})
+
+			It("deletes the source org", func() {
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(egressPolicyRepo.DeleteOrgCallCount()).To(Equal(1))
+				passedTx, passedSourceTerminalGUID := egressPolicyRepo.DeleteOrgArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedSourceTerminalGUID).To(Equal(srcTerminalGUID))
+				Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(0))
+				Expect(egressPolicyRepo.DeleteSpaceCallCount()).To(Equal(0))
+			})
+
+			Context("when the EgressPolicyRepo.DeleteOrg fails", func() {
+				BeforeEach(func() {
+					egressPolicyRepo.DeleteOrgReturns(errors.New("ther's a bug"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
+					Expect(err).To(MatchError("failed to delete source org: ther's a bug"))
+				})
+			})
+		})
+
+		Context("when the source is the default source", func() {
+			BeforeEach(func() {
+				egressPolicyRepo.GetByGUIDReturns([]store.EgressPolicy{
+					{
+						ID: egressPolicyGUID,
+						Source: store.EgressSource{
+							TerminalGUID: srcTerminalGUID,
+							Type:         "default",
+						},
+						Destination: store.EgressDestination{
+							GUID: destTerminalGUID,
+						},
+					},
+				}, nil)
+			})
+
+			It("deletes the default source", func() {
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(egressPolicyRepo.DeleteDefaultCallCount()).To(Equal(1))
+				passedTx, passedSourceTerminalGUID := egressPolicyRepo.DeleteDefaultArgsForCall(0)
+				Expect(passedTx).To(Equal(tx))
+				Expect(passedSourceTerminalGUID).To(Equal(srcTerminalGUID))
+			})
+
+			Context("when the EgressPolicyRepo.DeleteDefault fails", func() {
+				BeforeEach(func() {
+					egressPolicyRepo.DeleteDefaultReturns(errors.New("ther's a bug"))
+				})
+
+				It("returns an error", func() {
+					_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
+					Expect(err).To(MatchError("failed to delete default source: ther's a bug"))
+				})
+			})
+		})
+
 		Context("when the egress policy doesn't exist", func() {
 			BeforeEach(func() {
 				egressPolicyRepo.GetByGUIDReturns([]store.EgressPolicy{}, nil)
 			})
 
 			It("returns an empty array", func() {
-				egressPolicies, err := egressPolicyStore.Delete(egressPolicyGUID)
+				egressPolicies, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(egressPolicies).To(HaveLen(0))
 			})
@@ -419,20 +687,20 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("doesn't delete the source terminal or source app", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(egressPolicyRepo.DeleteAppCallCount()).To(Equal(0))
 			})
 		})
 
-		Context("when the deleteWithTx fails", func() {
+		Context("when the DeleteWithTx fails", func() {
 			BeforeEach(func() {
 				egressPolicyRepo.GetByGUIDReturns(nil, errors.New("ther's a bug"))
 			})
 
 			It("rollsback the transaction", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to find egress policy: ther's a bug"))
 				Expect(tx.RollbackCallCount()).To(Equal(1))
 			})
@@ -444,7 +712,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to find egress policy: ther's a bug"))
 			})
 		})
@@ -455,7 +723,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to delete egress policy: ther's a bug"))
 			})
 		})
@@ -466,7 +734,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to check if source terminal is in use: ther's a bug"))
 			})
 		})
@@ -477,18 +745,48 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("returns an error", func() {
-				_, err := egressPolicyStore.Delete(egressPolicyGUID)
+				_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 				Expect(err).To(MatchError("failed to delete source app: ther's a bug"))
 			})
 		})
 
 		It("returns an error when commit transaction fails", func() {
 			tx.CommitReturns(errors.New("failed to commit"))
-			_, err := egressPolicyStore.Delete(egressPolicyGUID)
+			_, err := egressPolicyStore.Delete(context.Background(), egressPolicyGUID)
 			Expect(err).To(MatchError("commit transaction: failed to commit"))
 		})
 	})
 
+	Describe("DeleteByDestinationWithTx", func() {
+		It("deletes the egress policies bound to the destination", func() {
+			boundPolicies := []store.EgressPolicy{
+				{ID: "policy-guid-1", Source: store.EgressSource{TerminalGUID: "src-terminal-1", Type: "app"}},
+				{ID: "policy-guid-2", Source: store.EgressSource{TerminalGUID: "src-terminal-2", Type: "space"}},
+			}
+			egressPolicyRepo.GetByDestinationGUIDReturns(boundPolicies, nil)
+			egressPolicyRepo.GetByGUIDReturns(boundPolicies, nil)
+
+			deletedPolicies, err := egressPolicyStore.DeleteByDestinationWithTx(tx, "some-destination-guid")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deletedPolicies).To(Equal(boundPolicies))
+
+			passedTx, passedDestinationGUIDs := egressPolicyRepo.GetByDestinationGUIDArgsForCall(0)
+			Expect(passedTx).To(Equal(tx))
+			Expect(passedDestinationGUIDs).To(Equal([]string{"some-destination-guid"}))
+
+			_, passedPolicyGUIDs := egressPolicyRepo.GetByGUIDArgsForCall(0)
+			Expect(passedPolicyGUIDs).To(Equal([]string{"policy-guid-1", "policy-guid-2"}))
+			Expect(egressPolicyRepo.DeleteEgressPolicyCallCount()).To(Equal(2))
+		})
+
+		It("returns an error when finding the bound policies fails", func() {
+			egressPolicyRepo.GetByDestinationGUIDReturns(nil, errors.New("potato"))
+
+			_, err := egressPolicyStore.DeleteByDestinationWithTx(tx, "some-destination-guid")
+			Expect(err).To(MatchError("failed to find egress policies by destination: potato"))
+		})
+	})
+
 	Describe("All", func() {
 		Context("when there are policies created", func() {
 			BeforeEach(func() {
@@ -496,7 +794,7 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("should return a list of all policies", func() {
-				policies, err := egressPolicyStore.All()
+				policies, err := egressPolicyStore.All(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(policies).To(Equal(egressPolicies))
 			})
@@ -510,11 +808,11 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("calls egressPolicyRepo.GetByGuid", func() {
-				policies, err := egressPolicyStore.GetBySourceGuids([]string{"meow"})
+				policies, err := egressPolicyStore.GetBySourceGuids(context.Background(), []string{"meow"})
 				Expect(err).NotTo(HaveOccurred())
 				Expect(policies).To(Equal(egressPolicies))
 
-				ids := egressPolicyRepo.GetBySourceGuidsArgsForCall(0)
+				_, ids := egressPolicyRepo.GetBySourceGuidsArgsForCall(0)
 				Expect(ids).To(Equal([]string{"meow"}))
 			})
 		})
@@ -525,9 +823,74 @@ var _ = Describe("EgressPolicyStore", func() {
 			})
 
 			It("calls egressPolicyRepo.GetByGuid", func() {
-				_, err := egressPolicyStore.GetBySourceGuids([]string{"meow"})
+				_, err := egressPolicyStore.GetBySourceGuids(context.Background(), []string{"meow"})
+				Expect(err).To(MatchError("failed to get policies by guids: bark bark"))
+			})
+		})
+	})
+
+	Describe("GetBySourceGuidsWithDefault", func() {
+		Context("when called with ids", func() {
+			BeforeEach(func() {
+				egressPolicyRepo.GetBySourceGuidsWithDefaultReturns(egressPolicies, nil)
+			})
+
+			It("calls egressPolicyRepo.GetByGuid", func() {
+				policies, err := egressPolicyStore.GetBySourceGuidsWithDefault(context.Background(), []string{"meow"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal(egressPolicies))
+
+				_, ids := egressPolicyRepo.GetBySourceGuidsWithDefaultArgsForCall(0)
+				Expect(ids).To(Equal([]string{"meow"}))
+			})
+		})
+
+		Context("when an error is returned from the repo", func() {
+			BeforeEach(func() {
+				egressPolicyRepo.GetBySourceGuidsWithDefaultReturns(nil, errors.New("bark bark"))
+			})
+
+			It("calls egressPolicyRepo.GetByGuid", func() {
+				_, err := egressPolicyStore.GetBySourceGuidsWithDefault(context.Background(), []string{"meow"})
 				Expect(err).To(MatchError("failed to get policies by guids: bark bark"))
 			})
 		})
 	})
+
+	Describe("GetByFilter", func() {
+		var filter store.EgressPolicyFilter
+
+		BeforeEach(func() {
+			filter = store.EgressPolicyFilter{
+				SourceIDs:        []string{"meow"},
+				DestinationNames: []string{"woof"},
+			}
+		})
+
+		Context("when called with a filter", func() {
+			BeforeEach(func() {
+				egressPolicyRepo.GetByFilterReturns(egressPolicies, nil)
+			})
+
+			It("calls egressPolicyRepo.GetByFilter", func() {
+				policies, err := egressPolicyStore.GetByFilter(context.Background(), filter)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(Equal(egressPolicies))
+
+				_, passedFilter := egressPolicyRepo.GetByFilterArgsForCall(0)
+				Expect(passedFilter).To(Equal(filter))
+			})
+		})
+
+		Context("when an error is returned from the repo", func() {
+			BeforeEach(func() {
+				egressPolicyRepo.GetByFilterReturns(nil, errors.New("bark bark"))
+			})
+
+			It("returns an error", func() {
+				_, err := egressPolicyStore.GetByFilter(context.Background(), filter)
+				Expect(err).To(MatchError("failed to get policies by filter: bark bark"))
+			})
+		})
+	})
 })
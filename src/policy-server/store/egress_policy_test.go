@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"policy-server/store"
@@ -146,6 +147,36 @@ var _ = Describe("Egress Policy Table", func() {
 		})
 	})
 
+	Context("CreateOrg", func() {
+		It("should create an org and return the ID", func() {
+			db, tx := getMigratedRealDb(dbConf)
+			setupEgressPolicyStore(db)
+
+			orgTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			id, err := egressPolicyTable.CreateOrg(tx, orgTerminalGUID, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(id).To(Equal(int64(1)))
+
+			var foundOrgGuid string
+			row := tx.QueryRow(`SELECT org_guid FROM orgs WHERE id = 1`)
+			err = row.Scan(&foundOrgGuid)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundOrgGuid).To(Equal("some-org-guid"))
+		})
+
+		It("should return an error if the driver is not supported", func() {
+			setupEgressPolicyStore(mockDb)
+			fakeTx := &dbfakes.Transaction{}
+
+			fakeTx.DriverNameReturns("db2")
+			_, err := egressPolicyTable.CreateOrg(fakeTx, "some-term-guid", "some-org-guid")
+			Expect(err).To(MatchError("unknown driver: db2"))
+		})
+	})
+
 	Context("CreateIPRange", func() {
 
 		It("should create an iprange and return the ID", func() {
@@ -209,7 +240,7 @@ var _ = Describe("Egress Policy Table", func() {
 			destinationTerminalId, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			guid, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId)
+			guid, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId, "allow", nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(guid).To(Equal("guid-1"))
 
@@ -221,9 +252,29 @@ var _ = Describe("Egress Policy Table", func() {
 			Expect(foundDestinationID).To(Equal(destinationTerminalId))
 
 			By("checking that if bad args are sent, it returns an error") // merged because db's are slow
-			_, err = egressPolicyTable.CreateEgressPolicy(tx, "some-term-guid", "some-term-guid")
+			_, err = egressPolicyTable.CreateEgressPolicy(tx, "some-term-guid", "some-term-guid", "allow", nil)
 			Expect(err).To(HaveOccurred())
 		})
+
+		It("stores the expiry when one is given", func() {
+			db, tx := getMigratedRealDb(dbConf)
+			setupEgressPolicyStore(db)
+
+			sourceTerminalId, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+			destinationTerminalId, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			expiresAt := time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)
+			guid, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId, "allow", &expiresAt)
+			Expect(err).ToNot(HaveOccurred())
+
+			var foundExpiresAt time.Time
+			row := tx.QueryRow(tx.Rebind(`SELECT expires_at FROM egress_policies WHERE guid = ?`), guid)
+			err = row.Scan(&foundExpiresAt)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundExpiresAt).To(BeTemporally("==", expiresAt))
+		})
 	})
 
 	Context("DeleteEgressPolicy", func() {
@@ -236,7 +287,7 @@ var _ = Describe("Egress Policy Table", func() {
 			destinationTerminalId, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			egressPolicyGUID, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId)
+			egressPolicyGUID, err := egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalId, destinationTerminalId, "allow", nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			err = egressPolicyTable.DeleteEgressPolicy(tx, egressPolicyGUID)
@@ -399,7 +450,7 @@ var _ = Describe("Egress Policy Table", func() {
 			sourceTerminalGUID, err := terminalsTable.Create(tx)
 			Expect(err).ToNot(HaveOccurred())
 
-			_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID)
+			_, err = egressPolicyTable.CreateEgressPolicy(tx, sourceTerminalGUID, destinationTerminalGUID, "allow", nil)
 			Expect(err).ToNot(HaveOccurred())
 			inUse, err := egressPolicyTable.IsTerminalInUse(tx, sourceTerminalGUID)
 			Expect(err).ToNot(HaveOccurred())
@@ -540,7 +591,8 @@ var _ = Describe("Egress Policy Table", func() {
 					Expect(err).NotTo(HaveOccurred())
 					Expect(egressPolicies).To(ConsistOf(
 						store.EgressPolicy{
-							ID: createdEgressPolicies[0].ID,
+							ID:     createdEgressPolicies[0].ID,
+							Action: "allow",
 							Source: store.EgressSource{
 								Type:         "app",
 								TerminalGUID: createdEgressPolicies[0].Source.TerminalGUID,
@@ -549,7 +601,8 @@ var _ = Describe("Egress Policy Table", func() {
 							Destination: createdEgressDestinations[0],
 						},
 						store.EgressPolicy{
-							ID: createdEgressPolicies[1].ID,
+							ID:     createdEgressPolicies[1].ID,
+							Action: "allow",
 							Source: store.EgressSource{
 								Type:         "space",
 								TerminalGUID: createdEgressPolicies[1].Source.TerminalGUID,
@@ -572,14 +625,28 @@ var _ = Describe("Egress Policy Table", func() {
 				})
 			})
 
+			Context("GetByDestinationGUID", func() {
+				It("should return the egress policies bound to the destinations", func() {
+					egressPolicies, err := egressPolicyTable.GetByDestinationGUID(tx, createdEgressDestinations[1].GUID)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(egressPolicies).To(HaveLen(1))
+					Expect(egressPolicies[0].ID).To(Equal(createdEgressPolicies[1].ID))
+
+					egressPolicies, err = egressPolicyTable.GetByDestinationGUID(tx, "not-a-destination")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(egressPolicies).To(HaveLen(0))
+				})
+			})
+
 			Context("GetAllPolicies", func() {
 				It("returns policies", func() {
-					listedPolicies, err := egressPolicyTable.GetAllPolicies()
+					listedPolicies, err := egressPolicyTable.GetAllPolicies(context.Background())
 					Expect(err).ToNot(HaveOccurred())
 					Expect(listedPolicies).To(HaveLen(4))
 					Expect(listedPolicies).To(ConsistOf([]store.EgressPolicy{
 						{
-							ID: "guid-1",
+							ID:     "guid-1",
+							Action: "allow",
 							Source: store.EgressSource{
 								ID:           "some-app-guid",
 								Type:         "app",
@@ -605,7 +672,8 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 						{
-							ID: "guid-2",
+							ID:     "guid-2",
+							Action: "allow",
 							Source: store.EgressSource{
 								ID:           "space-guid",
 								Type:         "space",
@@ -625,7 +693,8 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 						{
-							ID: "guid-3",
+							ID:     "guid-3",
+							Action: "allow",
 							Source: store.EgressSource{
 								ID:           "different-app-guid",
 								Type:         "app",
@@ -647,7 +716,8 @@ var _ = Describe("Egress Policy Table", func() {
 							},
 						},
 						{
-							ID: "guid-4",
+							ID:     "guid-4",
+							Action: "allow",
 							Source: store.EgressSource{
 								ID:           "different-space-guid",
 								Type:         "space",
@@ -676,13 +746,13 @@ var _ = Describe("Egress Policy Table", func() {
 			It("returns an error", func() {
 				setupEgressPolicyStore(mockDb)
 
-				mockDb.QueryReturns(nil, errors.New("some error that sql would return"))
+				mockDb.QueryContextReturns(nil, errors.New("some error that sql would return"))
 
 				egressPolicyTable = &store.EgressPolicyTable{
 					Conn: mockDb,
 				}
 
-				_, err := egressPolicyTable.GetAllPolicies()
+				_, err := egressPolicyTable.GetAllPolicies(context.Background())
 				Expect(err).To(MatchError("some error that sql would return"))
 			})
 		})
@@ -709,6 +779,50 @@ var _ = Describe("Egress Policy Table", func() {
 		})
 	})
 
+	Context("DeleteOrg", func() {
+		It("deletes the org provided a terminal guid", func() {
+			db, tx := getMigratedRealDb(dbConf)
+			setupEgressPolicyStore(db)
+
+			orgTerminalGUID, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+
+			orgID, err := egressPolicyTable.CreateOrg(tx, orgTerminalGUID, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(orgID).To(Equal(int64(1)))
+
+			err = egressPolicyTable.DeleteOrg(tx, orgTerminalGUID)
+			Expect(err).ToNot(HaveOccurred())
+
+			var orgCount int
+			row := tx.QueryRow(`SELECT COUNT(id) FROM orgs WHERE id = 1`)
+			err = row.Scan(&orgCount)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(orgCount).To(Equal(0))
+		})
+	})
+
+	Context("GetTerminalByOrgGUID", func() {
+		It("should return the terminal guid for an org if it exists", func() {
+			db, tx := getMigratedRealDb(dbConf)
+			setupEgressPolicyStore(db)
+
+			terminalId, err := terminalsTable.Create(tx)
+			Expect(err).ToNot(HaveOccurred())
+			_, err = egressPolicyTable.CreateOrg(tx, terminalId, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+
+			foundID, err := egressPolicyTable.GetTerminalByOrgGUID(tx, "some-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundID).To(Equal(terminalId))
+
+			By("should return empty string and no error if the org is not found")
+			foundID, err = egressPolicyTable.GetTerminalByOrgGUID(tx, "garbage-org-guid")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(foundID).To(Equal(""))
+		})
+	})
+
 	Context("GetTerminalBySpaceGUID", func() {
 		It("should return the terminal guid for a space if it exists", func() {
 			db, tx := getMigratedRealDb(dbConf)
@@ -866,7 +980,7 @@ var _ = Describe("Egress Policy Table", func() {
 			Context("when there are policies with the given id", func() {
 				It("returns egress policies with those ids", func() {
 					By("returning egress policies with existing ids")
-					policies, err := egressPolicyTable.GetBySourceGuids([]string{"some-app-guid", "different-app-guid", "some-space-guid"})
+					policies, err := egressPolicyTable.GetBySourceGuids(context.Background(), []string{"some-app-guid", "different-app-guid", "some-space-guid"})
 					Expect(err).ToNot(HaveOccurred())
 
 					// egressStore.Create doesn't return the full destination, but GetBySourceGuids does
@@ -878,7 +992,7 @@ var _ = Describe("Egress Policy Table", func() {
 					Expect(policies).To(ConsistOf(expectedEgressPolicies))
 
 					By("returning empty list for non-existent ids")
-					policies, err = egressPolicyTable.GetBySourceGuids([]string{"meow-this-is-a-bogus-app-guid"})
+					policies, err = egressPolicyTable.GetBySourceGuids(context.Background(), []string{"meow-this-is-a-bogus-app-guid"})
 					Expect(err).ToNot(HaveOccurred())
 					Expect(policies).To(HaveLen(0))
 				})
@@ -889,13 +1003,259 @@ var _ = Describe("Egress Policy Table", func() {
 			It("returns an error", func() {
 				setupEgressPolicyStore(mockDb)
 
-				mockDb.QueryReturns(nil, errors.New("some error that sql would return"))
+				mockDb.QueryContextReturns(nil, errors.New("some error that sql would return"))
+
+				egressPolicyTable = &store.EgressPolicyTable{
+					Conn: mockDb,
+				}
+
+				_, err := egressPolicyTable.GetBySourceGuids(context.Background(), []string{"id-does-not-matter"})
+				Expect(err).To(MatchError("some error that sql would return"))
+			})
+		})
+	})
+
+	Context("default sources", func() {
+		var (
+			realDb               *db.ConnWrapper
+			createdAppPolicy     store.EgressPolicy
+			createdDefaultPolicy store.EgressPolicy
+		)
+
+		BeforeEach(func() {
+			realDb, _ = getMigratedRealDb(dbConf)
+			egressStore := setupEgressPolicyStore(realDb)
+
+			createdDestinations, err := egressDestinationStore(realDb).Create([]store.EgressDestination{
+				{
+					Name:     "ntp",
+					Protocol: "udp",
+					Ports:    []store.Ports{{Start: 123, End: 123}},
+					IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.1"}},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			createdPolicies, err := egressStore.Create([]store.EgressPolicy{
+				{
+					Source:      store.EgressSource{ID: "some-app-guid", Type: "app"},
+					Destination: store.EgressDestination{GUID: createdDestinations[0].GUID},
+				},
+				{
+					Source:      store.EgressSource{Type: "default"},
+					Destination: store.EgressDestination{GUID: createdDestinations[0].GUID},
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			createdAppPolicy = createdPolicies[0]
+			createdDefaultPolicy = createdPolicies[1]
+		})
+
+		It("reads back default policies with the default source type", func() {
+			policies, err := egressPolicyTable.GetAllPolicies(context.Background())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(2))
+
+			var sources []store.EgressSource
+			for _, policy := range policies {
+				sources = append(sources, policy.Source)
+			}
+			Expect(sources).To(ContainElement(store.EgressSource{
+				Type:         "default",
+				TerminalGUID: createdDefaultPolicy.Source.TerminalGUID,
+			}))
+		})
+
+		It("includes default policies when getting policies by any source guid with the default", func() {
+			policies, err := egressPolicyTable.GetBySourceGuidsWithDefault(context.Background(), []string{"some-other-app-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].ID).To(Equal(createdDefaultPolicy.ID))
+
+			policies, err = egressPolicyTable.GetBySourceGuidsWithDefault(context.Background(), []string{"some-app-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(2))
+		})
+
+		It("only returns the policies of the given sources when getting policies by source guid", func() {
+			policies, err := egressPolicyTable.GetBySourceGuids(context.Background(), []string{"some-other-app-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(BeEmpty())
+
+			policies, err = egressPolicyTable.GetBySourceGuids(context.Background(), []string{"some-app-guid"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].ID).To(Equal(createdAppPolicy.ID))
+		})
+
+		It("filters by the default source type", func() {
+			policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+				SourceTypes: []string{"default"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policies).To(HaveLen(1))
+			Expect(policies[0].ID).To(Equal(createdDefaultPolicy.ID))
+			Expect(policies[0].ID).NotTo(Equal(createdAppPolicy.ID))
+		})
+
+		It("reuses the default terminal", func() {
+			tx, err := realDb.Beginx()
+			Expect(err).ToNot(HaveOccurred())
+			defer tx.Rollback()
+
+			terminalGUID, err := egressPolicyTable.GetDefaultTerminal(tx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(terminalGUID).To(Equal(createdDefaultPolicy.Source.TerminalGUID))
+		})
+	})
+
+	Context("GetByFilter", func() {
+		Context("When using a real db", func() {
+			var (
+				egressStore           store.EgressPolicyStore
+				createdDestinations   []store.EgressDestination
+				createdEgressPolicies []store.EgressPolicy
+			)
+
+			policyIDs := func(policies []store.EgressPolicy) []string {
+				var ids []string
+				for _, policy := range policies {
+					ids = append(ids, policy.ID)
+				}
+				return ids
+			}
+
+			BeforeEach(func() {
+				db, _ := getMigratedRealDb(dbConf)
+				egressStore = setupEgressPolicyStore(db)
+
+				var err error
+				createdDestinations, err = egressDestinationStore(db).Create([]store.EgressDestination{
+					{
+						Name:     "dest-tcp",
+						Protocol: "tcp",
+						Ports:    []store.Ports{{Start: 8080, End: 8081}},
+						IPRanges: []store.IPRange{{Start: "1.2.3.4", End: "1.2.3.5"}},
+					},
+					{
+						Name:     "dest-udp",
+						Protocol: "udp",
+						IPRanges: []store.IPRange{{Start: "2.2.3.4", End: "2.2.3.5"}},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				createdEgressPolicies, err = egressStore.Create([]store.EgressPolicy{
+					{
+						Source:      store.EgressSource{ID: "app-guid-1", Type: "app"},
+						Destination: store.EgressDestination{GUID: createdDestinations[0].GUID},
+					},
+					{
+						Source:      store.EgressSource{ID: "app-guid-2", Type: "app"},
+						Destination: store.EgressDestination{GUID: createdDestinations[1].GUID},
+					},
+					{
+						Source:      store.EgressSource{ID: "space-guid-1", Type: "space"},
+						Destination: store.EgressDestination{GUID: createdDestinations[0].GUID},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("filters by source id", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceIDs: []string{"app-guid-1", "space-guid-1"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[0].ID, createdEgressPolicies[2].ID))
+			})
+
+			It("filters by source type", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceTypes: []string{"space"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[2].ID))
+				Expect(policies[0].Source.Type).To(Equal("space"))
+			})
+
+			It("filters by org source type and id", func() {
+				orgPolicies, err := egressStore.Create([]store.EgressPolicy{
+					{
+						Source:      store.EgressSource{ID: "org-guid-1", Type: "org"},
+						Destination: store.EgressDestination{GUID: createdDestinations[1].GUID},
+					},
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceTypes: []string{"org"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(orgPolicies[0].ID))
+				Expect(policies[0].Source.Type).To(Equal("org"))
+				Expect(policies[0].Source.ID).To(Equal("org-guid-1"))
+
+				policies, err = egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceIDs: []string{"org-guid-1"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(orgPolicies[0].ID))
+			})
+
+			It("filters by destination id", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					DestinationIDs: []string{createdDestinations[1].GUID},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[1].ID))
+				Expect(policies[0].Destination).To(Equal(createdDestinations[1]))
+			})
+
+			It("filters by destination name", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					DestinationNames: []string{"dest-tcp"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[0].ID, createdEgressPolicies[2].ID))
+			})
+
+			It("filters by protocol", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					Protocols: []string{"udp"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[1].ID))
+			})
+
+			It("combines filters", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceTypes: []string{"app"},
+					Protocols:   []string{"tcp"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policyIDs(policies)).To(ConsistOf(createdEgressPolicies[0].ID))
+			})
+
+			It("returns an empty list when nothing matches", func() {
+				policies, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{
+					SourceIDs: []string{"app-guid-2"},
+					Protocols: []string{"tcp"},
+				})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(policies).To(HaveLen(0))
+			})
+		})
+
+		Context("when the query fails", func() {
+			It("returns an error", func() {
+				mockDb.QueryContextReturns(nil, errors.New("some error that sql would return"))
 
 				egressPolicyTable = &store.EgressPolicyTable{
 					Conn: mockDb,
 				}
 
-				_, err := egressPolicyTable.GetBySourceGuids([]string{"id-does-not-matter"})
+				_, err := egressPolicyTable.GetByFilter(context.Background(), store.EgressPolicyFilter{SourceIDs: []string{"id-does-not-matter"}})
 				Expect(err).To(MatchError("some error that sql would return"))
 			})
 		})
@@ -2,8 +2,9 @@
 package fakes
 
 import (
+	"context"
 	"database/sql"
-	"policy-server/store"
+	"policy-server/store/fakes"
 	"sync"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
@@ -22,6 +23,20 @@ type Db struct {
 		result1 db.Transaction
 		result2 error
 	}
+	BeginTxxStub        func(ctx context.Context, opts *sql.TxOptions) (db.Transaction, error)
+	beginTxxMutex       sync.RWMutex
+	beginTxxArgsForCall []struct {
+		ctx  context.Context
+		opts *sql.TxOptions
+	}
+	beginTxxReturns struct {
+		result1 db.Transaction
+		result2 error
+	}
+	beginTxxReturnsOnCall map[int]struct {
+		result1 db.Transaction
+		result2 error
+	}
 	ExecStub        func(query string, args ...interface{}) (sql.Result, error)
 	execMutex       sync.RWMutex
 	execArgsForCall []struct {
@@ -102,6 +117,34 @@ type Db struct {
 		result1 *sql.Rows
 		result2 error
 	}
+	QueryRowContextStub        func(ctx context.Context, query string, args ...interface{}) *sql.Row
+	queryRowContextMutex       sync.RWMutex
+	queryRowContextArgsForCall []struct {
+		ctx   context.Context
+		query string
+		args  []interface{}
+	}
+	queryRowContextReturns struct {
+		result1 *sql.Row
+	}
+	queryRowContextReturnsOnCall map[int]struct {
+		result1 *sql.Row
+	}
+	QueryContextStub        func(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	queryContextMutex       sync.RWMutex
+	queryContextArgsForCall []struct {
+		ctx   context.Context
+		query string
+		args  []interface{}
+	}
+	queryContextReturns struct {
+		result1 *sql.Rows
+		result2 error
+	}
+	queryContextReturnsOnCall map[int]struct {
+		result1 *sql.Rows
+		result2 error
+	}
 	DriverNameStub        func() string
 	driverNameMutex       sync.RWMutex
 	driverNameArgsForCall []struct{}
@@ -178,6 +221,58 @@ func (fake *Db) BeginxReturnsOnCall(i int, result1 db.Transaction, result2 error
 	}{result1, result2}
 }
 
+func (fake *Db) BeginTxx(ctx context.Context, opts *sql.TxOptions) (db.Transaction, error) {
+	fake.beginTxxMutex.Lock()
+	ret, specificReturn := fake.beginTxxReturnsOnCall[len(fake.beginTxxArgsForCall)]
+	fake.beginTxxArgsForCall = append(fake.beginTxxArgsForCall, struct {
+		ctx  context.Context
+		opts *sql.TxOptions
+	}{ctx, opts})
+	fake.recordInvocation("BeginTxx", []interface{}{ctx, opts})
+	fake.beginTxxMutex.Unlock()
+	if fake.BeginTxxStub != nil {
+		return fake.BeginTxxStub(ctx, opts)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.beginTxxReturns.result1, fake.beginTxxReturns.result2
+}
+
+func (fake *Db) BeginTxxCallCount() int {
+	fake.beginTxxMutex.RLock()
+	defer fake.beginTxxMutex.RUnlock()
+	return len(fake.beginTxxArgsForCall)
+}
+
+func (fake *Db) BeginTxxArgsForCall(i int) (context.Context, *sql.TxOptions) {
+	fake.beginTxxMutex.RLock()
+	defer fake.beginTxxMutex.RUnlock()
+	return fake.beginTxxArgsForCall[i].ctx, fake.beginTxxArgsForCall[i].opts
+}
+
+func (fake *Db) BeginTxxReturns(result1 db.Transaction, result2 error) {
+	fake.BeginTxxStub = nil
+	fake.beginTxxReturns = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Db) BeginTxxReturnsOnCall(i int, result1 db.Transaction, result2 error) {
+	fake.BeginTxxStub = nil
+	if fake.beginTxxReturnsOnCall == nil {
+		fake.beginTxxReturnsOnCall = make(map[int]struct {
+			result1 db.Transaction
+			result2 error
+		})
+	}
+	fake.beginTxxReturnsOnCall[i] = struct {
+		result1 db.Transaction
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Db) Exec(query string, args ...interface{}) (sql.Result, error) {
 	fake.execMutex.Lock()
 	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
@@ -483,6 +578,109 @@ func (fake *Db) QueryReturnsOnCall(i int, result1 *sql.Rows, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *Db) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	fake.queryRowContextMutex.Lock()
+	ret, specificReturn := fake.queryRowContextReturnsOnCall[len(fake.queryRowContextArgsForCall)]
+	fake.queryRowContextArgsForCall = append(fake.queryRowContextArgsForCall, struct {
+		ctx   context.Context
+		query string
+		args  []interface{}
+	}{ctx, query, args})
+	fake.recordInvocation("QueryRowContext", []interface{}{ctx, query, args})
+	fake.queryRowContextMutex.Unlock()
+	if fake.QueryRowContextStub != nil {
+		return fake.QueryRowContextStub(ctx, query, args...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.queryRowContextReturns.result1
+}
+
+func (fake *Db) QueryRowContextCallCount() int {
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	return len(fake.queryRowContextArgsForCall)
+}
+
+func (fake *Db) QueryRowContextArgsForCall(i int) (context.Context, string, []interface{}) {
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	return fake.queryRowContextArgsForCall[i].ctx, fake.queryRowContextArgsForCall[i].query, fake.queryRowContextArgsForCall[i].args
+}
+
+func (fake *Db) QueryRowContextReturns(result1 *sql.Row) {
+	fake.QueryRowContextStub = nil
+	fake.queryRowContextReturns = struct {
+		result1 *sql.Row
+	}{result1}
+}
+
+func (fake *Db) QueryRowContextReturnsOnCall(i int, result1 *sql.Row) {
+	fake.QueryRowContextStub = nil
+	if fake.queryRowContextReturnsOnCall == nil {
+		fake.queryRowContextReturnsOnCall = make(map[int]struct {
+			result1 *sql.Row
+		})
+	}
+	fake.queryRowContextReturnsOnCall[i] = struct {
+		result1 *sql.Row
+	}{result1}
+}
+
+func (fake *Db) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	fake.queryContextMutex.Lock()
+	ret, specificReturn := fake.queryContextReturnsOnCall[len(fake.queryContextArgsForCall)]
+	fake.queryContextArgsForCall = append(fake.queryContextArgsForCall, struct {
+		ctx   context.Context
+		query string
+		args  []interface{}
+	}{ctx, query, args})
+	fake.recordInvocation("QueryContext", []interface{}{ctx, query, args})
+	fake.queryContextMutex.Unlock()
+	if fake.QueryContextStub != nil {
+		return fake.QueryContextStub(ctx, query, args...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.queryContextReturns.result1, fake.queryContextReturns.result2
+}
+
+func (fake *Db) QueryContextCallCount() int {
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
+	return len(fake.queryContextArgsForCall)
+}
+
+func (fake *Db) QueryContextArgsForCall(i int) (context.Context, string, []interface{}) {
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
+	return fake.queryContextArgsForCall[i].ctx, fake.queryContextArgsForCall[i].query, fake.queryContextArgsForCall[i].args
+}
+
+func (fake *Db) QueryContextReturns(result1 *sql.Rows, result2 error) {
+	fake.QueryContextStub = nil
+	fake.queryContextReturns = struct {
+		result1 *sql.Rows
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *Db) QueryContextReturnsOnCall(i int, result1 *sql.Rows, result2 error) {
+	fake.QueryContextStub = nil
+	if fake.queryContextReturnsOnCall == nil {
+		fake.queryContextReturnsOnCall = make(map[int]struct {
+			result1 *sql.Rows
+			result2 error
+		})
+	}
+	fake.queryContextReturnsOnCall[i] = struct {
+		result1 *sql.Rows
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *Db) DriverName() string {
 	fake.driverNameMutex.Lock()
 	ret, specificReturn := fake.driverNameReturnsOnCall[len(fake.driverNameArgsForCall)]
@@ -616,6 +814,8 @@ func (fake *Db) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.beginxMutex.RLock()
 	defer fake.beginxMutex.RUnlock()
+	fake.beginTxxMutex.RLock()
+	defer fake.beginTxxMutex.RUnlock()
 	fake.execMutex.RLock()
 	defer fake.execMutex.RUnlock()
 	fake.namedExecMutex.RLock()
@@ -628,6 +828,10 @@ func (fake *Db) Invocations() map[string][][]interface{} {
 	defer fake.queryRowMutex.RUnlock()
 	fake.queryMutex.RLock()
 	defer fake.queryMutex.RUnlock()
+	fake.queryRowContextMutex.RLock()
+	defer fake.queryRowContextMutex.RUnlock()
+	fake.queryContextMutex.RLock()
+	defer fake.queryContextMutex.RUnlock()
 	fake.driverNameMutex.RLock()
 	defer fake.driverNameMutex.RUnlock()
 	fake.rawConnectionMutex.RLock()
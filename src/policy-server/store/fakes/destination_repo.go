@@ -9,281 +9,285 @@ import (
 )
 
 type DestinationRepo struct {
-	CreateStub        func(db.Transaction, int, int, int, int, string) (int, error)
-	createMutex       sync.RWMutex
-	createArgsForCall []struct {
+	CreateManyStub        func(db.Transaction, []store.DestinationKey) (map[store.DestinationKey]int, error)
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-		arg4 int
-		arg5 int
-		arg6 string
+		arg2 []store.DestinationKey
 	}
-	createReturns struct {
-		result1 int
+	createManyReturns struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}
-	createReturnsOnCall map[int]struct {
-		result1 int
+	createManyReturnsOnCall map[int]struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}
-	DeleteStub        func(db.Transaction, int) error
-	deleteMutex       sync.RWMutex
-	deleteArgsForCall []struct {
+	DeleteManyStub        func(db.Transaction, []int) error
+	deleteManyMutex       sync.RWMutex
+	deleteManyArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
+		arg2 []int
 	}
-	deleteReturns struct {
+	deleteManyReturns struct {
 		result1 error
 	}
-	deleteReturnsOnCall map[int]struct {
+	deleteManyReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetIDStub        func(db.Transaction, int, int, int, int, string) (int, error)
-	getIDMutex       sync.RWMutex
-	getIDArgsForCall []struct {
+	GetIDsStub        func(db.Transaction, []store.DestinationKey) (map[store.DestinationKey]int, error)
+	getIDsMutex       sync.RWMutex
+	getIDsArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-		arg4 int
-		arg5 int
-		arg6 string
+		arg2 []store.DestinationKey
 	}
-	getIDReturns struct {
-		result1 int
+	getIDsReturns struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}
-	getIDReturnsOnCall map[int]struct {
-		result1 int
+	getIDsReturnsOnCall map[int]struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}
-	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
-	countWhereGroupIDMutex       sync.RWMutex
-	countWhereGroupIDArgsForCall []struct {
+	GroupIDsInUseStub        func(db.Transaction, []int) (map[int]bool, error)
+	groupIDsInUseMutex       sync.RWMutex
+	groupIDsInUseArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
+		arg2 []int
 	}
-	countWhereGroupIDReturns struct {
-		result1 int
+	groupIDsInUseReturns struct {
+		result1 map[int]bool
 		result2 error
 	}
-	countWhereGroupIDReturnsOnCall map[int]struct {
-		result1 int
+	groupIDsInUseReturnsOnCall map[int]struct {
+		result1 map[int]bool
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *DestinationRepo) Create(arg1 db.Transaction, arg2 int, arg3 int, arg4 int, arg5 int, arg6 string) (int, error) {
-	fake.createMutex.Lock()
-	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
-	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+func (fake *DestinationRepo) CreateMany(arg1 db.Transaction, arg2 []store.DestinationKey) (map[store.DestinationKey]int, error) {
+	var arg2Copy []store.DestinationKey
+	if arg2 != nil {
+		arg2Copy = make([]store.DestinationKey, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-		arg4 int
-		arg5 int
-		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		arg2 []store.DestinationKey
+	}{arg1, arg2Copy})
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy})
+	fake.createManyMutex.Unlock()
+	if fake.CreateManyStub != nil {
+		return fake.CreateManyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.createReturns.result1, fake.createReturns.result2
+	return fake.createManyReturns.result1, fake.createManyReturns.result2
 }
 
-func (fake *DestinationRepo) CreateCallCount() int {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return len(fake.createArgsForCall)
+func (fake *DestinationRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
 }
 
-func (fake *DestinationRepo) CreateArgsForCall(i int) (db.Transaction, int, int, int, int, string) {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2, fake.createArgsForCall[i].arg3, fake.createArgsForCall[i].arg4, fake.createArgsForCall[i].arg5, fake.createArgsForCall[i].arg6
+func (fake *DestinationRepo) CreateManyArgsForCall(i int) (db.Transaction, []store.DestinationKey) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return fake.createManyArgsForCall[i].arg1, fake.createManyArgsForCall[i].arg2
 }
 
-func (fake *DestinationRepo) CreateReturns(result1 int, result2 error) {
-	fake.CreateStub = nil
-	fake.createReturns = struct {
-		result1 int
+func (fake *DestinationRepo) CreateManyReturns(result1 map[store.DestinationKey]int, result2 error) {
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) CreateReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CreateStub = nil
-	if fake.createReturnsOnCall == nil {
-		fake.createReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *DestinationRepo) CreateManyReturnsOnCall(i int, result1 map[store.DestinationKey]int, result2 error) {
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
+			result1 map[store.DestinationKey]int
 			result2 error
 		})
 	}
-	fake.createReturnsOnCall[i] = struct {
-		result1 int
+	fake.createManyReturnsOnCall[i] = struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) Delete(arg1 db.Transaction, arg2 int) error {
-	fake.deleteMutex.Lock()
-	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
-	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+func (fake *DestinationRepo) DeleteMany(arg1 db.Transaction, arg2 []int) error {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteManyMutex.Lock()
+	ret, specificReturn := fake.deleteManyReturnsOnCall[len(fake.deleteManyArgsForCall)]
+	fake.deleteManyArgsForCall = append(fake.deleteManyArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
-	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2)
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DeleteMany", []interface{}{arg1, arg2Copy})
+	fake.deleteManyMutex.Unlock()
+	if fake.DeleteManyStub != nil {
+		return fake.DeleteManyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fake.deleteManyReturns.result1
 }
 
-func (fake *DestinationRepo) DeleteCallCount() int {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return len(fake.deleteArgsForCall)
+func (fake *DestinationRepo) DeleteManyCallCount() int {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return len(fake.deleteManyArgsForCall)
 }
 
-func (fake *DestinationRepo) DeleteArgsForCall(i int) (db.Transaction, int) {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
+func (fake *DestinationRepo) DeleteManyArgsForCall(i int) (db.Transaction, []int) {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return fake.deleteManyArgsForCall[i].arg1, fake.deleteManyArgsForCall[i].arg2
 }
 
-func (fake *DestinationRepo) DeleteReturns(result1 error) {
-	fake.DeleteStub = nil
-	fake.deleteReturns = struct {
+func (fake *DestinationRepo) DeleteManyReturns(result1 error) {
+	fake.DeleteManyStub = nil
+	fake.deleteManyReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *DestinationRepo) DeleteReturnsOnCall(i int, result1 error) {
-	fake.DeleteStub = nil
-	if fake.deleteReturnsOnCall == nil {
-		fake.deleteReturnsOnCall = make(map[int]struct {
+func (fake *DestinationRepo) DeleteManyReturnsOnCall(i int, result1 error) {
+	fake.DeleteManyStub = nil
+	if fake.deleteManyReturnsOnCall == nil {
+		fake.deleteManyReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteReturnsOnCall[i] = struct {
+	fake.deleteManyReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *DestinationRepo) GetID(arg1 db.Transaction, arg2 int, arg3 int, arg4 int, arg5 int, arg6 string) (int, error) {
-	fake.getIDMutex.Lock()
-	ret, specificReturn := fake.getIDReturnsOnCall[len(fake.getIDArgsForCall)]
-	fake.getIDArgsForCall = append(fake.getIDArgsForCall, struct {
+func (fake *DestinationRepo) GetIDs(arg1 db.Transaction, arg2 []store.DestinationKey) (map[store.DestinationKey]int, error) {
+	var arg2Copy []store.DestinationKey
+	if arg2 != nil {
+		arg2Copy = make([]store.DestinationKey, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getIDsMutex.Lock()
+	ret, specificReturn := fake.getIDsReturnsOnCall[len(fake.getIDsArgsForCall)]
+	fake.getIDsArgsForCall = append(fake.getIDsArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-		arg4 int
-		arg5 int
-		arg6 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.recordInvocation("GetID", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
-	fake.getIDMutex.Unlock()
-	if fake.GetIDStub != nil {
-		return fake.GetIDStub(arg1, arg2, arg3, arg4, arg5, arg6)
+		arg2 []store.DestinationKey
+	}{arg1, arg2Copy})
+	fake.recordInvocation("GetIDs", []interface{}{arg1, arg2Copy})
+	fake.getIDsMutex.Unlock()
+	if fake.GetIDsStub != nil {
+		return fake.GetIDsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getIDReturns.result1, fake.getIDReturns.result2
+	return fake.getIDsReturns.result1, fake.getIDsReturns.result2
 }
 
-func (fake *DestinationRepo) GetIDCallCount() int {
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
-	return len(fake.getIDArgsForCall)
+func (fake *DestinationRepo) GetIDsCallCount() int {
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
+	return len(fake.getIDsArgsForCall)
 }
 
-func (fake *DestinationRepo) GetIDArgsForCall(i int) (db.Transaction, int, int, int, int, string) {
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
-	return fake.getIDArgsForCall[i].arg1, fake.getIDArgsForCall[i].arg2, fake.getIDArgsForCall[i].arg3, fake.getIDArgsForCall[i].arg4, fake.getIDArgsForCall[i].arg5, fake.getIDArgsForCall[i].arg6
+func (fake *DestinationRepo) GetIDsArgsForCall(i int) (db.Transaction, []store.DestinationKey) {
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
+	return fake.getIDsArgsForCall[i].arg1, fake.getIDsArgsForCall[i].arg2
 }
 
-func (fake *DestinationRepo) GetIDReturns(result1 int, result2 error) {
-	fake.GetIDStub = nil
-	fake.getIDReturns = struct {
-		result1 int
+func (fake *DestinationRepo) GetIDsReturns(result1 map[store.DestinationKey]int, result2 error) {
+	fake.GetIDsStub = nil
+	fake.getIDsReturns = struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) GetIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.GetIDStub = nil
-	if fake.getIDReturnsOnCall == nil {
-		fake.getIDReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *DestinationRepo) GetIDsReturnsOnCall(i int, result1 map[store.DestinationKey]int, result2 error) {
+	fake.GetIDsStub = nil
+	if fake.getIDsReturnsOnCall == nil {
+		fake.getIDsReturnsOnCall = make(map[int]struct {
+			result1 map[store.DestinationKey]int
 			result2 error
 		})
 	}
-	fake.getIDReturnsOnCall[i] = struct {
-		result1 int
+	fake.getIDsReturnsOnCall[i] = struct {
+		result1 map[store.DestinationKey]int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereGroupIDMutex.Lock()
-	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
-	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
+func (fake *DestinationRepo) GroupIDsInUse(arg1 db.Transaction, arg2 []int) (map[int]bool, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.groupIDsInUseMutex.Lock()
+	ret, specificReturn := fake.groupIDsInUseReturnsOnCall[len(fake.groupIDsInUseArgsForCall)]
+	fake.groupIDsInUseArgsForCall = append(fake.groupIDsInUseArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
-	fake.countWhereGroupIDMutex.Unlock()
-	if fake.CountWhereGroupIDStub != nil {
-		return fake.CountWhereGroupIDStub(arg1, arg2)
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("GroupIDsInUse", []interface{}{arg1, arg2Copy})
+	fake.groupIDsInUseMutex.Unlock()
+	if fake.GroupIDsInUseStub != nil {
+		return fake.GroupIDsInUseStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.countWhereGroupIDReturns.result1, fake.countWhereGroupIDReturns.result2
+	return fake.groupIDsInUseReturns.result1, fake.groupIDsInUseReturns.result2
 }
 
-func (fake *DestinationRepo) CountWhereGroupIDCallCount() int {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return len(fake.countWhereGroupIDArgsForCall)
+func (fake *DestinationRepo) GroupIDsInUseCallCount() int {
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
+	return len(fake.groupIDsInUseArgsForCall)
 }
 
-func (fake *DestinationRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return fake.countWhereGroupIDArgsForCall[i].arg1, fake.countWhereGroupIDArgsForCall[i].arg2
+func (fake *DestinationRepo) GroupIDsInUseArgsForCall(i int) (db.Transaction, []int) {
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
+	return fake.groupIDsInUseArgsForCall[i].arg1, fake.groupIDsInUseArgsForCall[i].arg2
 }
 
-func (fake *DestinationRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	fake.countWhereGroupIDReturns = struct {
-		result1 int
+func (fake *DestinationRepo) GroupIDsInUseReturns(result1 map[int]bool, result2 error) {
+	fake.GroupIDsInUseStub = nil
+	fake.groupIDsInUseReturns = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *DestinationRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	if fake.countWhereGroupIDReturnsOnCall == nil {
-		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *DestinationRepo) GroupIDsInUseReturnsOnCall(i int, result1 map[int]bool, result2 error) {
+	fake.GroupIDsInUseStub = nil
+	if fake.groupIDsInUseReturnsOnCall == nil {
+		fake.groupIDsInUseReturnsOnCall = make(map[int]struct {
+			result1 map[int]bool
 			result2 error
 		})
 	}
-	fake.countWhereGroupIDReturnsOnCall[i] = struct {
-		result1 int
+	fake.groupIDsInUseReturnsOnCall[i] = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
@@ -291,14 +295,14 @@ func (fake *DestinationRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int,
 func (fake *DestinationRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
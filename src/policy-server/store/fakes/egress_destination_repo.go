@@ -57,6 +57,20 @@ type EgressDestinationRepo struct {
 		result1 []store.EgressDestination
 		result2 error
 	}
+	GetByFilterStub        func(tx db.Transaction, filter store.EgressDestinationFilter) ([]store.EgressDestination, error)
+	getByFilterMutex       sync.RWMutex
+	getByFilterArgsForCall []struct {
+		tx     db.Transaction
+		filter store.EgressDestinationFilter
+	}
+	getByFilterReturns struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
+	getByFilterReturnsOnCall map[int]struct {
+		result1 []store.EgressDestination
+		result2 error
+	}
 	DeleteStub        func(tx db.Transaction, guid string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -235,6 +249,58 @@ func (fake *EgressDestinationRepo) GetByGUIDReturnsOnCall(i int, result1 []store
 	}{result1, result2}
 }
 
+func (fake *EgressDestinationRepo) GetByFilter(tx db.Transaction, filter store.EgressDestinationFilter) ([]store.EgressDestination, error) {
+	fake.getByFilterMutex.Lock()
+	ret, specificReturn := fake.getByFilterReturnsOnCall[len(fake.getByFilterArgsForCall)]
+	fake.getByFilterArgsForCall = append(fake.getByFilterArgsForCall, struct {
+		tx     db.Transaction
+		filter store.EgressDestinationFilter
+	}{tx, filter})
+	fake.recordInvocation("GetByFilter", []interface{}{tx, filter})
+	fake.getByFilterMutex.Unlock()
+	if fake.GetByFilterStub != nil {
+		return fake.GetByFilterStub(tx, filter)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getByFilterReturns.result1, fake.getByFilterReturns.result2
+}
+
+func (fake *EgressDestinationRepo) GetByFilterCallCount() int {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return len(fake.getByFilterArgsForCall)
+}
+
+func (fake *EgressDestinationRepo) GetByFilterArgsForCall(i int) (db.Transaction, store.EgressDestinationFilter) {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return fake.getByFilterArgsForCall[i].tx, fake.getByFilterArgsForCall[i].filter
+}
+
+func (fake *EgressDestinationRepo) GetByFilterReturns(result1 []store.EgressDestination, result2 error) {
+	fake.GetByFilterStub = nil
+	fake.getByFilterReturns = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressDestinationRepo) GetByFilterReturnsOnCall(i int, result1 []store.EgressDestination, result2 error) {
+	fake.GetByFilterStub = nil
+	if fake.getByFilterReturnsOnCall == nil {
+		fake.getByFilterReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressDestination
+			result2 error
+		})
+	}
+	fake.getByFilterReturnsOnCall[i] = struct {
+		result1 []store.EgressDestination
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressDestinationRepo) Delete(tx db.Transaction, guid string) error {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -293,6 +359,8 @@ func (fake *EgressDestinationRepo) Invocations() map[string][][]interface{} {
 	defer fake.createIPRangeMutex.RUnlock()
 	fake.getByGUIDMutex.RLock()
 	defer fake.getByGUIDMutex.RUnlock()
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
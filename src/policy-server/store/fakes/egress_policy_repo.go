@@ -2,8 +2,10 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
+	"time"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
@@ -45,12 +47,14 @@ type EgressPolicyRepo struct {
 		result1 int64
 		result2 error
 	}
-	CreateEgressPolicyStub        func(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID string) (string, error)
+	CreateEgressPolicyStub        func(tx db.Transaction, sourceTerminalGUID, destinationTerminalGUID, action string, expiresAt *time.Time) (string, error)
 	createEgressPolicyMutex       sync.RWMutex
 	createEgressPolicyArgsForCall []struct {
 		tx                      db.Transaction
 		sourceTerminalGUID      string
 		destinationTerminalGUID string
+		action                  string
+		expiresAt               *time.Time
 	}
 	createEgressPolicyReturns struct {
 		result1 string
@@ -75,6 +79,35 @@ type EgressPolicyRepo struct {
 		result1 int64
 		result2 error
 	}
+	CreateOrgStub        func(tx db.Transaction, sourceTerminalGUID string, orgGUID string) (int64, error)
+	createOrgMutex       sync.RWMutex
+	createOrgArgsForCall []struct {
+		tx                 db.Transaction
+		sourceTerminalGUID string
+		orgGUID            string
+	}
+	createOrgReturns struct {
+		result1 int64
+		result2 error
+	}
+	createOrgReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	CreateDefaultStub        func(tx db.Transaction, sourceTerminalGUID string) (int64, error)
+	createDefaultMutex       sync.RWMutex
+	createDefaultArgsForCall []struct {
+		tx                 db.Transaction
+		sourceTerminalGUID string
+	}
+	createDefaultReturns struct {
+		result1 int64
+		result2 error
+	}
+	createDefaultReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
 	GetTerminalByAppGUIDStub        func(tx db.Transaction, appGUID string) (string, error)
 	getTerminalByAppGUIDMutex       sync.RWMutex
 	getTerminalByAppGUIDArgsForCall []struct {
@@ -103,10 +136,39 @@ type EgressPolicyRepo struct {
 		result1 string
 		result2 error
 	}
-	GetAllPoliciesStub        func() ([]store.EgressPolicy, error)
+	GetTerminalByOrgGUIDStub        func(tx db.Transaction, orgGUID string) (string, error)
+	getTerminalByOrgGUIDMutex       sync.RWMutex
+	getTerminalByOrgGUIDArgsForCall []struct {
+		tx      db.Transaction
+		orgGUID string
+	}
+	getTerminalByOrgGUIDReturns struct {
+		result1 string
+		result2 error
+	}
+	getTerminalByOrgGUIDReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetDefaultTerminalStub        func(tx db.Transaction) (string, error)
+	getDefaultTerminalMutex       sync.RWMutex
+	getDefaultTerminalArgsForCall []struct {
+		tx db.Transaction
+	}
+	getDefaultTerminalReturns struct {
+		result1 string
+		result2 error
+	}
+	getDefaultTerminalReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	GetAllPoliciesStub        func(ctx context.Context) ([]store.EgressPolicy, error)
 	getAllPoliciesMutex       sync.RWMutex
-	getAllPoliciesArgsForCall []struct{}
-	getAllPoliciesReturns     struct {
+	getAllPoliciesArgsForCall []struct {
+		ctx context.Context
+	}
+	getAllPoliciesReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -114,9 +176,10 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	GetBySourceGuidsStub        func(ids []string) ([]store.EgressPolicy, error)
+	GetBySourceGuidsStub        func(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
 	getBySourceGuidsMutex       sync.RWMutex
 	getBySourceGuidsArgsForCall []struct {
+		ctx context.Context
 		ids []string
 	}
 	getBySourceGuidsReturns struct {
@@ -127,6 +190,34 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
+	GetBySourceGuidsWithDefaultStub        func(ctx context.Context, ids []string) ([]store.EgressPolicy, error)
+	getBySourceGuidsWithDefaultMutex       sync.RWMutex
+	getBySourceGuidsWithDefaultArgsForCall []struct {
+		ctx context.Context
+		ids []string
+	}
+	getBySourceGuidsWithDefaultReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	getBySourceGuidsWithDefaultReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	GetByFilterStub        func(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error)
+	getByFilterMutex       sync.RWMutex
+	getByFilterArgsForCall []struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}
+	getByFilterReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	getByFilterReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
 	GetByGUIDStub        func(tx db.Transaction, ids ...string) ([]store.EgressPolicy, error)
 	getByGUIDMutex       sync.RWMutex
 	getByGUIDArgsForCall []struct {
@@ -141,6 +232,20 @@ type EgressPolicyRepo struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
+	GetByDestinationGUIDStub        func(tx db.Transaction, destinationGUIDs ...string) ([]store.EgressPolicy, error)
+	getByDestinationGUIDMutex       sync.RWMutex
+	getByDestinationGUIDArgsForCall []struct {
+		tx               db.Transaction
+		destinationGUIDs []string
+	}
+	getByDestinationGUIDReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	getByDestinationGUIDReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
 	DeleteEgressPolicyStub        func(tx db.Transaction, egressPolicyGUID string) error
 	deleteEgressPolicyMutex       sync.RWMutex
 	deleteEgressPolicyArgsForCall []struct {
@@ -189,6 +294,30 @@ type EgressPolicyRepo struct {
 	deleteSpaceReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteOrgStub        func(tx db.Transaction, orgID string) error
+	deleteOrgMutex       sync.RWMutex
+	deleteOrgArgsForCall []struct {
+		tx    db.Transaction
+		orgID string
+	}
+	deleteOrgReturns struct {
+		result1 error
+	}
+	deleteOrgReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteDefaultStub        func(tx db.Transaction, terminalID string) error
+	deleteDefaultMutex       sync.RWMutex
+	deleteDefaultArgsForCall []struct {
+		tx         db.Transaction
+		terminalID string
+	}
+	deleteDefaultReturns struct {
+		result1 error
+	}
+	deleteDefaultReturnsOnCall map[int]struct {
+		result1 error
+	}
 	IsTerminalInUseStub        func(tx db.Transaction, terminalGUID string) (bool, error)
 	isTerminalInUseMutex       sync.RWMutex
 	isTerminalInUseArgsForCall []struct {
@@ -319,18 +448,20 @@ func (fake *EgressPolicyRepo) CreateIPRangeReturnsOnCall(i int, result1 int64, r
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID string, destinationTerminalGUID string) (string, error) {
+func (fake *EgressPolicyRepo) CreateEgressPolicy(tx db.Transaction, sourceTerminalGUID string, destinationTerminalGUID string, action string, expiresAt *time.Time) (string, error) {
 	fake.createEgressPolicyMutex.Lock()
 	ret, specificReturn := fake.createEgressPolicyReturnsOnCall[len(fake.createEgressPolicyArgsForCall)]
 	fake.createEgressPolicyArgsForCall = append(fake.createEgressPolicyArgsForCall, struct {
 		tx                      db.Transaction
 		sourceTerminalGUID      string
 		destinationTerminalGUID string
-	}{tx, sourceTerminalGUID, destinationTerminalGUID})
-	fake.recordInvocation("CreateEgressPolicy", []interface{}{tx, sourceTerminalGUID, destinationTerminalGUID})
+		action                  string
+		expiresAt               *time.Time
+	}{tx, sourceTerminalGUID, destinationTerminalGUID, action, expiresAt})
+	fake.recordInvocation("CreateEgressPolicy", []interface{}{tx, sourceTerminalGUID, destinationTerminalGUID, action, expiresAt})
 	fake.createEgressPolicyMutex.Unlock()
 	if fake.CreateEgressPolicyStub != nil {
-		return fake.CreateEgressPolicyStub(tx, sourceTerminalGUID, destinationTerminalGUID)
+		return fake.CreateEgressPolicyStub(tx, sourceTerminalGUID, destinationTerminalGUID, action, expiresAt)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -344,10 +475,10 @@ func (fake *EgressPolicyRepo) CreateEgressPolicyCallCount() int {
 	return len(fake.createEgressPolicyArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) CreateEgressPolicyArgsForCall(i int) (db.Transaction, string, string) {
+func (fake *EgressPolicyRepo) CreateEgressPolicyArgsForCall(i int) (db.Transaction, string, string, string, *time.Time) {
 	fake.createEgressPolicyMutex.RLock()
 	defer fake.createEgressPolicyMutex.RUnlock()
-	return fake.createEgressPolicyArgsForCall[i].tx, fake.createEgressPolicyArgsForCall[i].sourceTerminalGUID, fake.createEgressPolicyArgsForCall[i].destinationTerminalGUID
+	return fake.createEgressPolicyArgsForCall[i].tx, fake.createEgressPolicyArgsForCall[i].sourceTerminalGUID, fake.createEgressPolicyArgsForCall[i].destinationTerminalGUID, fake.createEgressPolicyArgsForCall[i].action, fake.createEgressPolicyArgsForCall[i].expiresAt
 }
 
 func (fake *EgressPolicyRepo) CreateEgressPolicyReturns(result1 string, result2 error) {
@@ -425,6 +556,111 @@ func (fake *EgressPolicyRepo) CreateSpaceReturnsOnCall(i int, result1 int64, res
 	}{result1, result2}
 }
 
+func (fake *EgressPolicyRepo) CreateOrg(tx db.Transaction, sourceTerminalGUID string, orgGUID string) (int64, error) {
+	fake.createOrgMutex.Lock()
+	ret, specificReturn := fake.createOrgReturnsOnCall[len(fake.createOrgArgsForCall)]
+	fake.createOrgArgsForCall = append(fake.createOrgArgsForCall, struct {
+		tx                 db.Transaction
+		sourceTerminalGUID string
+		orgGUID            string
+	}{tx, sourceTerminalGUID, orgGUID})
+	fake.recordInvocation("CreateOrg", []interface{}{tx, sourceTerminalGUID, orgGUID})
+	fake.createOrgMutex.Unlock()
+	if fake.CreateOrgStub != nil {
+		return fake.CreateOrgStub(tx, sourceTerminalGUID, orgGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createOrgReturns.result1, fake.createOrgReturns.result2
+}
+
+func (fake *EgressPolicyRepo) CreateOrgCallCount() int {
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	return len(fake.createOrgArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) CreateOrgArgsForCall(i int) (db.Transaction, string, string) {
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	return fake.createOrgArgsForCall[i].tx, fake.createOrgArgsForCall[i].sourceTerminalGUID, fake.createOrgArgsForCall[i].orgGUID
+}
+
+func (fake *EgressPolicyRepo) CreateOrgReturns(result1 int64, result2 error) {
+	fake.CreateOrgStub = nil
+	fake.createOrgReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) CreateOrgReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.CreateOrgStub = nil
+	if fake.createOrgReturnsOnCall == nil {
+		fake.createOrgReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.createOrgReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) CreateDefault(tx db.Transaction, sourceTerminalGUID string) (int64, error) {
+	fake.createDefaultMutex.Lock()
+	ret, specificReturn := fake.createDefaultReturnsOnCall[len(fake.createDefaultArgsForCall)]
+	fake.createDefaultArgsForCall = append(fake.createDefaultArgsForCall, struct {
+		tx                 db.Transaction
+		sourceTerminalGUID string
+	}{tx, sourceTerminalGUID})
+	fake.recordInvocation("CreateDefault", []interface{}{tx, sourceTerminalGUID})
+	fake.createDefaultMutex.Unlock()
+	if fake.CreateDefaultStub != nil {
+		return fake.CreateDefaultStub(tx, sourceTerminalGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createDefaultReturns.result1, fake.createDefaultReturns.result2
+}
+
+func (fake *EgressPolicyRepo) CreateDefaultCallCount() int {
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
+	return len(fake.createDefaultArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) CreateDefaultArgsForCall(i int) (db.Transaction, string) {
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
+	return fake.createDefaultArgsForCall[i].tx, fake.createDefaultArgsForCall[i].sourceTerminalGUID
+}
+
+func (fake *EgressPolicyRepo) CreateDefaultReturns(result1 int64, result2 error) {
+	fake.CreateDefaultStub = nil
+	fake.createDefaultReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) CreateDefaultReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.CreateDefaultStub = nil
+	if fake.createDefaultReturnsOnCall == nil {
+		fake.createDefaultReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.createDefaultReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressPolicyRepo) GetTerminalByAppGUID(tx db.Transaction, appGUID string) (string, error) {
 	fake.getTerminalByAppGUIDMutex.Lock()
 	ret, specificReturn := fake.getTerminalByAppGUIDReturnsOnCall[len(fake.getTerminalByAppGUIDArgsForCall)]
@@ -529,14 +765,119 @@ func (fake *EgressPolicyRepo) GetTerminalBySpaceGUIDReturnsOnCall(i int, result1
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetAllPolicies() ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUID(tx db.Transaction, orgGUID string) (string, error) {
+	fake.getTerminalByOrgGUIDMutex.Lock()
+	ret, specificReturn := fake.getTerminalByOrgGUIDReturnsOnCall[len(fake.getTerminalByOrgGUIDArgsForCall)]
+	fake.getTerminalByOrgGUIDArgsForCall = append(fake.getTerminalByOrgGUIDArgsForCall, struct {
+		tx      db.Transaction
+		orgGUID string
+	}{tx, orgGUID})
+	fake.recordInvocation("GetTerminalByOrgGUID", []interface{}{tx, orgGUID})
+	fake.getTerminalByOrgGUIDMutex.Unlock()
+	if fake.GetTerminalByOrgGUIDStub != nil {
+		return fake.GetTerminalByOrgGUIDStub(tx, orgGUID)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getTerminalByOrgGUIDReturns.result1, fake.getTerminalByOrgGUIDReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDCallCount() int {
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	return len(fake.getTerminalByOrgGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDArgsForCall(i int) (db.Transaction, string) {
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	return fake.getTerminalByOrgGUIDArgsForCall[i].tx, fake.getTerminalByOrgGUIDArgsForCall[i].orgGUID
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDReturns(result1 string, result2 error) {
+	fake.GetTerminalByOrgGUIDStub = nil
+	fake.getTerminalByOrgGUIDReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetTerminalByOrgGUIDReturnsOnCall(i int, result1 string, result2 error) {
+	fake.GetTerminalByOrgGUIDStub = nil
+	if fake.getTerminalByOrgGUIDReturnsOnCall == nil {
+		fake.getTerminalByOrgGUIDReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getTerminalByOrgGUIDReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminal(tx db.Transaction) (string, error) {
+	fake.getDefaultTerminalMutex.Lock()
+	ret, specificReturn := fake.getDefaultTerminalReturnsOnCall[len(fake.getDefaultTerminalArgsForCall)]
+	fake.getDefaultTerminalArgsForCall = append(fake.getDefaultTerminalArgsForCall, struct {
+		tx db.Transaction
+	}{tx})
+	fake.recordInvocation("GetDefaultTerminal", []interface{}{tx})
+	fake.getDefaultTerminalMutex.Unlock()
+	if fake.GetDefaultTerminalStub != nil {
+		return fake.GetDefaultTerminalStub(tx)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getDefaultTerminalReturns.result1, fake.getDefaultTerminalReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalCallCount() int {
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
+	return len(fake.getDefaultTerminalArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalArgsForCall(i int) db.Transaction {
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
+	return fake.getDefaultTerminalArgsForCall[i].tx
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalReturns(result1 string, result2 error) {
+	fake.GetDefaultTerminalStub = nil
+	fake.getDefaultTerminalReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetDefaultTerminalReturnsOnCall(i int, result1 string, result2 error) {
+	fake.GetDefaultTerminalStub = nil
+	if fake.getDefaultTerminalReturnsOnCall == nil {
+		fake.getDefaultTerminalReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getDefaultTerminalReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetAllPolicies(ctx context.Context) ([]store.EgressPolicy, error) {
 	fake.getAllPoliciesMutex.Lock()
 	ret, specificReturn := fake.getAllPoliciesReturnsOnCall[len(fake.getAllPoliciesArgsForCall)]
-	fake.getAllPoliciesArgsForCall = append(fake.getAllPoliciesArgsForCall, struct{}{})
-	fake.recordInvocation("GetAllPolicies", []interface{}{})
+	fake.getAllPoliciesArgsForCall = append(fake.getAllPoliciesArgsForCall, struct {
+		ctx context.Context
+	}{ctx})
+	fake.recordInvocation("GetAllPolicies", []interface{}{ctx})
 	fake.getAllPoliciesMutex.Unlock()
 	if fake.GetAllPoliciesStub != nil {
-		return fake.GetAllPoliciesStub()
+		return fake.GetAllPoliciesStub(ctx)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -550,6 +891,12 @@ func (fake *EgressPolicyRepo) GetAllPoliciesCallCount() int {
 	return len(fake.getAllPoliciesArgsForCall)
 }
 
+func (fake *EgressPolicyRepo) GetAllPoliciesArgsForCall(i int) context.Context {
+	fake.getAllPoliciesMutex.RLock()
+	defer fake.getAllPoliciesMutex.RUnlock()
+	return fake.getAllPoliciesArgsForCall[i].ctx
+}
+
 func (fake *EgressPolicyRepo) GetAllPoliciesReturns(result1 []store.EgressPolicy, result2 error) {
 	fake.GetAllPoliciesStub = nil
 	fake.getAllPoliciesReturns = struct {
@@ -572,7 +919,7 @@ func (fake *EgressPolicyRepo) GetAllPoliciesReturnsOnCall(i int, result1 []store
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuids(ids []string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyRepo) GetBySourceGuids(ctx context.Context, ids []string) ([]store.EgressPolicy, error) {
 	var idsCopy []string
 	if ids != nil {
 		idsCopy = make([]string, len(ids))
@@ -581,12 +928,13 @@ func (fake *EgressPolicyRepo) GetBySourceGuids(ids []string) ([]store.EgressPoli
 	fake.getBySourceGuidsMutex.Lock()
 	ret, specificReturn := fake.getBySourceGuidsReturnsOnCall[len(fake.getBySourceGuidsArgsForCall)]
 	fake.getBySourceGuidsArgsForCall = append(fake.getBySourceGuidsArgsForCall, struct {
+		ctx context.Context
 		ids []string
-	}{idsCopy})
-	fake.recordInvocation("GetBySourceGuids", []interface{}{idsCopy})
+	}{ctx, idsCopy})
+	fake.recordInvocation("GetBySourceGuids", []interface{}{ctx, idsCopy})
 	fake.getBySourceGuidsMutex.Unlock()
 	if fake.GetBySourceGuidsStub != nil {
-		return fake.GetBySourceGuidsStub(ids)
+		return fake.GetBySourceGuidsStub(ctx, ids)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -600,10 +948,10 @@ func (fake *EgressPolicyRepo) GetBySourceGuidsCallCount() int {
 	return len(fake.getBySourceGuidsArgsForCall)
 }
 
-func (fake *EgressPolicyRepo) GetBySourceGuidsArgsForCall(i int) []string {
+func (fake *EgressPolicyRepo) GetBySourceGuidsArgsForCall(i int) (context.Context, []string) {
 	fake.getBySourceGuidsMutex.RLock()
 	defer fake.getBySourceGuidsMutex.RUnlock()
-	return fake.getBySourceGuidsArgsForCall[i].ids
+	return fake.getBySourceGuidsArgsForCall[i].ctx, fake.getBySourceGuidsArgsForCall[i].ids
 }
 
 func (fake *EgressPolicyRepo) GetBySourceGuidsReturns(result1 []store.EgressPolicy, result2 error) {
@@ -628,6 +976,115 @@ func (fake *EgressPolicyRepo) GetBySourceGuidsReturnsOnCall(i int, result1 []sto
 	}{result1, result2}
 }
 
+func (fake *EgressPolicyRepo) GetBySourceGuidsWithDefault(ctx context.Context, ids []string) ([]store.EgressPolicy, error) {
+	var idsCopy []string
+	if ids != nil {
+		idsCopy = make([]string, len(ids))
+		copy(idsCopy, ids)
+	}
+	fake.getBySourceGuidsWithDefaultMutex.Lock()
+	ret, specificReturn := fake.getBySourceGuidsWithDefaultReturnsOnCall[len(fake.getBySourceGuidsWithDefaultArgsForCall)]
+	fake.getBySourceGuidsWithDefaultArgsForCall = append(fake.getBySourceGuidsWithDefaultArgsForCall, struct {
+		ctx context.Context
+		ids []string
+	}{ctx, idsCopy})
+	fake.recordInvocation("GetBySourceGuidsWithDefault", []interface{}{ctx, idsCopy})
+	fake.getBySourceGuidsWithDefaultMutex.Unlock()
+	if fake.GetBySourceGuidsWithDefaultStub != nil {
+		return fake.GetBySourceGuidsWithDefaultStub(ctx, ids)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBySourceGuidsWithDefaultReturns.result1, fake.getBySourceGuidsWithDefaultReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsWithDefaultCallCount() int {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return len(fake.getBySourceGuidsWithDefaultArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsWithDefaultArgsForCall(i int) (context.Context, []string) {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return fake.getBySourceGuidsWithDefaultArgsForCall[i].ctx, fake.getBySourceGuidsWithDefaultArgsForCall[i].ids
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsWithDefaultReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	fake.getBySourceGuidsWithDefaultReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetBySourceGuidsWithDefaultReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	if fake.getBySourceGuidsWithDefaultReturnsOnCall == nil {
+		fake.getBySourceGuidsWithDefaultReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getBySourceGuidsWithDefaultReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetByFilter(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error) {
+	fake.getByFilterMutex.Lock()
+	ret, specificReturn := fake.getByFilterReturnsOnCall[len(fake.getByFilterArgsForCall)]
+	fake.getByFilterArgsForCall = append(fake.getByFilterArgsForCall, struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}{ctx, filter})
+	fake.recordInvocation("GetByFilter", []interface{}{ctx, filter})
+	fake.getByFilterMutex.Unlock()
+	if fake.GetByFilterStub != nil {
+		return fake.GetByFilterStub(ctx, filter)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getByFilterReturns.result1, fake.getByFilterReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetByFilterCallCount() int {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return len(fake.getByFilterArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetByFilterArgsForCall(i int) (context.Context, store.EgressPolicyFilter) {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return fake.getByFilterArgsForCall[i].ctx, fake.getByFilterArgsForCall[i].filter
+}
+
+func (fake *EgressPolicyRepo) GetByFilterReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	fake.getByFilterReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetByFilterReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	if fake.getByFilterReturnsOnCall == nil {
+		fake.getByFilterReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getByFilterReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressPolicyRepo) GetByGUID(tx db.Transaction, ids ...string) ([]store.EgressPolicy, error) {
 	fake.getByGUIDMutex.Lock()
 	ret, specificReturn := fake.getByGUIDReturnsOnCall[len(fake.getByGUIDArgsForCall)]
@@ -680,6 +1137,58 @@ func (fake *EgressPolicyRepo) GetByGUIDReturnsOnCall(i int, result1 []store.Egre
 	}{result1, result2}
 }
 
+func (fake *EgressPolicyRepo) GetByDestinationGUID(tx db.Transaction, destinationGUIDs ...string) ([]store.EgressPolicy, error) {
+	fake.getByDestinationGUIDMutex.Lock()
+	ret, specificReturn := fake.getByDestinationGUIDReturnsOnCall[len(fake.getByDestinationGUIDArgsForCall)]
+	fake.getByDestinationGUIDArgsForCall = append(fake.getByDestinationGUIDArgsForCall, struct {
+		tx               db.Transaction
+		destinationGUIDs []string
+	}{tx, destinationGUIDs})
+	fake.recordInvocation("GetByDestinationGUID", []interface{}{tx, destinationGUIDs})
+	fake.getByDestinationGUIDMutex.Unlock()
+	if fake.GetByDestinationGUIDStub != nil {
+		return fake.GetByDestinationGUIDStub(tx, destinationGUIDs...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getByDestinationGUIDReturns.result1, fake.getByDestinationGUIDReturns.result2
+}
+
+func (fake *EgressPolicyRepo) GetByDestinationGUIDCallCount() int {
+	fake.getByDestinationGUIDMutex.RLock()
+	defer fake.getByDestinationGUIDMutex.RUnlock()
+	return len(fake.getByDestinationGUIDArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) GetByDestinationGUIDArgsForCall(i int) (db.Transaction, []string) {
+	fake.getByDestinationGUIDMutex.RLock()
+	defer fake.getByDestinationGUIDMutex.RUnlock()
+	return fake.getByDestinationGUIDArgsForCall[i].tx, fake.getByDestinationGUIDArgsForCall[i].destinationGUIDs
+}
+
+func (fake *EgressPolicyRepo) GetByDestinationGUIDReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetByDestinationGUIDStub = nil
+	fake.getByDestinationGUIDReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyRepo) GetByDestinationGUIDReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetByDestinationGUIDStub = nil
+	if fake.getByDestinationGUIDReturnsOnCall == nil {
+		fake.getByDestinationGUIDReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getByDestinationGUIDReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressPolicyRepo) DeleteEgressPolicy(tx db.Transaction, egressPolicyGUID string) error {
 	fake.deleteEgressPolicyMutex.Lock()
 	ret, specificReturn := fake.deleteEgressPolicyReturnsOnCall[len(fake.deleteEgressPolicyArgsForCall)]
@@ -876,6 +1385,104 @@ func (fake *EgressPolicyRepo) DeleteSpaceReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *EgressPolicyRepo) DeleteOrg(tx db.Transaction, orgID string) error {
+	fake.deleteOrgMutex.Lock()
+	ret, specificReturn := fake.deleteOrgReturnsOnCall[len(fake.deleteOrgArgsForCall)]
+	fake.deleteOrgArgsForCall = append(fake.deleteOrgArgsForCall, struct {
+		tx    db.Transaction
+		orgID string
+	}{tx, orgID})
+	fake.recordInvocation("DeleteOrg", []interface{}{tx, orgID})
+	fake.deleteOrgMutex.Unlock()
+	if fake.DeleteOrgStub != nil {
+		return fake.DeleteOrgStub(tx, orgID)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteOrgReturns.result1
+}
+
+func (fake *EgressPolicyRepo) DeleteOrgCallCount() int {
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
+	return len(fake.deleteOrgArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) DeleteOrgArgsForCall(i int) (db.Transaction, string) {
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
+	return fake.deleteOrgArgsForCall[i].tx, fake.deleteOrgArgsForCall[i].orgID
+}
+
+func (fake *EgressPolicyRepo) DeleteOrgReturns(result1 error) {
+	fake.DeleteOrgStub = nil
+	fake.deleteOrgReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyRepo) DeleteOrgReturnsOnCall(i int, result1 error) {
+	fake.DeleteOrgStub = nil
+	if fake.deleteOrgReturnsOnCall == nil {
+		fake.deleteOrgReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteOrgReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyRepo) DeleteDefault(tx db.Transaction, terminalID string) error {
+	fake.deleteDefaultMutex.Lock()
+	ret, specificReturn := fake.deleteDefaultReturnsOnCall[len(fake.deleteDefaultArgsForCall)]
+	fake.deleteDefaultArgsForCall = append(fake.deleteDefaultArgsForCall, struct {
+		tx         db.Transaction
+		terminalID string
+	}{tx, terminalID})
+	fake.recordInvocation("DeleteDefault", []interface{}{tx, terminalID})
+	fake.deleteDefaultMutex.Unlock()
+	if fake.DeleteDefaultStub != nil {
+		return fake.DeleteDefaultStub(tx, terminalID)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fake.deleteDefaultReturns.result1
+}
+
+func (fake *EgressPolicyRepo) DeleteDefaultCallCount() int {
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
+	return len(fake.deleteDefaultArgsForCall)
+}
+
+func (fake *EgressPolicyRepo) DeleteDefaultArgsForCall(i int) (db.Transaction, string) {
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
+	return fake.deleteDefaultArgsForCall[i].tx, fake.deleteDefaultArgsForCall[i].terminalID
+}
+
+func (fake *EgressPolicyRepo) DeleteDefaultReturns(result1 error) {
+	fake.DeleteDefaultStub = nil
+	fake.deleteDefaultReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *EgressPolicyRepo) DeleteDefaultReturnsOnCall(i int, result1 error) {
+	fake.DeleteDefaultStub = nil
+	if fake.deleteDefaultReturnsOnCall == nil {
+		fake.deleteDefaultReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteDefaultReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *EgressPolicyRepo) IsTerminalInUse(tx db.Transaction, terminalGUID string) (bool, error) {
 	fake.isTerminalInUseMutex.Lock()
 	ret, specificReturn := fake.isTerminalInUseReturnsOnCall[len(fake.isTerminalInUseArgsForCall)]
@@ -939,16 +1546,30 @@ func (fake *EgressPolicyRepo) Invocations() map[string][][]interface{} {
 	defer fake.createEgressPolicyMutex.RUnlock()
 	fake.createSpaceMutex.RLock()
 	defer fake.createSpaceMutex.RUnlock()
+	fake.createOrgMutex.RLock()
+	defer fake.createOrgMutex.RUnlock()
+	fake.createDefaultMutex.RLock()
+	defer fake.createDefaultMutex.RUnlock()
 	fake.getTerminalByAppGUIDMutex.RLock()
 	defer fake.getTerminalByAppGUIDMutex.RUnlock()
 	fake.getTerminalBySpaceGUIDMutex.RLock()
 	defer fake.getTerminalBySpaceGUIDMutex.RUnlock()
+	fake.getTerminalByOrgGUIDMutex.RLock()
+	defer fake.getTerminalByOrgGUIDMutex.RUnlock()
+	fake.getDefaultTerminalMutex.RLock()
+	defer fake.getDefaultTerminalMutex.RUnlock()
 	fake.getAllPoliciesMutex.RLock()
 	defer fake.getAllPoliciesMutex.RUnlock()
 	fake.getBySourceGuidsMutex.RLock()
 	defer fake.getBySourceGuidsMutex.RUnlock()
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
 	fake.getByGUIDMutex.RLock()
 	defer fake.getByGUIDMutex.RUnlock()
+	fake.getByDestinationGUIDMutex.RLock()
+	defer fake.getByDestinationGUIDMutex.RUnlock()
 	fake.deleteEgressPolicyMutex.RLock()
 	defer fake.deleteEgressPolicyMutex.RUnlock()
 	fake.deleteIPRangeMutex.RLock()
@@ -957,6 +1578,10 @@ func (fake *EgressPolicyRepo) Invocations() map[string][][]interface{} {
 	defer fake.deleteAppMutex.RUnlock()
 	fake.deleteSpaceMutex.RLock()
 	defer fake.deleteSpaceMutex.RUnlock()
+	fake.deleteOrgMutex.RLock()
+	defer fake.deleteOrgMutex.RUnlock()
+	fake.deleteDefaultMutex.RLock()
+	defer fake.deleteDefaultMutex.RUnlock()
 	fake.isTerminalInUseMutex.RLock()
 	defer fake.isTerminalInUseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
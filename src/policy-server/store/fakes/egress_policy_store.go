@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type EgressPolicyStore struct {
-	CreateStub        func([]store.EgressPolicy) ([]store.EgressPolicy, error)
+	CreateStub        func(context.Context, []store.EgressPolicy) ([]store.EgressPolicy, error)
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 []store.EgressPolicy
+		arg1 context.Context
+		arg2 []store.EgressPolicy
 	}
 	createReturns struct {
 		result1 []store.EgressPolicy
@@ -20,9 +22,10 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	DeleteStub        func(guids ...string) ([]store.EgressPolicy, error)
+	DeleteStub        func(ctx context.Context, guids ...string) ([]store.EgressPolicy, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
+		ctx   context.Context
 		guids []string
 	}
 	deleteReturns struct {
@@ -33,10 +36,12 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	AllStub        func() ([]store.EgressPolicy, error)
+	AllStub        func(context.Context) ([]store.EgressPolicy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		arg1 context.Context
+	}
+	allReturns struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
@@ -44,9 +49,10 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
-	GetBySourceGuidsStub        func(srcGuids []string) ([]store.EgressPolicy, error)
+	GetBySourceGuidsStub        func(ctx context.Context, srcGuids []string) ([]store.EgressPolicy, error)
 	getBySourceGuidsMutex       sync.RWMutex
 	getBySourceGuidsArgsForCall []struct {
+		ctx      context.Context
 		srcGuids []string
 	}
 	getBySourceGuidsReturns struct {
@@ -57,25 +63,54 @@ type EgressPolicyStore struct {
 		result1 []store.EgressPolicy
 		result2 error
 	}
+	GetBySourceGuidsWithDefaultStub        func(ctx context.Context, srcGuids []string) ([]store.EgressPolicy, error)
+	getBySourceGuidsWithDefaultMutex       sync.RWMutex
+	getBySourceGuidsWithDefaultArgsForCall []struct {
+		ctx      context.Context
+		srcGuids []string
+	}
+	getBySourceGuidsWithDefaultReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	getBySourceGuidsWithDefaultReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	GetByFilterStub        func(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error)
+	getByFilterMutex       sync.RWMutex
+	getByFilterArgsForCall []struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}
+	getByFilterReturns struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
+	getByFilterReturnsOnCall map[int]struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *EgressPolicyStore) Create(arg1 []store.EgressPolicy) ([]store.EgressPolicy, error) {
-	var arg1Copy []store.EgressPolicy
-	if arg1 != nil {
-		arg1Copy = make([]store.EgressPolicy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *EgressPolicyStore) Create(arg1 context.Context, arg2 []store.EgressPolicy) ([]store.EgressPolicy, error) {
+	var arg2Copy []store.EgressPolicy
+	if arg2 != nil {
+		arg2Copy = make([]store.EgressPolicy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 []store.EgressPolicy
-	}{arg1Copy})
-	fake.recordInvocation("Create", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.EgressPolicy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2Copy})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1)
+		return fake.CreateStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -89,10 +124,10 @@ func (fake *EgressPolicyStore) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *EgressPolicyStore) CreateArgsForCall(i int) []store.EgressPolicy {
+func (fake *EgressPolicyStore) CreateArgsForCall(i int) (context.Context, []store.EgressPolicy) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1
+	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2
 }
 
 func (fake *EgressPolicyStore) CreateReturns(result1 []store.EgressPolicy, result2 error) {
@@ -117,16 +152,17 @@ func (fake *EgressPolicyStore) CreateReturnsOnCall(i int, result1 []store.Egress
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) Delete(guids ...string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) Delete(ctx context.Context, guids ...string) ([]store.EgressPolicy, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+		ctx   context.Context
 		guids []string
-	}{guids})
-	fake.recordInvocation("Delete", []interface{}{guids})
+	}{ctx, guids})
+	fake.recordInvocation("Delete", []interface{}{ctx, guids})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(guids...)
+		return fake.DeleteStub(ctx, guids...)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -140,10 +176,10 @@ func (fake *EgressPolicyStore) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *EgressPolicyStore) DeleteArgsForCall(i int) []string {
+func (fake *EgressPolicyStore) DeleteArgsForCall(i int) (context.Context, []string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].guids
+	return fake.deleteArgsForCall[i].ctx, fake.deleteArgsForCall[i].guids
 }
 
 func (fake *EgressPolicyStore) DeleteReturns(result1 []store.EgressPolicy, result2 error) {
@@ -168,14 +204,16 @@ func (fake *EgressPolicyStore) DeleteReturnsOnCall(i int, result1 []store.Egress
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) All() ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) All(arg1 context.Context) ([]store.EgressPolicy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -189,6 +227,12 @@ func (fake *EgressPolicyStore) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *EgressPolicyStore) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].arg1
+}
+
 func (fake *EgressPolicyStore) AllReturns(result1 []store.EgressPolicy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -211,7 +255,7 @@ func (fake *EgressPolicyStore) AllReturnsOnCall(i int, result1 []store.EgressPol
 	}{result1, result2}
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuids(srcGuids []string) ([]store.EgressPolicy, error) {
+func (fake *EgressPolicyStore) GetBySourceGuids(ctx context.Context, srcGuids []string) ([]store.EgressPolicy, error) {
 	var srcGuidsCopy []string
 	if srcGuids != nil {
 		srcGuidsCopy = make([]string, len(srcGuids))
@@ -220,12 +264,13 @@ func (fake *EgressPolicyStore) GetBySourceGuids(srcGuids []string) ([]store.Egre
 	fake.getBySourceGuidsMutex.Lock()
 	ret, specificReturn := fake.getBySourceGuidsReturnsOnCall[len(fake.getBySourceGuidsArgsForCall)]
 	fake.getBySourceGuidsArgsForCall = append(fake.getBySourceGuidsArgsForCall, struct {
+		ctx      context.Context
 		srcGuids []string
-	}{srcGuidsCopy})
-	fake.recordInvocation("GetBySourceGuids", []interface{}{srcGuidsCopy})
+	}{ctx, srcGuidsCopy})
+	fake.recordInvocation("GetBySourceGuids", []interface{}{ctx, srcGuidsCopy})
 	fake.getBySourceGuidsMutex.Unlock()
 	if fake.GetBySourceGuidsStub != nil {
-		return fake.GetBySourceGuidsStub(srcGuids)
+		return fake.GetBySourceGuidsStub(ctx, srcGuids)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -239,10 +284,10 @@ func (fake *EgressPolicyStore) GetBySourceGuidsCallCount() int {
 	return len(fake.getBySourceGuidsArgsForCall)
 }
 
-func (fake *EgressPolicyStore) GetBySourceGuidsArgsForCall(i int) []string {
+func (fake *EgressPolicyStore) GetBySourceGuidsArgsForCall(i int) (context.Context, []string) {
 	fake.getBySourceGuidsMutex.RLock()
 	defer fake.getBySourceGuidsMutex.RUnlock()
-	return fake.getBySourceGuidsArgsForCall[i].srcGuids
+	return fake.getBySourceGuidsArgsForCall[i].ctx, fake.getBySourceGuidsArgsForCall[i].srcGuids
 }
 
 func (fake *EgressPolicyStore) GetBySourceGuidsReturns(result1 []store.EgressPolicy, result2 error) {
@@ -267,6 +312,115 @@ func (fake *EgressPolicyStore) GetBySourceGuidsReturnsOnCall(i int, result1 []st
 	}{result1, result2}
 }
 
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefault(ctx context.Context, srcGuids []string) ([]store.EgressPolicy, error) {
+	var srcGuidsCopy []string
+	if srcGuids != nil {
+		srcGuidsCopy = make([]string, len(srcGuids))
+		copy(srcGuidsCopy, srcGuids)
+	}
+	fake.getBySourceGuidsWithDefaultMutex.Lock()
+	ret, specificReturn := fake.getBySourceGuidsWithDefaultReturnsOnCall[len(fake.getBySourceGuidsWithDefaultArgsForCall)]
+	fake.getBySourceGuidsWithDefaultArgsForCall = append(fake.getBySourceGuidsWithDefaultArgsForCall, struct {
+		ctx      context.Context
+		srcGuids []string
+	}{ctx, srcGuidsCopy})
+	fake.recordInvocation("GetBySourceGuidsWithDefault", []interface{}{ctx, srcGuidsCopy})
+	fake.getBySourceGuidsWithDefaultMutex.Unlock()
+	if fake.GetBySourceGuidsWithDefaultStub != nil {
+		return fake.GetBySourceGuidsWithDefaultStub(ctx, srcGuids)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getBySourceGuidsWithDefaultReturns.result1, fake.getBySourceGuidsWithDefaultReturns.result2
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultCallCount() int {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return len(fake.getBySourceGuidsWithDefaultArgsForCall)
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultArgsForCall(i int) (context.Context, []string) {
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	return fake.getBySourceGuidsWithDefaultArgsForCall[i].ctx, fake.getBySourceGuidsWithDefaultArgsForCall[i].srcGuids
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	fake.getBySourceGuidsWithDefaultReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyStore) GetBySourceGuidsWithDefaultReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetBySourceGuidsWithDefaultStub = nil
+	if fake.getBySourceGuidsWithDefaultReturnsOnCall == nil {
+		fake.getBySourceGuidsWithDefaultReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getBySourceGuidsWithDefaultReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyStore) GetByFilter(ctx context.Context, filter store.EgressPolicyFilter) ([]store.EgressPolicy, error) {
+	fake.getByFilterMutex.Lock()
+	ret, specificReturn := fake.getByFilterReturnsOnCall[len(fake.getByFilterArgsForCall)]
+	fake.getByFilterArgsForCall = append(fake.getByFilterArgsForCall, struct {
+		ctx    context.Context
+		filter store.EgressPolicyFilter
+	}{ctx, filter})
+	fake.recordInvocation("GetByFilter", []interface{}{ctx, filter})
+	fake.getByFilterMutex.Unlock()
+	if fake.GetByFilterStub != nil {
+		return fake.GetByFilterStub(ctx, filter)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.getByFilterReturns.result1, fake.getByFilterReturns.result2
+}
+
+func (fake *EgressPolicyStore) GetByFilterCallCount() int {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return len(fake.getByFilterArgsForCall)
+}
+
+func (fake *EgressPolicyStore) GetByFilterArgsForCall(i int) (context.Context, store.EgressPolicyFilter) {
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
+	return fake.getByFilterArgsForCall[i].ctx, fake.getByFilterArgsForCall[i].filter
+}
+
+func (fake *EgressPolicyStore) GetByFilterReturns(result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	fake.getByFilterReturns = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *EgressPolicyStore) GetByFilterReturnsOnCall(i int, result1 []store.EgressPolicy, result2 error) {
+	fake.GetByFilterStub = nil
+	if fake.getByFilterReturnsOnCall == nil {
+		fake.getByFilterReturnsOnCall = make(map[int]struct {
+			result1 []store.EgressPolicy
+			result2 error
+		})
+	}
+	fake.getByFilterReturnsOnCall[i] = struct {
+		result1 []store.EgressPolicy
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *EgressPolicyStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -278,6 +432,10 @@ func (fake *EgressPolicyStore) Invocations() map[string][][]interface{} {
 	defer fake.allMutex.RUnlock()
 	fake.getBySourceGuidsMutex.RLock()
 	defer fake.getBySourceGuidsMutex.RUnlock()
+	fake.getBySourceGuidsWithDefaultMutex.RLock()
+	defer fake.getBySourceGuidsWithDefaultMutex.RUnlock()
+	fake.getByFilterMutex.RLock()
+	defer fake.getByFilterMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
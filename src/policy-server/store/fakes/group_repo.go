@@ -24,30 +24,45 @@ type GroupRepo struct {
 		result1 int
 		result2 error
 	}
-	DeleteStub        func(db.Transaction, int) error
-	deleteMutex       sync.RWMutex
-	deleteArgsForCall []struct {
+	CreateManyStub        func(db.Transaction, []string, string) (map[string]int, error)
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
+		arg2 []string
+		arg3 string
+	}
+	createManyReturns struct {
+		result1 map[string]int
+		result2 error
+	}
+	createManyReturnsOnCall map[int]struct {
+		result1 map[string]int
+		result2 error
 	}
-	deleteReturns struct {
+	DeleteManyStub        func(db.Transaction, []int) error
+	deleteManyMutex       sync.RWMutex
+	deleteManyArgsForCall []struct {
+		arg1 db.Transaction
+		arg2 []int
+	}
+	deleteManyReturns struct {
 		result1 error
 	}
-	deleteReturnsOnCall map[int]struct {
+	deleteManyReturnsOnCall map[int]struct {
 		result1 error
 	}
-	GetIDStub        func(db.Transaction, string) (int, error)
-	getIDMutex       sync.RWMutex
-	getIDArgsForCall []struct {
+	GetIDsStub        func(db.Transaction, []string) (map[string]int, error)
+	getIDsMutex       sync.RWMutex
+	getIDsArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 string
+		arg2 []string
 	}
-	getIDReturns struct {
-		result1 int
+	getIDsReturns struct {
+		result1 map[string]int
 		result2 error
 	}
-	getIDReturnsOnCall map[int]struct {
-		result1 int
+	getIDsReturnsOnCall map[int]struct {
+		result1 map[string]int
 		result2 error
 	}
 	invocations      map[string][][]interface{}
@@ -107,103 +122,171 @@ func (fake *GroupRepo) CreateReturnsOnCall(i int, result1 int, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *GroupRepo) Delete(arg1 db.Transaction, arg2 int) error {
-	fake.deleteMutex.Lock()
-	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
-	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+func (fake *GroupRepo) CreateMany(arg1 db.Transaction, arg2 []string, arg3 string) (map[string]int, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
+		arg1 db.Transaction
+		arg2 []string
+		arg3 string
+	}{arg1, arg2Copy, arg3})
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy, arg3})
+	fake.createManyMutex.Unlock()
+	if fake.CreateManyStub != nil {
+		return fake.CreateManyStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.createManyReturns.result1, fake.createManyReturns.result2
+}
+
+func (fake *GroupRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
+}
+
+func (fake *GroupRepo) CreateManyArgsForCall(i int) (db.Transaction, []string, string) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return fake.createManyArgsForCall[i].arg1, fake.createManyArgsForCall[i].arg2, fake.createManyArgsForCall[i].arg3
+}
+
+func (fake *GroupRepo) CreateManyReturns(result1 map[string]int, result2 error) {
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GroupRepo) CreateManyReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
+			result2 error
+		})
+	}
+	fake.createManyReturnsOnCall[i] = struct {
+		result1 map[string]int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *GroupRepo) DeleteMany(arg1 db.Transaction, arg2 []int) error {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteManyMutex.Lock()
+	ret, specificReturn := fake.deleteManyReturnsOnCall[len(fake.deleteManyArgsForCall)]
+	fake.deleteManyArgsForCall = append(fake.deleteManyArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2})
-	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2)
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DeleteMany", []interface{}{arg1, arg2Copy})
+	fake.deleteManyMutex.Unlock()
+	if fake.DeleteManyStub != nil {
+		return fake.DeleteManyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fake.deleteManyReturns.result1
 }
 
-func (fake *GroupRepo) DeleteCallCount() int {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return len(fake.deleteArgsForCall)
+func (fake *GroupRepo) DeleteManyCallCount() int {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return len(fake.deleteManyArgsForCall)
 }
 
-func (fake *GroupRepo) DeleteArgsForCall(i int) (db.Transaction, int) {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
+func (fake *GroupRepo) DeleteManyArgsForCall(i int) (db.Transaction, []int) {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return fake.deleteManyArgsForCall[i].arg1, fake.deleteManyArgsForCall[i].arg2
 }
 
-func (fake *GroupRepo) DeleteReturns(result1 error) {
-	fake.DeleteStub = nil
-	fake.deleteReturns = struct {
+func (fake *GroupRepo) DeleteManyReturns(result1 error) {
+	fake.DeleteManyStub = nil
+	fake.deleteManyReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *GroupRepo) DeleteReturnsOnCall(i int, result1 error) {
-	fake.DeleteStub = nil
-	if fake.deleteReturnsOnCall == nil {
-		fake.deleteReturnsOnCall = make(map[int]struct {
+func (fake *GroupRepo) DeleteManyReturnsOnCall(i int, result1 error) {
+	fake.DeleteManyStub = nil
+	if fake.deleteManyReturnsOnCall == nil {
+		fake.deleteManyReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteReturnsOnCall[i] = struct {
+	fake.deleteManyReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *GroupRepo) GetID(arg1 db.Transaction, arg2 string) (int, error) {
-	fake.getIDMutex.Lock()
-	ret, specificReturn := fake.getIDReturnsOnCall[len(fake.getIDArgsForCall)]
-	fake.getIDArgsForCall = append(fake.getIDArgsForCall, struct {
+func (fake *GroupRepo) GetIDs(arg1 db.Transaction, arg2 []string) (map[string]int, error) {
+	var arg2Copy []string
+	if arg2 != nil {
+		arg2Copy = make([]string, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.getIDsMutex.Lock()
+	ret, specificReturn := fake.getIDsReturnsOnCall[len(fake.getIDsArgsForCall)]
+	fake.getIDsArgsForCall = append(fake.getIDsArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 string
-	}{arg1, arg2})
-	fake.recordInvocation("GetID", []interface{}{arg1, arg2})
-	fake.getIDMutex.Unlock()
-	if fake.GetIDStub != nil {
-		return fake.GetIDStub(arg1, arg2)
+		arg2 []string
+	}{arg1, arg2Copy})
+	fake.recordInvocation("GetIDs", []interface{}{arg1, arg2Copy})
+	fake.getIDsMutex.Unlock()
+	if fake.GetIDsStub != nil {
+		return fake.GetIDsStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.getIDReturns.result1, fake.getIDReturns.result2
+	return fake.getIDsReturns.result1, fake.getIDsReturns.result2
 }
 
-func (fake *GroupRepo) GetIDCallCount() int {
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
-	return len(fake.getIDArgsForCall)
+func (fake *GroupRepo) GetIDsCallCount() int {
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
+	return len(fake.getIDsArgsForCall)
 }
 
-func (fake *GroupRepo) GetIDArgsForCall(i int) (db.Transaction, string) {
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
-	return fake.getIDArgsForCall[i].arg1, fake.getIDArgsForCall[i].arg2
+func (fake *GroupRepo) GetIDsArgsForCall(i int) (db.Transaction, []string) {
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
+	return fake.getIDsArgsForCall[i].arg1, fake.getIDsArgsForCall[i].arg2
 }
 
-func (fake *GroupRepo) GetIDReturns(result1 int, result2 error) {
-	fake.GetIDStub = nil
-	fake.getIDReturns = struct {
-		result1 int
+func (fake *GroupRepo) GetIDsReturns(result1 map[string]int, result2 error) {
+	fake.GetIDsStub = nil
+	fake.getIDsReturns = struct {
+		result1 map[string]int
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *GroupRepo) GetIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.GetIDStub = nil
-	if fake.getIDReturnsOnCall == nil {
-		fake.getIDReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *GroupRepo) GetIDsReturnsOnCall(i int, result1 map[string]int, result2 error) {
+	fake.GetIDsStub = nil
+	if fake.getIDsReturnsOnCall == nil {
+		fake.getIDsReturnsOnCall = make(map[int]struct {
+			result1 map[string]int
 			result2 error
 		})
 	}
-	fake.getIDReturnsOnCall[i] = struct {
-		result1 int
+	fake.getIDsReturnsOnCall[i] = struct {
+		result1 map[string]int
 		result2 error
 	}{result1, result2}
 }
@@ -213,10 +296,12 @@ func (fake *GroupRepo) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	fake.getIDMutex.RLock()
-	defer fake.getIDMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	fake.getIDsMutex.RLock()
+	defer fake.getIDsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
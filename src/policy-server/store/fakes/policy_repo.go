@@ -9,264 +9,280 @@ import (
 )
 
 type PolicyRepo struct {
-	CreateStub        func(db.Transaction, int, int) error
-	createMutex       sync.RWMutex
-	createArgsForCall []struct {
+	CreateManyStub        func(db.Transaction, []store.PolicyRow) error
+	createManyMutex       sync.RWMutex
+	createManyArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
+		arg2 []store.PolicyRow
 	}
-	createReturns struct {
+	createManyReturns struct {
 		result1 error
 	}
-	createReturnsOnCall map[int]struct {
+	createManyReturnsOnCall map[int]struct {
 		result1 error
 	}
-	DeleteStub        func(db.Transaction, int, int) error
-	deleteMutex       sync.RWMutex
-	deleteArgsForCall []struct {
+	DeleteManyStub        func(db.Transaction, []store.PolicyRow) error
+	deleteManyMutex       sync.RWMutex
+	deleteManyArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
+		arg2 []store.PolicyRow
 	}
-	deleteReturns struct {
+	deleteManyReturns struct {
 		result1 error
 	}
-	deleteReturnsOnCall map[int]struct {
+	deleteManyReturnsOnCall map[int]struct {
 		result1 error
 	}
-	CountWhereGroupIDStub        func(db.Transaction, int) (int, error)
-	countWhereGroupIDMutex       sync.RWMutex
-	countWhereGroupIDArgsForCall []struct {
+	GroupIDsInUseStub        func(db.Transaction, []int) (map[int]bool, error)
+	groupIDsInUseMutex       sync.RWMutex
+	groupIDsInUseArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
+		arg2 []int
 	}
-	countWhereGroupIDReturns struct {
-		result1 int
+	groupIDsInUseReturns struct {
+		result1 map[int]bool
 		result2 error
 	}
-	countWhereGroupIDReturnsOnCall map[int]struct {
-		result1 int
+	groupIDsInUseReturnsOnCall map[int]struct {
+		result1 map[int]bool
 		result2 error
 	}
-	CountWhereDestinationIDStub        func(db.Transaction, int) (int, error)
-	countWhereDestinationIDMutex       sync.RWMutex
-	countWhereDestinationIDArgsForCall []struct {
+	DestinationIDsInUseStub        func(db.Transaction, []int) (map[int]bool, error)
+	destinationIDsInUseMutex       sync.RWMutex
+	destinationIDsInUseArgsForCall []struct {
 		arg1 db.Transaction
-		arg2 int
+		arg2 []int
 	}
-	countWhereDestinationIDReturns struct {
-		result1 int
+	destinationIDsInUseReturns struct {
+		result1 map[int]bool
 		result2 error
 	}
-	countWhereDestinationIDReturnsOnCall map[int]struct {
-		result1 int
+	destinationIDsInUseReturnsOnCall map[int]struct {
+		result1 map[int]bool
 		result2 error
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *PolicyRepo) Create(arg1 db.Transaction, arg2 int, arg3 int) error {
-	fake.createMutex.Lock()
-	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
-	fake.createArgsForCall = append(fake.createArgsForCall, struct {
+func (fake *PolicyRepo) CreateMany(arg1 db.Transaction, arg2 []store.PolicyRow) error {
+	var arg2Copy []store.PolicyRow
+	if arg2 != nil {
+		arg2Copy = make([]store.PolicyRow, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.createManyMutex.Lock()
+	ret, specificReturn := fake.createManyReturnsOnCall[len(fake.createManyArgsForCall)]
+	fake.createManyArgsForCall = append(fake.createManyArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Create", []interface{}{arg1, arg2, arg3})
-	fake.createMutex.Unlock()
-	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1, arg2, arg3)
+		arg2 []store.PolicyRow
+	}{arg1, arg2Copy})
+	fake.recordInvocation("CreateMany", []interface{}{arg1, arg2Copy})
+	fake.createManyMutex.Unlock()
+	if fake.CreateManyStub != nil {
+		return fake.CreateManyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.createReturns.result1
+	return fake.createManyReturns.result1
 }
 
-func (fake *PolicyRepo) CreateCallCount() int {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return len(fake.createArgsForCall)
+func (fake *PolicyRepo) CreateManyCallCount() int {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return len(fake.createManyArgsForCall)
 }
 
-func (fake *PolicyRepo) CreateArgsForCall(i int) (db.Transaction, int, int) {
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2, fake.createArgsForCall[i].arg3
+func (fake *PolicyRepo) CreateManyArgsForCall(i int) (db.Transaction, []store.PolicyRow) {
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	return fake.createManyArgsForCall[i].arg1, fake.createManyArgsForCall[i].arg2
 }
 
-func (fake *PolicyRepo) CreateReturns(result1 error) {
-	fake.CreateStub = nil
-	fake.createReturns = struct {
+func (fake *PolicyRepo) CreateManyReturns(result1 error) {
+	fake.CreateManyStub = nil
+	fake.createManyReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *PolicyRepo) CreateReturnsOnCall(i int, result1 error) {
-	fake.CreateStub = nil
-	if fake.createReturnsOnCall == nil {
-		fake.createReturnsOnCall = make(map[int]struct {
+func (fake *PolicyRepo) CreateManyReturnsOnCall(i int, result1 error) {
+	fake.CreateManyStub = nil
+	if fake.createManyReturnsOnCall == nil {
+		fake.createManyReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.createReturnsOnCall[i] = struct {
+	fake.createManyReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *PolicyRepo) Delete(arg1 db.Transaction, arg2 int, arg3 int) error {
-	fake.deleteMutex.Lock()
-	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
-	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
+func (fake *PolicyRepo) DeleteMany(arg1 db.Transaction, arg2 []store.PolicyRow) error {
+	var arg2Copy []store.PolicyRow
+	if arg2 != nil {
+		arg2Copy = make([]store.PolicyRow, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteManyMutex.Lock()
+	ret, specificReturn := fake.deleteManyReturnsOnCall[len(fake.deleteManyArgsForCall)]
+	fake.deleteManyArgsForCall = append(fake.deleteManyArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-		arg3 int
-	}{arg1, arg2, arg3})
-	fake.recordInvocation("Delete", []interface{}{arg1, arg2, arg3})
-	fake.deleteMutex.Unlock()
-	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1, arg2, arg3)
+		arg2 []store.PolicyRow
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DeleteMany", []interface{}{arg1, arg2Copy})
+	fake.deleteManyMutex.Unlock()
+	if fake.DeleteManyStub != nil {
+		return fake.DeleteManyStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
 	}
-	return fake.deleteReturns.result1
+	return fake.deleteManyReturns.result1
 }
 
-func (fake *PolicyRepo) DeleteCallCount() int {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return len(fake.deleteArgsForCall)
+func (fake *PolicyRepo) DeleteManyCallCount() int {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return len(fake.deleteManyArgsForCall)
 }
 
-func (fake *PolicyRepo) DeleteArgsForCall(i int) (db.Transaction, int, int) {
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2, fake.deleteArgsForCall[i].arg3
+func (fake *PolicyRepo) DeleteManyArgsForCall(i int) (db.Transaction, []store.PolicyRow) {
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	return fake.deleteManyArgsForCall[i].arg1, fake.deleteManyArgsForCall[i].arg2
 }
 
-func (fake *PolicyRepo) DeleteReturns(result1 error) {
-	fake.DeleteStub = nil
-	fake.deleteReturns = struct {
+func (fake *PolicyRepo) DeleteManyReturns(result1 error) {
+	fake.DeleteManyStub = nil
+	fake.deleteManyReturns = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *PolicyRepo) DeleteReturnsOnCall(i int, result1 error) {
-	fake.DeleteStub = nil
-	if fake.deleteReturnsOnCall == nil {
-		fake.deleteReturnsOnCall = make(map[int]struct {
+func (fake *PolicyRepo) DeleteManyReturnsOnCall(i int, result1 error) {
+	fake.DeleteManyStub = nil
+	if fake.deleteManyReturnsOnCall == nil {
+		fake.deleteManyReturnsOnCall = make(map[int]struct {
 			result1 error
 		})
 	}
-	fake.deleteReturnsOnCall[i] = struct {
+	fake.deleteManyReturnsOnCall[i] = struct {
 		result1 error
 	}{result1}
 }
 
-func (fake *PolicyRepo) CountWhereGroupID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereGroupIDMutex.Lock()
-	ret, specificReturn := fake.countWhereGroupIDReturnsOnCall[len(fake.countWhereGroupIDArgsForCall)]
-	fake.countWhereGroupIDArgsForCall = append(fake.countWhereGroupIDArgsForCall, struct {
+func (fake *PolicyRepo) GroupIDsInUse(arg1 db.Transaction, arg2 []int) (map[int]bool, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.groupIDsInUseMutex.Lock()
+	ret, specificReturn := fake.groupIDsInUseReturnsOnCall[len(fake.groupIDsInUseArgsForCall)]
+	fake.groupIDsInUseArgsForCall = append(fake.groupIDsInUseArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereGroupID", []interface{}{arg1, arg2})
-	fake.countWhereGroupIDMutex.Unlock()
-	if fake.CountWhereGroupIDStub != nil {
-		return fake.CountWhereGroupIDStub(arg1, arg2)
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("GroupIDsInUse", []interface{}{arg1, arg2Copy})
+	fake.groupIDsInUseMutex.Unlock()
+	if fake.GroupIDsInUseStub != nil {
+		return fake.GroupIDsInUseStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.countWhereGroupIDReturns.result1, fake.countWhereGroupIDReturns.result2
+	return fake.groupIDsInUseReturns.result1, fake.groupIDsInUseReturns.result2
 }
 
-func (fake *PolicyRepo) CountWhereGroupIDCallCount() int {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return len(fake.countWhereGroupIDArgsForCall)
+func (fake *PolicyRepo) GroupIDsInUseCallCount() int {
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
+	return len(fake.groupIDsInUseArgsForCall)
 }
 
-func (fake *PolicyRepo) CountWhereGroupIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	return fake.countWhereGroupIDArgsForCall[i].arg1, fake.countWhereGroupIDArgsForCall[i].arg2
+func (fake *PolicyRepo) GroupIDsInUseArgsForCall(i int) (db.Transaction, []int) {
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
+	return fake.groupIDsInUseArgsForCall[i].arg1, fake.groupIDsInUseArgsForCall[i].arg2
 }
 
-func (fake *PolicyRepo) CountWhereGroupIDReturns(result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	fake.countWhereGroupIDReturns = struct {
-		result1 int
+func (fake *PolicyRepo) GroupIDsInUseReturns(result1 map[int]bool, result2 error) {
+	fake.GroupIDsInUseStub = nil
+	fake.groupIDsInUseReturns = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *PolicyRepo) CountWhereGroupIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereGroupIDStub = nil
-	if fake.countWhereGroupIDReturnsOnCall == nil {
-		fake.countWhereGroupIDReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *PolicyRepo) GroupIDsInUseReturnsOnCall(i int, result1 map[int]bool, result2 error) {
+	fake.GroupIDsInUseStub = nil
+	if fake.groupIDsInUseReturnsOnCall == nil {
+		fake.groupIDsInUseReturnsOnCall = make(map[int]struct {
+			result1 map[int]bool
 			result2 error
 		})
 	}
-	fake.countWhereGroupIDReturnsOnCall[i] = struct {
-		result1 int
+	fake.groupIDsInUseReturnsOnCall[i] = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *PolicyRepo) CountWhereDestinationID(arg1 db.Transaction, arg2 int) (int, error) {
-	fake.countWhereDestinationIDMutex.Lock()
-	ret, specificReturn := fake.countWhereDestinationIDReturnsOnCall[len(fake.countWhereDestinationIDArgsForCall)]
-	fake.countWhereDestinationIDArgsForCall = append(fake.countWhereDestinationIDArgsForCall, struct {
+func (fake *PolicyRepo) DestinationIDsInUse(arg1 db.Transaction, arg2 []int) (map[int]bool, error) {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.destinationIDsInUseMutex.Lock()
+	ret, specificReturn := fake.destinationIDsInUseReturnsOnCall[len(fake.destinationIDsInUseArgsForCall)]
+	fake.destinationIDsInUseArgsForCall = append(fake.destinationIDsInUseArgsForCall, struct {
 		arg1 db.Transaction
-		arg2 int
-	}{arg1, arg2})
-	fake.recordInvocation("CountWhereDestinationID", []interface{}{arg1, arg2})
-	fake.countWhereDestinationIDMutex.Unlock()
-	if fake.CountWhereDestinationIDStub != nil {
-		return fake.CountWhereDestinationIDStub(arg1, arg2)
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DestinationIDsInUse", []interface{}{arg1, arg2Copy})
+	fake.destinationIDsInUseMutex.Unlock()
+	if fake.DestinationIDsInUseStub != nil {
+		return fake.DestinationIDsInUseStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
 	}
-	return fake.countWhereDestinationIDReturns.result1, fake.countWhereDestinationIDReturns.result2
+	return fake.destinationIDsInUseReturns.result1, fake.destinationIDsInUseReturns.result2
 }
 
-func (fake *PolicyRepo) CountWhereDestinationIDCallCount() int {
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
-	return len(fake.countWhereDestinationIDArgsForCall)
+func (fake *PolicyRepo) DestinationIDsInUseCallCount() int {
+	fake.destinationIDsInUseMutex.RLock()
+	defer fake.destinationIDsInUseMutex.RUnlock()
+	return len(fake.destinationIDsInUseArgsForCall)
 }
 
-func (fake *PolicyRepo) CountWhereDestinationIDArgsForCall(i int) (db.Transaction, int) {
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
-	return fake.countWhereDestinationIDArgsForCall[i].arg1, fake.countWhereDestinationIDArgsForCall[i].arg2
+func (fake *PolicyRepo) DestinationIDsInUseArgsForCall(i int) (db.Transaction, []int) {
+	fake.destinationIDsInUseMutex.RLock()
+	defer fake.destinationIDsInUseMutex.RUnlock()
+	return fake.destinationIDsInUseArgsForCall[i].arg1, fake.destinationIDsInUseArgsForCall[i].arg2
 }
 
-func (fake *PolicyRepo) CountWhereDestinationIDReturns(result1 int, result2 error) {
-	fake.CountWhereDestinationIDStub = nil
-	fake.countWhereDestinationIDReturns = struct {
-		result1 int
+func (fake *PolicyRepo) DestinationIDsInUseReturns(result1 map[int]bool, result2 error) {
+	fake.DestinationIDsInUseStub = nil
+	fake.destinationIDsInUseReturns = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *PolicyRepo) CountWhereDestinationIDReturnsOnCall(i int, result1 int, result2 error) {
-	fake.CountWhereDestinationIDStub = nil
-	if fake.countWhereDestinationIDReturnsOnCall == nil {
-		fake.countWhereDestinationIDReturnsOnCall = make(map[int]struct {
-			result1 int
+func (fake *PolicyRepo) DestinationIDsInUseReturnsOnCall(i int, result1 map[int]bool, result2 error) {
+	fake.DestinationIDsInUseStub = nil
+	if fake.destinationIDsInUseReturnsOnCall == nil {
+		fake.destinationIDsInUseReturnsOnCall = make(map[int]struct {
+			result1 map[int]bool
 			result2 error
 		})
 	}
-	fake.countWhereDestinationIDReturnsOnCall[i] = struct {
-		result1 int
+	fake.destinationIDsInUseReturnsOnCall[i] = struct {
+		result1 map[int]bool
 		result2 error
 	}{result1, result2}
 }
@@ -274,14 +290,14 @@ func (fake *PolicyRepo) CountWhereDestinationIDReturnsOnCall(i int, result1 int,
 func (fake *PolicyRepo) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createMutex.RLock()
-	defer fake.createMutex.RUnlock()
-	fake.deleteMutex.RLock()
-	defer fake.deleteMutex.RUnlock()
-	fake.countWhereGroupIDMutex.RLock()
-	defer fake.countWhereGroupIDMutex.RUnlock()
-	fake.countWhereDestinationIDMutex.RLock()
-	defer fake.countWhereDestinationIDMutex.RUnlock()
+	fake.createManyMutex.RLock()
+	defer fake.createManyMutex.RUnlock()
+	fake.deleteManyMutex.RLock()
+	defer fake.deleteManyMutex.RUnlock()
+	fake.groupIDsInUseMutex.RLock()
+	defer fake.groupIDsInUseMutex.RUnlock()
+	fake.destinationIDsInUseMutex.RLock()
+	defer fake.destinationIDsInUseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
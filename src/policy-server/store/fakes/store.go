@@ -2,15 +2,17 @@
 package fakes
 
 import (
+	"context"
 	"policy-server/store"
 	"sync"
 )
 
 type Store struct {
-	CreateStub        func([]store.Policy) error
+	CreateStub        func(context.Context, []store.Policy) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
-		arg1 []store.Policy
+		arg1 context.Context
+		arg2 []store.Policy
 	}
 	createReturns struct {
 		result1 error
@@ -18,10 +20,12 @@ type Store struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
-	AllStub        func() ([]store.Policy, error)
+	AllStub        func(context.Context) ([]store.Policy, error)
 	allMutex       sync.RWMutex
-	allArgsForCall []struct{}
-	allReturns     struct {
+	allArgsForCall []struct {
+		arg1 context.Context
+	}
+	allReturns struct {
 		result1 []store.Policy
 		result2 error
 	}
@@ -29,10 +33,11 @@ type Store struct {
 		result1 []store.Policy
 		result2 error
 	}
-	DeleteStub        func([]store.Policy) error
+	DeleteStub        func(context.Context, []store.Policy) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		arg1 []store.Policy
+		arg1 context.Context
+		arg2 []store.Policy
 	}
 	deleteReturns struct {
 		result1 error
@@ -40,12 +45,13 @@ type Store struct {
 	deleteReturnsOnCall map[int]struct {
 		result1 error
 	}
-	ByGuidsStub        func([]string, []string, bool) ([]store.Policy, error)
+	ByGuidsStub        func(context.Context, []string, []string, bool) ([]store.Policy, error)
 	byGuidsMutex       sync.RWMutex
 	byGuidsArgsForCall []struct {
-		arg1 []string
+		arg1 context.Context
 		arg2 []string
-		arg3 bool
+		arg3 []string
+		arg4 bool
 	}
 	byGuidsReturns struct {
 		result1 []store.Policy
@@ -55,10 +61,12 @@ type Store struct {
 		result1 []store.Policy
 		result2 error
 	}
-	CheckDatabaseStub        func() error
+	CheckDatabaseStub        func(context.Context) error
 	checkDatabaseMutex       sync.RWMutex
-	checkDatabaseArgsForCall []struct{}
-	checkDatabaseReturns     struct {
+	checkDatabaseArgsForCall []struct {
+		arg1 context.Context
+	}
+	checkDatabaseReturns struct {
 		result1 error
 	}
 	checkDatabaseReturnsOnCall map[int]struct {
@@ -68,21 +76,22 @@ type Store struct {
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *Store) Create(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *Store) Create(arg1 context.Context, arg2 []store.Policy) error {
+	var arg2Copy []store.Policy
+	if arg2 != nil {
+		arg2Copy = make([]store.Policy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Create", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.Policy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Create", []interface{}{arg1, arg2Copy})
 	fake.createMutex.Unlock()
 	if fake.CreateStub != nil {
-		return fake.CreateStub(arg1)
+		return fake.CreateStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -96,10 +105,10 @@ func (fake *Store) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *Store) CreateArgsForCall(i int) []store.Policy {
+func (fake *Store) CreateArgsForCall(i int) (context.Context, []store.Policy) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
-	return fake.createArgsForCall[i].arg1
+	return fake.createArgsForCall[i].arg1, fake.createArgsForCall[i].arg2
 }
 
 func (fake *Store) CreateReturns(result1 error) {
@@ -121,14 +130,16 @@ func (fake *Store) CreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Store) All() ([]store.Policy, error) {
+func (fake *Store) All(arg1 context.Context) ([]store.Policy, error) {
 	fake.allMutex.Lock()
 	ret, specificReturn := fake.allReturnsOnCall[len(fake.allArgsForCall)]
-	fake.allArgsForCall = append(fake.allArgsForCall, struct{}{})
-	fake.recordInvocation("All", []interface{}{})
+	fake.allArgsForCall = append(fake.allArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("All", []interface{}{arg1})
 	fake.allMutex.Unlock()
 	if fake.AllStub != nil {
-		return fake.AllStub()
+		return fake.AllStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -142,6 +153,12 @@ func (fake *Store) AllCallCount() int {
 	return len(fake.allArgsForCall)
 }
 
+func (fake *Store) AllArgsForCall(i int) context.Context {
+	fake.allMutex.RLock()
+	defer fake.allMutex.RUnlock()
+	return fake.allArgsForCall[i].arg1
+}
+
 func (fake *Store) AllReturns(result1 []store.Policy, result2 error) {
 	fake.AllStub = nil
 	fake.allReturns = struct {
@@ -164,21 +181,22 @@ func (fake *Store) AllReturnsOnCall(i int, result1 []store.Policy, result2 error
 	}{result1, result2}
 }
 
-func (fake *Store) Delete(arg1 []store.Policy) error {
-	var arg1Copy []store.Policy
-	if arg1 != nil {
-		arg1Copy = make([]store.Policy, len(arg1))
-		copy(arg1Copy, arg1)
+func (fake *Store) Delete(arg1 context.Context, arg2 []store.Policy) error {
+	var arg2Copy []store.Policy
+	if arg2 != nil {
+		arg2Copy = make([]store.Policy, len(arg2))
+		copy(arg2Copy, arg2)
 	}
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		arg1 []store.Policy
-	}{arg1Copy})
-	fake.recordInvocation("Delete", []interface{}{arg1Copy})
+		arg1 context.Context
+		arg2 []store.Policy
+	}{arg1, arg2Copy})
+	fake.recordInvocation("Delete", []interface{}{arg1, arg2Copy})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(arg1)
+		return fake.DeleteStub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1
@@ -192,10 +210,10 @@ func (fake *Store) DeleteCallCount() int {
 	return len(fake.deleteArgsForCall)
 }
 
-func (fake *Store) DeleteArgsForCall(i int) []store.Policy {
+func (fake *Store) DeleteArgsForCall(i int) (context.Context, []store.Policy) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].arg1
+	return fake.deleteArgsForCall[i].arg1, fake.deleteArgsForCall[i].arg2
 }
 
 func (fake *Store) DeleteReturns(result1 error) {
@@ -217,28 +235,29 @@ func (fake *Store) DeleteReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *Store) ByGuids(arg1 []string, arg2 []string, arg3 bool) ([]store.Policy, error) {
-	var arg1Copy []string
-	if arg1 != nil {
-		arg1Copy = make([]string, len(arg1))
-		copy(arg1Copy, arg1)
-	}
+func (fake *Store) ByGuids(arg1 context.Context, arg2 []string, arg3 []string, arg4 bool) ([]store.Policy, error) {
 	var arg2Copy []string
 	if arg2 != nil {
 		arg2Copy = make([]string, len(arg2))
 		copy(arg2Copy, arg2)
 	}
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
 	fake.byGuidsMutex.Lock()
 	ret, specificReturn := fake.byGuidsReturnsOnCall[len(fake.byGuidsArgsForCall)]
 	fake.byGuidsArgsForCall = append(fake.byGuidsArgsForCall, struct {
-		arg1 []string
+		arg1 context.Context
 		arg2 []string
-		arg3 bool
-	}{arg1Copy, arg2Copy, arg3})
-	fake.recordInvocation("ByGuids", []interface{}{arg1Copy, arg2Copy, arg3})
+		arg3 []string
+		arg4 bool
+	}{arg1, arg2Copy, arg3Copy, arg4})
+	fake.recordInvocation("ByGuids", []interface{}{arg1, arg2Copy, arg3Copy, arg4})
 	fake.byGuidsMutex.Unlock()
 	if fake.ByGuidsStub != nil {
-		return fake.ByGuidsStub(arg1, arg2, arg3)
+		return fake.ByGuidsStub(arg1, arg2, arg3, arg4)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -252,10 +271,10 @@ func (fake *Store) ByGuidsCallCount() int {
 	return len(fake.byGuidsArgsForCall)
 }
 
-func (fake *Store) ByGuidsArgsForCall(i int) ([]string, []string, bool) {
+func (fake *Store) ByGuidsArgsForCall(i int) (context.Context, []string, []string, bool) {
 	fake.byGuidsMutex.RLock()
 	defer fake.byGuidsMutex.RUnlock()
-	return fake.byGuidsArgsForCall[i].arg1, fake.byGuidsArgsForCall[i].arg2, fake.byGuidsArgsForCall[i].arg3
+	return fake.byGuidsArgsForCall[i].arg1, fake.byGuidsArgsForCall[i].arg2, fake.byGuidsArgsForCall[i].arg3, fake.byGuidsArgsForCall[i].arg4
 }
 
 func (fake *Store) ByGuidsReturns(result1 []store.Policy, result2 error) {
@@ -280,14 +299,16 @@ func (fake *Store) ByGuidsReturnsOnCall(i int, result1 []store.Policy, result2 e
 	}{result1, result2}
 }
 
-func (fake *Store) CheckDatabase() error {
+func (fake *Store) CheckDatabase(arg1 context.Context) error {
 	fake.checkDatabaseMutex.Lock()
 	ret, specificReturn := fake.checkDatabaseReturnsOnCall[len(fake.checkDatabaseArgsForCall)]
-	fake.checkDatabaseArgsForCall = append(fake.checkDatabaseArgsForCall, struct{}{})
-	fake.recordInvocation("CheckDatabase", []interface{}{})
+	fake.checkDatabaseArgsForCall = append(fake.checkDatabaseArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	fake.recordInvocation("CheckDatabase", []interface{}{arg1})
 	fake.checkDatabaseMutex.Unlock()
 	if fake.CheckDatabaseStub != nil {
-		return fake.CheckDatabaseStub()
+		return fake.CheckDatabaseStub(arg1)
 	}
 	if specificReturn {
 		return ret.result1
@@ -301,6 +322,12 @@ func (fake *Store) CheckDatabaseCallCount() int {
 	return len(fake.checkDatabaseArgsForCall)
 }
 
+func (fake *Store) CheckDatabaseArgsForCall(i int) context.Context {
+	fake.checkDatabaseMutex.RLock()
+	defer fake.checkDatabaseMutex.RUnlock()
+	return fake.checkDatabaseArgsForCall[i].arg1
+}
+
 func (fake *Store) CheckDatabaseReturns(result1 error) {
 	fake.CheckDatabaseStub = nil
 	fake.checkDatabaseReturns = struct {
@@ -32,6 +32,30 @@ type TagStore struct {
 		result1 []store.Tag
 		result2 error
 	}
+	ReleaseTagsStub        func([]string) (int64, error)
+	releaseTagsMutex       sync.RWMutex
+	releaseTagsArgsForCall []struct {
+		arg1 []string
+	}
+	releaseTagsReturns struct {
+		result1 int64
+		result2 error
+	}
+	releaseTagsReturnsOnCall map[int]struct {
+		result1 int64
+		result2 error
+	}
+	TagCapacityStub        func() (store.TagCapacity, error)
+	tagCapacityMutex       sync.RWMutex
+	tagCapacityArgsForCall []struct{}
+	tagCapacityReturns     struct {
+		result1 store.TagCapacity
+		result2 error
+	}
+	tagCapacityReturnsOnCall map[int]struct {
+		result1 store.TagCapacity
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -131,6 +155,105 @@ func (fake *TagStore) TagsReturnsOnCall(i int, result1 []store.Tag, result2 erro
 	}{result1, result2}
 }
 
+func (fake *TagStore) ReleaseTags(arg1 []string) (int64, error) {
+	var arg1Copy []string
+	if arg1 != nil {
+		arg1Copy = make([]string, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.releaseTagsMutex.Lock()
+	ret, specificReturn := fake.releaseTagsReturnsOnCall[len(fake.releaseTagsArgsForCall)]
+	fake.releaseTagsArgsForCall = append(fake.releaseTagsArgsForCall, struct {
+		arg1 []string
+	}{arg1Copy})
+	fake.recordInvocation("ReleaseTags", []interface{}{arg1Copy})
+	fake.releaseTagsMutex.Unlock()
+	if fake.ReleaseTagsStub != nil {
+		return fake.ReleaseTagsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.releaseTagsReturns.result1, fake.releaseTagsReturns.result2
+}
+
+func (fake *TagStore) ReleaseTagsCallCount() int {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	return len(fake.releaseTagsArgsForCall)
+}
+
+func (fake *TagStore) ReleaseTagsArgsForCall(i int) []string {
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	return fake.releaseTagsArgsForCall[i].arg1
+}
+
+func (fake *TagStore) ReleaseTagsReturns(result1 int64, result2 error) {
+	fake.ReleaseTagsStub = nil
+	fake.releaseTagsReturns = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) ReleaseTagsReturnsOnCall(i int, result1 int64, result2 error) {
+	fake.ReleaseTagsStub = nil
+	if fake.releaseTagsReturnsOnCall == nil {
+		fake.releaseTagsReturnsOnCall = make(map[int]struct {
+			result1 int64
+			result2 error
+		})
+	}
+	fake.releaseTagsReturnsOnCall[i] = struct {
+		result1 int64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) TagCapacity() (store.TagCapacity, error) {
+	fake.tagCapacityMutex.Lock()
+	ret, specificReturn := fake.tagCapacityReturnsOnCall[len(fake.tagCapacityArgsForCall)]
+	fake.tagCapacityArgsForCall = append(fake.tagCapacityArgsForCall, struct{}{})
+	fake.recordInvocation("TagCapacity", []interface{}{})
+	fake.tagCapacityMutex.Unlock()
+	if fake.TagCapacityStub != nil {
+		return fake.TagCapacityStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fake.tagCapacityReturns.result1, fake.tagCapacityReturns.result2
+}
+
+func (fake *TagStore) TagCapacityCallCount() int {
+	fake.tagCapacityMutex.RLock()
+	defer fake.tagCapacityMutex.RUnlock()
+	return len(fake.tagCapacityArgsForCall)
+}
+
+func (fake *TagStore) TagCapacityReturns(result1 store.TagCapacity, result2 error) {
+	fake.TagCapacityStub = nil
+	fake.tagCapacityReturns = struct {
+		result1 store.TagCapacity
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *TagStore) TagCapacityReturnsOnCall(i int, result1 store.TagCapacity, result2 error) {
+	fake.TagCapacityStub = nil
+	if fake.tagCapacityReturnsOnCall == nil {
+		fake.tagCapacityReturnsOnCall = make(map[int]struct {
+			result1 store.TagCapacity
+			result2 error
+		})
+	}
+	fake.tagCapacityReturnsOnCall[i] = struct {
+		result1 store.TagCapacity
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *TagStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -138,6 +261,10 @@ func (fake *TagStore) Invocations() map[string][][]interface{} {
 	defer fake.createTagMutex.RUnlock()
 	fake.tagsMutex.RLock()
 	defer fake.tagsMutex.RUnlock()
+	fake.releaseTagsMutex.RLock()
+	defer fake.releaseTagsMutex.RUnlock()
+	fake.tagCapacityMutex.RLock()
+	defer fake.tagCapacityMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,6 +3,8 @@ package store
 import (
 	"database/sql"
 	"fmt"
+	"policy-server/store/helpers"
+	"strings"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
@@ -10,8 +12,9 @@ import (
 //go:generate counterfeiter -o fakes/group_repo.go --fake-name GroupRepo . GroupRepo
 type GroupRepo interface {
 	Create(db.Transaction, string, string) (int, error)
-	Delete(db.Transaction, int) error
-	GetID(db.Transaction, string) (int, error)
+	CreateMany(db.Transaction, []string, string) (map[string]int, error)
+	DeleteMany(db.Transaction, []int) error
+	GetIDs(db.Transaction, []string) (map[string]int, error)
 }
 
 type GroupTable struct {
@@ -75,20 +78,143 @@ func (g *GroupTable) updateRow(tx db.Transaction, id int, guid, groupType string
 	return err
 }
 
-func (g *GroupTable) Delete(tx db.Transaction, id int) error {
+// CreateMany returns the id of every guid, allocating tags to the guids
+// that do not have one yet. Tags are handed out in the order the guids are
+// given, lowest free row first, exactly as calling Create for each guid in
+// turn would.
+func (g *GroupTable) CreateMany(tx db.Transaction, guids []string, groupType string) (map[string]int, error) {
+	ids := map[string]int{}
+	err := inBatches(len(guids), func(start, end int) error {
+		args := append([]interface{}{groupType}, convertToInterfaceSlice(guids[start:end])...)
+		return g.scanIDs(tx, ids, `
+			SELECT id, guid FROM groups
+			WHERE type = ? AND guid IN (`+helpers.QuestionMarks(end-start)+`)`,
+			args...,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for _, guid := range guids {
+		if _, ok := ids[guid]; !ok {
+			ids[guid] = -1
+			missing = append(missing, guid)
+		}
+	}
+	if len(missing) == 0 {
+		return ids, nil
+	}
+
+	blankIDs, err := g.firstBlankRows(tx, len(missing))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find available tag: %s", err.Error())
+	}
+	if len(blankIDs) < len(missing) {
+		return nil, fmt.Errorf("failed to find available tag: %s", sql.ErrNoRows)
+	}
+
+	for i, guid := range missing {
+		ids[guid] = blankIDs[i]
+	}
+
+	err = inBatches(len(missing), func(start, end int) error {
+		return g.updateRows(tx, blankIDs[start:end], missing[start:end], groupType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (g *GroupTable) firstBlankRows(tx db.Transaction, count int) ([]int, error) {
+	rows, err := tx.Queryx(fmt.Sprintf(
+		`SELECT id FROM groups
+		WHERE guid is NULL
+		ORDER BY id
+		LIMIT %d
+		FOR UPDATE
+	`, count))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// updateRows assigns guids[i] to the row ids[i] in a single statement.
+func (g *GroupTable) updateRows(tx db.Transaction, ids []int, guids []string, groupType string) error {
+	var cases []string
+	var args []interface{}
+	for i, id := range ids {
+		cases = append(cases, "WHEN ? THEN ?")
+		args = append(args, id, guids[i])
+	}
+	args = append(args, groupType)
+	args = append(args, intsToInterfaceSlice(ids)...)
+
 	_, err := tx.Exec(
-		tx.Rebind(`UPDATE groups SET guid = NULL, type = NULL WHERE id = ?`),
-		id,
+		tx.Rebind(`
+			UPDATE groups SET guid = CASE id `+strings.Join(cases, " ")+` END, type = ?
+			WHERE id IN (`+helpers.QuestionMarks(len(ids))+`)
+		`),
+		args...,
 	)
 	return err
 }
 
-func (g *GroupTable) GetID(tx db.Transaction, guid string) (int, error) {
-	var id int
-	err := tx.QueryRow(
-		tx.Rebind(`SELECT id FROM groups WHERE guid = ?`),
-		guid,
-	).Scan(&id)
+func (g *GroupTable) DeleteMany(tx db.Transaction, ids []int) error {
+	return inBatches(len(ids), func(start, end int) error {
+		_, err := tx.Exec(
+			tx.Rebind(`UPDATE groups SET guid = NULL, type = NULL WHERE id IN (`+helpers.QuestionMarks(end-start)+`)`),
+			intsToInterfaceSlice(ids[start:end])...,
+		)
+		return err
+	})
+}
 
-	return id, err
+// GetIDs returns the ids of the guids that have a tag. Guids without one
+// are left out of the map.
+func (g *GroupTable) GetIDs(tx db.Transaction, guids []string) (map[string]int, error) {
+	ids := map[string]int{}
+	err := inBatches(len(guids), func(start, end int) error {
+		return g.scanIDs(tx, ids,
+			`SELECT id, guid FROM groups WHERE guid IN (`+helpers.QuestionMarks(end-start)+`)`,
+			convertToInterfaceSlice(guids[start:end])...,
+		)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+func (g *GroupTable) scanIDs(tx db.Transaction, ids map[string]int, query string, args ...interface{}) error {
+	rows, err := tx.Queryx(tx.Rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var guid string
+		err = rows.Scan(&id, &guid)
+		if err != nil {
+			return err
+		}
+		ids[guid] = id
+	}
+	return rows.Err()
 }
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"context"
 	"time"
 )
 
@@ -16,9 +17,9 @@ type MetricsWrapper struct {
 	MetricsSender metricsSender
 }
 
-func (mw *MetricsWrapper) Create(policies []Policy) error {
+func (mw *MetricsWrapper) Create(ctx context.Context, policies []Policy) error {
 	startTime := time.Now()
-	err := mw.Store.Create(policies)
+	err := mw.Store.Create(ctx, policies)
 	createTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("StoreCreateError")
@@ -29,9 +30,9 @@ func (mw *MetricsWrapper) Create(policies []Policy) error {
 	return err
 }
 
-func (mw *MetricsWrapper) All() ([]Policy, error) {
+func (mw *MetricsWrapper) All(ctx context.Context) ([]Policy, error) {
 	startTime := time.Now()
-	policies, err := mw.Store.All()
+	policies, err := mw.Store.All(ctx)
 	allTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("StoreAllError")
@@ -42,9 +43,9 @@ func (mw *MetricsWrapper) All() ([]Policy, error) {
 	return policies, err
 }
 
-func (mw *MetricsWrapper) Delete(policies []Policy) error {
+func (mw *MetricsWrapper) Delete(ctx context.Context, policies []Policy) error {
 	startTime := time.Now()
-	err := mw.Store.Delete(policies)
+	err := mw.Store.Delete(ctx, policies)
 	deleteTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("StoreDeleteError")
@@ -81,9 +82,35 @@ func (mw *MetricsWrapper) CreateTag(groupGuid, groupType string) (Tag, error) {
 	return tag, err
 }
 
-func (mw *MetricsWrapper) ByGuids(srcGuids, dstGuids []string, inSourceAndDest bool) ([]Policy, error) {
+func (mw *MetricsWrapper) ReleaseTags(groupGuids []string) (int64, error) {
 	startTime := time.Now()
-	policies, err := mw.Store.ByGuids(srcGuids, dstGuids, inSourceAndDest)
+	released, err := mw.TagStore.ReleaseTags(groupGuids)
+	releaseTagsTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreReleaseTagsError")
+		mw.MetricsSender.SendDuration("StoreReleaseTagsErrorTime", releaseTagsTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreReleaseTagsSuccessTime", releaseTagsTimeDuration)
+	}
+	return released, err
+}
+
+func (mw *MetricsWrapper) TagCapacity() (TagCapacity, error) {
+	startTime := time.Now()
+	capacity, err := mw.TagStore.TagCapacity()
+	tagCapacityTimeDuration := time.Now().Sub(startTime)
+	if err != nil {
+		mw.MetricsSender.IncrementCounter("StoreTagCapacityError")
+		mw.MetricsSender.SendDuration("StoreTagCapacityErrorTime", tagCapacityTimeDuration)
+	} else {
+		mw.MetricsSender.SendDuration("StoreTagCapacitySuccessTime", tagCapacityTimeDuration)
+	}
+	return capacity, err
+}
+
+func (mw *MetricsWrapper) ByGuids(ctx context.Context, srcGuids, dstGuids []string, inSourceAndDest bool) ([]Policy, error) {
+	startTime := time.Now()
+	policies, err := mw.Store.ByGuids(ctx, srcGuids, dstGuids, inSourceAndDest)
 	byGuidsTimeDuration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("StoreByGuidsError")
@@ -94,9 +121,9 @@ func (mw *MetricsWrapper) ByGuids(srcGuids, dstGuids []string, inSourceAndDest b
 	return policies, err
 }
 
-func (mw *MetricsWrapper) CheckDatabase() error {
+func (mw *MetricsWrapper) CheckDatabase(ctx context.Context) error {
 	startTime := time.Now()
-	err := mw.Store.CheckDatabase()
+	err := mw.Store.CheckDatabase(ctx)
 	duration := time.Now().Sub(startTime)
 	if err != nil {
 		mw.MetricsSender.IncrementCounter("StoreCheckDatabaseError")
@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"errors"
 	"policy-server/store"
 	"policy-server/store/fakes"
@@ -51,16 +52,16 @@ var _ = Describe("MetricsWrapper", func() {
 
 	Describe("Create", func() {
 		It("calls Create on the Store", func() {
-			err := metricsWrapper.Create(policies)
+			err := metricsWrapper.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeStore.CreateCallCount()).To(Equal(1))
-			passedPolicies := fakeStore.CreateArgsForCall(0)
+			_, passedPolicies := fakeStore.CreateArgsForCall(0)
 			Expect(passedPolicies).To(Equal(policies))
 		})
 
 		It("emits a metric", func() {
-			err := metricsWrapper.Create(policies)
+			err := metricsWrapper.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -73,7 +74,7 @@ var _ = Describe("MetricsWrapper", func() {
 				fakeStore.CreateReturns(errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				err := metricsWrapper.Create(policies)
+				err := metricsWrapper.Create(context.Background(), policies)
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -143,7 +144,7 @@ var _ = Describe("MetricsWrapper", func() {
 			fakeStore.AllReturns(policies, nil)
 		})
 		It("returns the result of All on the Store", func() {
-			returnedPolicies, err := metricsWrapper.All()
+			returnedPolicies, err := metricsWrapper.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedPolicies).To(Equal(policies))
 
@@ -151,7 +152,7 @@ var _ = Describe("MetricsWrapper", func() {
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.All()
+			_, err := metricsWrapper.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -164,7 +165,7 @@ var _ = Describe("MetricsWrapper", func() {
 				fakeStore.AllReturns(nil, errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.All()
+				_, err := metricsWrapper.All(context.Background())
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -183,19 +184,19 @@ var _ = Describe("MetricsWrapper", func() {
 			fakeStore.ByGuidsReturns(policies, nil)
 		})
 		It("returns the result of ByGuids on the Store", func() {
-			returnedPolicies, err := metricsWrapper.ByGuids(srcGuids, destGuids, true)
+			returnedPolicies, err := metricsWrapper.ByGuids(context.Background(), srcGuids, destGuids, true)
 			Expect(err).NotTo(HaveOccurred())
 			Expect(returnedPolicies).To(Equal(policies))
 
 			Expect(fakeStore.ByGuidsCallCount()).To(Equal(1))
-			returnedSrcGuids, returnedDestGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			_, returnedSrcGuids, returnedDestGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
 			Expect(returnedSrcGuids).To(Equal(srcGuids))
 			Expect(returnedDestGuids).To(Equal(destGuids))
 			Expect(inSourceAndDest).To(BeTrue())
 		})
 
 		It("emits a metric", func() {
-			_, err := metricsWrapper.ByGuids(srcGuids, destGuids, true)
+			_, err := metricsWrapper.ByGuids(context.Background(), srcGuids, destGuids, true)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -208,7 +209,7 @@ var _ = Describe("MetricsWrapper", func() {
 				fakeStore.ByGuidsReturns(nil, errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				_, err := metricsWrapper.ByGuids(srcGuids, destGuids, true)
+				_, err := metricsWrapper.ByGuids(context.Background(), srcGuids, destGuids, true)
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -224,14 +225,14 @@ var _ = Describe("MetricsWrapper", func() {
 
 	Describe("CheckDatabase", func() {
 		It("calls CheckDatabase on the Store", func() {
-			err := metricsWrapper.CheckDatabase()
+			err := metricsWrapper.CheckDatabase(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeStore.CheckDatabaseCallCount()).To(Equal(1))
 		})
 
 		It("emits a metric", func() {
-			err := metricsWrapper.CheckDatabase()
+			err := metricsWrapper.CheckDatabase(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -244,7 +245,7 @@ var _ = Describe("MetricsWrapper", func() {
 				fakeStore.CheckDatabaseReturns(errors.New("huckleberry"))
 			})
 			It("emits an error metric", func() {
-				err := metricsWrapper.CheckDatabase()
+				err := metricsWrapper.CheckDatabase(context.Background())
 				Expect(err).To(MatchError("huckleberry"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -259,16 +260,16 @@ var _ = Describe("MetricsWrapper", func() {
 
 	Describe("Delete", func() {
 		It("calls Delete on the Store", func() {
-			err := metricsWrapper.Delete(policies)
+			err := metricsWrapper.Delete(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeStore.DeleteCallCount()).To(Equal(1))
-			passedPolicies := fakeStore.DeleteArgsForCall(0)
+			_, passedPolicies := fakeStore.DeleteArgsForCall(0)
 			Expect(passedPolicies).To(Equal(policies))
 		})
 
 		It("emits a metric", func() {
-			err := metricsWrapper.Delete(policies)
+			err := metricsWrapper.Delete(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
@@ -281,7 +282,7 @@ var _ = Describe("MetricsWrapper", func() {
 				fakeStore.DeleteReturns(errors.New("banana"))
 			})
 			It("emits an error metric", func() {
-				err := metricsWrapper.Delete(policies)
+				err := metricsWrapper.Delete(context.Background(), policies)
 				Expect(err).To(MatchError("banana"))
 
 				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
@@ -334,4 +335,83 @@ var _ = Describe("MetricsWrapper", func() {
 			})
 		})
 	})
+
+	Describe("ReleaseTags", func() {
+		BeforeEach(func() {
+			fakeTagStore.ReleaseTagsReturns(2, nil)
+		})
+		It("calls ReleaseTags on the Store", func() {
+			released, err := metricsWrapper.ReleaseTags([]string{"some-app-guid", "some-other-app-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(Equal(int64(2)))
+
+			Expect(fakeTagStore.ReleaseTagsCallCount()).To(Equal(1))
+			Expect(fakeTagStore.ReleaseTagsArgsForCall(0)).To(Equal([]string{"some-app-guid", "some-other-app-guid"}))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.ReleaseTags([]string{"some-app-guid"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreReleaseTagsSuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeTagStore.ReleaseTagsReturns(0, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.ReleaseTags([]string{"some-app-guid"})
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreReleaseTagsError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreReleaseTagsErrorTime"))
+			})
+		})
+	})
+
+	Describe("TagCapacity", func() {
+		BeforeEach(func() {
+			fakeTagStore.TagCapacityReturns(store.TagCapacity{Total: 255, Free: 250}, nil)
+		})
+		It("calls TagCapacity on the Store", func() {
+			capacity, err := metricsWrapper.TagCapacity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity).To(Equal(store.TagCapacity{Total: 255, Free: 250}))
+
+			Expect(fakeTagStore.TagCapacityCallCount()).To(Equal(1))
+		})
+
+		It("emits a metric", func() {
+			_, err := metricsWrapper.TagCapacity()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+			name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+			Expect(name).To(Equal("StoreTagCapacitySuccessTime"))
+		})
+
+		Context("when there is an error", func() {
+			BeforeEach(func() {
+				fakeTagStore.TagCapacityReturns(store.TagCapacity{}, errors.New("banana"))
+			})
+			It("emits an error metric", func() {
+				_, err := metricsWrapper.TagCapacity()
+				Expect(err).To(MatchError("banana"))
+
+				Expect(fakeMetricsSender.IncrementCounterCallCount()).To(Equal(1))
+				Expect(fakeMetricsSender.IncrementCounterArgsForCall(0)).To(Equal("StoreTagCapacityError"))
+
+				Expect(fakeMetricsSender.SendDurationCallCount()).To(Equal(1))
+				name, _ := fakeMetricsSender.SendDurationArgsForCall(0)
+				Expect(name).To(Equal("StoreTagCapacityErrorTime"))
+			})
+		})
+	})
 })
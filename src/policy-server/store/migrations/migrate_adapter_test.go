@@ -1,9 +1,15 @@
 package migrations_test
 
 import (
+	"fmt"
 	"policy-server/store/migrations"
-	"policy-server/store/migrations/fakes"
+	"time"
 
+	"test-helpers"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/lager"
 	"github.com/cf-container-networking/sql-migrate"
 
 	. "github.com/onsi/ginkgo"
@@ -11,21 +17,127 @@ import (
 )
 
 var _ = Describe("MigrateAdapter", func() {
-
 	var (
-		migrateAdapter *migrations.MigrateAdapter
+		dbConf          db.Config
+		realDb          *db.ConnWrapper
+		migrateAdapter  *migrations.MigrateAdapter
+		migrationSource migrate.MemoryMigrationSource
 	)
 
+	tableExists := func(name string) bool {
+		var count int
+		err := realDb.QueryRow(fmt.Sprintf("SELECT COUNT(*) FROM %s", name)).Scan(&count)
+		return err == nil
+	}
+
+	appliedIDs := func() []string {
+		records, err := migrateAdapter.GetMigrationRecords(realDb, realDb.DriverName())
+		Expect(err).NotTo(HaveOccurred())
+
+		ids := []string{}
+		for _, record := range records {
+			ids = append(ids, record.Id)
+		}
+		return ids
+	}
+
 	BeforeEach(func() {
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("migrate_adapter_test_node_%d", time.Now().UnixNano())
+		dbConf.Timeout = 30
+		testhelpers.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Migrate Adapter Test")
+
+		var err error
+		realDb, err = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Migrate Adapter Test", "Migrate Adapter Test", logger)
+		Expect(err).NotTo(HaveOccurred())
+
 		migrateAdapter = &migrations.MigrateAdapter{}
+		migrationSource = migrate.MemoryMigrationSource{
+			Migrations: []*migrate.Migration{
+				{
+					Id:   "1",
+					Up:   []string{"CREATE TABLE adapter_test_one (id INT)"},
+					Down: []string{"DROP TABLE adapter_test_one"},
+				},
+				{
+					Id:   "2",
+					Up:   []string{"CREATE TABLE adapter_test_two (id INT)"},
+					Down: []string{"DROP TABLE adapter_test_two"},
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testhelpers.RemoveDatabase(dbConf)
 	})
 
 	Describe("ExecMax", func() {
+		It("applies at most max migrations up", func() {
+			numMigrations, err := migrateAdapter.ExecMax(realDb, realDb.DriverName(), migrationSource, migrate.Up, 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(1))
+
+			Expect(tableExists("adapter_test_one")).To(BeTrue())
+			Expect(tableExists("adapter_test_two")).To(BeFalse())
+		})
+
 		Context("when the migration direction is down", func() {
+			BeforeEach(func() {
+				_, err := migrateAdapter.ExecMax(realDb, realDb.DriverName(), migrationSource, migrate.Up, 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("rolls back at most max migrations, newest first", func() {
+				numMigrations, err := migrateAdapter.ExecMax(realDb, realDb.DriverName(), migrationSource, migrate.Down, 1)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(1))
+
+				Expect(tableExists("adapter_test_one")).To(BeTrue())
+				Expect(tableExists("adapter_test_two")).To(BeFalse())
+				Expect(appliedIDs()).To(Equal([]string{"1"}))
+			})
+
+			It("rolls back every migration when max is zero", func() {
+				numMigrations, err := migrateAdapter.ExecMax(realDb, realDb.DriverName(), migrationSource, migrate.Down, 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numMigrations).To(Equal(2))
+
+				Expect(tableExists("adapter_test_one")).To(BeFalse())
+				Expect(appliedIDs()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("GetMigrationRecords", func() {
+		It("returns no records before any migration is applied", func() {
+			Expect(appliedIDs()).To(BeEmpty())
+		})
+
+		It("returns the applied migrations in order", func() {
+			_, err := migrateAdapter.ExecMax(realDb, realDb.DriverName(), migrationSource, migrate.Up, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			records, err := migrateAdapter.GetMigrationRecords(realDb, realDb.DriverName())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0].Id).To(Equal("1"))
+			Expect(records[1].Id).To(Equal("2"))
+			Expect(records[0].AppliedAt).NotTo(BeZero())
+		})
+
+		Context("when the database is closed", func() {
 			It("returns an error", func() {
-				fakeMigrationDb := &fakes.MigrationDb{}
-				_, err := migrateAdapter.ExecMax(fakeMigrationDb, "some-dialect", migrate.MemoryMigrationSource{}, migrate.Down, 0)
-				Expect(err).To(MatchError("down migration not supported"))
+				Expect(realDb.Close()).To(Succeed())
+
+				_, err := migrateAdapter.GetMigrationRecords(realDb, realDb.DriverName())
+				Expect(err).To(HaveOccurred())
+				realDb = nil
 			})
 		})
 	})
@@ -2,8 +2,11 @@ package migrations
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/lager"
 	"github.com/cf-container-networking/sql-migrate"
 	"github.com/jmoiron/sqlx"
 )
@@ -11,6 +14,7 @@ import (
 //go:generate counterfeiter -o fakes/migrate_adapter.go --fake-name MigrateAdapter . migrateAdapter
 type migrateAdapter interface {
 	ExecMax(db MigrationDb, dialect string, m migrate.MigrationSource, dir migrate.MigrationDirection, maxNumMigrations int) (int, error)
+	GetMigrationRecords(db MigrationDb, dialect string) ([]*migrate.MigrationRecord, error)
 }
 
 //go:generate counterfeiter -o fakes/migration_db.go --fake-name MigrationDb . MigrationDb
@@ -30,6 +34,7 @@ type migrationsProvider interface {
 type Migrator struct {
 	MigrateAdapter     migrateAdapter
 	MigrationsProvider migrationsProvider
+	Logger             lager.Logger
 }
 
 func (m *Migrator) PerformMigrations(driverName string, migrationDb MigrationDb, maxNumMigrations int) (int, error) {
@@ -42,6 +47,10 @@ func (m *Migrator) PerformMigrations(driverName string, migrationDb MigrationDb,
 		return 0, fmt.Errorf("unsupported driver: %s", driverName)
 	}
 
+	if migrationsToPerform.hasBackfills() {
+		return m.performOnlineMigrations(driverName, migrationDb, migrationsToPerform, maxNumMigrations)
+	}
+
 	numMigrations, err := m.MigrateAdapter.ExecMax(
 		migrationDb,
 		driverName,
@@ -58,6 +67,179 @@ func (m *Migrator) PerformMigrations(driverName string, migrationDb MigrationDb,
 	return numMigrations, nil
 }
 
+// performOnlineMigrations applies pending migrations in order, stopping
+// after each migration with a backfill to run it before any later
+// migration that may depend on the backfilled data.
+func (m *Migrator) performOnlineMigrations(driverName string, migrationDb MigrationDb, migrationsToPerform PolicyServerMigrations, maxNumMigrations int) (int, error) {
+	logger := m.Logger
+	if logger == nil {
+		logger = lager.NewLogger("migrator")
+	}
+
+	records, err := m.MigrateAdapter.GetMigrationRecords(migrationDb, driverName)
+	if err != nil {
+		return 0, fmt.Errorf("getting migration records: %s", err)
+	}
+
+	applied := map[string]bool{}
+	for _, record := range records {
+		applied[record.Id] = true
+	}
+
+	source := migrate.MemoryMigrationSource{
+		Migrations: migrationsToPerform.ForDriver(driverName),
+	}
+
+	numMigrations := 0
+	unapplied := 0
+	for _, migration := range migrationsToPerform {
+		if maxNumMigrations > 0 && numMigrations+unapplied == maxNumMigrations {
+			break
+		}
+		if !applied[migration.Id] {
+			unapplied++
+		}
+		if migration.Backfill == nil {
+			continue
+		}
+
+		if unapplied > 0 {
+			n, err := m.MigrateAdapter.ExecMax(migrationDb, driverName, source, migrate.Up, unapplied)
+			numMigrations += n
+			if err != nil {
+				return numMigrations, fmt.Errorf("executing migration: %s", err)
+			}
+			unapplied = 0
+		}
+
+		err = migration.Backfill.run(logger, migrationDb, migration.Id)
+		if err != nil {
+			return numMigrations, fmt.Errorf("backfilling migration %s: %s", migration.Id, err)
+		}
+	}
+
+	if unapplied > 0 {
+		n, err := m.MigrateAdapter.ExecMax(migrationDb, driverName, source, migrate.Up, unapplied)
+		numMigrations += n
+		if err != nil {
+			return numMigrations, fmt.Errorf("executing migration: %s", err)
+		}
+	}
+
+	return numMigrations, nil
+}
+
+// RollbackMigrations reverts the most recently applied numMigrations
+// migrations. It refuses to run unless every one of them has a down
+// migration for the driver and no rows would be lost or changed by it.
+func (m *Migrator) RollbackMigrations(driverName string, migrationDb MigrationDb, numMigrations int) (int, error) {
+	if numMigrations < 1 {
+		return 0, errors.New("number of migrations to roll back must be at least 1")
+	}
+
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return 0, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	if !migrationsToPerform.supportsDriver(driverName) {
+		return 0, fmt.Errorf("unsupported driver: %s", driverName)
+	}
+
+	records, err := m.MigrateAdapter.GetMigrationRecords(migrationDb, driverName)
+	if err != nil {
+		return 0, fmt.Errorf("getting migration records: %s", err)
+	}
+
+	applied := map[string]bool{}
+	for _, record := range records {
+		applied[record.Id] = true
+	}
+
+	toRollback := 0
+	for i := len(migrationsToPerform) - 1; i >= 0 && toRollback < numMigrations; i-- {
+		migration := migrationsToPerform[i]
+		if !applied[migration.Id] {
+			continue
+		}
+		if !migration.reversible(driverName) {
+			return 0, fmt.Errorf("migration %s cannot be rolled back", migration.Id)
+		}
+		if guard, ok := migration.RollbackGuard[driverName]; ok {
+			var blockingRows int
+			err := migrationDb.QueryRow(guard).Scan(&blockingRows)
+			if err != nil {
+				return 0, fmt.Errorf("checking rollback of migration %s: %s", migration.Id, err)
+			}
+			if blockingRows > 0 {
+				return 0, fmt.Errorf("migration %s cannot be rolled back while %d rows depend on it", migration.Id, blockingRows)
+			}
+		}
+		toRollback++
+	}
+
+	// ExecMax treats a max of 0 as unlimited, so never pass it through
+	if toRollback == 0 {
+		return 0, nil
+	}
+
+	numRolledBack, err := m.MigrateAdapter.ExecMax(
+		migrationDb,
+		driverName,
+		migrate.MemoryMigrationSource{
+			Migrations: migrationsToPerform.ForDriver(driverName),
+		},
+		migrate.Down,
+		toRollback,
+	)
+	if err != nil {
+		return numRolledBack, fmt.Errorf("executing rollback: %s", err)
+	}
+	return numRolledBack, nil
+}
+
+type MigrationStatus struct {
+	Id         string
+	Applied    bool
+	AppliedAt  time.Time
+	Reversible bool
+}
+
+// MigrationStatus lists every known migration in the order it is applied
+// along with whether it has been recorded in gorp_migrations.
+func (m *Migrator) MigrationStatus(driverName string, migrationDb MigrationDb) ([]MigrationStatus, error) {
+	migrationsToPerform, err := m.MigrationsProvider.MigrationsToPerform()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving migrations to perform: %s", err)
+	}
+
+	if !migrationsToPerform.supportsDriver(driverName) {
+		return nil, fmt.Errorf("unsupported driver: %s", driverName)
+	}
+
+	records, err := m.MigrateAdapter.GetMigrationRecords(migrationDb, driverName)
+	if err != nil {
+		return nil, fmt.Errorf("getting migration records: %s", err)
+	}
+
+	appliedAt := map[string]time.Time{}
+	for _, record := range records {
+		appliedAt[record.Id] = record.AppliedAt
+	}
+
+	statuses := []MigrationStatus{}
+	for _, migration := range migrationsToPerform {
+		at, applied := appliedAt[migration.Id]
+		statuses = append(statuses, MigrationStatus{
+			Id:         migration.Id,
+			Applied:    applied,
+			AppliedAt:  at,
+			Reversible: migration.reversible(driverName),
+		})
+	}
+	return statuses, nil
+}
+
 type PolicyServerMigrations []PolicyServerMigration
 
 func (s PolicyServerMigrations) ForDriver(driverName string) []*migrate.Migration {
@@ -69,6 +251,15 @@ func (s PolicyServerMigrations) ForDriver(driverName string) []*migrate.Migratio
 	return migrationMapped
 }
 
+func (s PolicyServerMigrations) hasBackfills() bool {
+	for _, migration := range s {
+		if migration.Backfill != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (s PolicyServerMigrations) supportsDriver(driverName string) bool {
 	for _, migration := range s {
 		if !migration.supportsDriver(driverName) {
@@ -78,15 +269,31 @@ func (s PolicyServerMigrations) supportsDriver(driverName string) bool {
 	return true
 }
 
+// PolicyServerMigration is a single schema change. Migrations that touch
+// large tables should set OnlineDDL so MySQL alters the table without
+// locking it, and move any data population into a Backfill instead of an
+// UPDATE in Up. A RollbackGuard counts the rows that Down would leave
+// unreadable or change the meaning of; the migration is only rolled back
+// when it counts none.
 type PolicyServerMigration struct {
-	Id string
-	Up map[string][]string
+	Id            string
+	Up            map[string][]string
+	Down          map[string][]string
+	RollbackGuard map[string]string
+	OnlineDDL     bool
+	Backfill      *Backfill
 }
 
 func (psm *PolicyServerMigration) forDriver(driverName string) *migrate.Migration {
+	up, down := psm.Up[driverName], psm.Down[driverName]
+	if psm.OnlineDDL && driverName == "mysql" {
+		up, down = withMySQLOnlineDDL(up), withMySQLOnlineDDL(down)
+	}
+
 	return &migrate.Migration{
-		Id: psm.Id,
-		Up: psm.Up[driverName],
+		Id:   psm.Id,
+		Up:   up,
+		Down: down,
 	}
 }
 
@@ -94,3 +301,7 @@ func (psm *PolicyServerMigration) supportsDriver(driverName string) bool {
 	_, foundUp := psm.Up[driverName]
 	return foundUp
 }
+
+func (psm *PolicyServerMigration) reversible(driverName string) bool {
+	return len(psm.Down[driverName]) > 0
+}
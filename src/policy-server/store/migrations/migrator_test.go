@@ -20,9 +20,11 @@ import (
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cf-container-networking/sql-migrate"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 type columnUsage struct {
@@ -1537,6 +1539,170 @@ var _ = Describe("migrations", func() {
 			})
 		})
 
+		Describe("V57 - IP Range protocol index", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("57")
+
+				By("verifying the index exists")
+				var rows *sql.Rows
+				var err error
+				if realDb.DriverName() == "postgres" {
+					rows, err = realDb.Query(`SELECT COUNT(*) FROM pg_indexes WHERE tablename = 'ip_ranges' AND indexname = 'ip_ranges_protocol_idx'`)
+				} else {
+					rows, err = realDb.Query(`SELECT COUNT(*) FROM information_schema.statistics WHERE table_schema = DATABASE() AND table_name = 'ip_ranges' AND index_name = 'ip_ranges_protocol_idx'`)
+				}
+				Expect(err).NotTo(HaveOccurred())
+				Expect(scanCountRow(rows)).To(Equal(1))
+			})
+		})
+
+		Describe("V58 - orgs", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("58")
+
+				By("verifying the columns exist")
+				columns := queryTableColumnNames("orgs", realDb)
+				Expect(columns).To(ContainElement("terminal_guid"))
+				Expect(columns).To(ContainElement("org_guid"))
+
+				By("validating that the same org cannot be inserted twice")
+				_, err := realDb.Exec("INSERT INTO terminals (guid) VALUES ('some-terminal-guid-1')")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec("INSERT INTO terminals (guid) VALUES ('some-terminal-guid-2')")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = realDb.Exec(realDb.RawConnection().Rebind(`
+					INSERT INTO orgs (terminal_guid, org_guid)
+					VALUES (?, ?)`), "some-terminal-guid-1", "some-org-guid")
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = realDb.Exec(realDb.RawConnection().Rebind(`
+					INSERT INTO orgs (terminal_guid, org_guid)
+					VALUES (?, ?)`), "some-terminal-guid-2", "some-org-guid")
+				Expect(err).To(MatchError(Or(
+					ContainSubstring("duplicate key value violates unique constraint"), // postgres error
+					ContainSubstring("Duplicate entry"),                                // mysql error
+				)))
+			})
+		})
+
+		Describe("V59 - default sources", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("59")
+
+				By("verifying the columns exist")
+				Expect(queryTableColumnNames("default_sources", realDb)).To(ContainElement("terminal_guid"))
+
+				By("validating that the terminal guid must reference a terminal")
+				_, err := realDb.Exec(realDb.RawConnection().Rebind(`
+					INSERT INTO default_sources (terminal_guid)
+					VALUES (?)`), "some-missing-terminal-guid")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+
+		Describe("V60 - policies expires_at", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("60")
+
+				By("verifying the expires_at column exists")
+				Expect(queryTableColumnNames("policies", realDb)).To(ContainElement("expires_at"))
+			})
+		})
+
+		Describe("V61 - egress policies expires_at", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("61")
+
+				By("verifying the expires_at column exists")
+				Expect(queryTableColumnNames("egress_policies", realDb)).To(ContainElement("expires_at"))
+			})
+		})
+
+		Describe("V62 - egress policies action", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("61")
+
+				_, err := realDb.Exec(`INSERT INTO terminals (guid) VALUES ('source-terminal'), ('destination-terminal')`)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec(`INSERT INTO egress_policies (guid, source_guid, destination_guid) VALUES ('existing-policy', 'source-terminal', 'destination-terminal')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				migrateTo("62")
+
+				By("verifying the action column exists")
+				Expect(queryTableColumnNames("egress_policies", realDb)).To(ContainElement("action"))
+
+				By("verifying existing policies default to allow")
+				var action string
+				err = realDb.QueryRow(`SELECT action FROM egress_policies WHERE guid = 'existing-policy'`).Scan(&action)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(action).To(Equal("allow"))
+			})
+		})
+
+		Describe("V63 - tombstones", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("63")
+
+				By("verifying the columns exist")
+				columns := queryTableColumnNames("tombstones", realDb)
+				Expect(columns).To(ContainElement("policy_type"))
+				Expect(columns).To(ContainElement("source_id"))
+				Expect(columns).To(ContainElement("destination_id"))
+				Expect(columns).To(ContainElement("payload"))
+				Expect(columns).To(ContainElement("deleted_at"))
+
+				By("verifying deleted_at defaults to the current time")
+				_, err := realDb.Exec(`INSERT INTO tombstones (policy_type, source_id, destination_id, payload) VALUES ('c2c', 'some-app', 'other-app', '{}')`)
+				Expect(err).NotTo(HaveOccurred())
+
+				var count int
+				err = realDb.QueryRow(`SELECT COUNT(*) FROM tombstones WHERE deleted_at IS NOT NULL`).Scan(&count)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(count).To(Equal(1))
+			})
+		})
+
+		Describe("V64-V66 - tombstones indexes", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("66")
+
+				By("verifying the indexes exist")
+				query := `SELECT indexname FROM pg_indexes WHERE tablename = 'tombstones'`
+				if realDb.DriverName() == "mysql" {
+					query = `SELECT INDEX_NAME FROM INFORMATION_SCHEMA.STATISTICS WHERE TABLE_NAME = 'tombstones'`
+				}
+				rows, err := realDb.Query(query)
+				Expect(err).NotTo(HaveOccurred())
+				indexes := scanStrings(rows)
+				Expect(indexes).To(ContainElement("tombstones_source_id_idx"))
+				Expect(indexes).To(ContainElement("tombstones_destination_id_idx"))
+				Expect(indexes).To(ContainElement("tombstones_deleted_at_idx"))
+			})
+		})
+
+		Describe("V67-V68 - policies info", func() {
+			It("should migrate", func() {
+				By("performing migration")
+				migrateTo("68")
+
+				By("verifying the revision row exists")
+				var revision int64
+				err := realDb.QueryRow(`SELECT revision FROM policies_info WHERE id = 1`).Scan(&revision)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision).To(Equal(int64(0)))
+			})
+		})
+
 		Context("when migrating in parallel", func() {
 			Context("mysql", func() {
 				BeforeEach(func() {
@@ -1625,35 +1791,305 @@ var _ = Describe("migrations", func() {
 		})
 	})
 
-	Describe("Down Migration", func() {
-		It("should no-op", func() {
-			adapter := &migrations.MigrateAdapter{}
+	Describe("online migrations", func() {
+		var (
+			logger            *lagertest.TestLogger
+			onlineProvider    *migrationsFakes.MigrationsProvider
+			onlineMigrator    *migrations.Migrator
+			backfillMigration migrations.PolicyServerMigration
+		)
 
-			_, err := adapter.ExecMax(
-				realDb,
-				realDb.DriverName(),
-				migrate.MemoryMigrationSource{
-					Migrations: migrations.MigrationsToPerform.ForDriver(realDb.DriverName()),
-				},
-				migrate.Up,
-				0,
-			)
+		BeforeEach(func() {
+			_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
 			Expect(err).NotTo(HaveOccurred())
 
-			numberOfMigrations, err := adapter.ExecMax(
-				realDb,
-				realDb.DriverName(),
-				migrate.MemoryMigrationSource{
-					Migrations: migrations.MigrationsToPerform.ForDriver(realDb.DriverName()),
+			for i := 0; i < 5; i++ {
+				_, err = realDb.Exec(`INSERT INTO groups (guid) VALUES (NULL)`)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			backfillMigration = migrations.PolicyServerMigration{
+				Id: "9001",
+				Up: map[string][]string{
+					"mysql":    {`ALTER TABLE groups ADD COLUMN backfilled INT;`},
+					"postgres": {`ALTER TABLE groups ADD COLUMN backfilled INT;`},
+				},
+				OnlineDDL: true,
+				Backfill: &migrations.Backfill{
+					Table:     "groups",
+					Set:       "backfilled = id",
+					Where:     "backfilled IS NULL",
+					BatchSize: 2,
 				},
-				migrate.Down,
-				0,
-			)
+			}
+
+			existingMigrations, err := modifiedMigrationsProvider.MigrationsToPerform()
+			Expect(err).NotTo(HaveOccurred())
+
+			onlineProvider = &migrationsFakes.MigrationsProvider{}
+			onlineProvider.MigrationsToPerformReturns(append(existingMigrations, backfillMigration), nil)
+
+			logger = lagertest.NewTestLogger("migrator")
+			onlineMigrator = &migrations.Migrator{
+				MigrateAdapter:     &migrations.MigrateAdapter{},
+				MigrationsProvider: onlineProvider,
+				Logger:             logger,
+			}
+		})
+
+		It("applies the migration and backfills every row in batches", func() {
+			numMigrations, err := onlineMigrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(1))
+
+			var count int
+			Expect(realDb.QueryRow(`SELECT COUNT(*) FROM groups WHERE backfilled IS NULL OR backfilled <> id`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(0))
+
+			Expect(logger).To(gbytes.Say("migrator.backfill.started.*migration-id.*9001"))
+			Expect(logger).To(gbytes.Say("migrator.backfill.complete"))
+		})
+
+		It("resumes an interrupted backfill on the next run", func() {
+			_, err := onlineMigrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = realDb.Exec(`UPDATE groups SET backfilled = NULL`)
+			Expect(err).NotTo(HaveOccurred())
+
+			numMigrations, err := onlineMigrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(0))
+
+			var count int
+			Expect(realDb.QueryRow(`SELECT COUNT(*) FROM groups WHERE backfilled IS NULL`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(0))
+		})
+
+		Context("when the backfill fails", func() {
+			It("returns an error", func() {
+				backfillMigration.Backfill.Where = "not_a_column IS NULL"
+				existingMigrations, err := modifiedMigrationsProvider.MigrationsToPerform()
+				Expect(err).NotTo(HaveOccurred())
+				onlineProvider.MigrationsToPerformReturns(append(existingMigrations, backfillMigration), nil)
+
+				numMigrations, err := onlineMigrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+				Expect(err).To(MatchError(ContainSubstring("backfilling migration 9001: counting rows to backfill:")))
+				Expect(numMigrations).To(Equal(1))
+			})
+		})
+
+		Context("when getting the migration records fails", func() {
+			It("returns an error", func() {
+				onlineMigrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("banana"))
+
+				_, err := onlineMigrator.PerformMigrations(realDb.DriverName(), mockDb, 0)
+				Expect(err).To(MatchError("getting migration records: banana"))
+				Expect(mockMigrateAdapter.ExecMaxCallCount()).To(Equal(0))
+			})
+		})
+
+		Describe("OnlineDDL", func() {
+			It("runs mysql ALTER TABLE statements in place without locking", func() {
+				migration := migrations.PolicyServerMigrations{backfillMigration}
+
+				mysqlMigrations := migration.ForDriver("mysql")
+				Expect(mysqlMigrations[0].Up).To(Equal([]string{
+					`ALTER TABLE groups ADD COLUMN backfilled INT, ALGORITHM=INPLACE, LOCK=NONE;`,
+				}))
+
+				postgresMigrations := migration.ForDriver("postgres")
+				Expect(postgresMigrations[0].Up).To(Equal([]string{
+					`ALTER TABLE groups ADD COLUMN backfilled INT;`,
+				}))
+			})
+		})
+	})
+
+	Describe("RollbackMigrations", func() {
+		BeforeEach(func() {
+			_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rolls back the most recent migrations", func() {
+			numRolledBack, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numRolledBack).To(Equal(6))
+
+			var count int
+			Expect(realDb.QueryRow(`SELECT COUNT(*) FROM gorp_migrations WHERE id IN ('63', '64', '65', '66', '67', '68')`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(0))
+			Expect(realDb.QueryRow(`SELECT COUNT(*) FROM gorp_migrations WHERE id = '62'`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(1))
+
+			_, err = realDb.Exec(`SELECT id FROM tombstones`)
+			Expect(err).To(HaveOccurred())
+
+			By("migrating back up")
+			numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(6))
+		})
+
+		It("can roll back every migration after v56", func() {
+			numRolledBack, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 12)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numRolledBack).To(Equal(12))
+
+			var count int
+			Expect(realDb.QueryRow(`SELECT COUNT(*) FROM gorp_migrations WHERE id = '56'`).Scan(&count)).To(Succeed())
+			Expect(count).To(Equal(1))
+
+			numMigrations, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(numMigrations).To(Equal(12))
+		})
+
+		Context("when egress policies depend on the migrations being rolled back", func() {
+			exec := func(query string, args ...interface{}) {
+				_, err := realDb.Exec(realDb.RawConnection().Rebind(query), args...)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			BeforeEach(func() {
+				for _, guid := range []string{"app-terminal", "org-terminal", "destination-terminal"} {
+					exec(`INSERT INTO terminals (guid) VALUES (?)`, guid)
+				}
+				exec(`INSERT INTO apps (terminal_guid, app_guid) VALUES (?, ?)`, "app-terminal", "some-app-guid")
+				exec(`INSERT INTO orgs (terminal_guid, org_guid) VALUES (?, ?)`, "org-terminal", "some-org-guid")
+				exec(`INSERT INTO egress_policies (guid, source_guid, destination_guid) VALUES (?, ?, ?)`,
+					"app-policy", "app-terminal", "destination-terminal")
+				exec(`INSERT INTO egress_policies (guid, source_guid, destination_guid, action) VALUES (?, ?, ?, ?)`,
+					"org-deny-policy", "org-terminal", "destination-terminal", "deny")
+			})
+
+			It("refuses to roll back until no rows would be lost or changed", func() {
+				By("refusing to drop the action column while deny policies exist")
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 11)
+				Expect(err).To(MatchError("migration 62 cannot be rolled back while 1 rows depend on it"))
+
+				var count int
+				Expect(realDb.QueryRow(`SELECT COUNT(*) FROM gorp_migrations WHERE id = '68'`).Scan(&count)).To(Succeed())
+				Expect(count).To(Equal(1))
+
+				By("refusing to drop the orgs table while org sourced policies exist")
+				exec(`UPDATE egress_policies SET action = 'allow' WHERE guid = ?`, "org-deny-policy")
+				_, err = migrator.RollbackMigrations(realDb.DriverName(), realDb, 11)
+				Expect(err).To(MatchError("migration 58 cannot be rolled back while 1 rows depend on it"))
+
+				By("rolling back once the dependent policy is gone")
+				exec(`DELETE FROM egress_policies WHERE guid = ?`, "org-deny-policy")
+				numRolledBack, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 11)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(numRolledBack).To(Equal(11))
+
+				By("reading the remaining policies the way the v57 policy server does")
+				rows, err := realDb.Query(`
+					SELECT egress_policies.guid, apps.app_guid, spaces.space_guid
+					FROM egress_policies
+					LEFT OUTER JOIN apps ON (egress_policies.source_guid = apps.terminal_guid)
+					LEFT OUTER JOIN spaces ON (egress_policies.source_guid = spaces.terminal_guid)`)
+				Expect(err).NotTo(HaveOccurred())
+				defer rows.Close()
+
+				var guids []string
+				for rows.Next() {
+					var guid string
+					var appGUID, spaceGUID sql.NullString
+					Expect(rows.Scan(&guid, &appGUID, &spaceGUID)).To(Succeed())
+					Expect(appGUID.Valid || spaceGUID.Valid).To(BeTrue())
+					guids = append(guids, guid)
+				}
+				Expect(rows.Err()).NotTo(HaveOccurred())
+				Expect(guids).To(ConsistOf("app-policy"))
+			})
+		})
+
+		Context("when a migration in range has no down migration", func() {
+			It("returns an error without rolling anything back", func() {
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 13)
+				Expect(err).To(MatchError("migration 56 cannot be rolled back"))
+
+				var count int
+				Expect(realDb.QueryRow(`SELECT COUNT(*) FROM gorp_migrations WHERE id = '68'`).Scan(&count)).To(Succeed())
+				Expect(count).To(Equal(1))
+			})
+		})
+
+		Context("when the number of migrations is less than 1", func() {
+			It("returns an error", func() {
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), realDb, 0)
+				Expect(err).To(MatchError("number of migrations to roll back must be at least 1"))
+			})
+		})
+
+		Context("when getting the migration records fails", func() {
+			It("returns an error", func() {
+				migrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("banana"))
+
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), mockDb, 1)
+				Expect(err).To(MatchError("getting migration records: banana"))
+				Expect(mockMigrateAdapter.ExecMaxCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the rollback fails", func() {
+			It("returns an error", func() {
+				migrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns([]*migrate.MigrationRecord{{Id: "68"}}, nil)
+				mockMigrateAdapter.ExecMaxReturns(0, errors.New("banana"))
+
+				_, err := migrator.RollbackMigrations(realDb.DriverName(), mockDb, 1)
+				Expect(err).To(MatchError("executing rollback: banana"))
+				_, _, _, migrationDir, numMigrations := mockMigrateAdapter.ExecMaxArgsForCall(0)
+				Expect(migrationDir).To(Equal(migrate.Down))
+				Expect(numMigrations).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("MigrationStatus", func() {
+		It("reports applied and pending migrations", func() {
+			migrateTo("60")
+
+			statuses, err := migrator.MigrationStatus(realDb.DriverName(), realDb)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(statuses).To(HaveLen(getMigrationIndex(modifiedMigrationsProvider, "68")))
+
+			byId := map[string]migrations.MigrationStatus{}
+			for _, status := range statuses {
+				byId[status.Id] = status
+			}
+			Expect(byId["56"].Applied).To(BeTrue())
+			Expect(byId["56"].Reversible).To(BeFalse())
+			Expect(byId["60"].Applied).To(BeTrue())
+			Expect(byId["60"].AppliedAt).NotTo(BeZero())
+			Expect(byId["60"].Reversible).To(BeTrue())
+			Expect(byId["61"].Applied).To(BeFalse())
+			Expect(byId["61"].AppliedAt).To(BeZero())
+		})
 
-			Expect(err).To(MatchError("down migration not supported"))
-			Expect(numberOfMigrations).To(Equal(0))
+		Context("when getting the migration records fails", func() {
+			It("returns an error", func() {
+				migrator.MigrateAdapter = mockMigrateAdapter
+				mockMigrateAdapter.GetMigrationRecordsReturns(nil, errors.New("banana"))
+
+				_, err := migrator.MigrationStatus(realDb.DriverName(), mockDb)
+				Expect(err).To(MatchError("getting migration records: banana"))
+			})
+		})
+
+		Context("when the driver name is not mysql or postgres", func() {
+			It("returns an error", func() {
+				_, err := migrator.MigrationStatus("etcd", mockDb)
+				Expect(err).To(MatchError("unsupported driver: etcd"))
+			})
 		})
 	})
+
 	Describe("Migrations should be atomic", func() {
 		It("should contain a single statement per migration", func() {
 			for _, migration := range migrations.MigrationsToPerform {
@@ -1663,7 +2099,12 @@ var _ = Describe("migrations", func() {
 							migration.Id, dbType, len(statements)))
 					}
 				}
-
+				for dbType, statements := range migration.Down {
+					if len(statements) > 1 {
+						Fail(fmt.Sprintf("Down migration %s for %s has %d statements. Expected a single statement per migration.",
+							migration.Id, dbType, len(statements)))
+					}
+				}
 			}
 		})
 	})
@@ -1770,3 +2211,15 @@ func getMigrationIndex(migrationsProvider *migrations.MigrationsProvider, migrat
 	Fail("couldn't find migration with id: " + migrationId)
 	return -1
 }
+
+func scanStrings(rows *sql.Rows) []string {
+	defer rows.Close()
+	values := []string{}
+	for rows.Next() {
+		var value string
+		Expect(rows.Scan(&value)).To(Succeed())
+		values = append(values, value)
+	}
+	Expect(rows.Err()).NotTo(HaveOccurred())
+	return values
+}
@@ -0,0 +1,106 @@
+package migrations
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+const (
+	defaultBackfillBatchSize = 1000
+	backfillProgressInterval = 10
+	mysqlOnlineDDLClause     = ", ALGORITHM=INPLACE, LOCK=NONE"
+)
+
+// Backfill populates existing rows of Table after its migration's schema
+// change has been applied. Rows are updated in ranges of the integer id
+// column so each statement only locks up to BatchSize rows, keeping the
+// table readable while it runs.
+//
+// Where must select exactly the rows that still need the backfill. It is
+// checked on every run of the migrator, so a backfill that was interrupted
+// resumes on the next run and one that finished costs a single query.
+type Backfill struct {
+	Table      string
+	Set        string
+	Where      string
+	BatchSize  int
+	BatchDelay time.Duration
+}
+
+func (b *Backfill) run(logger lager.Logger, migrationDb MigrationDb, migrationId string) error {
+	logger = logger.Session("backfill", lager.Data{"migration-id": migrationId, "table": b.Table})
+
+	var pending int64
+	err := migrationDb.QueryRow(fmt.Sprintf(`SELECT COUNT(*) FROM %s WHERE %s`, b.Table, b.Where)).Scan(&pending)
+	if err != nil {
+		return fmt.Errorf("counting rows to backfill: %s", err)
+	}
+	if pending == 0 {
+		return nil
+	}
+
+	var minId, maxId int64
+	err = migrationDb.QueryRow(fmt.Sprintf(`SELECT COALESCE(MIN(id), 0), COALESCE(MAX(id), 0) FROM %s`, b.Table)).Scan(&minId, &maxId)
+	if err != nil {
+		return fmt.Errorf("reading id range: %s", err)
+	}
+
+	batchSize := int64(b.BatchSize)
+	if batchSize <= 0 {
+		batchSize = defaultBackfillBatchSize
+	}
+
+	logger.Info("started", lager.Data{"rows-pending": pending, "batch-size": batchSize})
+
+	var updated int64
+	batches := 0
+	for lower := minId; lower <= maxId; lower += batchSize {
+		result, err := migrationDb.Exec(fmt.Sprintf(`UPDATE %s SET %s WHERE id >= %d AND id < %d AND (%s)`,
+			b.Table, b.Set, lower, lower+batchSize, b.Where))
+		if err != nil {
+			return fmt.Errorf("updating rows with ids from %d: %s", lower, err)
+		}
+
+		rowsAffected, err := result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("getting rows affected: %s", err)
+		}
+		updated += rowsAffected
+
+		batches++
+		if batches%backfillProgressInterval == 0 {
+			logger.Info("progress", lager.Data{
+				"rows-updated": updated,
+				"rows-pending": pending,
+				"last-id":      lower + batchSize - 1,
+				"max-id":       maxId,
+			})
+		}
+
+		if b.BatchDelay > 0 {
+			time.Sleep(b.BatchDelay)
+		}
+	}
+
+	logger.Info("complete", lager.Data{"rows-updated": updated, "batches": batches})
+	return nil
+}
+
+// withMySQLOnlineDDL asks MySQL to run ALTER TABLE statements in place
+// without blocking reads or writes. MySQL rejects the statement instead of
+// silently falling back to a locking copy when that is not possible.
+func withMySQLOnlineDDL(statements []string) []string {
+	online := make([]string, len(statements))
+	for i, statement := range statements {
+		trimmed := strings.TrimSpace(statement)
+		if !strings.HasPrefix(strings.ToUpper(trimmed), "ALTER TABLE") {
+			online[i] = statement
+			continue
+		}
+		online[i] = strings.TrimSuffix(trimmed, ";") + mysqlOnlineDDLClause + ";"
+	}
+	return online
+}
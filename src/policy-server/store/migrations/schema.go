@@ -0,0 +1,151 @@
+package migrations
+
+// TableSchema describes a table as it should look once every migration has
+// run. Constraints and indexes are identified by their columns rather than
+// their names because mysql and postgres generate different names for the
+// same unique constraints.
+type TableSchema struct {
+	Name        string
+	Columns     []string
+	NotNull     []string
+	PrimaryKey  []string
+	Unique      [][]string
+	Indexes     [][]string
+	ForeignKeys []ForeignKey
+}
+
+type ForeignKey struct {
+	Column           string
+	ReferencedTable  string
+	ReferencedColumn string
+}
+
+// ExpectedSchema returns the schema produced by MigrationsToPerform. It must
+// be updated alongside any migration that changes the schema; the migrator
+// tests verify a freshly migrated database against it.
+func ExpectedSchema(driverName string) []TableSchema {
+	toTerminals := func(column string) ForeignKey {
+		return ForeignKey{Column: column, ReferencedTable: "terminals", ReferencedColumn: "guid"}
+	}
+
+	destinationsForeignKeys := []ForeignKey{}
+	policiesForeignKeys := []ForeignKey{}
+	// mysql ignores REFERENCES clauses on column definitions, so the
+	// foreign keys declared that way in migration 1 only exist in postgres
+	if driverName == "postgres" {
+		destinationsForeignKeys = []ForeignKey{
+			{Column: "group_id", ReferencedTable: "groups", ReferencedColumn: "id"},
+		}
+		policiesForeignKeys = []ForeignKey{
+			{Column: "group_id", ReferencedTable: "groups", ReferencedColumn: "id"},
+			{Column: "destination_id", ReferencedTable: "destinations", ReferencedColumn: "id"},
+		}
+	}
+
+	return []TableSchema{
+		{
+			Name:       "groups",
+			Columns:    []string{"id", "guid", "type"},
+			NotNull:    []string{"id"},
+			PrimaryKey: []string{"id"},
+			Unique:     [][]string{{"guid"}},
+			Indexes:    [][]string{{"type"}},
+		},
+		{
+			Name:        "destinations",
+			Columns:     []string{"id", "group_id", "port", "protocol", "start_port", "end_port"},
+			NotNull:     []string{"id"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"group_id", "start_port", "end_port", "protocol"}},
+			ForeignKeys: destinationsForeignKeys,
+		},
+		{
+			Name:        "policies",
+			Columns:     []string{"id", "group_id", "destination_id", "expires_at"},
+			NotNull:     []string{"id"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"group_id", "destination_id"}},
+			ForeignKeys: policiesForeignKeys,
+		},
+		{
+			Name:       "terminals",
+			Columns:    []string{"guid"},
+			NotNull:    []string{"guid"},
+			PrimaryKey: []string{"guid"},
+		},
+		{
+			Name:        "egress_policies",
+			Columns:     []string{"guid", "source_guid", "destination_guid", "expires_at", "action"},
+			NotNull:     []string{"guid", "source_guid", "destination_guid", "action"},
+			PrimaryKey:  []string{"guid"},
+			Unique:      [][]string{{"source_guid", "destination_guid"}},
+			Indexes:     [][]string{{"source_guid"}, {"destination_guid"}},
+			ForeignKeys: []ForeignKey{toTerminals("source_guid"), toTerminals("destination_guid")},
+		},
+		{
+			Name:        "ip_ranges",
+			Columns:     []string{"id", "protocol", "start_ip", "end_ip", "start_port", "end_port", "icmp_type", "icmp_code", "terminal_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"terminal_guid"}},
+			Indexes:     [][]string{{"terminal_guid"}, {"protocol"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:        "apps",
+			Columns:     []string{"id", "app_guid", "terminal_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"app_guid"}, {"terminal_guid"}},
+			Indexes:     [][]string{{"terminal_guid"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:        "spaces",
+			Columns:     []string{"id", "space_guid", "terminal_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"space_guid"}, {"terminal_guid"}},
+			Indexes:     [][]string{{"terminal_guid"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:        "orgs",
+			Columns:     []string{"id", "terminal_guid", "org_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"terminal_guid"}, {"org_guid"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:        "default_sources",
+			Columns:     []string{"id", "terminal_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"terminal_guid"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:        "destination_metadatas",
+			Columns:     []string{"id", "name", "description", "terminal_guid"},
+			NotNull:     []string{"id", "terminal_guid"},
+			PrimaryKey:  []string{"id"},
+			Unique:      [][]string{{"name"}, {"terminal_guid"}},
+			Indexes:     [][]string{{"terminal_guid"}, {"name"}},
+			ForeignKeys: []ForeignKey{toTerminals("terminal_guid")},
+		},
+		{
+			Name:       "tombstones",
+			Columns:    []string{"id", "policy_type", "source_id", "destination_id", "payload", "deleted_at"},
+			NotNull:    []string{"id", "policy_type", "source_id", "destination_id", "payload", "deleted_at"},
+			PrimaryKey: []string{"id"},
+			Indexes:    [][]string{{"source_id"}, {"destination_id"}, {"deleted_at"}},
+		},
+		{
+			Name:       "policies_info",
+			Columns:    []string{"id", "revision"},
+			NotNull:    []string{"id", "revision"},
+			PrimaryKey: []string{"id"},
+		},
+	}
+}
@@ -0,0 +1,318 @@
+package migrations
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type migrationStatuser interface {
+	MigrationStatus(driverName string, migrationDb MigrationDb) ([]MigrationStatus, error)
+}
+
+// SchemaVerifier compares the live database schema with ExpectedSchema so
+// that manual changes are caught before an upgrade migration trips over
+// them.
+type SchemaVerifier struct {
+	Migrator   migrationStatuser
+	DB         MigrationDb
+	DriverName string
+}
+
+type liveIndex struct {
+	name    string
+	unique  bool
+	primary bool
+	columns []string
+}
+
+type liveTable struct {
+	notNull     map[string]bool
+	indexes     []*liveIndex
+	foreignKeys []ForeignKey
+}
+
+// Verify returns a description of every difference between the live and the
+// expected schema, including migrations that have not been applied yet.
+func (v *SchemaVerifier) Verify() ([]string, error) {
+	if v.DriverName != "mysql" && v.DriverName != "postgres" {
+		return nil, fmt.Errorf("unsupported driver: %s", v.DriverName)
+	}
+
+	statuses, err := v.Migrator.MigrationStatus(v.DriverName, v.DB)
+	if err != nil {
+		return nil, fmt.Errorf("getting migration status: %s", err)
+	}
+
+	differences := []string{}
+	for _, status := range statuses {
+		if !status.Applied {
+			differences = append(differences, fmt.Sprintf("migration %s has not been applied", status.Id))
+		}
+	}
+
+	live, err := v.liveSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	return append(differences, compareSchemas(ExpectedSchema(v.DriverName), live)...), nil
+}
+
+func (v *SchemaVerifier) liveSchema() (map[string]*liveTable, error) {
+	schemaFilter := "DATABASE()"
+	if v.DriverName == "postgres" {
+		schemaFilter = "current_schema()"
+	}
+
+	tables := map[string]*liveTable{}
+	table := func(name string) *liveTable {
+		if _, ok := tables[name]; !ok {
+			tables[name] = &liveTable{notNull: map[string]bool{}}
+		}
+		return tables[name]
+	}
+
+	rows, err := v.DB.Query(`SELECT table_name, column_name, is_nullable FROM information_schema.columns
+		WHERE table_schema = ` + schemaFilter)
+	if err != nil {
+		return nil, fmt.Errorf("reading columns: %s", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, columnName, isNullable string
+		if err := rows.Scan(&tableName, &columnName, &isNullable); err != nil {
+			return nil, fmt.Errorf("scanning columns: %s", err)
+		}
+		table(tableName).notNull[columnName] = isNullable == "NO"
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("reading columns: %s", err)
+	}
+
+	indexQuery := `SELECT table_name, index_name, non_unique = 0, index_name = 'PRIMARY', column_name
+		FROM information_schema.statistics
+		WHERE table_schema = DATABASE()
+		ORDER BY table_name, index_name, seq_in_index`
+	if v.DriverName == "postgres" {
+		indexQuery = `SELECT t.relname, i.relname, ix.indisunique, ix.indisprimary, a.attname
+		FROM pg_index ix
+		JOIN pg_class t ON t.oid = ix.indrelid
+		JOIN pg_class i ON i.oid = ix.indexrelid
+		JOIN pg_namespace n ON n.oid = t.relnamespace
+		JOIN LATERAL unnest(ix.indkey) WITH ORDINALITY AS k(attnum, ord) ON true
+		JOIN pg_attribute a ON a.attrelid = t.oid AND a.attnum = k.attnum
+		WHERE n.nspname = current_schema()
+		ORDER BY t.relname, i.relname, k.ord`
+	}
+	indexRows, err := v.DB.Query(indexQuery)
+	if err != nil {
+		return nil, fmt.Errorf("reading indexes: %s", err)
+	}
+	defer indexRows.Close()
+	for indexRows.Next() {
+		var tableName, indexName, columnName string
+		var unique, primary bool
+		if err := indexRows.Scan(&tableName, &indexName, &unique, &primary, &columnName); err != nil {
+			return nil, fmt.Errorf("scanning indexes: %s", err)
+		}
+		t := table(tableName)
+		if len(t.indexes) == 0 || t.indexes[len(t.indexes)-1].name != indexName {
+			t.indexes = append(t.indexes, &liveIndex{name: indexName, unique: unique, primary: primary})
+		}
+		index := t.indexes[len(t.indexes)-1]
+		index.columns = append(index.columns, columnName)
+	}
+	if err := indexRows.Err(); err != nil {
+		return nil, fmt.Errorf("reading indexes: %s", err)
+	}
+
+	foreignKeyQuery := `SELECT table_name, column_name, referenced_table_name, referenced_column_name
+		FROM information_schema.key_column_usage
+		WHERE table_schema = DATABASE() AND referenced_table_name IS NOT NULL`
+	if v.DriverName == "postgres" {
+		foreignKeyQuery = `SELECT kcu.table_name, kcu.column_name, ccu.table_name, ccu.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON kcu.constraint_name = tc.constraint_name AND kcu.table_schema = tc.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON ccu.constraint_name = tc.constraint_name AND ccu.table_schema = tc.table_schema
+		WHERE tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = current_schema()`
+	}
+	foreignKeyRows, err := v.DB.Query(foreignKeyQuery)
+	if err != nil {
+		return nil, fmt.Errorf("reading foreign keys: %s", err)
+	}
+	defer foreignKeyRows.Close()
+	for foreignKeyRows.Next() {
+		var tableName string
+		var foreignKey ForeignKey
+		if err := foreignKeyRows.Scan(&tableName, &foreignKey.Column, &foreignKey.ReferencedTable, &foreignKey.ReferencedColumn); err != nil {
+			return nil, fmt.Errorf("scanning foreign keys: %s", err)
+		}
+		t := table(tableName)
+		t.foreignKeys = append(t.foreignKeys, foreignKey)
+	}
+	if err := foreignKeyRows.Err(); err != nil {
+		return nil, fmt.Errorf("reading foreign keys: %s", err)
+	}
+
+	for name := range tables {
+		if strings.HasPrefix(name, "gorp_") {
+			delete(tables, name)
+		}
+	}
+	return tables, nil
+}
+
+func compareSchemas(expected []TableSchema, live map[string]*liveTable) []string {
+	differences := []string{}
+	expectedNames := map[string]bool{}
+
+	for _, expectedTable := range expected {
+		expectedNames[expectedTable.Name] = true
+		liveTable, ok := live[expectedTable.Name]
+		if !ok {
+			differences = append(differences, fmt.Sprintf("missing table %s", expectedTable.Name))
+			continue
+		}
+		differences = append(differences, compareTable(expectedTable, liveTable)...)
+	}
+
+	unexpected := []string{}
+	for name := range live {
+		if !expectedNames[name] {
+			unexpected = append(unexpected, name)
+		}
+	}
+	sort.Strings(unexpected)
+	for _, name := range unexpected {
+		differences = append(differences, fmt.Sprintf("unexpected table %s", name))
+	}
+
+	return differences
+}
+
+func compareTable(expected TableSchema, live *liveTable) []string {
+	differences := []string{}
+	name := expected.Name
+
+	expectedColumns := map[string]bool{}
+	notNull := map[string]bool{}
+	for _, column := range expected.NotNull {
+		notNull[column] = true
+	}
+	for _, column := range expected.Columns {
+		expectedColumns[column] = true
+		liveNotNull, ok := live.notNull[column]
+		switch {
+		case !ok:
+			differences = append(differences, fmt.Sprintf("missing column %s.%s", name, column))
+		case notNull[column] && !liveNotNull:
+			differences = append(differences, fmt.Sprintf("column %s.%s should be NOT NULL", name, column))
+		case !notNull[column] && liveNotNull:
+			differences = append(differences, fmt.Sprintf("column %s.%s should be nullable", name, column))
+		}
+	}
+	unexpectedColumns := []string{}
+	for column := range live.notNull {
+		if !expectedColumns[column] {
+			unexpectedColumns = append(unexpectedColumns, column)
+		}
+	}
+	sort.Strings(unexpectedColumns)
+	for _, column := range unexpectedColumns {
+		differences = append(differences, fmt.Sprintf("unexpected column %s.%s", name, column))
+	}
+
+	var primaryKey []string
+	for _, index := range live.indexes {
+		if index.primary {
+			primaryKey = index.columns
+		}
+	}
+	if !sameColumns(primaryKey, expected.PrimaryKey) {
+		differences = append(differences, fmt.Sprintf("primary key of %s is (%s), expected (%s)",
+			name, strings.Join(primaryKey, ", "), strings.Join(expected.PrimaryKey, ", ")))
+	}
+
+	for _, columns := range expected.Unique {
+		if !hasIndex(live.indexes, columns, true) {
+			differences = append(differences, fmt.Sprintf("missing unique constraint on %s (%s)", name, strings.Join(columns, ", ")))
+		}
+	}
+	for _, columns := range expected.Indexes {
+		if !hasIndex(live.indexes, columns, false) {
+			differences = append(differences, fmt.Sprintf("missing index on %s (%s)", name, strings.Join(columns, ", ")))
+		}
+	}
+
+	knownColumnSets := [][]string{expected.PrimaryKey}
+	knownColumnSets = append(knownColumnSets, expected.Unique...)
+	knownColumnSets = append(knownColumnSets, expected.Indexes...)
+	for _, foreignKey := range expected.ForeignKeys {
+		// mysql indexes foreign key columns automatically
+		knownColumnSets = append(knownColumnSets, []string{foreignKey.Column})
+	}
+	for _, index := range live.indexes {
+		known := false
+		for _, columns := range knownColumnSets {
+			if sameColumns(index.columns, columns) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			differences = append(differences, fmt.Sprintf("unexpected index %s on %s (%s)", index.name, name, strings.Join(index.columns, ", ")))
+		}
+	}
+
+	for _, foreignKey := range expected.ForeignKeys {
+		if !hasForeignKey(live.foreignKeys, foreignKey) {
+			differences = append(differences, fmt.Sprintf("missing foreign key %s.%s -> %s.%s",
+				name, foreignKey.Column, foreignKey.ReferencedTable, foreignKey.ReferencedColumn))
+		}
+	}
+	for _, foreignKey := range live.foreignKeys {
+		if !hasForeignKey(expected.ForeignKeys, foreignKey) {
+			differences = append(differences, fmt.Sprintf("unexpected foreign key %s.%s -> %s.%s",
+				name, foreignKey.Column, foreignKey.ReferencedTable, foreignKey.ReferencedColumn))
+		}
+	}
+
+	return differences
+}
+
+func hasIndex(indexes []*liveIndex, columns []string, unique bool) bool {
+	for _, index := range indexes {
+		if (index.unique || !unique) && sameColumns(index.columns, columns) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasForeignKey(foreignKeys []ForeignKey, foreignKey ForeignKey) bool {
+	for _, candidate := range foreignKeys {
+		if candidate == foreignKey {
+			return true
+		}
+	}
+	return false
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	sortedA := append([]string{}, a...)
+	sortedB := append([]string{}, b...)
+	sort.Strings(sortedA)
+	sort.Strings(sortedB)
+	for i := range sortedA {
+		if sortedA[i] != sortedB[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,145 @@
+package migrations_test
+
+import (
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/migrations"
+	"time"
+
+	"test-helpers"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SchemaVerifier", func() {
+	var (
+		dbConf         db.Config
+		realDb         *db.ConnWrapper
+		migrator       *migrations.Migrator
+		schemaVerifier *migrations.SchemaVerifier
+	)
+
+	BeforeEach(func() {
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("schema_verifier_test_node_%d", time.Now().UnixNano())
+		dbConf.Timeout = 30
+		testhelpers.CreateDatabase(dbConf)
+
+		var err error
+		realDb, err = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Schema Verifier Test", "Schema Verifier Test", lager.NewLogger("Schema Verifier Test"))
+		Expect(err).NotTo(HaveOccurred())
+
+		migrator = &migrations.Migrator{
+			MigrateAdapter: &migrations.MigrateAdapter{},
+			MigrationsProvider: &migrations.MigrationsProvider{
+				Store: &store.MigrationsStore{
+					DBConn: realDb,
+				},
+			},
+		}
+
+		schemaVerifier = &migrations.SchemaVerifier{
+			Migrator:   migrator,
+			DB:         realDb,
+			DriverName: realDb.DriverName(),
+		}
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testhelpers.RemoveDatabase(dbConf)
+	})
+
+	Context("when every migration has run", func() {
+		BeforeEach(func() {
+			_, err := migrator.PerformMigrations(realDb.DriverName(), realDb, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("matches the expected schema", func() {
+			differences, err := schemaVerifier.Verify()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(differences).To(BeEmpty())
+		})
+
+		Context("when the schema has been altered by hand", func() {
+			BeforeEach(func() {
+				dropIndex := `DROP INDEX ip_ranges_protocol_idx`
+				if realDb.DriverName() == "mysql" {
+					dropIndex += ` ON ip_ranges`
+				}
+
+				for _, statement := range []string{
+					`ALTER TABLE policies ADD COLUMN dba_note TEXT`,
+					`CREATE INDEX dba_idx ON policies (expires_at)`,
+					`CREATE TABLE dba_table (id INT)`,
+					dropIndex,
+				} {
+					_, err := realDb.Exec(statement)
+					Expect(err).NotTo(HaveOccurred())
+				}
+			})
+
+			It("reports every difference", func() {
+				differences, err := schemaVerifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(differences).To(ConsistOf(
+					"unexpected column policies.dba_note",
+					"unexpected index dba_idx on policies (expires_at)",
+					"missing index on ip_ranges (protocol)",
+					"unexpected table dba_table",
+				))
+			})
+		})
+
+		Context("when a column's nullability has changed", func() {
+			BeforeEach(func() {
+				statement := `ALTER TABLE tombstones ALTER COLUMN payload DROP NOT NULL`
+				if realDb.DriverName() == "mysql" {
+					statement = `ALTER TABLE tombstones MODIFY payload TEXT NULL`
+				}
+				_, err := realDb.Exec(statement)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the column", func() {
+				differences, err := schemaVerifier.Verify()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(differences).To(Equal([]string{"column tombstones.payload should be NOT NULL"}))
+			})
+		})
+	})
+
+	Context("when migrations are pending", func() {
+		BeforeEach(func() {
+			migrationsToPerform, err := migrator.MigrationsProvider.MigrationsToPerform()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = migrator.PerformMigrations(realDb.DriverName(), realDb, len(migrationsToPerform)-2)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("reports the pending migrations and the missing schema", func() {
+			differences, err := schemaVerifier.Verify()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(differences).To(Equal([]string{
+				"migration 67 has not been applied",
+				"migration 68 has not been applied",
+				"missing table policies_info",
+			}))
+		})
+	})
+
+	Context("when the driver is not supported", func() {
+		It("returns an error", func() {
+			schemaVerifier.DriverName = "mssql"
+			_, err := schemaVerifier.Verify()
+			Expect(err).To(MatchError("unsupported driver: mssql"))
+		})
+	})
+})
@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0057 = map[string][]string{
+	"mysql": {
+		`CREATE INDEX ip_ranges_protocol_idx ON ip_ranges (protocol);`,
+	},
+	"postgres": {
+		`CREATE INDEX ip_ranges_protocol_idx ON ip_ranges (protocol);`,
+	},
+}
+
+var migration_v0057_down = map[string][]string{
+	"mysql": {
+		`DROP INDEX ip_ranges_protocol_idx ON ip_ranges;`,
+	},
+	"postgres": {
+		`DROP INDEX ip_ranges_protocol_idx;`,
+	},
+}
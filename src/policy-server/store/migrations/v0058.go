@@ -0,0 +1,38 @@
+package migrations
+
+var migration_v0058 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS orgs (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		terminal_guid VARCHAR(36) NOT NULL UNIQUE,
+		CONSTRAINT orgs_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid),
+		org_guid varchar(255),
+		UNIQUE (org_guid)
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS orgs (
+		id SERIAL PRIMARY KEY,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT orgs_terminal_guid_unique UNIQUE,
+		CONSTRAINT orgs_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid),
+		org_guid text CONSTRAINT orgs_org_guid_unique UNIQUE
+	);`,
+	},
+}
+
+var migration_v0058_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE orgs;`,
+	},
+	"postgres": {
+		`DROP TABLE orgs;`,
+	},
+}
+
+// Egress policies with an org source would be left pointing at a terminal
+// that is neither an app nor a space, which older policy servers cannot read.
+var migration_v0058_rollback_guard = map[string]string{
+	"mysql":    `SELECT COUNT(*) FROM egress_policies WHERE source_guid IN (SELECT terminal_guid FROM orgs)`,
+	"postgres": `SELECT COUNT(*) FROM egress_policies WHERE source_guid IN (SELECT terminal_guid FROM orgs)`,
+}
@@ -0,0 +1,35 @@
+package migrations
+
+var migration_v0059 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS default_sources (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		terminal_guid VARCHAR(36) NOT NULL UNIQUE,
+		CONSTRAINT default_sources_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS default_sources (
+		id SERIAL PRIMARY KEY,
+		terminal_guid VARCHAR(36) NOT NULL CONSTRAINT default_sources_terminal_guid_unique UNIQUE,
+		CONSTRAINT default_sources_terminal_guid_fk FOREIGN KEY (terminal_guid) REFERENCES terminals(guid)
+	);`,
+	},
+}
+
+var migration_v0059_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE default_sources;`,
+	},
+	"postgres": {
+		`DROP TABLE default_sources;`,
+	},
+}
+
+// Egress policies with a default source would be left pointing at a terminal
+// that is neither an app nor a space, which older policy servers cannot read.
+var migration_v0059_rollback_guard = map[string]string{
+	"mysql":    `SELECT COUNT(*) FROM egress_policies WHERE source_guid IN (SELECT terminal_guid FROM default_sources)`,
+	"postgres": `SELECT COUNT(*) FROM egress_policies WHERE source_guid IN (SELECT terminal_guid FROM default_sources)`,
+}
@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0060 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE policies ADD COLUMN expires_at TIMESTAMP NULL DEFAULT NULL;`,
+	},
+	"postgres": {
+		`ALTER TABLE policies ADD COLUMN expires_at TIMESTAMP;`,
+	},
+}
+
+var migration_v0060_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE policies DROP COLUMN expires_at;`,
+	},
+	"postgres": {
+		`ALTER TABLE policies DROP COLUMN expires_at;`,
+	},
+}
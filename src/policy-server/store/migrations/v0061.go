@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0061 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies ADD COLUMN expires_at TIMESTAMP NULL DEFAULT NULL;`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies ADD COLUMN expires_at TIMESTAMP;`,
+	},
+}
+
+var migration_v0061_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies DROP COLUMN expires_at;`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies DROP COLUMN expires_at;`,
+	},
+}
@@ -0,0 +1,25 @@
+package migrations
+
+var migration_v0062 = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies ADD COLUMN action VARCHAR(16) NOT NULL DEFAULT 'allow';`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies ADD COLUMN action VARCHAR(16) NOT NULL DEFAULT 'allow';`,
+	},
+}
+
+var migration_v0062_down = map[string][]string{
+	"mysql": {
+		`ALTER TABLE egress_policies DROP COLUMN action;`,
+	},
+	"postgres": {
+		`ALTER TABLE egress_policies DROP COLUMN action;`,
+	},
+}
+
+// Dropping the action column would turn every deny policy into an allow.
+var migration_v0062_rollback_guard = map[string]string{
+	"mysql":    `SELECT COUNT(*) FROM egress_policies WHERE action = 'deny'`,
+	"postgres": `SELECT COUNT(*) FROM egress_policies WHERE action = 'deny'`,
+}
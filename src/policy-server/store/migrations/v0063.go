@@ -0,0 +1,34 @@
+package migrations
+
+var migration_v0063 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS tombstones (
+		id int NOT NULL AUTO_INCREMENT,
+		PRIMARY KEY (id),
+		policy_type VARCHAR(16) NOT NULL,
+		source_id VARCHAR(255) NOT NULL,
+		destination_id VARCHAR(255) NOT NULL,
+		payload TEXT NOT NULL,
+		deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS tombstones (
+		id SERIAL PRIMARY KEY,
+		policy_type VARCHAR(16) NOT NULL,
+		source_id VARCHAR(255) NOT NULL,
+		destination_id VARCHAR(255) NOT NULL,
+		payload TEXT NOT NULL,
+		deleted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);`,
+	},
+}
+
+var migration_v0063_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE tombstones;`,
+	},
+	"postgres": {
+		`DROP TABLE tombstones;`,
+	},
+}
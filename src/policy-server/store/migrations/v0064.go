@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0064 = map[string][]string{
+	"mysql": {
+		`CREATE INDEX tombstones_source_id_idx ON tombstones (source_id);`,
+	},
+	"postgres": {
+		`CREATE INDEX tombstones_source_id_idx ON tombstones (source_id);`,
+	},
+}
+
+var migration_v0064_down = map[string][]string{
+	"mysql": {
+		`DROP INDEX tombstones_source_id_idx ON tombstones;`,
+	},
+	"postgres": {
+		`DROP INDEX tombstones_source_id_idx;`,
+	},
+}
@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0065 = map[string][]string{
+	"mysql": {
+		`CREATE INDEX tombstones_destination_id_idx ON tombstones (destination_id);`,
+	},
+	"postgres": {
+		`CREATE INDEX tombstones_destination_id_idx ON tombstones (destination_id);`,
+	},
+}
+
+var migration_v0065_down = map[string][]string{
+	"mysql": {
+		`DROP INDEX tombstones_destination_id_idx ON tombstones;`,
+	},
+	"postgres": {
+		`DROP INDEX tombstones_destination_id_idx;`,
+	},
+}
@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0066 = map[string][]string{
+	"mysql": {
+		`CREATE INDEX tombstones_deleted_at_idx ON tombstones (deleted_at);`,
+	},
+	"postgres": {
+		`CREATE INDEX tombstones_deleted_at_idx ON tombstones (deleted_at);`,
+	},
+}
+
+var migration_v0066_down = map[string][]string{
+	"mysql": {
+		`DROP INDEX tombstones_deleted_at_idx ON tombstones;`,
+	},
+	"postgres": {
+		`DROP INDEX tombstones_deleted_at_idx;`,
+	},
+}
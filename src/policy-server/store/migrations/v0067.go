@@ -0,0 +1,26 @@
+package migrations
+
+var migration_v0067 = map[string][]string{
+	"mysql": {
+		`CREATE TABLE IF NOT EXISTS policies_info (
+		id int NOT NULL,
+		PRIMARY KEY (id),
+		revision BIGINT NOT NULL DEFAULT 0
+	);`,
+	},
+	"postgres": {
+		`CREATE TABLE IF NOT EXISTS policies_info (
+		id int PRIMARY KEY,
+		revision BIGINT NOT NULL DEFAULT 0
+	);`,
+	},
+}
+
+var migration_v0067_down = map[string][]string{
+	"mysql": {
+		`DROP TABLE policies_info;`,
+	},
+	"postgres": {
+		`DROP TABLE policies_info;`,
+	},
+}
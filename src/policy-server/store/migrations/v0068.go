@@ -0,0 +1,19 @@
+package migrations
+
+var migration_v0068 = map[string][]string{
+	"mysql": {
+		`INSERT INTO policies_info (id, revision) VALUES (1, 0);`,
+	},
+	"postgres": {
+		`INSERT INTO policies_info (id, revision) VALUES (1, 0);`,
+	},
+}
+
+var migration_v0068_down = map[string][]string{
+	"mysql": {
+		`DELETE FROM policies_info WHERE id = 1;`,
+	},
+	"postgres": {
+		`DELETE FROM policies_info WHERE id = 1;`,
+	},
+}
@@ -1,5 +1,7 @@
 package store
 
+import "time"
+
 type PolicyCollection struct {
 	Policies       []Policy
 	EgressPolicies []EgressPolicy
@@ -8,6 +10,7 @@ type PolicyCollection struct {
 type Policy struct {
 	Source      Source
 	Destination Destination
+	ExpiresAt   *time.Time
 }
 
 type Source struct {
@@ -34,10 +37,31 @@ type Tag struct {
 	Type string
 }
 
+type TagCapacity struct {
+	Total int
+	Free  int
+}
+
 type EgressPolicy struct {
 	ID          string
 	Source      EgressSource
 	Destination EgressDestination
+	Action      string
+	ExpiresAt   *time.Time
+}
+
+type EgressPolicyFilter struct {
+	SourceIDs        []string
+	SourceTypes      []string
+	DestinationIDs   []string
+	DestinationNames []string
+	Protocols        []string
+}
+
+type EgressDestinationFilter struct {
+	IDs       []string
+	Names     []string
+	Protocols []string
 }
 
 type EgressSource struct {
@@ -61,3 +85,11 @@ type IPRange struct {
 	Start string
 	End   string
 }
+
+type Tombstone struct {
+	ID           int
+	Type         string
+	Policy       *Policy
+	EgressPolicy *EgressPolicy
+	DeletedAt    time.Time
+}
@@ -1,65 +1,132 @@
 package store
 
-import "code.cloudfoundry.org/cf-networking-helpers/db"
+import (
+	"policy-server/store/helpers"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+)
 
 //go:generate counterfeiter -o fakes/policy_repo.go --fake-name PolicyRepo . PolicyRepo
 type PolicyRepo interface {
-	Create(db.Transaction, int, int) error
-	Delete(db.Transaction, int, int) error
-	CountWhereGroupID(db.Transaction, int) (int, error)
-	CountWhereDestinationID(db.Transaction, int) (int, error)
+	CreateMany(db.Transaction, []PolicyRow) error
+	DeleteMany(db.Transaction, []PolicyRow) error
+	GroupIDsInUse(db.Transaction, []int) (map[int]bool, error)
+	DestinationIDsInUse(db.Transaction, []int) (map[int]bool, error)
+}
+
+// PolicyRow is a policies row. ExpiresAt is ignored when deleting.
+type PolicyRow struct {
+	GroupID       int
+	DestinationID int
+	ExpiresAt     *time.Time
 }
 
 type PolicyTable struct {
 }
 
-func (p *PolicyTable) Create(tx db.Transaction, sourceGroupId int, destinationId int) error {
-	dualStatement := ""
+// CreateMany inserts the rows whose source group and destination do not
+// already have a policy. Existing policies, including their expiry, are
+// left untouched. The policies of the source groups are locked while they
+// are checked so that concurrent creates of the same policy wait for each
+// other instead of failing on the unique key.
+func (p *PolicyTable) CreateMany(tx db.Transaction, rows []PolicyRow) error {
+	lockStatement := " FOR UPDATE "
 	if tx.DriverName() == "mysql" {
-		dualStatement = " FROM DUAL "
+		lockStatement = " LOCK IN SHARE MODE "
 	}
 
-	_, err := tx.Exec(tx.Rebind(`
-		INSERT INTO policies (group_id, destination_id)
-		SELECT ?, ? `+dualStatement+`
-		WHERE
-		NOT EXISTS (
-			SELECT *
-			FROM policies
-			WHERE group_id = ? AND destination_id = ?
-		)`),
-		sourceGroupId,
-		destinationId,
-		sourceGroupId,
-		destinationId,
-	)
-	return err
-}
+	var groupIDs []int
+	seenGroup := map[int]bool{}
+	for _, row := range rows {
+		if !seenGroup[row.GroupID] {
+			seenGroup[row.GroupID] = true
+			groupIDs = append(groupIDs, row.GroupID)
+		}
+	}
+
+	type pair struct{ groupID, destinationID int }
+	existing := map[pair]bool{}
+	err := inBatches(len(groupIDs), func(start, end int) error {
+		rows, err := tx.Queryx(
+			tx.Rebind(`SELECT group_id, destination_id FROM policies WHERE group_id IN (`+helpers.QuestionMarks(end-start)+`) `+lockStatement),
+			intsToInterfaceSlice(groupIDs[start:end])...,
+		)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
 
-func (p *PolicyTable) Delete(tx db.Transaction, sourceGroupId int, destinationId int) error {
-	_, err := tx.Exec(tx.Rebind(`DELETE FROM policies WHERE group_id = ? AND destination_id = ?`),
-		sourceGroupId,
-		destinationId,
-	)
-	return err
+		for rows.Next() {
+			var existingPair pair
+			err = rows.Scan(&existingPair.groupID, &existingPair.destinationID)
+			if err != nil {
+				return err
+			}
+			existing[existingPair] = true
+		}
+		return rows.Err()
+	})
+	if err != nil {
+		return err
+	}
+
+	var missing []PolicyRow
+	for _, row := range rows {
+		key := pair{row.GroupID, row.DestinationID}
+		if !existing[key] {
+			existing[key] = true
+			missing = append(missing, row)
+		}
+	}
+
+	return inBatches(len(missing), func(start, end int) error {
+		var values []string
+		var args []interface{}
+		for _, row := range missing[start:end] {
+			values = append(values, "(?, ?, ?)")
+			args = append(args, row.GroupID, row.DestinationID, utcTime(row.ExpiresAt))
+		}
+		_, err := tx.Exec(tx.Rebind(`
+			INSERT INTO policies (group_id, destination_id, expires_at)
+			VALUES `+strings.Join(values, ", ")),
+			args...,
+		)
+		return err
+	})
 }
 
-func (p *PolicyTable) CountWhereGroupID(tx db.Transaction, sourceGroupId int) (int, error) {
-	var count int
-	err := tx.QueryRow(
-		tx.Rebind(`SELECT COUNT(*) FROM policies WHERE group_id = ?`),
-		sourceGroupId,
-	).Scan(&count)
+func utcTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
 
-	return count, err
+func (p *PolicyTable) DeleteMany(tx db.Transaction, rows []PolicyRow) error {
+	return inBatches(len(rows), func(start, end int) error {
+		var conditions []string
+		var args []interface{}
+		for _, row := range rows[start:end] {
+			conditions = append(conditions, "(group_id = ? AND destination_id = ?)")
+			args = append(args, row.GroupID, row.DestinationID)
+		}
+		_, err := tx.Exec(tx.Rebind(`DELETE FROM policies WHERE `+strings.Join(conditions, " OR ")),
+			args...,
+		)
+		return err
+	})
 }
 
-func (p *PolicyTable) CountWhereDestinationID(tx db.Transaction, destinationId int) (int, error) {
-	var count int
-	err := tx.QueryRow(
-		tx.Rebind(`SELECT COUNT(*) FROM policies WHERE destination_id = ?`),
-		destinationId,
-	).Scan(&count)
+// GroupIDsInUse returns which of the group ids are still the source of a
+// policy.
+func (p *PolicyTable) GroupIDsInUse(tx db.Transaction, groupIDs []int) (map[int]bool, error) {
+	return idsInUse(tx, `SELECT DISTINCT group_id FROM policies WHERE group_id IN (%s)`, groupIDs)
+}
 
-	return count, err
+// DestinationIDsInUse returns which of the destination ids are still used
+// by a policy.
+func (p *PolicyTable) DestinationIDsInUse(tx db.Transaction, destinationIDs []int) (map[int]bool, error) {
+	return idsInUse(tx, `SELECT DISTINCT destination_id FROM policies WHERE destination_id IN (%s)`, destinationIDs)
 }
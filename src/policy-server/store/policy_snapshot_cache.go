@@ -0,0 +1,209 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+//go:generate counterfeiter -o fakes/egress_policy_lister.go --fake-name EgressPolicyLister . egressPolicyLister
+type egressPolicyLister interface {
+	All(context.Context) ([]EgressPolicy, error)
+}
+
+// PolicySnapshotCache keeps the full c2c and egress policy collection in
+// memory so that policy-server-internal can answer the agents' polls
+// without running the policy joins on every request. The snapshot is
+// reloaded by Refresh when the policies revision changes or when it is
+// older than MaxAge, and is read through from the database when no fresh
+// snapshot is available.
+//
+// EgressStore may be nil, in which case egress policies are never loaded.
+type PolicySnapshotCache struct {
+	Store         Store
+	EgressStore   egressPolicyLister
+	RevisionStore RevisionStore
+	MaxAge        time.Duration
+
+	refreshMutex sync.Mutex
+	mutex        sync.RWMutex
+	snapshot     *policySnapshot
+}
+
+type policySnapshot struct {
+	revision       int64
+	loadedAt       time.Time
+	policies       []Policy
+	egressPolicies []EgressPolicy
+
+	policiesByGuid       map[string][]int
+	egressPoliciesByGuid map[string][]int
+	defaultEgress        []int
+}
+
+// Refresh reloads the snapshot if the policies revision has changed since
+// it was loaded or if it has reached MaxAge.
+func (c *PolicySnapshotCache) Refresh() error {
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	revision, err := c.RevisionStore.Revision()
+	if err != nil {
+		return err
+	}
+
+	snapshot := c.current()
+	if snapshot != nil && snapshot.revision == revision && !c.expired(snapshot) {
+		return nil
+	}
+	return c.load(revision)
+}
+
+// Policies returns every c2c policy with one of ids as its source or
+// destination and every egress policy with one of ids as its source, along
+// with the default egress policies. When ids is empty it returns the whole
+// collection. The returned slices are shared and must not be modified.
+func (c *PolicySnapshotCache) Policies(ids []string) ([]Policy, []EgressPolicy, error) {
+	snapshot, err := c.fresh()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(ids) == 0 {
+		return snapshot.policies, snapshot.egressPolicies, nil
+	}
+
+	var policies []Policy
+	for _, i := range lookup(snapshot.policiesByGuid, ids, nil) {
+		policies = append(policies, snapshot.policies[i])
+	}
+
+	var egressPolicies []EgressPolicy
+	if snapshot.egressPolicies != nil {
+		egressPolicies = []EgressPolicy{}
+		for _, i := range lookup(snapshot.egressPoliciesByGuid, ids, snapshot.defaultEgress) {
+			egressPolicies = append(egressPolicies, snapshot.egressPolicies[i])
+		}
+	}
+
+	return policies, egressPolicies, nil
+}
+
+func (c *PolicySnapshotCache) current() *policySnapshot {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.snapshot
+}
+
+func (c *PolicySnapshotCache) expired(snapshot *policySnapshot) bool {
+	return c.MaxAge > 0 && time.Since(snapshot.loadedAt) >= c.MaxAge
+}
+
+// fresh returns the current snapshot, loading it from the database first
+// if there is none yet or it has outlived MaxAge because Refresh has been
+// failing.
+func (c *PolicySnapshotCache) fresh() (*policySnapshot, error) {
+	snapshot := c.current()
+	if snapshot != nil && !c.expired(snapshot) {
+		return snapshot, nil
+	}
+
+	c.refreshMutex.Lock()
+	defer c.refreshMutex.Unlock()
+
+	snapshot = c.current()
+	if snapshot != nil && !c.expired(snapshot) {
+		return snapshot, nil
+	}
+
+	revision, err := c.RevisionStore.Revision()
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.load(revision)
+	if err != nil {
+		return nil, err
+	}
+	return c.current(), nil
+}
+
+// load must be called with refreshMutex held. The revision is read before
+// the policies so that a write landing in between is picked up by the next
+// Refresh rather than missed. The snapshot is shared by every request, so it
+// is not loaded on behalf of any one of them.
+func (c *PolicySnapshotCache) load(revision int64) error {
+	loadedAt := time.Now()
+
+	policies, err := c.Store.All(context.Background())
+	if err != nil {
+		return fmt.Errorf("loading policies: %s", err)
+	}
+
+	var egressPolicies []EgressPolicy
+	if c.EgressStore != nil {
+		egressPolicies, err = c.EgressStore.All(context.Background())
+		if err != nil {
+			return fmt.Errorf("loading egress policies: %s", err)
+		}
+		if egressPolicies == nil {
+			egressPolicies = []EgressPolicy{}
+		}
+	}
+
+	snapshot := &policySnapshot{
+		revision:             revision,
+		loadedAt:             loadedAt,
+		policies:             policies,
+		egressPolicies:       egressPolicies,
+		policiesByGuid:       map[string][]int{},
+		egressPoliciesByGuid: map[string][]int{},
+	}
+
+	for i, policy := range policies {
+		snapshot.policiesByGuid[policy.Source.ID] = append(snapshot.policiesByGuid[policy.Source.ID], i)
+		if policy.Destination.ID != policy.Source.ID {
+			snapshot.policiesByGuid[policy.Destination.ID] = append(snapshot.policiesByGuid[policy.Destination.ID], i)
+		}
+	}
+
+	for i, egressPolicy := range egressPolicies {
+		if egressPolicy.Source.Type == "default" {
+			snapshot.defaultEgress = append(snapshot.defaultEgress, i)
+			continue
+		}
+		snapshot.egressPoliciesByGuid[egressPolicy.Source.ID] = append(snapshot.egressPoliciesByGuid[egressPolicy.Source.ID], i)
+	}
+
+	c.mutex.Lock()
+	c.snapshot = snapshot
+	c.mutex.Unlock()
+	return nil
+}
+
+// lookup returns the positions indexed under any of guids, plus always,
+// without duplicates and in the order they appear in the snapshot.
+func lookup(index map[string][]int, guids []string, always []int) []int {
+	seen := map[int]bool{}
+	var positions []int
+	add := func(i int) {
+		if !seen[i] {
+			seen[i] = true
+			positions = append(positions, i)
+		}
+	}
+
+	for _, guid := range guids {
+		for _, i := range index[guid] {
+			add(i)
+		}
+	}
+	for _, i := range always {
+		add(i)
+	}
+
+	sort.Ints(positions)
+	return positions
+}
@@ -0,0 +1,228 @@
+package store_test
+
+import (
+	"errors"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("PolicySnapshotCache", func() {
+	var (
+		cache             *store.PolicySnapshotCache
+		fakeStore         *fakes.Store
+		fakeEgressStore   *fakes.EgressPolicyLister
+		fakeRevisionStore *fakes.RevisionStore
+
+		policies       []store.Policy
+		egressPolicies []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		policies = []store.Policy{
+			{
+				Source:      store.Source{ID: "app-a"},
+				Destination: store.Destination{ID: "app-b", Protocol: "tcp", Port: 8080},
+			},
+			{
+				Source:      store.Source{ID: "app-c"},
+				Destination: store.Destination{ID: "app-a", Protocol: "udp", Port: 53},
+			},
+			{
+				Source:      store.Source{ID: "app-d"},
+				Destination: store.Destination{ID: "app-d", Protocol: "tcp", Port: 9090},
+			},
+		}
+		egressPolicies = []store.EgressPolicy{
+			{ID: "egress-app-a", Source: store.EgressSource{ID: "app-a", Type: "app"}},
+			{ID: "egress-default", Source: store.EgressSource{Type: "default"}},
+			{ID: "egress-space", Source: store.EgressSource{ID: "space-1", Type: "space"}},
+		}
+
+		fakeStore = &fakes.Store{}
+		fakeStore.AllReturns(policies, nil)
+		fakeEgressStore = &fakes.EgressPolicyLister{}
+		fakeEgressStore.AllReturns(egressPolicies, nil)
+		fakeRevisionStore = &fakes.RevisionStore{}
+		fakeRevisionStore.RevisionReturns(7, nil)
+
+		cache = &store.PolicySnapshotCache{
+			Store:         fakeStore,
+			EgressStore:   fakeEgressStore,
+			RevisionStore: fakeRevisionStore,
+			MaxAge:        time.Minute,
+		}
+	})
+
+	Describe("Policies", func() {
+		It("reads the snapshot through on first use and then serves it from memory", func() {
+			allPolicies, allEgressPolicies, err := cache.Policies(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allPolicies).To(Equal(policies))
+			Expect(allEgressPolicies).To(Equal(egressPolicies))
+
+			_, _, err = cache.Policies([]string{"app-a"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStore.AllCallCount()).To(Equal(1))
+			Expect(fakeEgressStore.AllCallCount()).To(Equal(1))
+			Expect(fakeRevisionStore.RevisionCallCount()).To(Equal(1))
+		})
+
+		It("returns the policies with an id as source or destination, in snapshot order", func() {
+			found, _, err := cache.Policies([]string{"app-a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]store.Policy{policies[0], policies[1]}))
+
+			found, _, err = cache.Policies([]string{"app-d", "app-b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]store.Policy{policies[0], policies[2]}))
+		})
+
+		It("returns the egress policies for the ids and every default egress policy", func() {
+			_, found, err := cache.Policies([]string{"app-a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]store.EgressPolicy{egressPolicies[0], egressPolicies[1]}))
+
+			_, found, err = cache.Policies([]string{"space-1"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal([]store.EgressPolicy{egressPolicies[1], egressPolicies[2]}))
+		})
+
+		It("returns nil c2c policies when nothing matches", func() {
+			found, _, err := cache.Policies([]string{"unknown-app"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeNil())
+		})
+
+		It("loads the snapshot once for concurrent first requests", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 10; i++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					defer GinkgoRecover()
+					_, _, err := cache.Policies(nil)
+					Expect(err).NotTo(HaveOccurred())
+				}()
+			}
+			wg.Wait()
+
+			Expect(fakeStore.AllCallCount()).To(Equal(1))
+		})
+
+		Context("when there is no egress store", func() {
+			BeforeEach(func() {
+				cache.EgressStore = nil
+			})
+
+			It("returns no egress policies", func() {
+				_, found, err := cache.Policies([]string{"app-a"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeNil())
+			})
+		})
+
+		Context("when the snapshot is older than the max age", func() {
+			BeforeEach(func() {
+				cache.MaxAge = time.Millisecond
+			})
+
+			It("reads it through again", func() {
+				_, _, err := cache.Policies(nil)
+				Expect(err).NotTo(HaveOccurred())
+				time.Sleep(2 * time.Millisecond)
+
+				_, _, err = cache.Policies(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(fakeStore.AllCallCount()).To(Equal(2))
+			})
+		})
+
+		Context("when getting the revision fails", func() {
+			BeforeEach(func() {
+				fakeRevisionStore.RevisionReturns(0, errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, _, err := cache.Policies(nil)
+				Expect(err).To(MatchError("banana"))
+			})
+		})
+
+		Context("when loading the policies fails", func() {
+			BeforeEach(func() {
+				fakeStore.AllReturns(nil, errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, _, err := cache.Policies(nil)
+				Expect(err).To(MatchError("loading policies: banana"))
+			})
+		})
+
+		Context("when loading the egress policies fails", func() {
+			BeforeEach(func() {
+				fakeEgressStore.AllReturns(nil, errors.New("banana"))
+			})
+
+			It("returns the error", func() {
+				_, _, err := cache.Policies(nil)
+				Expect(err).To(MatchError("loading egress policies: banana"))
+			})
+		})
+	})
+
+	Describe("Refresh", func() {
+		BeforeEach(func() {
+			_, _, err := cache.Policies(nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not reload while the revision is unchanged", func() {
+			Expect(cache.Refresh()).To(Succeed())
+			Expect(cache.Refresh()).To(Succeed())
+			Expect(fakeStore.AllCallCount()).To(Equal(1))
+		})
+
+		It("reloads when the revision changes", func() {
+			newPolicies := []store.Policy{policies[0]}
+			fakeStore.AllReturns(newPolicies, nil)
+			fakeRevisionStore.RevisionReturns(8, nil)
+
+			Expect(cache.Refresh()).To(Succeed())
+			Expect(fakeStore.AllCallCount()).To(Equal(2))
+
+			found, _, err := cache.Policies(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(Equal(newPolicies))
+		})
+
+		It("reloads when the snapshot reaches the max age", func() {
+			cache.MaxAge = time.Millisecond
+			time.Sleep(2 * time.Millisecond)
+
+			Expect(cache.Refresh()).To(Succeed())
+			Expect(fakeStore.AllCallCount()).To(Equal(2))
+		})
+
+		Context("when the reload fails", func() {
+			BeforeEach(func() {
+				fakeRevisionStore.RevisionReturns(8, nil)
+				fakeStore.AllReturns(nil, errors.New("banana"))
+			})
+
+			It("returns the error and keeps serving the previous snapshot", func() {
+				Expect(cache.Refresh()).To(MatchError("loading policies: banana"))
+
+				found, _, err := cache.Policies(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(Equal(policies))
+			})
+		})
+	})
+})
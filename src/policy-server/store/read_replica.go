@@ -0,0 +1,116 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// ReadReplica is a Database that sends transactions and writes to Primary
+// and plain reads to Replica, as long as the replica has been seen to catch
+// up with the primary within MaxStaleness. Whether it has is decided by
+// Check, which compares the policies revision on both connections and is
+// meant to be called periodically. Until the first successful Check, and
+// whenever the replica falls further behind than MaxStaleness or cannot be
+// reached, reads go to Primary.
+type ReadReplica struct {
+	Primary      Database
+	Replica      Database
+	MaxStaleness time.Duration
+
+	mutex    sync.RWMutex
+	inSyncAt time.Time
+}
+
+// Check records that the replica is in sync when its policies revision has
+// reached the primary's.
+func (r *ReadReplica) Check() error {
+	primaryRevision, err := NewRevisionStore(r.Primary).Revision()
+	if err != nil {
+		return fmt.Errorf("checking primary: %s", err)
+	}
+
+	replicaRevision, err := NewRevisionStore(r.Replica).Revision()
+	if err != nil {
+		return fmt.Errorf("checking read replica: %s", err)
+	}
+
+	if replicaRevision >= primaryRevision {
+		r.mutex.Lock()
+		r.inSyncAt = time.Now()
+		r.mutex.Unlock()
+	}
+	return nil
+}
+
+// Stale reports whether reads are currently being served by Primary because
+// the replica has not caught up within MaxStaleness.
+func (r *ReadReplica) Stale() bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.inSyncAt.IsZero() || time.Since(r.inSyncAt) > r.MaxStaleness
+}
+
+func (r *ReadReplica) reader() Database {
+	if r.Stale() {
+		return r.Primary
+	}
+	return r.Replica
+}
+
+func (r *ReadReplica) Beginx() (db.Transaction, error) {
+	return r.Primary.Beginx()
+}
+
+func (r *ReadReplica) BeginTxx(ctx context.Context, opts *sql.TxOptions) (db.Transaction, error) {
+	return r.Primary.BeginTxx(ctx, opts)
+}
+
+func (r *ReadReplica) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return r.Primary.Exec(query, args...)
+}
+
+func (r *ReadReplica) NamedExec(query string, arg interface{}) (sql.Result, error) {
+	return r.Primary.NamedExec(query, arg)
+}
+
+func (r *ReadReplica) Get(dest interface{}, query string, args ...interface{}) error {
+	return r.reader().Get(dest, query, args...)
+}
+
+func (r *ReadReplica) Select(dest interface{}, query string, args ...interface{}) error {
+	return r.reader().Select(dest, query, args...)
+}
+
+func (r *ReadReplica) QueryRow(query string, args ...interface{}) *sql.Row {
+	return r.reader().QueryRow(query, args...)
+}
+
+func (r *ReadReplica) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return r.reader().Query(query, args...)
+}
+
+func (r *ReadReplica) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return r.reader().QueryRowContext(ctx, query, args...)
+}
+
+func (r *ReadReplica) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return r.reader().QueryContext(ctx, query, args...)
+}
+
+func (r *ReadReplica) DriverName() string {
+	return r.Primary.DriverName()
+}
+
+func (r *ReadReplica) RawConnection() *sqlx.DB {
+	return r.Primary.RawConnection()
+}
+
+func (r *ReadReplica) Rebind(query string) string {
+	return r.Primary.Rebind(query)
+}
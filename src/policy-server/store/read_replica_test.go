@@ -0,0 +1,164 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ReadReplica", func() {
+	var (
+		primaryConf, replicaConf db.Config
+		primaryDb, replicaDb     *db.ConnWrapper
+		readReplica              *store.ReadReplica
+	)
+
+	newDb := func(name string) (db.Config, *db.ConnWrapper) {
+		conf := testsupport.GetDBConfig()
+		conf.DatabaseName = fmt.Sprintf("read_replica_%s_test_node_%d", name, time.Now().UnixNano())
+		testsupport.CreateDatabase(conf)
+
+		logger := lager.NewLogger("Read Replica Test")
+		conn, err := db.NewConnectionPool(conf, 200, 200, 5*time.Minute, "Read Replica Test", "Read Replica Test", logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrateAndPopulateTags(conn, 1)
+		return conf, conn
+	}
+
+	setRevision := func(conn *db.ConnWrapper, revision int) {
+		_, err := conn.Exec(conn.Rebind(`UPDATE policies_info SET revision = ? WHERE id = 1`), revision)
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		primaryConf, primaryDb = newDb("primary")
+		replicaConf, replicaDb = newDb("replica")
+
+		readReplica = &store.ReadReplica{
+			Primary:      primaryDb,
+			Replica:      replicaDb,
+			MaxStaleness: time.Minute,
+		}
+	})
+
+	AfterEach(func() {
+		if primaryDb != nil {
+			Expect(primaryDb.Close()).To(Succeed())
+		}
+		if replicaDb != nil {
+			Expect(replicaDb.Close()).To(Succeed())
+		}
+		testsupport.RemoveDatabase(primaryConf)
+		testsupport.RemoveDatabase(replicaConf)
+	})
+
+	It("reads from the primary until the replica has been checked", func() {
+		setRevision(replicaDb, 10)
+
+		Expect(readReplica.Stale()).To(BeTrue())
+		revision, err := store.NewRevisionStore(readReplica).Revision()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revision).To(Equal(int64(0)))
+	})
+
+	Context("when the replica has caught up with the primary", func() {
+		BeforeEach(func() {
+			setRevision(primaryDb, 3)
+			setRevision(replicaDb, 3)
+			Expect(readReplica.Check()).To(Succeed())
+		})
+
+		It("reads from the replica", func() {
+			setRevision(replicaDb, 10)
+
+			Expect(readReplica.Stale()).To(BeFalse())
+			revision, err := store.NewRevisionStore(readReplica).Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision).To(Equal(int64(10)))
+		})
+
+		It("writes to the primary", func() {
+			_, err := readReplica.Exec(`UPDATE policies_info SET revision = 20 WHERE id = 1`)
+			Expect(err).NotTo(HaveOccurred())
+
+			revision, err := store.NewRevisionStore(primaryDb).Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(revision).To(Equal(int64(20)))
+		})
+
+		Context("when the replica then falls behind for longer than the max staleness", func() {
+			BeforeEach(func() {
+				readReplica.MaxStaleness = 50 * time.Millisecond
+				setRevision(primaryDb, 4)
+			})
+
+			It("reads from the primary again", func() {
+				time.Sleep(100 * time.Millisecond)
+				Expect(readReplica.Check()).To(Succeed())
+				Expect(readReplica.Stale()).To(BeTrue())
+
+				revision, err := store.NewRevisionStore(readReplica).Revision()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(revision).To(Equal(int64(4)))
+			})
+		})
+	})
+
+	Context("when the replica cannot be reached", func() {
+		It("returns an error and keeps reading from the primary", func() {
+			Expect(replicaDb.Close()).To(Succeed())
+			replicaDb = nil
+
+			Expect(readReplica.Check()).To(MatchError(HavePrefix("checking read replica:")))
+			Expect(readReplica.Stale()).To(BeTrue())
+		})
+	})
+
+	Context("when the primary cannot be reached", func() {
+		It("returns an error", func() {
+			Expect(primaryDb.Close()).To(Succeed())
+			primaryDb = nil
+
+			Expect(readReplica.Check()).To(MatchError(HavePrefix("checking primary:")))
+		})
+	})
+
+	Describe("transactions", func() {
+		It("are always started on the primary", func() {
+			fakePrimary := &fakes.Db{}
+			fakeReplica := &fakes.Db{}
+			fakePrimary.BeginxReturns(nil, errors.New("banana"))
+			readReplica.Primary = fakePrimary
+			readReplica.Replica = fakeReplica
+
+			_, err := readReplica.Beginx()
+			Expect(err).To(MatchError("banana"))
+			Expect(fakePrimary.BeginxCallCount()).To(Equal(1))
+			Expect(fakeReplica.BeginxCallCount()).To(Equal(0))
+		})
+
+		It("are always started on the primary when bound to a context", func() {
+			fakePrimary := &fakes.Db{}
+			fakeReplica := &fakes.Db{}
+			fakePrimary.BeginTxxReturns(nil, errors.New("banana"))
+			readReplica.Primary = fakePrimary
+			readReplica.Replica = fakeReplica
+
+			_, err := readReplica.BeginTxx(context.Background(), nil)
+			Expect(err).To(MatchError("banana"))
+			Expect(fakePrimary.BeginTxxCallCount()).To(Equal(1))
+			Expect(fakeReplica.BeginTxxCallCount()).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+)
+
+//go:generate counterfeiter -o fakes/revision_store.go --fake-name RevisionStore . RevisionStore
+type RevisionStore interface {
+	Revision() (int64, error)
+}
+
+// revisionStore reads the policies revision, a counter in policies_info
+// that every transaction changing c2c or egress policies increments.
+type revisionStore struct {
+	conn Database
+}
+
+func NewRevisionStore(dbConnectionPool Database) *revisionStore {
+	return &revisionStore{
+		conn: dbConnectionPool,
+	}
+}
+
+func (r *revisionStore) Revision() (int64, error) {
+	var revision int64
+	err := r.conn.QueryRow(`SELECT revision FROM policies_info WHERE id = 1`).Scan(&revision)
+	if err != nil {
+		return 0, fmt.Errorf("getting policies revision: %s", err)
+	}
+	return revision, nil
+}
+
+// bumpRevision increments the policies revision. Write transactions call it
+// before they read anything, so the lock it takes on the policies_info row
+// serializes them until they commit. That keeps concurrent writes from
+// racing between checking for existing groups, destinations and policies
+// and inserting the missing ones.
+func bumpRevision(tx db.Transaction) error {
+	_, err := tx.Exec(`UPDATE policies_info SET revision = revision + 1 WHERE id = 1`)
+	if err != nil {
+		return fmt.Errorf("bumping policies revision: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package store_test
+
+import (
+	"context"
+	"fmt"
+	"policy-server/store"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionStore", func() {
+	var (
+		dbConf        db.Config
+		realDb        *db.ConnWrapper
+		revisionStore store.RevisionStore
+	)
+
+	BeforeEach(func() {
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("revision_store_test_node_%d", time.Now().UnixNano())
+
+		testsupport.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Revision Store Test")
+
+		var err error
+		realDb, err = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Revision Store Test", "Revision Store Test", logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrateAndPopulateTags(realDb, 1)
+
+		revisionStore = store.NewRevisionStore(realDb)
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testsupport.RemoveDatabase(dbConf)
+	})
+
+	It("starts at zero", func() {
+		revision, err := revisionStore.Revision()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revision).To(Equal(int64(0)))
+	})
+
+	It("changes when c2c or egress policies change", func() {
+		dataStore := store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1)
+		policy := store.Policy{
+			Source:      store.Source{ID: "some-app-guid"},
+			Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+		}
+
+		Expect(dataStore.Create(context.Background(), []store.Policy{policy})).To(Succeed())
+		Expect(dataStore.Delete(context.Background(), []store.Policy{policy})).To(Succeed())
+
+		revision, err := revisionStore.Revision()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(revision).To(Equal(int64(2)))
+	})
+
+	Context("when the query fails", func() {
+		It("returns an error", func() {
+			Expect(realDb.Close()).To(Succeed())
+
+			_, err := revisionStore.Revision()
+			Expect(err).To(MatchError(HavePrefix("getting policies revision:")))
+			realDb = nil
+		})
+	})
+})
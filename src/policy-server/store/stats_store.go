@@ -0,0 +1,341 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"policy-server/store/helpers"
+	"strings"
+)
+
+// appSpacesPerQuery bounds the apps whose space and org are bound as
+// parameters to a single query.
+const appSpacesPerQuery = 500
+
+//go:generate counterfeiter -o fakes/stats_store.go --fake-name StatsStore . StatsStore
+type StatsStore interface {
+	Stats() (Stats, error)
+	SpaceCounts(apps []AppSpace) (spaces []GUIDCount, orgs []GUIDCount, err error)
+}
+
+// Stats are aggregates over every c2c and egress policy, for capacity
+// planning. Each count is computed by the database rather than by loading
+// the policies.
+type Stats struct {
+	TotalPolicies       int
+	TotalEgressPolicies int
+	// FanOut counts the c2c policies of each app as a source, and FanIn
+	// as a destination. Apps without policies are left out.
+	FanOut []GUIDCount
+	FanIn  []GUIDCount
+	// PoliciesPerSpace and PoliciesPerOrg count the c2c policies by the
+	// space and org of their source app. The database does not know where
+	// apps live, so Stats leaves them empty and SpaceCounts computes them.
+	PoliciesPerSpace   []GUIDCount
+	PoliciesPerOrg     []GUIDCount
+	EgressSources      []EgressSourceCount
+	EgressDestinations []EgressDestinationCount
+	Tags               TagStats
+}
+
+type GUIDCount struct {
+	GUID  string
+	Count int
+}
+
+// AppSpace is the space and org an app belongs to.
+type AppSpace struct {
+	AppGUID   string
+	SpaceGUID string
+	OrgGUID   string
+}
+
+type EgressSourceCount struct {
+	Type  string
+	GUID  string
+	Count int
+}
+
+// EgressDestinationCount includes destinations without any egress policy.
+type EgressDestinationCount struct {
+	GUID  string
+	Name  string
+	Count int
+}
+
+// TagStats compares the tags in use with the TagLength the policy server
+// is configured with. Populated is the number of rows in groups, which the
+// tag populator fills in advance.
+type TagStats struct {
+	TagLength  int
+	Max        int
+	Populated  int
+	Used       int
+	UsedByType map[string]int
+}
+
+// PolicyCounts are the number of c2c and egress policies.
+type PolicyCounts struct {
+	C2C    int
+	Egress int
+}
+
+type statsStore struct {
+	conn      Database
+	tagLength int
+}
+
+func NewStatsStore(dbConnectionPool Database, tagLength int) *statsStore {
+	return &statsStore{
+		conn:      dbConnectionPool,
+		tagLength: tagLength,
+	}
+}
+
+// PolicyCounts counts the policies without loading them, so that it is
+// cheap enough for the detailed health check.
+func (s *statsStore) PolicyCounts(ctx context.Context) (PolicyCounts, error) {
+	counts := PolicyCounts{}
+
+	err := s.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM policies`).Scan(&counts.C2C)
+	if err != nil {
+		return PolicyCounts{}, fmt.Errorf("counting policies: %s", err)
+	}
+
+	err = s.conn.QueryRowContext(ctx, `SELECT COUNT(*) FROM egress_policies`).Scan(&counts.Egress)
+	if err != nil {
+		return PolicyCounts{}, fmt.Errorf("counting egress policies: %s", err)
+	}
+
+	return counts, nil
+}
+
+func (s *statsStore) Stats() (Stats, error) {
+	stats := Stats{}
+
+	err := s.conn.QueryRow(`SELECT COUNT(*) FROM policies`).Scan(&stats.TotalPolicies)
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting policies: %s", err)
+	}
+
+	err = s.conn.QueryRow(`SELECT COUNT(*) FROM egress_policies`).Scan(&stats.TotalEgressPolicies)
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting egress policies: %s", err)
+	}
+
+	stats.FanOut, err = s.guidCounts(`
+		SELECT g.guid, COUNT(*)
+		FROM policies p
+		JOIN groups g ON p.group_id = g.id
+		GROUP BY g.guid`)
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting policies by source: %s", err)
+	}
+
+	stats.FanIn, err = s.guidCounts(`
+		SELECT g.guid, COUNT(*)
+		FROM policies p
+		JOIN destinations d ON p.destination_id = d.id
+		JOIN groups g ON d.group_id = g.id
+		GROUP BY g.guid`)
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting policies by destination: %s", err)
+	}
+
+	stats.EgressSources, err = s.egressSourceCounts()
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting egress policies by source: %s", err)
+	}
+
+	stats.EgressDestinations, err = s.egressDestinationCounts()
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting egress policies by destination: %s", err)
+	}
+
+	stats.Tags, err = s.tagStats()
+	if err != nil {
+		return Stats{}, fmt.Errorf("counting tags: %s", err)
+	}
+
+	return stats, nil
+}
+
+// SpaceCounts counts the c2c policies of each space and org by the space and
+// org of their source app, as given by apps. Policies of apps missing from
+// apps are not counted.
+func (s *statsStore) SpaceCounts(apps []AppSpace) ([]GUIDCount, []GUIDCount, error) {
+	spaces, err := s.appSpaceCounts(apps, func(app AppSpace) string { return app.SpaceGUID })
+	if err != nil {
+		return nil, nil, fmt.Errorf("counting policies by space: %s", err)
+	}
+
+	orgs, err := s.appSpaceCounts(apps, func(app AppSpace) string { return app.OrgGUID })
+	if err != nil {
+		return nil, nil, fmt.Errorf("counting policies by org: %s", err)
+	}
+
+	return spaces, orgs, nil
+}
+
+// appSpaceCounts groups the c2c policies of apps by the guid that group
+// returns for their source app. The apps are bound appSpacesPerQuery at a
+// time and the counts of each query are added up, which is exact because
+// every policy has a single source app.
+func (s *statsStore) appSpaceCounts(apps []AppSpace, group func(AppSpace) string) ([]GUIDCount, error) {
+	totals := map[string]int{}
+	for start := 0; start < len(apps); start += appSpacesPerQuery {
+		end := start + appSpacesPerQuery
+		if end > len(apps) {
+			end = len(apps)
+		}
+		chunk := apps[start:end]
+
+		var whens []string
+		var args []interface{}
+		for _, app := range chunk {
+			whens = append(whens, `WHEN ? THEN ?`)
+			args = append(args, app.AppGUID, group(app))
+		}
+		for _, app := range chunk {
+			args = append(args, app.AppGUID)
+		}
+
+		counts, err := s.guidCounts(s.conn.Rebind(`
+			SELECT CASE g.guid `+strings.Join(whens, ` `)+` END, COUNT(*)
+			FROM policies p
+			JOIN groups g ON p.group_id = g.id
+			WHERE g.guid IN (`+helpers.QuestionMarks(len(chunk))+`)
+			GROUP BY 1`), args...)
+		if err != nil {
+			return nil, err
+		}
+		for _, count := range counts {
+			totals[count.GUID] += count.Count
+		}
+	}
+
+	counts := []GUIDCount{}
+	for guid, count := range totals {
+		counts = append(counts, GUIDCount{GUID: guid, Count: count})
+	}
+	return counts, nil
+}
+
+func (s *statsStore) guidCounts(query string, args ...interface{}) ([]GUIDCount, error) {
+	rows, err := s.conn.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // untested
+
+	counts := []GUIDCount{}
+	for rows.Next() {
+		var count GUIDCount
+		err = rows.Scan(&count.GUID, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+func (s *statsStore) egressSourceCounts() ([]EgressSourceCount, error) {
+	rows, err := s.conn.Query(`
+		SELECT 'app', a.app_guid, COUNT(*)
+		FROM egress_policies ep
+		JOIN apps a ON ep.source_guid = a.terminal_guid
+		GROUP BY a.app_guid
+		UNION ALL
+		SELECT 'space', sp.space_guid, COUNT(*)
+		FROM egress_policies ep
+		JOIN spaces sp ON ep.source_guid = sp.terminal_guid
+		GROUP BY sp.space_guid
+		UNION ALL
+		SELECT 'org', o.org_guid, COUNT(*)
+		FROM egress_policies ep
+		JOIN orgs o ON ep.source_guid = o.terminal_guid
+		GROUP BY o.org_guid
+		UNION ALL
+		SELECT 'default', '', COUNT(*)
+		FROM egress_policies ep
+		JOIN default_sources ds ON ep.source_guid = ds.terminal_guid`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // untested
+
+	counts := []EgressSourceCount{}
+	for rows.Next() {
+		var count EgressSourceCount
+		err = rows.Scan(&count.Type, &count.GUID, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		// the default source is counted even when it has no policies
+		if count.Count > 0 {
+			counts = append(counts, count)
+		}
+	}
+	return counts, rows.Err()
+}
+
+func (s *statsStore) egressDestinationCounts() ([]EgressDestinationCount, error) {
+	rows, err := s.conn.Query(`
+		SELECT ir.terminal_guid, dm.name, COUNT(ep.guid)
+		FROM ip_ranges ir
+		LEFT JOIN destination_metadatas dm ON ir.terminal_guid = dm.terminal_guid
+		LEFT JOIN egress_policies ep ON ir.terminal_guid = ep.destination_guid
+		GROUP BY ir.terminal_guid, dm.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() // untested
+
+	counts := []EgressDestinationCount{}
+	for rows.Next() {
+		var (
+			count EgressDestinationCount
+			name  sql.NullString
+		)
+		err = rows.Scan(&count.GUID, &name, &count.Count)
+		if err != nil {
+			return nil, err
+		}
+		count.Name = name.String
+		counts = append(counts, count)
+	}
+	return counts, rows.Err()
+}
+
+func (s *statsStore) tagStats() (TagStats, error) {
+	tags := TagStats{
+		TagLength:  s.tagLength,
+		Max:        1<<(8*uint(s.tagLength)) - 1,
+		UsedByType: map[string]int{},
+	}
+
+	err := s.conn.QueryRow(`SELECT COUNT(*), COUNT(guid) FROM groups`).Scan(&tags.Populated, &tags.Used)
+	if err != nil {
+		return TagStats{}, err
+	}
+
+	rows, err := s.conn.Query(`SELECT type, COUNT(*) FROM groups WHERE guid IS NOT NULL GROUP BY type`)
+	if err != nil {
+		return TagStats{}, err
+	}
+	defer rows.Close() // untested
+
+	for rows.Next() {
+		var (
+			groupType sql.NullString
+			count     int
+		)
+		err = rows.Scan(&groupType, &count)
+		if err != nil {
+			return TagStats{}, err
+		}
+		tags.UsedByType[groupType.String] += count
+	}
+	return tags, rows.Err()
+}
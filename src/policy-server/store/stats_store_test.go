@@ -0,0 +1,288 @@
+package store_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StatsStore", func() {
+	var (
+		dbConf     db.Config
+		realDb     *db.ConnWrapper
+		statsStore store.StatsStore
+	)
+
+	BeforeEach(func() {
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("stats_store_test_node_%d", time.Now().UnixNano())
+
+		testsupport.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Stats Store Test")
+
+		var err error
+		realDb, err = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Stats Store Test", "Stats Store Test", logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrateAndPopulateTags(realDb, 1)
+
+		statsStore = store.NewStatsStore(realDb, 1)
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testsupport.RemoveDatabase(dbConf)
+	})
+
+	c2cPolicy := func(source, destination string, port int) store.Policy {
+		return store.Policy{
+			Source: store.Source{ID: source},
+			Destination: store.Destination{
+				ID:       destination,
+				Protocol: "tcp",
+				Port:     port,
+				Ports:    store.Ports{Start: port, End: port},
+			},
+		}
+	}
+
+	It("counts nothing in an empty database", func() {
+		stats, err := statsStore.Stats()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats).To(Equal(store.Stats{
+			FanOut:             []store.GUIDCount{},
+			FanIn:              []store.GUIDCount{},
+			EgressSources:      []store.EgressSourceCount{},
+			EgressDestinations: []store.EgressDestinationCount{},
+			Tags: store.TagStats{
+				TagLength:  1,
+				Max:        255,
+				Populated:  255,
+				UsedByType: map[string]int{},
+			},
+		}))
+	})
+
+	Context("when there are policies", func() {
+		var destinations []store.EgressDestination
+
+		BeforeEach(func() {
+			dataStore := store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1)
+			Expect(dataStore.Create(context.Background(), []store.Policy{
+				c2cPolicy("app-a", "app-b", 8080),
+				c2cPolicy("app-a", "app-b", 9090),
+				c2cPolicy("app-a", "app-c", 8080),
+				c2cPolicy("app-c", "app-b", 8080),
+			})).To(Succeed())
+
+			terminalsRepo := &store.TerminalsTable{Guids: &store.GuidGenerator{}}
+			egressPolicyStore := &store.EgressPolicyStore{
+				TerminalsRepo:    terminalsRepo,
+				EgressPolicyRepo: &store.EgressPolicyTable{Conn: realDb, Guids: &store.GuidGenerator{}},
+				Conn:             realDb,
+			}
+			egressDestinationStore := &store.EgressDestinationStore{
+				TerminalsRepo:           terminalsRepo,
+				DestinationMetadataRepo: &store.DestinationMetadataTable{},
+				Conn:                    realDb,
+				EgressDestinationRepo:   &store.EgressDestinationTable{},
+				EgressPolicyStore:       egressPolicyStore,
+			}
+
+			var err error
+			destinations, err = egressDestinationStore.Create([]store.EgressDestination{
+				{Name: "dest-1", Protocol: "tcp", IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.1"}}},
+				{Name: "dest-2", Protocol: "tcp", IPRanges: []store.IPRange{{Start: "10.0.0.2", End: "10.0.0.2"}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			egressPolicy := func(sourceType, sourceID string, destination store.EgressDestination) store.EgressPolicy {
+				return store.EgressPolicy{
+					Source:      store.EgressSource{Type: sourceType, ID: sourceID},
+					Destination: store.EgressDestination{GUID: destination.GUID},
+				}
+			}
+			_, err = egressPolicyStore.Create(context.Background(), []store.EgressPolicy{
+				egressPolicy("app", "app-a", destinations[0]),
+				egressPolicy("space", "space-a", destinations[0]),
+				egressPolicy("org", "org-a", destinations[0]),
+			})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("aggregates them", func() {
+			stats, err := statsStore.Stats()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stats.TotalPolicies).To(Equal(4))
+			Expect(stats.TotalEgressPolicies).To(Equal(3))
+			Expect(stats.FanOut).To(ConsistOf(
+				store.GUIDCount{GUID: "app-a", Count: 3},
+				store.GUIDCount{GUID: "app-c", Count: 1},
+			))
+			Expect(stats.FanIn).To(ConsistOf(
+				store.GUIDCount{GUID: "app-b", Count: 3},
+				store.GUIDCount{GUID: "app-c", Count: 1},
+			))
+			Expect(stats.EgressSources).To(ConsistOf(
+				store.EgressSourceCount{Type: "app", GUID: "app-a", Count: 1},
+				store.EgressSourceCount{Type: "space", GUID: "space-a", Count: 1},
+				store.EgressSourceCount{Type: "org", GUID: "org-a", Count: 1},
+			))
+			Expect(stats.EgressDestinations).To(ConsistOf(
+				store.EgressDestinationCount{GUID: destinations[0].GUID, Name: "dest-1", Count: 3},
+				store.EgressDestinationCount{GUID: destinations[1].GUID, Name: "dest-2", Count: 0},
+			))
+			Expect(stats.Tags).To(Equal(store.TagStats{
+				TagLength:  1,
+				Max:        255,
+				Populated:  255,
+				Used:       3,
+				UsedByType: map[string]int{"app": 3},
+			}))
+		})
+
+		Describe("SpaceCounts", func() {
+			It("counts the c2c policies by the space and org of their source app", func() {
+				spaces, orgs, err := statsStore.SpaceCounts([]store.AppSpace{
+					{AppGUID: "app-a", SpaceGUID: "space-1", OrgGUID: "org-1"},
+					{AppGUID: "app-b", SpaceGUID: "space-2", OrgGUID: "org-1"},
+					{AppGUID: "app-c", SpaceGUID: "space-3", OrgGUID: "org-2"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaces).To(ConsistOf(
+					store.GUIDCount{GUID: "space-1", Count: 3},
+					store.GUIDCount{GUID: "space-3", Count: 1},
+				))
+				Expect(orgs).To(ConsistOf(
+					store.GUIDCount{GUID: "org-1", Count: 3},
+					store.GUIDCount{GUID: "org-2", Count: 1},
+				))
+			})
+
+			It("does not count the policies of apps it is not given", func() {
+				spaces, orgs, err := statsStore.SpaceCounts([]store.AppSpace{
+					{AppGUID: "app-c", SpaceGUID: "space-3", OrgGUID: "org-2"},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaces).To(Equal([]store.GUIDCount{{GUID: "space-3", Count: 1}}))
+				Expect(orgs).To(Equal([]store.GUIDCount{{GUID: "org-2", Count: 1}}))
+			})
+
+			It("adds up the counts of apps in the same space across queries", func() {
+				apps := []store.AppSpace{}
+				for i := 0; i < 600; i++ {
+					apps = append(apps, store.AppSpace{AppGUID: fmt.Sprintf("other-app-%d", i), SpaceGUID: "space-1", OrgGUID: "org-1"})
+				}
+				apps[10] = store.AppSpace{AppGUID: "app-c", SpaceGUID: "space-1", OrgGUID: "org-1"}
+				apps[550] = store.AppSpace{AppGUID: "app-a", SpaceGUID: "space-1", OrgGUID: "org-1"}
+
+				spaces, orgs, err := statsStore.SpaceCounts(apps)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaces).To(Equal([]store.GUIDCount{{GUID: "space-1", Count: 4}}))
+				Expect(orgs).To(Equal([]store.GUIDCount{{GUID: "org-1", Count: 4}}))
+			})
+
+			It("counts nothing without apps", func() {
+				spaces, orgs, err := statsStore.SpaceCounts(nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaces).To(BeEmpty())
+				Expect(orgs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("PolicyCounts", func() {
+		It("counts the c2c and egress policies", func() {
+			policyStore := store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1)
+			Expect(policyStore.Create(context.Background(), []store.Policy{
+				c2cPolicy("app-a", "app-b", 8080),
+				c2cPolicy("app-a", "app-c", 8080),
+			})).To(Succeed())
+
+			counts, err := store.NewStatsStore(realDb, 1).PolicyCounts(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(counts).To(Equal(store.PolicyCounts{C2C: 2, Egress: 0}))
+		})
+
+		Context("when the context is done", func() {
+			It("returns the error", func() {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+
+				_, err := store.NewStatsStore(realDb, 1).PolicyCounts(ctx)
+				Expect(err).To(MatchError(ContainSubstring("counting policies: ")))
+			})
+		})
+	})
+
+	Context("when the database fails", func() {
+		var mockDb *fakes.Db
+
+		BeforeEach(func() {
+			mockDb = &fakes.Db{}
+			mockDb.QueryRowReturns(realDb.QueryRow(`SELECT 1 FROM nonexistent_table`))
+			statsStore = store.NewStatsStore(mockDb, 1)
+		})
+
+		It("returns the error", func() {
+			_, err := statsStore.Stats()
+			Expect(err).To(MatchError(HavePrefix("counting policies: ")))
+		})
+
+		Context("when an aggregate query fails", func() {
+			BeforeEach(func() {
+				mockDb.QueryRowStub = realDb.QueryRow
+				mockDb.QueryReturns(nil, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				_, err := statsStore.Stats()
+				Expect(err).To(MatchError("counting policies by source: potato"))
+			})
+		})
+
+		Context("when counting the policies by space fails", func() {
+			BeforeEach(func() {
+				mockDb.QueryReturns(nil, errors.New("potato"))
+			})
+
+			It("returns the error", func() {
+				_, _, err := statsStore.SpaceCounts([]store.AppSpace{
+					{AppGUID: "app-a", SpaceGUID: "space-1", OrgGUID: "org-1"},
+				})
+				Expect(err).To(MatchError("counting policies by space: potato"))
+			})
+		})
+
+		Context("when counting the egress policies fails", func() {
+			BeforeEach(func() {
+				mockDb.QueryRowContextStub = func(ctx context.Context, query string, args ...interface{}) *sql.Row {
+					if strings.Contains(query, "egress_policies") {
+						return realDb.QueryRowContext(ctx, `SELECT 1 FROM nonexistent_table`)
+					}
+					return realDb.QueryRowContext(ctx, query, args...)
+				}
+			})
+
+			It("returns the error", func() {
+				_, err := store.NewStatsStore(mockDb, 1).PolicyCounts(context.Background())
+				Expect(err).To(MatchError(HavePrefix("counting egress policies: ")))
+			})
+		})
+	})
+})
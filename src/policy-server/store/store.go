@@ -1,10 +1,12 @@
 package store
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"policy-server/store/helpers"
 	"strings"
+	"time"
 
 	"policy-server/store/migrations"
 
@@ -19,22 +21,25 @@ type Migrator interface {
 
 //go:generate counterfeiter -o fakes/store.go --fake-name Store . Store
 type Store interface {
-	Create([]Policy) error
-	All() ([]Policy, error)
-	Delete([]Policy) error
-	ByGuids([]string, []string, bool) ([]Policy, error)
-	CheckDatabase() error
+	Create(context.Context, []Policy) error
+	All(context.Context) ([]Policy, error)
+	Delete(context.Context, []Policy) error
+	ByGuids(context.Context, []string, []string, bool) ([]Policy, error)
+	CheckDatabase(context.Context) error
 }
 
 //go:generate counterfeiter -o fakes/database.go --fake-name Db . Database
 type Database interface {
 	Beginx() (db.Transaction, error)
+	BeginTxx(ctx context.Context, opts *sql.TxOptions) (db.Transaction, error)
 	Exec(query string, args ...interface{}) (sql.Result, error)
 	NamedExec(query string, arg interface{}) (sql.Result, error)
 	Get(dest interface{}, query string, args ...interface{}) error
 	Select(dest interface{}, query string, args ...interface{}) error
 	QueryRow(query string, args ...interface{}) *sql.Row
 	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
 	DriverName() string
 	RawConnection() *sqlx.DB
 	Rebind(string) string
@@ -48,7 +53,7 @@ type store struct {
 	tagLength   int
 }
 
-func New(dbConnectionPool Database, g GroupRepo, d DestinationRepo, p PolicyRepo, tl int) Store {
+func New(dbConnectionPool Database, g GroupRepo, d DestinationRepo, p PolicyRepo, tl int) *store {
 	return &store{
 		conn:        dbConnectionPool,
 		group:       g,
@@ -58,166 +63,214 @@ func New(dbConnectionPool Database, g GroupRepo, d DestinationRepo, p PolicyRepo
 	}
 }
 
-func (s *store) Create(policies []Policy) error {
-	tx, err := s.conn.Beginx()
+func (s *store) Create(ctx context.Context, policies []Policy) error {
+	tx, err := beginx(ctx, s.conn)
 	if err != nil {
 		return fmt.Errorf("create transaction: %s", err)
 	}
 
-	err = s.createWithTx(tx, policies)
+	err = s.CreateWithTx(tx, policies)
 	if err != nil {
 		return rollback(tx, err)
 	}
 
-	return commit(tx)
+	return commitContext(ctx, tx)
 }
 
-func (s *store) Delete(policies []Policy) error {
-	tx, err := s.conn.Beginx()
+func (s *store) Delete(ctx context.Context, policies []Policy) error {
+	tx, err := beginx(ctx, s.conn)
 	if err != nil {
 		return fmt.Errorf("create transaction: %s", err)
 	}
 
-	err = s.deleteWithTx(tx, policies)
+	err = s.DeleteWithTx(tx, policies)
 	if err != nil {
 		return rollback(tx, err)
 	}
 
-	return commit(tx)
+	return commitContext(ctx, tx)
 }
 
-func (s *store) CheckDatabase() error {
+func (s *store) CheckDatabase(ctx context.Context) error {
 	var result int
-	return s.conn.QueryRow("SELECT 1").Scan(&result)
+	return s.conn.QueryRowContext(ctx, "SELECT 1").Scan(&result)
 }
 
-func (s *store) createWithTx(tx db.Transaction, policies []Policy) error {
-	for _, policy := range policies {
-		sourceGroupId, err := s.group.Create(tx, policy.Source.ID, "app")
-		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
-		}
+func (s *store) CreateWithTx(tx db.Transaction, policies []Policy) error {
+	if len(policies) == 0 {
+		return nil
+	}
 
-		destinationGroupId, err := s.group.Create(tx, policy.Destination.ID, "app")
-		if err != nil {
-			return fmt.Errorf("creating group: %s", err)
-		}
+	err := bumpRevision(tx)
+	if err != nil {
+		return err
+	}
 
-		destinationId, err := s.destination.Create(
-			tx,
-			destinationGroupId,
-			policy.Destination.Port,
-			policy.Destination.Ports.Start,
-			policy.Destination.Ports.End,
-			policy.Destination.Protocol,
-		)
-		if err != nil {
-			return fmt.Errorf("creating destination: %s", err)
-		}
+	// Groups are created in the order their guids first appear so that tags
+	// are handed out the same way as when policies were created one by one.
+	var guids []string
+	for _, policy := range policies {
+		guids = append(guids, policy.Source.ID, policy.Destination.ID)
+	}
+	groupIDs, err := s.group.CreateMany(tx, guids, "app")
+	if err != nil {
+		return fmt.Errorf("creating group: %s", err)
+	}
 
-		err = s.policy.Create(tx, sourceGroupId, destinationId)
-		if err != nil {
-			return fmt.Errorf("creating policy: %s", err)
+	destinationKeys := make([]DestinationKey, len(policies))
+	for i, policy := range policies {
+		destinationKeys[i] = destinationKey(groupIDs[policy.Destination.ID], policy.Destination)
+	}
+	destinationIDs, err := s.destination.CreateMany(tx, destinationKeys)
+	if err != nil {
+		return fmt.Errorf("creating destination: %s", err)
+	}
+
+	policyRows := make([]PolicyRow, len(policies))
+	for i, policy := range policies {
+		policyRows[i] = PolicyRow{
+			GroupID:       groupIDs[policy.Source.ID],
+			DestinationID: destinationIDs[destinationKeys[i]],
+			ExpiresAt:     policy.ExpiresAt,
 		}
 	}
+	err = s.policy.CreateMany(tx, policyRows)
+	if err != nil {
+		return fmt.Errorf("creating policy: %s", err)
+	}
 	return nil
 }
 
-func (s *store) deleteWithTx(tx db.Transaction, policies []Policy) error {
-	for _, p := range policies {
-		sourceGroupID, err := s.group.GetID(tx, p.Source.ID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				continue
-			} else {
-				return fmt.Errorf("getting source id: %s", err)
-			}
-		}
+func (s *store) DeleteWithTx(tx db.Transaction, policies []Policy) error {
+	if len(policies) == 0 {
+		return nil
+	}
 
-		destGroupID, err := s.group.GetID(tx, p.Destination.ID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				continue
-			} else {
-				return fmt.Errorf("getting destination group id: %s", err)
-			}
-		}
+	err := bumpRevision(tx)
+	if err != nil {
+		return err
+	}
 
-		destID, err := s.destination.GetID(
-			tx,
-			destGroupID,
-			p.Destination.Port,
-			p.Destination.Ports.Start,
-			p.Destination.Ports.End,
-			p.Destination.Protocol,
-		)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				continue
-			} else {
-				return fmt.Errorf("getting destination id: %s", err)
-			}
+	var guids []string
+	for _, policy := range policies {
+		guids = append(guids, policy.Source.ID, policy.Destination.ID)
+	}
+	groupIDs, err := s.group.GetIDs(tx, guids)
+	if err != nil {
+		return fmt.Errorf("getting group ids: %s", err)
+	}
+
+	var destinationKeys []DestinationKey
+	for _, policy := range policies {
+		destGroupID, ok := groupIDs[policy.Destination.ID]
+		if !ok {
+			continue
 		}
+		destinationKeys = append(destinationKeys, destinationKey(destGroupID, policy.Destination))
+	}
+	destinationIDs, err := s.destination.GetIDs(tx, destinationKeys)
+	if err != nil {
+		return fmt.Errorf("getting destination ids: %s", err)
+	}
 
-		err = s.policy.Delete(tx, sourceGroupID, destID)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				continue
-			} else {
-				return fmt.Errorf("deleting policy: %s", err)
-			}
+	var policyRows []PolicyRow
+	var touchedDestinationIDs, touchedGroupIDs []int
+	seenDestination := map[int]bool{}
+	seenGroup := map[int]bool{}
+	for _, policy := range policies {
+		sourceGroupID, ok := groupIDs[policy.Source.ID]
+		if !ok {
+			continue
+		}
+		destGroupID, ok := groupIDs[policy.Destination.ID]
+		if !ok {
+			continue
+		}
+		destID, ok := destinationIDs[destinationKey(destGroupID, policy.Destination)]
+		if !ok {
+			continue
 		}
 
-		destIDCount, err := s.policy.CountWhereDestinationID(tx, destID)
-		if err != nil {
-			return fmt.Errorf("counting destination id: %s", err)
+		policyRows = append(policyRows, PolicyRow{GroupID: sourceGroupID, DestinationID: destID})
+		if !seenDestination[destID] {
+			seenDestination[destID] = true
+			touchedDestinationIDs = append(touchedDestinationIDs, destID)
 		}
-		if destIDCount == 0 {
-			err = s.destination.Delete(tx, destID)
-			if err != nil {
-				return fmt.Errorf("deleting destination: %s", err)
+		for _, groupID := range []int{sourceGroupID, destGroupID} {
+			if !seenGroup[groupID] {
+				seenGroup[groupID] = true
+				touchedGroupIDs = append(touchedGroupIDs, groupID)
 			}
 		}
+	}
+	if len(policyRows) == 0 {
+		return nil
+	}
 
-		err = s.deleteGroupRowIfLast(tx, sourceGroupID)
-		if err != nil {
-			return fmt.Errorf("deleting group row: %s", err)
-		}
+	err = s.policy.DeleteMany(tx, policyRows)
+	if err != nil {
+		return fmt.Errorf("deleting policy: %s", err)
+	}
 
-		err = s.deleteGroupRowIfLast(tx, destGroupID)
-		if err != nil {
-			return fmt.Errorf("deleting group row: %s", err)
+	destinationsInUse, err := s.policy.DestinationIDsInUse(tx, touchedDestinationIDs)
+	if err != nil {
+		return fmt.Errorf("counting destination id: %s", err)
+	}
+	var unusedDestinationIDs []int
+	for _, destID := range touchedDestinationIDs {
+		if !destinationsInUse[destID] {
+			unusedDestinationIDs = append(unusedDestinationIDs, destID)
 		}
 	}
+	err = s.destination.DeleteMany(tx, unusedDestinationIDs)
+	if err != nil {
+		return fmt.Errorf("deleting destination: %s", err)
+	}
+
+	err = s.deleteUnusedGroupRows(tx, touchedGroupIDs)
+	if err != nil {
+		return fmt.Errorf("deleting group row: %s", err)
+	}
 	return nil
 }
 
-func (s *store) deleteGroupRowIfLast(tx db.Transaction, groupId int) error {
-	policiesGroupIDCount, err := s.policy.CountWhereGroupID(tx, groupId)
+// deleteUnusedGroupRows releases the groups that are no longer the source
+// of a policy and no longer have a destination, freeing their tags.
+func (s *store) deleteUnusedGroupRows(tx db.Transaction, groupIDs []int) error {
+	sourcesInUse, err := s.policy.GroupIDsInUse(tx, groupIDs)
 	if err != nil {
 		return err
 	}
 
-	destinationsGroupIDCount, err := s.destination.CountWhereGroupID(tx, groupId)
+	destinationsInUse, err := s.destination.GroupIDsInUse(tx, groupIDs)
 	if err != nil {
 		return err
 	}
 
-	if policiesGroupIDCount == 0 && destinationsGroupIDCount == 0 {
-		err = s.group.Delete(tx, groupId)
-		if err != nil {
-			return err
+	var unused []int
+	for _, groupID := range groupIDs {
+		if !sourcesInUse[groupID] && !destinationsInUse[groupID] {
+			unused = append(unused, groupID)
 		}
 	}
+	return s.group.DeleteMany(tx, unused)
+}
 
-	return nil
+func destinationKey(groupID int, destination Destination) DestinationKey {
+	return DestinationKey{
+		GroupID:   groupID,
+		Port:      destination.Port,
+		StartPort: destination.Ports.Start,
+		EndPort:   destination.Ports.End,
+		Protocol:  destination.Protocol,
+	}
 }
 
-func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, error) {
+func (s *store) policiesQuery(ctx context.Context, query string, args ...interface{}) ([]Policy, error) {
 	var policies []Policy
 	rebindedQuery := helpers.RebindForSQLDialect(query, s.conn.DriverName())
 
-	rows, err := s.conn.Query(rebindedQuery, args...)
+	rows, err := s.conn.QueryContext(ctx, rebindedQuery, args...)
 	if err != nil {
 		return nil, fmt.Errorf("listing all: %s", err)
 	}
@@ -226,6 +279,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 	for rows.Next() {
 		var sourceId, destinationId, protocol string
 		var port, startPort, endPort, sourceTag, destinationTag int
+		var expiresAt *time.Time
 		err = rows.Scan(
 			&sourceId,
 			&sourceTag,
@@ -235,6 +289,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 			&startPort,
 			&endPort,
 			&protocol,
+			&expiresAt,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("listing all: %s", err)
@@ -255,6 +310,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 					End:   endPort,
 				},
 			},
+			ExpiresAt: expiresAt,
 		})
 	}
 	err = rows.Err()
@@ -264,7 +320,7 @@ func (s *store) policiesQuery(query string, args ...interface{}) ([]Policy, erro
 	return policies, nil
 }
 
-func (s *store) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]Policy, error) {
+func (s *store) ByGuids(ctx context.Context, srcGuids, destGuids []string, inSourceAndDest bool) ([]Policy, error) {
 	numSourceGuids := len(srcGuids)
 	numDestinationGuids := len(destGuids)
 	if numSourceGuids == 0 && numDestinationGuids == 0 {
@@ -289,7 +345,8 @@ func (s *store) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]P
 			destinations.port,
 			destinations.start_port,
 			destinations.end_port,
-			destinations.protocol
+			destinations.protocol,
+			policies.expires_at
 		from policies
 		left outer join groups as src_grp on (policies.group_id = src_grp.id)
 		left outer join destinations on (destinations.id = policies.destination_id)
@@ -313,11 +370,11 @@ func (s *store) ByGuids(srcGuids, destGuids []string, inSourceAndDest bool) ([]P
 		}
 	}
 
-	return s.policiesQuery(query, whereBindings...)
+	return s.policiesQuery(ctx, query, whereBindings...)
 }
 
-func (s *store) All() ([]Policy, error) {
-	return s.policiesQuery(`
+func (s *store) All(ctx context.Context) ([]Policy, error) {
+	return s.policiesQuery(ctx, `
 		select
 			src_grp.guid,
 			src_grp.id,
@@ -326,7 +383,8 @@ func (s *store) All() ([]Policy, error) {
 			destinations.port,
 			destinations.start_port,
 			destinations.end_port,
-			destinations.protocol
+			destinations.protocol,
+			policies.expires_at
 		from policies
 		left outer join groups as src_grp on (policies.group_id = src_grp.id)
 		left outer join destinations on (destinations.id = policies.destination_id)
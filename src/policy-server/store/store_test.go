@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -59,7 +60,7 @@ var _ = Describe("Store", func() {
 		tx = &dbfakes.Transaction{}
 
 		mockDb.DriverNameReturns(realDb.DriverName())
-		mockDb.BeginxReturns(tx, nil)
+		mockDb.BeginTxxReturns(tx, nil)
 	})
 
 	AfterEach(func() {
@@ -76,9 +77,9 @@ var _ = Describe("Store", func() {
 				time.Sleep(time.Duration(attempt) * time.Second)
 				switch crud {
 				case "create":
-					err = dataStore.Create([]store.Policy{p})
+					err = dataStore.Create(context.Background(), []store.Policy{p})
 				case "delete":
-					err = dataStore.Delete([]store.Policy{p})
+					err = dataStore.Delete(context.Background(), []store.Policy{p})
 				}
 				if err == nil {
 					break
@@ -125,11 +126,37 @@ var _ = Describe("Store", func() {
 
 			Expect(nDeleted).To(Equal(int32(nPolicies)))
 
-			allPolicies, err := dataStore.All()
+			allPolicies, err := dataStore.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(allPolicies).To(BeEmpty())
 		})
+
+		It("creates the same policy concurrently without errors", func() {
+			migrateAndPopulateTags(realDb, 2)
+			dataStore := store.New(realDb, group, destination, policy, 2)
+
+			p := store.Policy{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 1234},
+			}
+			var attempts []interface{}
+			for i := 0; i < 20; i++ {
+				attempts = append(attempts, p)
+			}
+
+			parallelRunner := &testsupport.ParallelRunner{
+				NumWorkers: 4,
+			}
+			parallelRunner.RunOnSlice(attempts, func(policy interface{}) {
+				defer GinkgoRecover()
+				Expect(dataStore.Create(context.Background(), []store.Policy{policy.(store.Policy)})).To(Succeed())
+			})
+
+			allPolicies, err := dataStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allPolicies).To(HaveLen(1))
+		})
 	})
 
 	Describe("Create", func() {
@@ -163,36 +190,104 @@ var _ = Describe("Store", func() {
 				},
 			}}
 
-			err := dataStore.Create(policies)
+			err := dataStore.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 
-			p, err := dataStore.All()
+			p, err := dataStore.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(len(p)).To(Equal(2))
 		})
 
+		It("does not save the policies when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := dataStore.Create(ctx, []store.Policy{{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+			}})
+			Expect(err).To(MatchError("create transaction: context canceled"))
+
+			p, err := dataStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(p).To(BeEmpty())
+		})
+
+		It("allocates tags in the order the guids first appear", func() {
+			policies := []store.Policy{{
+				Source:      store.Source{ID: "app-a"},
+				Destination: store.Destination{ID: "app-b", Protocol: "tcp", Port: 8080},
+			}, {
+				Source:      store.Source{ID: "app-c"},
+				Destination: store.Destination{ID: "app-a", Protocol: "tcp", Port: 8080},
+			}}
+
+			err := dataStore.Create(context.Background(), policies)
+			Expect(err).NotTo(HaveOccurred())
+
+			tags, err := tagDataStore.Tags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(ConsistOf(
+				store.Tag{ID: "app-a", Tag: "01", Type: "app"},
+				store.Tag{ID: "app-b", Tag: "02", Type: "app"},
+				store.Tag{ID: "app-c", Tag: "03", Type: "app"},
+			))
+		})
+
+		It("bumps the policies revision", func() {
+			revisionStore := store.NewRevisionStore(realDb)
+			before, err := revisionStore.Revision()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = dataStore.Create(context.Background(), []store.Policy{{
+				Source:      store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := revisionStore.Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before + 1))
+		})
+
+		Context("when bumping the policies revision fails", func() {
+			It("rolls back the transaction", func() {
+				fakeGroup := &fakes.GroupRepo{}
+				fakeDestination := &fakes.DestinationRepo{}
+				fakePolicy := &fakes.PolicyRepo{}
+				tx.ExecReturns(nil, errors.New("potato"))
+
+				dataStore := store.New(mockDb, fakeGroup, fakeDestination, fakePolicy, 2)
+
+				err := dataStore.Create(context.Background(), []store.Policy{{}})
+				Expect(err).To(MatchError("bumping policies revision: potato"))
+				Expect(tx.RollbackCallCount()).To(Equal(1))
+				Expect(tx.CommitCallCount()).To(Equal(0))
+			})
+		})
+
 		Context("when a transaction begin fails", func() {
 			var err error
 
 			BeforeEach(func() {
-				mockDb.BeginxReturns(nil, errors.New("some-db-error"))
+				mockDb.BeginTxxReturns(nil, errors.New("some-db-error"))
 				dataStore = store.New(mockDb, group, destination, policy, 2)
 			})
 
 			It("returns an error", func() {
-				err = dataStore.Create(nil)
+				err = dataStore.Create(context.Background(), nil)
 				Expect(err).To(MatchError("create transaction: some-db-error"))
 			})
 		})
 
-		Context("when the createWithTx fails", func() {
+		Context("when the CreateWithTx fails", func() {
 			It("rollsback the transaction", func() {
 				fakeGroup := &fakes.GroupRepo{}
-				fakeGroup.CreateReturns(-1, errors.New("failed to create group"))
+				fakeGroup.CreateManyReturns(nil, errors.New("failed to create group"))
 
 				dataStore := store.New(mockDb, fakeGroup, destination, policy, 2)
 
-				err := dataStore.Create([]store.Policy{{}})
+				err := dataStore.Create(context.Background(), []store.Policy{{}})
 				Expect(err).To(MatchError("creating group: failed to create group"))
 				Expect(tx.RollbackCallCount()).To(Equal(1))
 			})
@@ -208,7 +303,7 @@ var _ = Describe("Store", func() {
 				tx.CommitReturns(errors.New("commit failure"))
 
 				dataStore := store.New(mockDb, fakeGroup, fakeDestination, fakePolicy, 2)
-				err := dataStore.Create([]store.Policy{{}})
+				err := dataStore.Create(context.Background(), []store.Policy{{}})
 				Expect(err).To(MatchError("commit transaction: commit failure"))
 			})
 		})
@@ -227,10 +322,10 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err := dataStore.Create(policies)
+				err := dataStore.Create(context.Background(), policies)
 				Expect(err).NotTo(HaveOccurred())
 
-				p, err := dataStore.All()
+				p, err := dataStore.All(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(p)).To(Equal(1))
 
@@ -246,10 +341,10 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err = dataStore.Create(policyDuplicate)
+				err = dataStore.Create(context.Background(), policyDuplicate)
 				Expect(err).NotTo(HaveOccurred())
 
-				p, err = dataStore.All()
+				p, err = dataStore.All(context.Background())
 				Expect(err).NotTo(HaveOccurred())
 				Expect(len(p)).To(Equal(1))
 			})
@@ -268,10 +363,10 @@ var _ = Describe("Store", func() {
 						},
 					})
 				}
-				err := dataStore.Create(policies)
+				err := dataStore.Create(context.Background(), policies)
 				Expect(err).NotTo(HaveOccurred())
 
-				Expect(dataStore.All()).To(HaveLen(255))
+				Expect(dataStore.All(context.Background())).To(HaveLen(255))
 			})
 			It("returns an error", func() {
 				policies := []store.Policy{{
@@ -283,7 +378,7 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err := dataStore.Create(policies)
+				err := dataStore.Create(context.Background(), policies)
 				Expect(err).To(MatchError(ContainSubstring("failed to find available tag")))
 			})
 		})
@@ -306,7 +401,7 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err := dataStore.Create(policies)
+				err := dataStore.Create(context.Background(), policies)
 				Expect(err).NotTo(HaveOccurred())
 
 				tags, err := tagDataStore.Tags()
@@ -317,7 +412,7 @@ var _ = Describe("Store", func() {
 					{ID: "another-app-guid", Tag: "03", Type: "app"},
 				}))
 
-				err = dataStore.Delete(policies[:1])
+				err = dataStore.Delete(context.Background(), policies[:1])
 				Expect(err).NotTo(HaveOccurred())
 
 				newPolicies := []store.Policy{{
@@ -329,7 +424,7 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err = dataStore.Create(newPolicies)
+				err = dataStore.Create(context.Background(), newPolicies)
 				Expect(err).NotTo(HaveOccurred())
 
 				Expect(err).NotTo(HaveOccurred())
@@ -344,13 +439,13 @@ var _ = Describe("Store", func() {
 			})
 		})
 
-		Context("when a Group create record fails", func() {
+		Context("when creating the groups fails", func() {
 			var fakeGroup *fakes.GroupRepo
 			var err error
 
 			BeforeEach(func() {
 				fakeGroup = &fakes.GroupRepo{}
-				fakeGroup.CreateReturns(-1, errors.New("some-insert-error"))
+				fakeGroup.CreateManyReturns(nil, errors.New("some-insert-error"))
 				migrateAndPopulateTags(realDb, 2)
 
 				dataStore = store.New(realDb, fakeGroup, destination, policy, 2)
@@ -366,61 +461,33 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err = dataStore.Create(policies)
+				err = dataStore.Create(context.Background(), policies)
 
 				Expect(err).To(MatchError("creating group: some-insert-error"))
 			})
 
-		})
-
-		Context("when the second create group fails", func() {
-			var fakeGroup *fakes.GroupRepo
-			var err error
-
-			BeforeEach(func() {
-				fakeGroup = &fakes.GroupRepo{}
-				type response struct {
-					Id  int
-					Err error
+			It("creates the source and destination groups in a single call, in policy order", func() {
+				policies := []store.Policy{
+					{Source: store.Source{ID: "app-a"}, Destination: store.Destination{ID: "app-b"}},
+					{Source: store.Source{ID: "app-c"}, Destination: store.Destination{ID: "app-a"}},
 				}
 
-				responses := []response{
-					{2, nil},
-					{-1, errors.New("some-insert-error")},
-				}
-				fakeGroup.CreateStub = func(t db.Transaction, guid, groupType string) (int, error) {
-					response := responses[0]
-					responses = responses[1:]
-					return response.Id, response.Err
-				}
+				dataStore.Create(context.Background(), policies)
 
-				migrateAndPopulateTags(realDb, 2)
-				dataStore = store.New(realDb, fakeGroup, destination, policy, 2)
-			})
-
-			It("returns the error", func() {
-				policies := []store.Policy{{
-					Source: store.Source{ID: "some-app-guid"},
-					Destination: store.Destination{
-						ID:       "some-other-app-guid",
-						Protocol: "tcp",
-						Port:     8080,
-					},
-				}}
-
-				err = dataStore.Create(policies)
-
-				Expect(err).To(MatchError("creating group: some-insert-error"))
+				Expect(fakeGroup.CreateManyCallCount()).To(Equal(1))
+				_, guids, groupType := fakeGroup.CreateManyArgsForCall(0)
+				Expect(guids).To(Equal([]string{"app-a", "app-b", "app-c", "app-a"}))
+				Expect(groupType).To(Equal("app"))
 			})
 		})
 
-		Context("when a Destination create record fails", func() {
+		Context("when creating the destinations fails", func() {
 			var fakeDestination *fakes.DestinationRepo
 			var err error
 
 			BeforeEach(func() {
 				fakeDestination = &fakes.DestinationRepo{}
-				fakeDestination.CreateReturns(-1, errors.New("some-insert-error"))
+				fakeDestination.CreateManyReturns(nil, errors.New("some-insert-error"))
 
 				migrateAndPopulateTags(realDb, 2)
 				dataStore = store.New(realDb, group, fakeDestination, policy, 2)
@@ -436,7 +503,7 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err = dataStore.Create(policies)
+				err = dataStore.Create(context.Background(), policies)
 
 				Expect(err).To(MatchError("creating destination: some-insert-error"))
 				var groupsCount int
@@ -445,13 +512,13 @@ var _ = Describe("Store", func() {
 			})
 		})
 
-		Context("when a Policy create record fails", func() {
+		Context("when creating the policies fails", func() {
 			var fakePolicy *fakes.PolicyRepo
 			var err error
 
 			BeforeEach(func() {
 				fakePolicy = &fakes.PolicyRepo{}
-				fakePolicy.CreateReturns(errors.New("some-insert-error"))
+				fakePolicy.CreateManyReturns(errors.New("some-insert-error"))
 
 				migrateAndPopulateTags(realDb, 2)
 				dataStore = store.New(realDb, group, destination, fakePolicy, 2)
@@ -467,11 +534,43 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err = dataStore.Create(policies)
+				err = dataStore.Create(context.Background(), policies)
 
 				Expect(err).To(MatchError("creating policy: some-insert-error"))
 			})
 		})
+
+		Context("when creating more policies than fit in one batch", func() {
+			It("creates all of them", func() {
+				migrateAndPopulateTags(realDb, 2)
+				dataStore = store.New(realDb, group, destination, policy, 2)
+
+				var policies []store.Policy
+				for i := 0; i < 150; i++ {
+					policies = append(policies, store.Policy{
+						Source: store.Source{ID: fmt.Sprintf("source-app-%d", i)},
+						Destination: store.Destination{
+							ID:       "some-destination-app",
+							Protocol: "tcp",
+							Port:     1000 + i,
+						},
+					})
+				}
+
+				Expect(dataStore.Create(context.Background(), policies)).To(Succeed())
+
+				allPolicies, err := dataStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allPolicies).To(HaveLen(150))
+
+				Expect(dataStore.Delete(context.Background(), policies)).To(Succeed())
+
+				var groupsCount int
+				err = realDb.QueryRow(`SELECT count(*) FROM groups WHERE guid IS NOT NULL`).Scan(&groupsCount)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(groupsCount).To(BeZero())
+			})
+		})
 	})
 
 	Describe("All", func() {
@@ -494,26 +593,62 @@ var _ = Describe("Store", func() {
 			migrateAndPopulateTags(realDb, 1)
 			dataStore = store.New(realDb, group, destination, policy, 1)
 
-			err = dataStore.Create(expectedPolicies)
+			err = dataStore.Create(context.Background(), expectedPolicies)
 			Expect(err).NotTo(HaveOccurred())
 
 		})
 
 		It("returns all containers that have been added", func() {
-			policies, err := dataStore.All()
+			policies, err := dataStore.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(policies).To(ConsistOf(expectedPolicies))
 		})
 
+		Context("when a policy has an expiry", func() {
+			var expiresAt time.Time
+
+			BeforeEach(func() {
+				expiresAt = time.Date(2030, time.January, 1, 12, 0, 0, 0, time.UTC)
+				err := dataStore.Create(context.Background(), []store.Policy{{
+					Source: store.Source{ID: "some-expiring-app-guid"},
+					Destination: store.Destination{
+						ID:       "some-other-app-guid",
+						Protocol: "udp",
+						Ports: store.Ports{
+							Start: 7000,
+							End:   7000,
+						},
+					},
+					ExpiresAt: &expiresAt,
+				}})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the expiry", func() {
+				policies, err := dataStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(policies).To(HaveLen(2))
+
+				for _, policy := range policies {
+					if policy.Source.ID == "some-expiring-app-guid" {
+						Expect(policy.ExpiresAt).NotTo(BeNil())
+						Expect(*policy.ExpiresAt).To(BeTemporally("==", expiresAt))
+					} else {
+						Expect(policy.ExpiresAt).To(BeNil())
+					}
+				}
+			})
+		})
+
 		Context("when the db operation fails", func() {
 			BeforeEach(func() {
-				mockDb.QueryReturns(nil, errors.New("some query error"))
+				mockDb.QueryContextReturns(nil, errors.New("some query error"))
 			})
 
 			It("should return a sensible error", func() {
 				store := store.New(mockDb, group, destination, policy, 2)
 
-				_, err := store.All()
+				_, err := store.All(context.Background())
 				Expect(err).To(MatchError("listing all: some query error"))
 			})
 		})
@@ -531,7 +666,7 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err := dataStore.Create(expectedPolicies)
+				err := dataStore.Create(context.Background(), expectedPolicies)
 				Expect(err).NotTo(HaveOccurred())
 
 				store.New(realDb, group, destination, policy, 2)
@@ -539,7 +674,7 @@ var _ = Describe("Store", func() {
 				rows, err = realDb.Query(`select * from policies`)
 				Expect(err).NotTo(HaveOccurred())
 
-				mockDb.QueryReturns(rows, nil)
+				mockDb.QueryContextReturns(rows, nil)
 			})
 
 			AfterEach(func() {
@@ -548,7 +683,7 @@ var _ = Describe("Store", func() {
 
 			It("should return a sensible error", func() {
 				store := store.New(mockDb, group, destination, policy, 2)
-				_, err := store.All()
+				_, err := store.All(context.Background())
 				Expect(err).To(MatchError(ContainSubstring("listing all: sql: expected")))
 			})
 		})
@@ -631,7 +766,7 @@ var _ = Describe("Store", func() {
 
 			dataStore = store.New(realDb, group, destination, policy, 1)
 
-			err := dataStore.Create(allPolicies)
+			err := dataStore.Create(context.Background(), allPolicies)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -641,18 +776,18 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns an empty slice ", func() {
-				policies, err := dataStore.ByGuids(nil, nil, false)
+				policies, err := dataStore.ByGuids(context.Background(), nil, nil, false)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(policies).To(BeEmpty())
 
 				By("not making any queries")
-				Expect(mockDb.QueryCallCount()).To(Equal(0))
+				Expect(mockDb.QueryContextCallCount()).To(Equal(0))
 			})
 		})
 
 		Context("when srcGuids is provided", func() {
 			It("returns policies whose source is in srcGuids", func() {
-				policies, err := dataStore.ByGuids([]string{"app-guid-00", "app-guid-01"}, nil, false)
+				policies, err := dataStore.ByGuids(context.Background(), []string{"app-guid-00", "app-guid-01"}, nil, false)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(policies).To(ConsistOf(allPolicies[0], allPolicies[1]))
 			})
@@ -660,7 +795,7 @@ var _ = Describe("Store", func() {
 
 		Context("when destGuids is provided", func() {
 			It("returns policies whose destination is in destGuids", func() {
-				policies, err := dataStore.ByGuids(nil, []string{"app-guid-00", "app-guid-01"}, false)
+				policies, err := dataStore.ByGuids(context.Background(), nil, []string{"app-guid-00", "app-guid-01"}, false)
 				Expect(err).NotTo(HaveOccurred())
 				Expect(policies).To(ConsistOf(allPolicies[0], allPolicies[2]))
 			})
@@ -669,6 +804,7 @@ var _ = Describe("Store", func() {
 		Context("when srcGuids and destGuids are provided", func() {
 			It("returns policies that satisfy either srcGuids or destGuids", func() {
 				policies, err := dataStore.ByGuids(
+					context.Background(),
 					[]string{"app-guid-00", "app-guid-01"},
 					[]string{"app-guid-00", "app-guid-01"},
 					false,
@@ -683,6 +819,7 @@ var _ = Describe("Store", func() {
 		Context("when inSourceAndDest is true", func() {
 			It("returns policies that are in srcGuids and destGuids", func() {
 				policies, err := dataStore.ByGuids(
+					context.Background(),
 					[]string{"app-guid-00", "app-guid-01"},
 					[]string{"app-guid-00", "app-guid-01"},
 					true,
@@ -696,13 +833,14 @@ var _ = Describe("Store", func() {
 
 		Context("when the db operation fails", func() {
 			BeforeEach(func() {
-				mockDb.QueryReturns(nil, errors.New("some query error"))
+				mockDb.QueryContextReturns(nil, errors.New("some query error"))
 			})
 
 			It("should return a sensible error", func() {
 				store := store.New(mockDb, group, destination, policy, 2)
 
 				_, err = store.ByGuids(
+					context.Background(),
 					[]string{"does-not-matter"},
 					[]string{"does-not-matter"},
 					false,
@@ -724,14 +862,14 @@ var _ = Describe("Store", func() {
 					},
 				}}
 
-				err := dataStore.Create(expectedPolicies)
+				err := dataStore.Create(context.Background(), expectedPolicies)
 				Expect(err).NotTo(HaveOccurred())
 
 				store.New(realDb, group, destination, policy, 2)
 				rows, err = realDb.Query(`select * from policies`)
 				Expect(err).NotTo(HaveOccurred())
 
-				mockDb.QueryReturns(rows, nil)
+				mockDb.QueryContextReturns(rows, nil)
 			})
 
 			AfterEach(func() {
@@ -742,6 +880,7 @@ var _ = Describe("Store", func() {
 				store := store.New(mockDb, group, destination, policy, 2)
 
 				_, err = store.ByGuids(
+					context.Background(),
 					[]string{"does-not-matter"},
 					[]string{"does-not-matter"},
 					true,
@@ -758,10 +897,18 @@ var _ = Describe("Store", func() {
 		})
 
 		It("checks that the database exists", func() {
-			err := dataStore.CheckDatabase()
+			err := dataStore.CheckDatabase(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("cancels the query when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			err := dataStore.CheckDatabase(ctx)
+			Expect(err).To(MatchError(context.Canceled))
+		})
+
 		Context("when the database connection is closed", func() {
 			BeforeEach(func() {
 				if realDb != nil {
@@ -770,7 +917,7 @@ var _ = Describe("Store", func() {
 			})
 
 			It("returns an error", func() {
-				err := dataStore.CheckDatabase()
+				err := dataStore.CheckDatabase(context.Background())
 				Expect(err).To(HaveOccurred())
 				Expect(err).To(MatchError("sql: database is closed"))
 			})
@@ -803,12 +950,12 @@ var _ = Describe("Store", func() {
 				},
 			}
 
-			err := dataStore.Create(policies)
+			err := dataStore.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
 		It("deletes the specified policies", func() {
-			err := dataStore.Delete([]store.Policy{{
+			err := dataStore.Delete(context.Background(), []store.Policy{{
 				Source: store.Source{ID: "some-app-guid"},
 				Destination: store.Destination{
 					ID:       "some-other-app-guid",
@@ -818,7 +965,7 @@ var _ = Describe("Store", func() {
 			}})
 			Expect(err).NotTo(HaveOccurred())
 
-			policies, err := dataStore.All()
+			policies, err := dataStore.All(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(policies).To(Equal([]store.Policy{{
 				Source: store.Source{ID: "another-app-guid", Tag: "03"},
@@ -831,8 +978,28 @@ var _ = Describe("Store", func() {
 			}}))
 		})
 
+		It("bumps the policies revision", func() {
+			revisionStore := store.NewRevisionStore(realDb)
+			before, err := revisionStore.Revision()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = dataStore.Delete(context.Background(), []store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+				},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			after, err := revisionStore.Revision()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(after).To(Equal(before + 1))
+		})
+
 		It("deletes the tags if no longer referenced", func() {
-			err := dataStore.Delete([]store.Policy{{
+			err := dataStore.Delete(context.Background(), []store.Policy{{
 				Source: store.Source{ID: "some-app-guid"},
 				Destination: store.Destination{
 					ID:       "some-other-app-guid",
@@ -873,12 +1040,12 @@ var _ = Describe("Store", func() {
 				var err error
 
 				BeforeEach(func() {
-					mockDb.BeginxReturns(nil, errors.New("some-db-error"))
+					mockDb.BeginTxxReturns(nil, errors.New("some-db-error"))
 					dataStore = store.New(mockDb, group, destination, policy, 2)
 				})
 
 				It("returns an error", func() {
-					err = dataStore.Delete(nil)
+					err = dataStore.Delete(context.Background(), nil)
 					Expect(err).To(MatchError("create transaction: some-db-error"))
 				})
 			})
@@ -887,116 +1054,165 @@ var _ = Describe("Store", func() {
 				It("returns the error", func() {
 					tx.CommitReturns(errors.New("failed to commit"))
 					dataStore := store.New(mockDb, fakeGroup, fakeDestination, fakePolicy, 2)
-					err := dataStore.Delete([]store.Policy{{}})
+					err := dataStore.Delete(context.Background(), []store.Policy{{}})
 					Expect(err).To(MatchError("commit transaction: failed to commit"))
 				})
 			})
 
-			Context("when the deleteWithTx fails", func() {
+			Context("when the DeleteWithTx fails", func() {
 				It("rollsback the transaction", func() {
-					fakeGroup.GetIDReturns(-1, errors.New("failed to get id"))
+					fakeGroup.GetIDsReturns(nil, errors.New("failed to get ids"))
 					dataStore := store.New(mockDb, fakeGroup, fakeDestination, fakePolicy, 2)
 
-					err := dataStore.Delete([]store.Policy{{}})
-					Expect(err).To(MatchError("getting source id: failed to get id"))
+					err := dataStore.Delete(context.Background(), []store.Policy{{}})
+					Expect(err).To(MatchError("getting group ids: failed to get ids"))
 					Expect(tx.RollbackCallCount()).To(Equal(1))
 				})
 			})
 
-			Context("when getting the source id fails", func() {
-				Context("when the error is because the source does not exist", func() {
-					BeforeEach(func() {
-						fakeGroup.GetIDStub = func(db.Transaction, string) (int, error) {
-							if fakeGroup.GetIDCallCount() == 1 {
-								return -1, sql.ErrNoRows
-							}
-							return 0, nil
+			Context("when the groups and destinations exist", func() {
+				BeforeEach(func() {
+					fakeGroup.GetIDsStub = func(_ db.Transaction, guids []string) (map[string]int, error) {
+						ids := map[string]int{}
+						for i, guid := range guids {
+							ids[guid] = i + 1
 						}
+						return ids, nil
+					}
+					fakeDestination.GetIDsStub = func(_ db.Transaction, keys []store.DestinationKey) (map[store.DestinationKey]int, error) {
+						ids := map[store.DestinationKey]int{}
+						for i, key := range keys {
+							ids[key] = i + 1
+						}
+						return ids, nil
+					}
+				})
+
+				It("looks everything up and deletes in bulk", func() {
+					err = dataStore.Delete(context.Background(), []store.Policy{
+						{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear", Port: 8080}},
+						{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana", Port: 9090}},
 					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeGroup.GetIDsCallCount()).To(Equal(1))
+					_, guids := fakeGroup.GetIDsArgsForCall(0)
+					Expect(guids).To(Equal([]string{"peach", "pear", "apple", "banana"}))
+
+					Expect(fakeDestination.GetIDsCallCount()).To(Equal(1))
+					_, keys := fakeDestination.GetIDsArgsForCall(0)
+					Expect(keys).To(Equal([]store.DestinationKey{
+						{GroupID: 2, Port: 8080},
+						{GroupID: 4, Port: 9090},
+					}))
+
+					Expect(fakePolicy.DeleteManyCallCount()).To(Equal(1))
+					_, rows := fakePolicy.DeleteManyArgsForCall(0)
+					Expect(rows).To(Equal([]store.PolicyRow{
+						{GroupID: 1, DestinationID: 1},
+						{GroupID: 3, DestinationID: 2},
+					}))
+
+					_, destinationIDs := fakeDestination.DeleteManyArgsForCall(0)
+					Expect(destinationIDs).To(Equal([]int{1, 2}))
+
+					_, groupIDs := fakeGroup.DeleteManyArgsForCall(0)
+					Expect(groupIDs).To(Equal([]int{1, 2, 3, 4}))
+				})
 
-					It("swallows the error and continues", func() {
-						err = dataStore.Delete([]store.Policy{
-							{Source: store.Source{ID: "0"}},
-							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana"}},
-						})
-						Expect(err).NotTo(HaveOccurred())
-						Expect(fakeGroup.GetIDCallCount()).To(Equal(3))
+				It("keeps the destinations and groups that are still in use", func() {
+					fakePolicy.DestinationIDsInUseReturns(map[int]bool{1: true}, nil)
+					fakePolicy.GroupIDsInUseReturns(map[int]bool{1: true}, nil)
+					fakeDestination.GroupIDsInUseReturns(map[int]bool{2: true}, nil)
 
-						_, call0SourceID := fakeGroup.GetIDArgsForCall(0)
-						Expect(call0SourceID).To(Equal("0"))
+					err = dataStore.Delete(context.Background(), []store.Policy{
+						{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear", Port: 8080}},
+						{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana", Port: 9090}},
+					})
+					Expect(err).NotTo(HaveOccurred())
 
-						_, call1SourceID := fakeGroup.GetIDArgsForCall(1)
-						Expect(call1SourceID).To(Equal("apple"))
+					_, destinationIDs := fakeDestination.DeleteManyArgsForCall(0)
+					Expect(destinationIDs).To(Equal([]int{2}))
 
-						_, call2SourceID := fakeGroup.GetIDArgsForCall(2)
-						Expect(call2SourceID).To(Equal("banana"))
-					})
+					_, groupIDs := fakeGroup.DeleteManyArgsForCall(0)
+					Expect(groupIDs).To(Equal([]int{3, 4}))
 				})
 
-				Context("when the error is for any other reason", func() {
+				Context("when a source group does not exist", func() {
 					BeforeEach(func() {
-						fakeGroup.GetIDReturns(-1, errors.New("some-get-error"))
+						fakeGroup.GetIDsReturns(map[string]int{"pear": 2, "apple": 3, "banana": 4}, nil)
 					})
-					It("returns the error", func() {
-						err = dataStore.Delete([]store.Policy{{
-							Source: store.Source{ID: "some-app-guid"},
-							Destination: store.Destination{
-								ID:       "some-other-app-guid",
-								Protocol: "tcp",
-								Port:     8080,
-							},
-						}})
-						Expect(err).To(MatchError("getting source id: some-get-error"))
+
+					It("skips that policy and continues", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{
+							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear"}},
+							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana"}},
+						})
+						Expect(err).NotTo(HaveOccurred())
+
+						_, rows := fakePolicy.DeleteManyArgsForCall(0)
+						Expect(rows).To(Equal([]store.PolicyRow{{GroupID: 3, DestinationID: 2}}))
 					})
 				})
-			})
 
-			Context("when getting the destination group id fails", func() {
-				Context("when the error is because the destination group does not exist", func() {
+				Context("when a destination group does not exist", func() {
 					BeforeEach(func() {
-						fakeGroup.GetIDStub = func(db.Transaction, string) (int, error) {
-							if fakeGroup.GetIDCallCount() == 2 {
-								return -1, sql.ErrNoRows
-							}
-							return 0, nil
-						}
+						fakeGroup.GetIDsReturns(map[string]int{"peach": 1, "apple": 3, "banana": 4}, nil)
 					})
 
-					It("swallows the error and continues", func() {
-						err = dataStore.Delete([]store.Policy{
+					It("skips that policy and continues", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{
 							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear"}},
 							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana"}},
 						})
 						Expect(err).NotTo(HaveOccurred())
-						Expect(fakeGroup.GetIDCallCount()).To(Equal(4))
 
-						_, call0SourceID := fakeGroup.GetIDArgsForCall(0)
-						Expect(call0SourceID).To(Equal("peach"))
+						_, keys := fakeDestination.GetIDsArgsForCall(0)
+						Expect(keys).To(Equal([]store.DestinationKey{{GroupID: 4}}))
 
-						_, call1SourceID := fakeGroup.GetIDArgsForCall(1)
-						Expect(call1SourceID).To(Equal("pear"))
+						_, rows := fakePolicy.DeleteManyArgsForCall(0)
+						Expect(rows).To(Equal([]store.PolicyRow{{GroupID: 3, DestinationID: 1}}))
+					})
+				})
 
-						_, call2SourceID := fakeGroup.GetIDArgsForCall(2)
-						Expect(call2SourceID).To(Equal("apple"))
+				Context("when a destination does not exist", func() {
+					BeforeEach(func() {
+						fakeDestination.GetIDsReturns(map[store.DestinationKey]int{{GroupID: 4, Port: 9090}: 7}, nil)
+					})
 
-						_, call3SourceID := fakeGroup.GetIDArgsForCall(3)
-						Expect(call3SourceID).To(Equal("banana"))
+					It("skips that policy and continues", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{
+							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear", Port: 8080}},
+							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana", Port: 9090}},
+						})
+						Expect(err).NotTo(HaveOccurred())
 
-						Expect(fakeDestination.GetIDCallCount()).To(Equal(1))
+						_, rows := fakePolicy.DeleteManyArgsForCall(0)
+						Expect(rows).To(Equal([]store.PolicyRow{{GroupID: 3, DestinationID: 7}}))
 					})
 				})
 
-				Context("when the error is for any other reason", func() {
+				Context("when nothing matches", func() {
 					BeforeEach(func() {
-						fakeGroup.GetIDStub = func(db.Transaction, string) (int, error) {
-							if fakeGroup.GetIDCallCount() > 1 {
-								return -1, errors.New("some-get-error")
-							}
-							return 0, nil
-						}
+						fakeDestination.GetIDsReturns(map[store.DestinationKey]int{}, nil)
+					})
+
+					It("does not delete anything", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{
+							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear"}},
+						})
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakePolicy.DeleteManyCallCount()).To(Equal(0))
 					})
+				})
+
+				Context("when getting the destination ids fails", func() {
+					BeforeEach(func() {
+						fakeDestination.GetIDsReturns(nil, errors.New("some-dest-id-get-error"))
+					})
+
 					It("returns a error", func() {
-						err = dataStore.Delete([]store.Policy{{
+						err = dataStore.Delete(context.Background(), []store.Policy{{
 							Source: store.Source{ID: "some-app-guid"},
 							Destination: store.Destination{
 								ID:       "some-other-app-guid",
@@ -1004,39 +1220,35 @@ var _ = Describe("Store", func() {
 								Port:     8080,
 							},
 						}})
-						Expect(err).To(MatchError("getting destination group id: some-get-error"))
+						Expect(err).To(MatchError("getting destination ids: some-dest-id-get-error"))
 					})
 				})
-			})
 
-			Context("when getting the destination id fails", func() {
-				Context("when the error is because the destination does not exist", func() {
+				Context("when deleting the policies fails", func() {
 					BeforeEach(func() {
-						fakeDestination.GetIDStub = func(db.Transaction, int, int, int, int, string) (int, error) {
-							if fakeDestination.GetIDCallCount() == 1 {
-								return -1, sql.ErrNoRows
-							}
-							return 0, nil
-						}
+						fakePolicy.DeleteManyReturns(errors.New("some-delete-error"))
 					})
 
-					It("swallows the error and continues", func() {
-						err = dataStore.Delete([]store.Policy{
-							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear"}},
-							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana"}},
-						})
-						Expect(err).NotTo(HaveOccurred())
-						Expect(fakePolicy.DeleteCallCount()).To(Equal(1))
+					It("returns a error", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{{
+							Source: store.Source{ID: "some-app-guid"},
+							Destination: store.Destination{
+								ID:       "some-other-app-guid",
+								Protocol: "tcp",
+								Port:     8080,
+							},
+						}})
+						Expect(err).To(MatchError("deleting policy: some-delete-error"))
 					})
 				})
 
-				Context("when the error is for any other reason", func() {
+				Context("when finding the destinations in use fails", func() {
 					BeforeEach(func() {
-						fakeDestination.GetIDReturns(-1, errors.New("some-dest-id-get-error"))
+						fakePolicy.DestinationIDsInUseReturns(nil, errors.New("some-dst-count-error"))
 					})
 
 					It("returns a error", func() {
-						err = dataStore.Delete([]store.Policy{{
+						err = dataStore.Delete(context.Background(), []store.Policy{{
 							Source: store.Source{ID: "some-app-guid"},
 							Destination: store.Destination{
 								ID:       "some-other-app-guid",
@@ -1044,39 +1256,35 @@ var _ = Describe("Store", func() {
 								Port:     8080,
 							},
 						}})
-						Expect(err).To(MatchError("getting destination id: some-dest-id-get-error"))
+						Expect(err).To(MatchError("counting destination id: some-dst-count-error"))
 					})
 				})
-			})
 
-			Context("when deleting the policy fails", func() {
-				Context("when the error is because the policy does not exist", func() {
+				Context("when deleting the destinations fails", func() {
 					BeforeEach(func() {
-						fakePolicy.DeleteStub = func(db.Transaction, int, int) error {
-							if fakePolicy.DeleteCallCount() == 1 {
-								return sql.ErrNoRows
-							}
-							return nil
-						}
+						fakeDestination.DeleteManyReturns(errors.New("some-dst-delete-error"))
 					})
 
-					It("swallows the error and continues", func() {
-						err = dataStore.Delete([]store.Policy{
-							{Source: store.Source{ID: "peach"}, Destination: store.Destination{ID: "pear"}},
-							{Source: store.Source{ID: "apple"}, Destination: store.Destination{ID: "banana"}},
-						})
-						Expect(err).NotTo(HaveOccurred())
-						Expect(fakePolicy.DeleteCallCount()).To(Equal(2))
+					It("returns a error", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{{
+							Source: store.Source{ID: "some-app-guid"},
+							Destination: store.Destination{
+								ID:       "some-other-app-guid",
+								Protocol: "tcp",
+								Port:     8080,
+							},
+						}})
+						Expect(err).To(MatchError("deleting destination: some-dst-delete-error"))
 					})
 				})
 
-				Context("when the error is for any other reason", func() {
+				Context("when finding the groups used by policies fails", func() {
 					BeforeEach(func() {
-						fakePolicy.DeleteReturns(errors.New("some-delete-error"))
+						fakePolicy.GroupIDsInUseReturns(nil, errors.New("some-group-id-count-error"))
 					})
 
 					It("returns a error", func() {
-						err = dataStore.Delete([]store.Policy{{
+						err = dataStore.Delete(context.Background(), []store.Policy{{
 							Source: store.Source{ID: "some-app-guid"},
 							Destination: store.Destination{
 								ID:       "some-other-app-guid",
@@ -1084,98 +1292,44 @@ var _ = Describe("Store", func() {
 								Port:     8080,
 							},
 						}})
-						Expect(err).To(MatchError("deleting policy: some-delete-error"))
+						Expect(err).To(MatchError("deleting group row: some-group-id-count-error"))
 					})
 				})
-			})
 
-			Context("when counting policies by destination_id fails", func() {
-				BeforeEach(func() {
-					fakePolicy.CountWhereDestinationIDReturns(0, errors.New("some-dst-count-error"))
-				})
-
-				It("returns a error", func() {
-					err = dataStore.Delete([]store.Policy{{
-						Source: store.Source{ID: "some-app-guid"},
-						Destination: store.Destination{
-							ID:       "some-other-app-guid",
-							Protocol: "tcp",
-							Port:     8080,
-						},
-					}})
-					Expect(err).To(MatchError("counting destination id: some-dst-count-error"))
-				})
-			})
-
-			Context("when deleting a destination fails", func() {
-				BeforeEach(func() {
-					fakeDestination.DeleteReturns(errors.New("some-dst-delete-error"))
-				})
-
-				It("returns a error", func() {
-					err = dataStore.Delete([]store.Policy{{
-						Source: store.Source{ID: "some-app-guid"},
-						Destination: store.Destination{
-							ID:       "some-other-app-guid",
-							Protocol: "tcp",
-							Port:     8080,
-						},
-					}})
-					Expect(err).To(MatchError("deleting destination: some-dst-delete-error"))
-				})
-			})
-
-			Context("when counting policies by group_id fails", func() {
-				BeforeEach(func() {
-					fakePolicy.CountWhereGroupIDReturns(-1, errors.New("some-group-id-count-error"))
-				})
-
-				It("returns a error", func() {
-					err = dataStore.Delete([]store.Policy{{
-						Source: store.Source{ID: "some-app-guid"},
-						Destination: store.Destination{
-							ID:       "some-other-app-guid",
-							Protocol: "tcp",
-							Port:     8080,
-						},
-					}})
-					Expect(err).To(MatchError("deleting group row: some-group-id-count-error"))
-				})
-			})
-
-			Context("when counting destinations by group_id fails", func() {
-				BeforeEach(func() {
-					fakeDestination.CountWhereGroupIDReturns(-1, errors.New("some-dst-count-error"))
-				})
+				Context("when finding the groups used by destinations fails", func() {
+					BeforeEach(func() {
+						fakeDestination.GroupIDsInUseReturns(nil, errors.New("some-dst-count-error"))
+					})
 
-				It("returns a error", func() {
-					err = dataStore.Delete([]store.Policy{{
-						Source: store.Source{ID: "some-app-guid"},
-						Destination: store.Destination{
-							ID:       "some-other-app-guid",
-							Protocol: "tcp",
-							Port:     8080,
-						},
-					}})
-					Expect(err).To(MatchError("deleting group row: some-dst-count-error"))
+					It("returns a error", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{{
+							Source: store.Source{ID: "some-app-guid"},
+							Destination: store.Destination{
+								ID:       "some-other-app-guid",
+								Protocol: "tcp",
+								Port:     8080,
+							},
+						}})
+						Expect(err).To(MatchError("deleting group row: some-dst-count-error"))
+					})
 				})
-			})
 
-			Context("when deleting the group fails", func() {
-				BeforeEach(func() {
-					fakeGroup.DeleteReturns(errors.New("some-group-delete-error"))
-				})
+				Context("when deleting the groups fails", func() {
+					BeforeEach(func() {
+						fakeGroup.DeleteManyReturns(errors.New("some-group-delete-error"))
+					})
 
-				It("returns a error", func() {
-					err = dataStore.Delete([]store.Policy{{
-						Source: store.Source{ID: "some-app-guid"},
-						Destination: store.Destination{
-							ID:       "some-other-app-guid",
-							Protocol: "tcp",
-							Port:     8080,
-						},
-					}})
-					Expect(err).To(MatchError("deleting group row: some-group-delete-error"))
+					It("returns a error", func() {
+						err = dataStore.Delete(context.Background(), []store.Policy{{
+							Source: store.Source{ID: "some-app-guid"},
+							Destination: store.Destination{
+								ID:       "some-other-app-guid",
+								Protocol: "tcp",
+								Port:     8080,
+							},
+						}})
+						Expect(err).To(MatchError("deleting group row: some-group-delete-error"))
+					})
 				})
 			})
 		})
@@ -1,7 +1,9 @@
 package store
 
 import (
+	"context"
 	"fmt"
+	"policy-server/store/helpers"
 
 	"code.cloudfoundry.org/cf-networking-helpers/db"
 )
@@ -10,6 +12,8 @@ import (
 type TagStore interface {
 	CreateTag(string, string) (Tag, error)
 	Tags() ([]Tag, error)
+	ReleaseTags([]string) (int64, error)
+	TagCapacity() (TagCapacity, error)
 }
 
 type tagStore struct {
@@ -86,10 +90,63 @@ func (s *tagStore) Tags() ([]Tag, error) {
 	return tags, nil
 }
 
+// ReleaseTags frees the tags of the given groups so they can be allocated
+// again. Tags still referenced by a c2c policy are left alone.
+func (s *tagStore) ReleaseTags(groupGuids []string) (int64, error) {
+	if len(groupGuids) == 0 {
+		return 0, nil
+	}
+
+	result, err := s.conn.Exec(s.conn.Rebind(`
+		UPDATE groups SET guid = NULL, type = NULL
+		WHERE guid IN (`+helpers.QuestionMarks(len(groupGuids))+`)
+		AND NOT EXISTS (SELECT 1 FROM policies WHERE policies.group_id = groups.id)
+		AND NOT EXISTS (SELECT 1 FROM destinations WHERE destinations.group_id = groups.id)
+	`), convertToInterfaceSlice(groupGuids)...)
+	if err != nil {
+		return 0, fmt.Errorf("releasing tags: %s", err)
+	}
+
+	released, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("releasing tags: %s", err) // untested
+	}
+	return released, nil
+}
+
+func (s *tagStore) TagCapacity() (TagCapacity, error) {
+	var total, used int
+	err := s.conn.QueryRow(`SELECT COUNT(*), COUNT(guid) FROM groups`).Scan(&total, &used)
+	if err != nil {
+		return TagCapacity{}, fmt.Errorf("counting tags: %s", err)
+	}
+
+	return TagCapacity{
+		Total: total,
+		Free:  total - used,
+	}, nil
+}
+
 func (s *tagStore) tagIntToString(tag int) string {
 	return fmt.Sprintf("%"+fmt.Sprintf("0%d", s.tagLength*2)+"X", tag)
 }
 
+// beginx begins a transaction bound to ctx. database/sql rolls it back as
+// soon as ctx is done, so a statement that is still running when a request
+// is cancelled or times out is abandoned rather than left to finish.
+func beginx(ctx context.Context, conn Database) (db.Transaction, error) {
+	return conn.BeginTxx(ctx, nil)
+}
+
+// commitContext commits tx unless ctx is done, so that a request that has
+// been cancelled or has timed out never commits.
+func commitContext(ctx context.Context, tx db.Transaction) error {
+	if err := ctx.Err(); err != nil {
+		return rollback(tx, err)
+	}
+	return commit(tx)
+}
+
 func commit(tx db.Transaction) error {
 	err := tx.Commit()
 	if err != nil {
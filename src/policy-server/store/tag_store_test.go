@@ -1,6 +1,7 @@
 package store_test
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -181,7 +182,7 @@ var _ = Describe("TagStore", func() {
 				},
 			}}
 
-			err := dataStore.Create(policies)
+			err := dataStore.Create(context.Background(), policies)
 			Expect(err).NotTo(HaveOccurred())
 		})
 
@@ -231,4 +232,80 @@ var _ = Describe("TagStore", func() {
 			})
 		})
 	})
+
+	Describe("ReleaseTags", func() {
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength)
+			dataStore = store.New(realDb, group, destination, policy, 1)
+
+			err := dataStore.Create(context.Background(), []store.Policy{{
+				Source: store.Source{ID: "some-app-guid"},
+				Destination: store.Destination{
+					ID:       "some-other-app-guid",
+					Protocol: "tcp",
+					Port:     8080,
+				},
+			}})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = tagStore.CreateTag("dead-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("frees the tags of groups without policies", func() {
+			released, err := tagStore.ReleaseTags([]string{"dead-app-guid", "some-app-guid", "some-other-app-guid"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(Equal(int64(1)))
+
+			tags, err := tagStore.Tags()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tags).To(ConsistOf([]store.Tag{
+				{ID: "some-app-guid", Tag: "01", Type: "app"},
+				{ID: "some-other-app-guid", Tag: "02", Type: "app"},
+			}))
+		})
+
+		It("makes the freed tag available again", func() {
+			_, err := tagStore.ReleaseTags([]string{"dead-app-guid"})
+			Expect(err).NotTo(HaveOccurred())
+
+			tag, err := tagStore.CreateTag("new-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tag.Tag).To(Equal("03"))
+		})
+
+		It("does nothing for an empty list", func() {
+			released, err := tagStore.ReleaseTags([]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(released).To(Equal(int64(0)))
+		})
+
+		Context("when the db operation fails", func() {
+			BeforeEach(func() {
+				mockDb.ExecReturns(nil, errors.New("some exec error"))
+			})
+
+			It("should return a sensible error", func() {
+				store := store.NewTagStore(mockDb, group, tagLength)
+
+				_, err := store.ReleaseTags([]string{"dead-app-guid"})
+				Expect(err).To(MatchError("releasing tags: some exec error"))
+			})
+		})
+	})
+
+	Describe("TagCapacity", func() {
+		BeforeEach(func() {
+			tagStore = store.NewTagStore(realDb, group, tagLength)
+
+			_, err := tagStore.CreateTag("some-app-guid", "app")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the total and free number of tags", func() {
+			capacity, err := tagStore.TagCapacity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(capacity).To(Equal(store.TagCapacity{Total: 255, Free: 254}))
+		})
+	})
 })
@@ -0,0 +1,426 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"policy-server/store/helpers"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	TombstoneTypeC2C    = "c2c"
+	TombstoneTypeEgress = "egress"
+)
+
+//go:generate counterfeiter -o fakes/tombstone_store.go --fake-name TombstoneStore . TombstoneStore
+type TombstoneStore interface {
+	Create([]Policy, []EgressPolicy) error
+	All() ([]Tombstone, error)
+	ByGuids([]string) ([]Tombstone, error)
+	ByIDs(...int) ([]Tombstone, error)
+	Delete(...int) error
+	DeleteOlderThan(time.Time) (int64, error)
+	DeleteWithTombstones(context.Context, []Policy, []EgressPolicy) error
+	Restore(context.Context, ...int) (TombstoneRestore, error)
+}
+
+//go:generate counterfeiter -o fakes/policy_tx_store.go --fake-name PolicyTxStore . policyTxStore
+type policyTxStore interface {
+	CreateWithTx(tx db.Transaction, policies []Policy) error
+	DeleteWithTx(tx db.Transaction, policies []Policy) error
+}
+
+//go:generate counterfeiter -o fakes/egress_policy_tx_store.go --fake-name EgressPolicyTxStore . egressPolicyTxStore
+type egressPolicyTxStore interface {
+	CreateWithTx(tx db.Transaction, policies []EgressPolicy) ([]EgressPolicy, error)
+	DeleteWithTx(tx db.Transaction, egressPolicyGUIDs ...string) ([]EgressPolicy, error)
+}
+
+// TombstoneRestore lists the tombstones whose policies were restored, and
+// those that were skipped because their policy conflicts with the current
+// ones: an identical policy exists, or the egress destination is gone.
+// Skipped tombstones are kept.
+type TombstoneRestore struct {
+	Restored []Tombstone
+	Skipped  []Tombstone
+}
+
+type tombstoneStore struct {
+	conn              Database
+	policyStore       policyTxStore
+	egressPolicyStore egressPolicyTxStore
+}
+
+func NewTombstoneStore(dbConnectionPool Database, policyStore policyTxStore, egressPolicyStore egressPolicyTxStore) *tombstoneStore {
+	return &tombstoneStore{
+		conn:              dbConnectionPool,
+		policyStore:       policyStore,
+		egressPolicyStore: egressPolicyStore,
+	}
+}
+
+func (t *tombstoneStore) Create(policies []Policy, egressPolicies []EgressPolicy) error {
+	tx, err := t.conn.Beginx()
+	if err != nil {
+		return fmt.Errorf("begin transaction: %s", err)
+	}
+
+	err = t.createWithTx(tx, policies, egressPolicies)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	return commit(tx)
+}
+
+// DeleteWithTombstones deletes the policies and records tombstones for them
+// in a single transaction, so that a policy is never deleted without a way
+// to restore it.
+func (t *tombstoneStore) DeleteWithTombstones(ctx context.Context, policies []Policy, egressPolicies []EgressPolicy) error {
+	tx, err := beginx(ctx, t.conn)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %s", err)
+	}
+
+	err = t.createWithTx(tx, policies, egressPolicies)
+	if err != nil {
+		return rollback(tx, err)
+	}
+
+	if len(policies) > 0 {
+		err = t.policyStore.DeleteWithTx(tx, policies)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("deleting policies: %s", err))
+		}
+	}
+
+	if len(egressPolicies) > 0 {
+		egressPolicyGUIDs := make([]string, len(egressPolicies))
+		for i, egressPolicy := range egressPolicies {
+			egressPolicyGUIDs[i] = egressPolicy.ID
+		}
+
+		_, err = t.egressPolicyStore.DeleteWithTx(tx, egressPolicyGUIDs...)
+		if err != nil {
+			return rollback(tx, fmt.Errorf("deleting egress policies: %s", err))
+		}
+	}
+
+	return commitContext(ctx, tx)
+}
+
+// Restore recreates the policies of the tombstones with the given ids and
+// deletes those tombstones in a single transaction. Tombstones whose policy
+// conflicts with the current policies, or has already expired, are skipped
+// and kept.
+func (t *tombstoneStore) Restore(ctx context.Context, ids ...int) (TombstoneRestore, error) {
+	restore := TombstoneRestore{Restored: []Tombstone{}, Skipped: []Tombstone{}}
+	if len(ids) == 0 {
+		return restore, nil
+	}
+
+	tx, err := beginx(ctx, t.conn)
+	if err != nil {
+		return TombstoneRestore{}, fmt.Errorf("begin transaction: %s", err)
+	}
+
+	rows, err := tx.Queryx(tx.Rebind(`
+		SELECT id, policy_type, payload, deleted_at FROM tombstones
+		WHERE id IN (`+helpers.QuestionMarks(len(ids))+`)
+		ORDER BY id`), intsToInterfaceSlice(ids)...)
+	if err != nil {
+		return TombstoneRestore{}, rollback(tx, fmt.Errorf("listing tombstones: %s", err))
+	}
+	tombstones, err := convertRowsToTombstones(rows)
+	if err != nil {
+		return TombstoneRestore{}, rollback(tx, err)
+	}
+
+	var (
+		policies       []Policy
+		egressPolicies []EgressPolicy
+		restoredIDs    []int
+	)
+	now := time.Now()
+	for _, tombstone := range tombstones {
+		if tombstoneExpired(tombstone, now) {
+			restore.Skipped = append(restore.Skipped, tombstone)
+			continue
+		}
+
+		conflict, err := tombstoneConflicts(tx, tombstone)
+		if err != nil {
+			return TombstoneRestore{}, rollback(tx, fmt.Errorf("checking tombstone %d: %s", tombstone.ID, err))
+		}
+		if conflict {
+			restore.Skipped = append(restore.Skipped, tombstone)
+			continue
+		}
+
+		if tombstone.Policy != nil {
+			policies = append(policies, *tombstone.Policy)
+		}
+		if tombstone.EgressPolicy != nil {
+			egressPolicies = append(egressPolicies, *tombstone.EgressPolicy)
+		}
+		restore.Restored = append(restore.Restored, tombstone)
+		restoredIDs = append(restoredIDs, tombstone.ID)
+	}
+
+	if len(restoredIDs) == 0 {
+		return restore, commitContext(ctx, tx)
+	}
+
+	// Deleting the tombstones first means that a concurrent restore of the
+	// same tombstones waits for this one and then finds them gone, rather
+	// than creating the policies twice.
+	result, err := tx.Exec(tx.Rebind(`DELETE FROM tombstones WHERE id IN (`+helpers.QuestionMarks(len(restoredIDs))+`)`),
+		intsToInterfaceSlice(restoredIDs)...)
+	if err != nil {
+		return TombstoneRestore{}, rollback(tx, fmt.Errorf("deleting tombstones: %s", err))
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return TombstoneRestore{}, rollback(tx, fmt.Errorf("deleting tombstones: %s", err)) // untested
+	}
+	if deleted != int64(len(restoredIDs)) {
+		return TombstoneRestore{}, rollback(tx, errors.New("deleting tombstones: tombstones were restored concurrently"))
+	}
+
+	if len(policies) > 0 {
+		err = t.policyStore.CreateWithTx(tx, policies)
+		if err != nil {
+			return TombstoneRestore{}, rollback(tx, fmt.Errorf("restoring policies: %s", err))
+		}
+	}
+
+	if len(egressPolicies) > 0 {
+		_, err = t.egressPolicyStore.CreateWithTx(tx, egressPolicies)
+		if err != nil {
+			return TombstoneRestore{}, rollback(tx, fmt.Errorf("restoring egress policies: %s", err))
+		}
+	}
+
+	return restore, commitContext(ctx, tx)
+}
+
+func (t *tombstoneStore) createWithTx(tx db.Transaction, policies []Policy, egressPolicies []EgressPolicy) error {
+	deletedAt := time.Now().UTC()
+	insert := tx.Rebind(`
+		INSERT INTO tombstones (policy_type, source_id, destination_id, payload, deleted_at)
+		VALUES (?, ?, ?, ?, ?)`)
+
+	for _, policy := range policies {
+		policy.Source.Tag = ""
+		policy.Destination.Tag = ""
+		payload, err := json.Marshal(policy)
+		if err != nil {
+			return fmt.Errorf("marshal policy: %s", err)
+		}
+
+		_, err = tx.Exec(insert, TombstoneTypeC2C, policy.Source.ID, policy.Destination.ID, string(payload), deletedAt)
+		if err != nil {
+			return fmt.Errorf("creating tombstone: %s", err)
+		}
+	}
+
+	for _, egressPolicy := range egressPolicies {
+		payload, err := json.Marshal(egressPolicy)
+		if err != nil {
+			return fmt.Errorf("marshal egress policy: %s", err)
+		}
+
+		_, err = tx.Exec(insert, TombstoneTypeEgress, egressPolicy.Source.ID, egressPolicy.Destination.GUID, string(payload), deletedAt)
+		if err != nil {
+			return fmt.Errorf("creating tombstone: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func (t *tombstoneStore) All() ([]Tombstone, error) {
+	return t.tombstonesQuery(`ORDER BY id`)
+}
+
+func (t *tombstoneStore) ByGuids(guids []string) ([]Tombstone, error) {
+	if len(guids) == 0 {
+		return []Tombstone{}, nil
+	}
+
+	questionMarks := helpers.QuestionMarks(len(guids))
+	args := append(convertToInterfaceSlice(guids), convertToInterfaceSlice(guids)...)
+	return t.tombstonesQuery(`
+		WHERE source_id IN (`+questionMarks+`)
+		OR destination_id IN (`+questionMarks+`)
+		ORDER BY id`, args...)
+}
+
+func (t *tombstoneStore) ByIDs(ids ...int) ([]Tombstone, error) {
+	if len(ids) == 0 {
+		return []Tombstone{}, nil
+	}
+
+	return t.tombstonesQuery(`
+		WHERE id IN (`+helpers.QuestionMarks(len(ids))+`)
+		ORDER BY id`, intsToInterfaceSlice(ids)...)
+}
+
+func (t *tombstoneStore) Delete(ids ...int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := t.conn.Exec(t.conn.Rebind(`DELETE FROM tombstones WHERE id IN (`+helpers.QuestionMarks(len(ids))+`)`),
+		intsToInterfaceSlice(ids)...)
+	if err != nil {
+		return fmt.Errorf("deleting tombstones: %s", err)
+	}
+	return nil
+}
+
+func (t *tombstoneStore) DeleteOlderThan(cutoff time.Time) (int64, error) {
+	result, err := t.conn.Exec(t.conn.Rebind(`DELETE FROM tombstones WHERE deleted_at < ?`), cutoff.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired tombstones: %s", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("deleting expired tombstones: %s", err) // untested
+	}
+	return deleted, nil
+}
+
+func (t *tombstoneStore) tombstonesQuery(where string, args ...interface{}) ([]Tombstone, error) {
+	rows, err := t.conn.RawConnection().Queryx(t.conn.Rebind(`
+		SELECT id, policy_type, payload, deleted_at FROM tombstones `+where), args...)
+	if err != nil {
+		return nil, fmt.Errorf("listing tombstones: %s", err)
+	}
+
+	return convertRowsToTombstones(rows)
+}
+
+func convertRowsToTombstones(rows *sqlx.Rows) ([]Tombstone, error) {
+	defer rows.Close() // untested
+
+	tombstones := []Tombstone{}
+	for rows.Next() {
+		var (
+			id         int
+			policyType string
+			payload    string
+			deletedAt  time.Time
+		)
+
+		err := rows.Scan(&id, &policyType, &payload, &deletedAt)
+		if err != nil {
+			return nil, fmt.Errorf("listing tombstones: %s", err)
+		}
+
+		tombstone := Tombstone{
+			ID:        id,
+			Type:      policyType,
+			DeletedAt: deletedAt,
+		}
+
+		switch policyType {
+		case TombstoneTypeC2C:
+			tombstone.Policy = &Policy{}
+			err = json.Unmarshal([]byte(payload), tombstone.Policy)
+		case TombstoneTypeEgress:
+			tombstone.EgressPolicy = &EgressPolicy{}
+			err = json.Unmarshal([]byte(payload), tombstone.EgressPolicy)
+		default:
+			err = fmt.Errorf("unknown policy type: %s", policyType)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing tombstone %d: %s", id, err)
+		}
+
+		tombstones = append(tombstones, tombstone)
+	}
+
+	err := rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("listing tombstones, getting next row: %s", err) // untested
+	}
+
+	return tombstones, nil
+}
+
+// tombstoneExpired reports whether the tombstone's policy expired before it
+// could be restored.
+func tombstoneExpired(tombstone Tombstone, now time.Time) bool {
+	var expiresAt *time.Time
+	if tombstone.Policy != nil {
+		expiresAt = tombstone.Policy.ExpiresAt
+	} else {
+		expiresAt = tombstone.EgressPolicy.ExpiresAt
+	}
+	return expiresAt != nil && !expiresAt.After(now)
+}
+
+// tombstoneConflicts reports whether restoring the tombstone's policy would
+// duplicate a current policy, or bind an egress policy to a destination that
+// has since been deleted. An egress policy conflicts with any current policy
+// between the same source and destination, whatever its action, since there
+// can only be one of them.
+func tombstoneConflicts(tx db.Transaction, tombstone Tombstone) (bool, error) {
+	if tombstone.Policy != nil {
+		policy := tombstone.Policy
+		return rowsExist(tx, `
+			SELECT COUNT(*) FROM policies
+			JOIN groups AS src_grp ON (policies.group_id = src_grp.id)
+			JOIN destinations ON (destinations.id = policies.destination_id)
+			JOIN groups AS dst_grp ON (destinations.group_id = dst_grp.id)
+			WHERE src_grp.guid = ? AND dst_grp.guid = ? AND destinations.protocol = ?
+			AND destinations.start_port = ? AND destinations.end_port = ?`,
+			policy.Source.ID, policy.Destination.ID, policy.Destination.Protocol,
+			policy.Destination.Ports.Start, policy.Destination.Ports.End)
+	}
+
+	egressPolicy := tombstone.EgressPolicy
+	destinationExists, err := rowsExist(tx, `SELECT COUNT(*) FROM terminals WHERE guid = ?`, egressPolicy.Destination.GUID)
+	if err != nil || !destinationExists {
+		return !destinationExists, err
+	}
+
+	query := `
+		SELECT COUNT(*) FROM egress_policies
+		LEFT OUTER JOIN apps ON (egress_policies.source_guid = apps.terminal_guid)
+		LEFT OUTER JOIN spaces ON (egress_policies.source_guid = spaces.terminal_guid)
+		LEFT OUTER JOIN orgs ON (egress_policies.source_guid = orgs.terminal_guid)
+		LEFT OUTER JOIN default_sources ON (egress_policies.source_guid = default_sources.terminal_guid)
+		WHERE egress_policies.destination_guid = ? AND `
+	args := []interface{}{egressPolicy.Destination.GUID}
+	switch egressPolicy.Source.Type {
+	case "default":
+		query += `default_sources.terminal_guid IS NOT NULL`
+	case "org":
+		query += `orgs.org_guid = ?`
+		args = append(args, egressPolicy.Source.ID)
+	case "space":
+		query += `spaces.space_guid = ?`
+		args = append(args, egressPolicy.Source.ID)
+	default:
+		query += `apps.app_guid = ?`
+		args = append(args, egressPolicy.Source.ID)
+	}
+	return rowsExist(tx, query, args...)
+}
+
+func rowsExist(tx db.Transaction, query string, args ...interface{}) (bool, error) {
+	var count int
+	err := tx.QueryRow(tx.Rebind(query), args...).Scan(&count)
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,434 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+	dbfakes "code.cloudfoundry.org/cf-networking-helpers/db/fakes"
+	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
+
+	"code.cloudfoundry.org/lager"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TombstoneStore", func() {
+	var (
+		dbConf            db.Config
+		realDb            *db.ConnWrapper
+		tombstoneStore    store.TombstoneStore
+		policyStore       store.Store
+		egressPolicyStore *store.EgressPolicyStore
+		policies          []store.Policy
+		egressPolicies    []store.EgressPolicy
+	)
+
+	BeforeEach(func() {
+		dbConf = testsupport.GetDBConfig()
+		dbConf.DatabaseName = fmt.Sprintf("tombstone_store_test_node_%d", time.Now().UnixNano())
+
+		testsupport.CreateDatabase(dbConf)
+
+		logger := lager.NewLogger("Tombstone Store Test")
+
+		var err error
+		realDb, err = db.NewConnectionPool(dbConf, 200, 200, 5*time.Minute, "Tombstone Store Test", "Tombstone Store Test", logger)
+		Expect(err).NotTo(HaveOccurred())
+
+		migrateAndPopulateTags(realDb, 1)
+
+		c2cStore := store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1)
+		policyStore = c2cStore
+		egressPolicyStore = &store.EgressPolicyStore{
+			TerminalsRepo:    &store.TerminalsTable{Guids: &store.GuidGenerator{}},
+			EgressPolicyRepo: &store.EgressPolicyTable{Conn: realDb, Guids: &store.GuidGenerator{}},
+			Conn:             realDb,
+		}
+		tombstoneStore = store.NewTombstoneStore(realDb, c2cStore, egressPolicyStore)
+
+		policies = []store.Policy{{
+			Source: store.Source{ID: "app-a", Tag: "01"},
+			Destination: store.Destination{
+				ID:       "app-b",
+				Tag:      "02",
+				Protocol: "tcp",
+				Port:     8080,
+				Ports:    store.Ports{Start: 8080, End: 8080},
+			},
+		}}
+		egressPolicies = []store.EgressPolicy{{
+			ID:     "egress-policy-guid",
+			Source: store.EgressSource{ID: "app-c", Type: "app"},
+			Destination: store.EgressDestination{
+				GUID:     "destination-guid",
+				Protocol: "tcp",
+				IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.2"}},
+			},
+			Action: "allow",
+		}}
+	})
+
+	AfterEach(func() {
+		if realDb != nil {
+			Expect(realDb.Close()).To(Succeed())
+		}
+		testsupport.RemoveDatabase(dbConf)
+	})
+
+	Describe("Create and All", func() {
+		It("stores the deleted policies without their tags", func() {
+			Expect(tombstoneStore.Create(policies, egressPolicies)).To(Succeed())
+
+			tombstones, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(HaveLen(2))
+
+			Expect(tombstones[0].Type).To(Equal("c2c"))
+			Expect(tombstones[0].EgressPolicy).To(BeNil())
+			Expect(*tombstones[0].Policy).To(Equal(store.Policy{
+				Source: store.Source{ID: "app-a"},
+				Destination: store.Destination{
+					ID:       "app-b",
+					Protocol: "tcp",
+					Port:     8080,
+					Ports:    store.Ports{Start: 8080, End: 8080},
+				},
+			}))
+			Expect(tombstones[0].DeletedAt).To(BeTemporally("~", time.Now(), time.Minute))
+
+			Expect(tombstones[1].Type).To(Equal("egress"))
+			Expect(tombstones[1].Policy).To(BeNil())
+			Expect(*tombstones[1].EgressPolicy).To(Equal(egressPolicies[0]))
+		})
+	})
+
+	Describe("ByGuids", func() {
+		BeforeEach(func() {
+			Expect(tombstoneStore.Create(policies, egressPolicies)).To(Succeed())
+		})
+
+		It("returns tombstones where the guid is the source or the destination", func() {
+			tombstones, err := tombstoneStore.ByGuids([]string{"app-b"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(HaveLen(1))
+			Expect(tombstones[0].Policy.Source.ID).To(Equal("app-a"))
+
+			tombstones, err = tombstoneStore.ByGuids([]string{"app-c", "app-a"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(HaveLen(2))
+		})
+
+		It("returns nothing for an empty list", func() {
+			tombstones, err := tombstoneStore.ByGuids([]string{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(BeEmpty())
+		})
+	})
+
+	Describe("ByIDs and Delete", func() {
+		BeforeEach(func() {
+			Expect(tombstoneStore.Create(policies, egressPolicies)).To(Succeed())
+		})
+
+		It("finds and deletes tombstones by id", func() {
+			all, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+
+			tombstones, err := tombstoneStore.ByIDs(all[1].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(Equal(all[1:]))
+
+			Expect(tombstoneStore.Delete(all[0].ID)).To(Succeed())
+
+			remaining, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(Equal(all[1:]))
+		})
+	})
+
+	Describe("DeleteOlderThan", func() {
+		BeforeEach(func() {
+			Expect(tombstoneStore.Create(policies, egressPolicies)).To(Succeed())
+		})
+
+		It("deletes tombstones past the cutoff", func() {
+			deleted, err := tombstoneStore.DeleteOlderThan(time.Now().Add(-time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(0)))
+
+			deleted, err = tombstoneStore.DeleteOlderThan(time.Now().Add(time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(Equal(int64(2)))
+
+			tombstones, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(BeEmpty())
+		})
+	})
+
+	Describe("Restore", func() {
+		Context("when the policies of the tombstones have expired", func() {
+			BeforeEach(func() {
+				expiredAt := time.Now().Add(-time.Minute)
+				policies[0].ExpiresAt = &expiredAt
+				egressPolicies[0].ExpiresAt = &expiredAt
+				Expect(tombstoneStore.Create(policies, egressPolicies)).To(Succeed())
+			})
+
+			It("skips and keeps their tombstones", func() {
+				tombstones, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				restore, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restore.Restored).To(BeEmpty())
+				Expect(restore.Skipped).To(Equal(tombstones))
+
+				restored, err := policyStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored).To(BeEmpty())
+
+				remaining, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(Equal(tombstones))
+			})
+		})
+	})
+
+	Describe("Restore and DeleteWithTombstones", func() {
+		var destination store.EgressDestination
+
+		BeforeEach(func() {
+			egressDestinationStore := &store.EgressDestinationStore{
+				TerminalsRepo:           &store.TerminalsTable{Guids: &store.GuidGenerator{}},
+				DestinationMetadataRepo: &store.DestinationMetadataTable{},
+				Conn:                    realDb,
+				EgressDestinationRepo:   &store.EgressDestinationTable{},
+				EgressPolicyStore:       egressPolicyStore,
+			}
+			destinations, err := egressDestinationStore.Create([]store.EgressDestination{
+				{Name: "dest", Protocol: "tcp", IPRanges: []store.IPRange{{Start: "10.0.0.1", End: "10.0.0.2"}}},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			destination = destinations[0]
+
+			Expect(policyStore.Create(context.Background(), policies)).To(Succeed())
+			egressPolicies[0].Destination = store.EgressDestination{GUID: destination.GUID}
+			egressPolicies, err = egressPolicyStore.Create(context.Background(), egressPolicies)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(tombstoneStore.DeleteWithTombstones(context.Background(), policies, egressPolicies)).To(Succeed())
+		})
+
+		It("deletes the policies along with creating their tombstones", func() {
+			remaining, err := policyStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(BeEmpty())
+
+			remainingEgress, err := egressPolicyStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remainingEgress).To(BeEmpty())
+
+			tombstones, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tombstones).To(HaveLen(2))
+		})
+
+		It("restores the policies and deletes their tombstones", func() {
+			tombstones, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+
+			restore, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restore.Restored).To(Equal(tombstones))
+			Expect(restore.Skipped).To(BeEmpty())
+
+			restored, err := policyStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restored).To(HaveLen(1))
+			Expect(restored[0].Source.ID).To(Equal("app-a"))
+
+			restoredEgress, err := egressPolicyStore.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(restoredEgress).To(HaveLen(1))
+			Expect(restoredEgress[0].Source.ID).To(Equal("app-c"))
+
+			remaining, err := tombstoneStore.All()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(remaining).To(BeEmpty())
+		})
+
+		Context("when the policies conflict with the current policies", func() {
+			BeforeEach(func() {
+				Expect(policyStore.Create(context.Background(), policies)).To(Succeed())
+				_, err := egressPolicyStore.Create(context.Background(), egressPolicies)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("skips and keeps their tombstones", func() {
+				tombstones, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				restore, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restore.Restored).To(BeEmpty())
+				Expect(restore.Skipped).To(Equal(tombstones))
+
+				current, err := egressPolicyStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(current).To(HaveLen(1))
+
+				remaining, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(Equal(tombstones))
+			})
+		})
+
+		Context("when a policy with the other action exists for the same source and destination", func() {
+			BeforeEach(func() {
+				denyPolicy := egressPolicies[0]
+				denyPolicy.ID = ""
+				denyPolicy.Action = "deny"
+				_, err := egressPolicyStore.Create(context.Background(), []store.EgressPolicy{denyPolicy})
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("restores the c2c policy and skips the egress policy", func() {
+				tombstones, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				restore, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restore.Restored).To(Equal(tombstones[:1]))
+				Expect(restore.Skipped).To(Equal(tombstones[1:]))
+
+				current, err := egressPolicyStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(current).To(HaveLen(1))
+				Expect(current[0].Action).To(Equal("deny"))
+
+				remaining, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(Equal(tombstones[1:]))
+			})
+		})
+
+		Context("when the egress destination has been deleted", func() {
+			BeforeEach(func() {
+				_, err := realDb.Exec(realDb.Rebind(`DELETE FROM ip_ranges WHERE terminal_guid = ?`), destination.GUID)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec(realDb.Rebind(`DELETE FROM destination_metadatas WHERE terminal_guid = ?`), destination.GUID)
+				Expect(err).NotTo(HaveOccurred())
+				_, err = realDb.Exec(realDb.Rebind(`DELETE FROM terminals WHERE guid = ?`), destination.GUID)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("restores the c2c policy and skips the egress policy", func() {
+				tombstones, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				restore, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restore.Restored).To(Equal(tombstones[:1]))
+				Expect(restore.Skipped).To(Equal(tombstones[1:]))
+
+				remaining, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(Equal(tombstones[1:]))
+			})
+		})
+
+		Context("when restoring the egress policies fails", func() {
+			var tombstones []store.Tombstone
+
+			BeforeEach(func() {
+				fakeEgressPolicyStore := &fakes.EgressPolicyTxStore{}
+				fakeEgressPolicyStore.CreateWithTxReturns(nil, errors.New("potato"))
+				tombstoneStore = store.NewTombstoneStore(realDb, store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1), fakeEgressPolicyStore)
+
+				var err error
+				tombstones, err = tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("restores nothing and keeps every tombstone", func() {
+				_, err := tombstoneStore.Restore(context.Background(), tombstones[0].ID, tombstones[1].ID)
+				Expect(err).To(MatchError("restoring egress policies: potato"))
+
+				restored, err := policyStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(restored).To(BeEmpty())
+
+				remaining, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(remaining).To(Equal(tombstones))
+			})
+		})
+
+		Context("when deleting the egress policies fails", func() {
+			BeforeEach(func() {
+				Expect(policyStore.Create(context.Background(), policies)).To(Succeed())
+
+				fakeEgressPolicyStore := &fakes.EgressPolicyTxStore{}
+				fakeEgressPolicyStore.DeleteWithTxReturns(nil, errors.New("potato"))
+				tombstoneStore = store.NewTombstoneStore(realDb, store.New(realDb, &store.GroupTable{}, &store.DestinationTable{}, &store.PolicyTable{}, 1), fakeEgressPolicyStore)
+			})
+
+			It("deletes nothing and creates no tombstones", func() {
+				before, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+
+				err = tombstoneStore.DeleteWithTombstones(context.Background(), policies, egressPolicies)
+				Expect(err).To(MatchError("deleting egress policies: potato"))
+
+				current, err := policyStore.All(context.Background())
+				Expect(err).NotTo(HaveOccurred())
+				Expect(current).To(HaveLen(1))
+
+				after, err := tombstoneStore.All()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(after).To(Equal(before))
+			})
+		})
+	})
+
+	Context("when the database fails", func() {
+		var (
+			mockDb *fakes.Db
+			tx     *dbfakes.Transaction
+		)
+
+		BeforeEach(func() {
+			mockDb = &fakes.Db{}
+			tx = &dbfakes.Transaction{}
+			mockDb.BeginxReturns(tx, nil)
+			tombstoneStore = store.NewTombstoneStore(mockDb, &fakes.PolicyTxStore{}, &fakes.EgressPolicyTxStore{})
+		})
+
+		It("returns an error when the transaction cannot begin", func() {
+			mockDb.BeginxReturns(nil, errors.New("potato"))
+			err := tombstoneStore.Create(policies, egressPolicies)
+			Expect(err).To(MatchError("begin transaction: potato"))
+		})
+
+		It("rolls back when an insert fails", func() {
+			tx.ExecReturns(nil, errors.New("potato"))
+			err := tombstoneStore.Create(policies, egressPolicies)
+			Expect(err).To(MatchError("creating tombstone: potato"))
+			Expect(tx.RollbackCallCount()).To(Equal(1))
+		})
+
+		It("returns an error when deleting fails", func() {
+			mockDb.ExecReturns(nil, errors.New("potato"))
+			Expect(tombstoneStore.Delete(1)).To(MatchError("deleting tombstones: potato"))
+
+			_, err := tombstoneStore.DeleteOlderThan(time.Now())
+			Expect(err).To(MatchError("deleting expired tombstones: potato"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"lib/tracing"
+)
+
+// TracingWrapper records a span for every transaction and query made
+// through Store, as a child of the span in the caller's context.
+type TracingWrapper struct {
+	Store  Store
+	Tracer *tracing.Tracer
+}
+
+func (tw *TracingWrapper) Create(ctx context.Context, policies []Policy) error {
+	ctx, span := tw.Tracer.Start(ctx, "store.Create")
+	defer span.End()
+	span.SetAttribute("policies", len(policies))
+
+	err := tw.Store.Create(ctx, policies)
+	span.RecordError(err)
+	return err
+}
+
+func (tw *TracingWrapper) All(ctx context.Context) ([]Policy, error) {
+	ctx, span := tw.Tracer.Start(ctx, "store.All")
+	defer span.End()
+
+	policies, err := tw.Store.All(ctx)
+	span.RecordError(err)
+	return policies, err
+}
+
+func (tw *TracingWrapper) Delete(ctx context.Context, policies []Policy) error {
+	ctx, span := tw.Tracer.Start(ctx, "store.Delete")
+	defer span.End()
+	span.SetAttribute("policies", len(policies))
+
+	err := tw.Store.Delete(ctx, policies)
+	span.RecordError(err)
+	return err
+}
+
+func (tw *TracingWrapper) ByGuids(ctx context.Context, srcGuids, dstGuids []string, inSourceAndDest bool) ([]Policy, error) {
+	ctx, span := tw.Tracer.Start(ctx, "store.ByGuids")
+	defer span.End()
+
+	policies, err := tw.Store.ByGuids(ctx, srcGuids, dstGuids, inSourceAndDest)
+	span.RecordError(err)
+	return policies, err
+}
+
+func (tw *TracingWrapper) CheckDatabase(ctx context.Context) error {
+	ctx, span := tw.Tracer.Start(ctx, "store.CheckDatabase")
+	defer span.End()
+
+	err := tw.Store.CheckDatabase(ctx)
+	span.RecordError(err)
+	return err
+}
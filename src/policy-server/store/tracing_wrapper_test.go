@@ -0,0 +1,155 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"lib/tracing"
+	tracingfakes "lib/tracing/fakes"
+	"os"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/tedsuo/ifrit"
+)
+
+var _ = Describe("TracingWrapper", func() {
+	var (
+		tracingWrapper *store.TracingWrapper
+		fakeStore      *fakes.Store
+		exporter       *tracingfakes.SpanExporter
+		tracer         *tracing.Tracer
+		process        ifrit.Process
+		policies       []store.Policy
+	)
+
+	BeforeEach(func() {
+		fakeStore = &fakes.Store{}
+		exporter = &tracingfakes.SpanExporter{}
+		tracer = tracing.NewTracer(exporter, lagertest.NewTestLogger("test"), 10*time.Millisecond, 10)
+		process = ifrit.Invoke(tracer)
+		tracingWrapper = &store.TracingWrapper{
+			Store:  fakeStore,
+			Tracer: tracer,
+		}
+		policies = []store.Policy{{
+			Source:      store.Source{ID: "some-app-guid"},
+			Destination: store.Destination{ID: "some-other-app-guid", Protocol: "tcp", Port: 8080},
+		}}
+	})
+
+	AfterEach(func() {
+		process.Signal(os.Interrupt)
+		Eventually(process.Wait()).Should(Receive())
+	})
+
+	exportedSpans := func() []tracing.SpanData {
+		Eventually(exporter.ExportSpansCallCount).Should(Equal(1))
+		return exporter.ExportSpansArgsForCall(0)
+	}
+
+	Describe("Create", func() {
+		It("calls Create on the Store within a child span", func() {
+			ctx, parent := tracer.Start(context.Background(), "parent")
+
+			err := tracingWrapper.Create(ctx, policies)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStore.CreateCallCount()).To(Equal(1))
+			storeCtx, passedPolicies := fakeStore.CreateArgsForCall(0)
+			Expect(passedPolicies).To(Equal(policies))
+
+			spans := exportedSpans()
+			Expect(spans).To(HaveLen(1))
+			Expect(spans[0].Name).To(Equal("store.Create"))
+			Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+			Expect(spans[0].Attributes).To(HaveKeyWithValue("policies", 1))
+
+			storeSpan, ok := tracing.SpanContextFromContext(storeCtx)
+			Expect(ok).To(BeTrue())
+			Expect(storeSpan).To(Equal(spans[0].SpanContext))
+		})
+
+		Context("when the store fails", func() {
+			BeforeEach(func() {
+				fakeStore.CreateReturns(errors.New("banana"))
+			})
+
+			It("returns the error and marks the span as failed", func() {
+				err := tracingWrapper.Create(context.Background(), policies)
+				Expect(err).To(MatchError("banana"))
+
+				spans := exportedSpans()
+				Expect(spans[0].Error).To(BeTrue())
+				Expect(spans[0].StatusMessage).To(Equal("banana"))
+			})
+		})
+	})
+
+	Describe("All", func() {
+		It("returns the result of All on the Store", func() {
+			fakeStore.AllReturns(policies, nil)
+
+			returnedPolicies, err := tracingWrapper.All(context.Background())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedPolicies).To(Equal(policies))
+
+			Expect(exportedSpans()[0].Name).To(Equal("store.All"))
+		})
+	})
+
+	Describe("Delete", func() {
+		It("calls Delete on the Store", func() {
+			err := tracingWrapper.Delete(context.Background(), policies)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, passedPolicies := fakeStore.DeleteArgsForCall(0)
+			Expect(passedPolicies).To(Equal(policies))
+
+			Expect(exportedSpans()[0].Name).To(Equal("store.Delete"))
+		})
+	})
+
+	Describe("ByGuids", func() {
+		It("returns the result of ByGuids on the Store", func() {
+			fakeStore.ByGuidsReturns(policies, nil)
+
+			returnedPolicies, err := tracingWrapper.ByGuids(context.Background(), []string{"some-app-guid"}, nil, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(returnedPolicies).To(Equal(policies))
+
+			_, srcGuids, dstGuids, inSourceAndDest := fakeStore.ByGuidsArgsForCall(0)
+			Expect(srcGuids).To(Equal([]string{"some-app-guid"}))
+			Expect(dstGuids).To(BeNil())
+			Expect(inSourceAndDest).To(BeTrue())
+
+			Expect(exportedSpans()[0].Name).To(Equal("store.ByGuids"))
+		})
+	})
+
+	Describe("CheckDatabase", func() {
+		It("calls CheckDatabase on the Store", func() {
+			fakeStore.CheckDatabaseReturns(errors.New("huckleberry"))
+
+			err := tracingWrapper.CheckDatabase(context.Background())
+			Expect(err).To(MatchError("huckleberry"))
+
+			Expect(exportedSpans()[0].Name).To(Equal("store.CheckDatabase"))
+		})
+	})
+
+	Context("when there is no tracer", func() {
+		BeforeEach(func() {
+			tracingWrapper.Tracer = nil
+		})
+
+		It("still calls the Store", func() {
+			err := tracingWrapper.Create(context.Background(), policies)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fakeStore.CreateCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/cf-networking-helpers/db"
+)
+
+const transactionPollInterval = 10 * time.Millisecond
+
+// TransactionTracker is a Database that counts the transactions begun on it
+// and not yet committed or rolled back, so that a shutdown can wait for them
+// before closing the connection pool.
+type TransactionTracker struct {
+	Database
+
+	mutex sync.Mutex
+	open  int
+}
+
+func (t *TransactionTracker) Beginx() (db.Transaction, error) {
+	return t.track(t.Database.Beginx())
+}
+
+func (t *TransactionTracker) BeginTxx(ctx context.Context, opts *sql.TxOptions) (db.Transaction, error) {
+	return t.track(t.Database.BeginTxx(ctx, opts))
+}
+
+func (t *TransactionTracker) track(tx db.Transaction, err error) (db.Transaction, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	t.mutex.Lock()
+	t.open++
+	t.mutex.Unlock()
+	return &trackedTransaction{Transaction: tx, tracker: t}, nil
+}
+
+func (t *TransactionTracker) Open() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.open
+}
+
+// Wait returns once no transaction is open, or with an error once deadline
+// has passed.
+func (t *TransactionTracker) Wait(deadline time.Time) error {
+	for {
+		open := t.Open()
+		if open == 0 {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			return fmt.Errorf("%d transactions still open", open)
+		}
+		time.Sleep(transactionPollInterval)
+	}
+}
+
+func (t *TransactionTracker) finished() {
+	t.mutex.Lock()
+	t.open--
+	t.mutex.Unlock()
+}
+
+// trackedTransaction tells its tracker the first time it is committed or
+// rolled back, since callers commonly defer a rollback after committing.
+type trackedTransaction struct {
+	db.Transaction
+	tracker *TransactionTracker
+	once    sync.Once
+}
+
+func (t *trackedTransaction) Commit() error {
+	defer t.once.Do(t.tracker.finished)
+	return t.Transaction.Commit()
+}
+
+func (t *trackedTransaction) Rollback() error {
+	defer t.once.Do(t.tracker.finished)
+	return t.Transaction.Rollback()
+}
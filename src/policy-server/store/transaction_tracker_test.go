@@ -0,0 +1,120 @@
+package store_test
+
+import (
+	"context"
+	"errors"
+	"policy-server/store"
+	"policy-server/store/fakes"
+	"time"
+
+	dbfakes "code.cloudfoundry.org/cf-networking-helpers/db/fakes"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TransactionTracker", func() {
+	var (
+		mockDb  *fakes.Db
+		tx      *dbfakes.Transaction
+		tracker *store.TransactionTracker
+	)
+
+	BeforeEach(func() {
+		mockDb = &fakes.Db{}
+		tx = &dbfakes.Transaction{}
+		mockDb.BeginxReturns(tx, nil)
+		tracker = &store.TransactionTracker{Database: mockDb}
+	})
+
+	It("counts transactions until they are committed or rolled back", func() {
+		committed, err := tracker.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+		rolledBack, err := tracker.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tracker.Open()).To(Equal(2))
+
+		Expect(committed.Commit()).To(Succeed())
+		Expect(tx.CommitCallCount()).To(Equal(1))
+		Expect(tracker.Open()).To(Equal(1))
+
+		Expect(rolledBack.Rollback()).To(Succeed())
+		Expect(tx.RollbackCallCount()).To(Equal(1))
+		Expect(tracker.Open()).To(Equal(0))
+	})
+
+	It("counts a transaction once when it is rolled back after committing", func() {
+		_, err := tracker.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+		other, err := tracker.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(other.Commit()).To(Succeed())
+		other.Rollback()
+		Expect(tracker.Open()).To(Equal(1))
+	})
+
+	It("still counts a transaction as finished when committing fails", func() {
+		tx.CommitReturns(errors.New("potato"))
+		transaction, err := tracker.Beginx()
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(transaction.Commit()).To(MatchError("potato"))
+		Expect(tracker.Open()).To(Equal(0))
+	})
+
+	It("counts transactions bound to a context", func() {
+		mockDb.BeginTxxReturns(tx, nil)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		transaction, err := tracker.BeginTxx(ctx, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(tracker.Open()).To(Equal(1))
+		txCtx, _ := mockDb.BeginTxxArgsForCall(0)
+		Expect(txCtx).To(Equal(ctx))
+
+		Expect(transaction.Commit()).To(Succeed())
+		Expect(tracker.Open()).To(Equal(0))
+	})
+
+	It("passes other queries through", func() {
+		_, err := tracker.Exec("some query", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockDb.ExecCallCount()).To(Equal(1))
+	})
+
+	Context("when beginning the transaction fails", func() {
+		BeforeEach(func() {
+			mockDb.BeginxReturns(nil, errors.New("potato"))
+		})
+
+		It("returns the error and does not count it", func() {
+			_, err := tracker.Beginx()
+			Expect(err).To(MatchError("potato"))
+			Expect(tracker.Open()).To(Equal(0))
+		})
+	})
+
+	Describe("Wait", func() {
+		It("returns once every transaction has finished", func() {
+			transaction, err := tracker.Beginx()
+			Expect(err).NotTo(HaveOccurred())
+
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				transaction.Rollback()
+			}()
+
+			Expect(tracker.Wait(time.Now().Add(time.Second))).To(Succeed())
+		})
+
+		It("returns an error when transactions are still open at the deadline", func() {
+			_, err := tracker.Beginx()
+			Expect(err).NotTo(HaveOccurred())
+
+			err = tracker.Wait(time.Now().Add(50 * time.Millisecond))
+			Expect(err).To(MatchError("1 transactions still open"))
+		})
+	})
+})
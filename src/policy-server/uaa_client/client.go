@@ -1,9 +1,11 @@
 package uaa_client
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"lib/tracing"
 	"net/http"
 	"strings"
 
@@ -25,6 +27,7 @@ type Client struct {
 	Secret     string
 	HTTPClient httpClient
 	Logger     lager.Logger
+	Tracer     *tracing.Tracer
 }
 
 //go:generate counterfeiter -o fakes/http_client.go --fake-name HTTPClient . httpClient
@@ -38,7 +41,7 @@ type CheckTokenResponse struct {
 	UserName string   `json:"user_name"`
 }
 
-func (c *Client) GetToken() (string, error) {
+func (c *Client) GetToken(ctx context.Context) (string, error) {
 	reqURL := fmt.Sprintf("%s/oauth/token", c.BaseURL)
 	bodyString := fmt.Sprintf("client_id=%s&grant_type=client_credentials", c.Name)
 	request, err := http.NewRequest("POST", reqURL, strings.NewReader(bodyString))
@@ -51,14 +54,14 @@ func (c *Client) GetToken() (string, error) {
 		AccessToken string `json:"access_token"`
 	}
 	response := &getTokenResponse{}
-	err = c.makeRequest(request, response)
+	err = c.makeRequest(ctx, "uaa_client.GetToken", request, response)
 	if err != nil {
 		return "", err
 	}
 	return response.AccessToken, nil
 }
 
-func (c *Client) CheckToken(token string) (CheckTokenResponse, error) {
+func (c *Client) CheckToken(ctx context.Context, token string) (CheckTokenResponse, error) {
 	reqURL := fmt.Sprintf("%s/check_token", c.BaseURL)
 	bodyString := "token=" + token
 	request, err := http.NewRequest("POST", reqURL, strings.NewReader(bodyString))
@@ -68,14 +71,24 @@ func (c *Client) CheckToken(token string) (CheckTokenResponse, error) {
 	c.Logger.Debug("check-token", lager.Data{"URL": request.URL})
 
 	response := &CheckTokenResponse{}
-	err = c.makeRequest(request, response)
+	err = c.makeRequest(ctx, "uaa_client.CheckToken", request, response)
 	if err != nil {
 		return CheckTokenResponse{}, err
 	}
 	return *response, nil
 }
 
-func (c *Client) makeRequest(request *http.Request, response interface{}) error {
+func (c *Client) makeRequest(ctx context.Context, spanName string, request *http.Request, response interface{}) error {
+	ctx, span := c.Tracer.StartClient(ctx, spanName)
+	defer span.End()
+	tracing.Inject(ctx, request.Header)
+
+	err := c.doRequest(request.WithContext(ctx), response)
+	span.RecordError(err)
+	return err
+}
+
+func (c *Client) doRequest(request *http.Request, response interface{}) error {
 	resp, err := c.HTTPClient.Do(request)
 	if err != nil {
 		return fmt.Errorf("http client: %s", err)
@@ -1,18 +1,24 @@
 package uaa_client_test
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"lib/tracing"
+	tracingfakes "lib/tracing/fakes"
 	"math/rand"
 	"net/http"
+	"os"
 	"policy-server/uaa_client"
 	"policy-server/uaa_client/fakes"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	"github.com/tedsuo/ifrit"
 
 	"code.cloudfoundry.org/cf-networking-helpers/testsupport"
 	"code.cloudfoundry.org/lager/lagertest"
@@ -56,7 +62,7 @@ var _ = Describe("Client", func() {
 		})
 
 		It("Returns the token", func() {
-			token, err := client.GetToken()
+			token, err := client.GetToken(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 			Expect(token).To(Equal("valid-token"))
 
@@ -75,12 +81,23 @@ var _ = Describe("Client", func() {
 		})
 
 		It("logs the request before sending", func() {
-			_, err := client.GetToken()
+			_, err := client.GetToken(context.Background())
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(logger).To(gbytes.Say("get-token"))
 		})
 
+		It("makes the request within the given context", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := client.GetToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			receivedRequest := httpClient.DoArgsForCall(0)
+			Expect(receivedRequest.Context().Err()).To(Equal(context.Canceled))
+		})
+
 		Context("when the http client returns an error", func() {
 			BeforeEach(func() {
 				httpClient.DoReturns(nil, errors.New("potato"))
@@ -88,7 +105,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetToken()
+				_, err := client.GetToken(context.Background())
 
 				Expect(err).To(MatchError(ContainSubstring("http client: potato")))
 			})
@@ -104,7 +121,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the response body in the error", func() {
-				_, err := client.GetToken()
+				_, err := client.GetToken(context.Background())
 
 				Expect(err).To(Equal(uaa_client.BadUaaResponse{
 					StatusCode:      418,
@@ -120,7 +137,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetToken()
+				_, err := client.GetToken(context.Background())
 
 				Expect(err).To(MatchError(ContainSubstring("read body: banana")))
 			})
@@ -136,7 +153,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.GetToken()
+				_, err := client.GetToken(context.Background())
 
 				Expect(err).To(MatchError(ContainSubstring("unmarshal json: invalid character")))
 			})
@@ -163,7 +180,7 @@ var _ = Describe("Client", func() {
 
 		It("Returns the scopes and user name for the token", func() {
 			fakeToken := fmt.Sprintf("%x", rand.Int31())
-			tokenData, err := client.CheckToken(fakeToken)
+			tokenData, err := client.CheckToken(context.Background(), fakeToken)
 			Expect(err).NotTo(HaveOccurred())
 
 			receivedRequest := httpClient.DoArgsForCall(0)
@@ -184,12 +201,45 @@ var _ = Describe("Client", func() {
 		})
 
 		It("logs the request before sending", func() {
-			_, err := client.CheckToken("valid-token")
+			_, err := client.CheckToken(context.Background(), "valid-token")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(logger).To(gbytes.Say("check_token"))
 		})
 
+		Context("when a tracer is set", func() {
+			var (
+				exporter *tracingfakes.SpanExporter
+				process  ifrit.Process
+			)
+
+			BeforeEach(func() {
+				exporter = &tracingfakes.SpanExporter{}
+				client.Tracer = tracing.NewTracer(exporter, logger, 10*time.Millisecond, 10)
+				process = ifrit.Invoke(client.Tracer)
+			})
+
+			AfterEach(func() {
+				process.Signal(os.Interrupt)
+				Eventually(process.Wait()).Should(Receive())
+			})
+
+			It("records a client span and propagates the trace to UAA", func() {
+				ctx, parent := client.Tracer.Start(context.Background(), "parent")
+				_, err := client.CheckToken(ctx, "valid-token")
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(exporter.ExportSpansCallCount).Should(Equal(1))
+				spans := exporter.ExportSpansArgsForCall(0)
+				Expect(spans).To(HaveLen(1))
+				Expect(spans[0].Name).To(Equal("uaa_client.CheckToken"))
+				Expect(spans[0].ParentSpanID).To(Equal(parent.SpanContext().SpanID))
+
+				receivedRequest := httpClient.DoArgsForCall(0)
+				Expect(receivedRequest.Header.Get("traceparent")).To(Equal(spans[0].SpanContext.Traceparent()))
+			})
+		})
+
 		Context("when the http client returns an error", func() {
 			BeforeEach(func() {
 				httpClient.DoReturns(nil, errors.New("potato"))
@@ -197,7 +247,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.CheckToken("valid-token")
+				_, err := client.CheckToken(context.Background(), "valid-token")
 
 				Expect(err).To(MatchError(ContainSubstring("http client: potato")))
 			})
@@ -213,7 +263,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns the response body in the error", func() {
-				_, err := client.CheckToken("something")
+				_, err := client.CheckToken(context.Background(), "something")
 
 				Expect(err).To(Equal(uaa_client.BadUaaResponse{
 					StatusCode:      418,
@@ -229,7 +279,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.CheckToken("valid-token")
+				_, err := client.CheckToken(context.Background(), "valid-token")
 
 				Expect(err).To(MatchError(ContainSubstring("read body: banana")))
 			})
@@ -245,7 +295,7 @@ var _ = Describe("Client", func() {
 			})
 
 			It("returns a helpful error", func() {
-				_, err := client.CheckToken("valid-token")
+				_, err := client.CheckToken(context.Background(), "valid-token")
 
 				Expect(err).To(MatchError(ContainSubstring("unmarshal json: invalid character")))
 			})
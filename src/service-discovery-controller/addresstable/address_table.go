@@ -99,6 +99,15 @@ func (at *AddressTable) GetAllAddresses() map[string][]string {
 	return addresses
 }
 
+// Size returns the number of hostnames in the table.
+func (at *AddressTable) Size() int {
+	at.mutex.RLock()
+	size := len(at.addresses)
+	at.mutex.RUnlock()
+
+	return size
+}
+
 func (at *AddressTable) SetWarm() {
 	at.warmMutex.Lock()
 	at.warm = true
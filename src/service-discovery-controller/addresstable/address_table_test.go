@@ -93,6 +93,19 @@ var _ = Describe("AddressTable", func() {
 		})
 	})
 
+	Describe("Size", func() {
+		It("returns the number of hostnames", func() {
+			Expect(table.Size()).To(Equal(0))
+
+			table.Add([]string{"foo.com", "bar.com"}, "192.0.0.1")
+			table.Add([]string{"foo.com"}, "192.0.0.2")
+			Expect(table.Size()).To(Equal(2))
+
+			table.Remove([]string{"bar.com"}, "192.0.0.1")
+			Expect(table.Size()).To(Equal(1))
+		})
+	})
+
 	Describe("Remove", func() {
 		It("removes an endpoint", func() {
 			table.Add([]string{"foo.com"}, "192.0.0.1")
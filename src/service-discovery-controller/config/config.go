@@ -24,6 +24,8 @@ type Config struct {
 	MetricsEmitSeconds        int          `json:"metrics_emit_seconds" validate:"min=1"`
 	ResumePruningDelaySeconds int          `json:"resume_pruning_delay_seconds" validate:"min=0"`
 	WarmDurationSeconds       int          `json:"warm_duration_seconds" validate:"min=0"`
+	PrometheusListenAddress   string       `json:"prometheus_listen_address"`
+	PrometheusListenPort      int          `json:"prometheus_listen_port" validate:"min=0"`
 }
 
 type NatsConfig struct {
@@ -41,7 +41,9 @@ var _ = Describe("Config", func() {
 				"metrics_emit_seconds": 6,
 				"metron_port": 8080,
 				"resume_pruning_delay_seconds": 2,
-				"warm_duration_seconds": 5
+				"warm_duration_seconds": 5,
+				"prometheus_listen_address": "127.0.0.1",
+				"prometheus_listen_port": 9101
 			}`)
 
 			parsedConfig, err := NewConfig(configJSON)
@@ -63,6 +65,8 @@ var _ = Describe("Config", func() {
 			Expect(parsedConfig.MetricsEmitSeconds).To(Equal(6))
 			Expect(parsedConfig.ResumePruningDelaySeconds).To(Equal(2))
 			Expect(parsedConfig.WarmDurationSeconds).To(Equal(5))
+			Expect(parsedConfig.PrometheusListenAddress).To(Equal("127.0.0.1"))
+			Expect(parsedConfig.PrometheusListenPort).To(Equal(9101))
 		})
 	})
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"lib/common"
+	"lib/prometheus"
 	"os"
 	"os/signal"
 	"service-discovery-controller/addresstable"
@@ -86,17 +87,40 @@ func mainWithError() error {
 		Getter: routeMessageRecorder.GetRegisterMessagesReceived,
 	}
 
+	addressTableSizeSource := metrics.MetricSource{
+		Name: "addressTableSize",
+		Unit: "hostname",
+		Getter: func() (float64, error) {
+			return float64(addressTable.Size()), nil
+		},
+	}
+
+	uptimeSource := metrics.NewUptimeSource()
+
 	metricsEmitter := metrics.NewMetricsEmitter(
 		logger,
 		time.Duration(conf.MetricsEmitSeconds)*time.Second,
-		metrics.NewUptimeSource(),
+		uptimeSource,
 		dnsRequestSource,
 		routeMessageSource,
 		registerMessagesReceivedSource,
+		addressTableSizeSource,
 	)
 
-	metricsSender := &metrics.MetricsSender{
-		Logger: logger.Session("time-metric-emitter"),
+	var prometheusRegistry *prometheus.Registry
+	if conf.PrometheusListenPort > 0 {
+		prometheusRegistry = prometheus.NewRegistry("service_discovery_controller")
+		// dnsRequest and maxRouteMessageTimePerInterval reset every time
+		// they are read, so they are left to the metrics emitter. The
+		// addressTableLookup histogram counts DNS requests instead.
+		prometheusRegistry.AddSources(uptimeSource, registerMessagesReceivedSource, addressTableSizeSource)
+	}
+
+	metricsSender := &prometheus.MetricsSender{
+		Sender: &metrics.MetricsSender{
+			Logger: logger.Session("time-metric-emitter"),
+		},
+		Registry: prometheusRegistry,
 	}
 
 	logLevelServer := lagerlevel.NewServer(
@@ -120,6 +144,10 @@ func mainWithError() error {
 		{"log-level-server", logLevelServer},
 		{"routes-server", routesServer},
 	}
+	if prometheusRegistry != nil {
+		prometheusAddress := fmt.Sprintf("%s:%d", conf.PrometheusListenAddress, conf.PrometheusListenPort)
+		members = append(members, grouper.Member{Name: "prometheus-server", Runner: prometheus.NewServer(prometheusAddress, prometheusRegistry)})
+	}
 
 	group := grouper.NewOrdered(os.Interrupt, members)
 	monitor := ifrit.Invoke(sigmon.New(group))
@@ -44,6 +44,7 @@ var _ = Describe("Service Discovery Controller process", func() {
 		logLevelEndpointPort      int
 		logLevelEndpointAddress   string
 		fakeMetron                metrics.FakeMetron
+		prometheusPort            int
 	)
 
 	BeforeEach(func() {
@@ -56,6 +57,7 @@ var _ = Describe("Service Discovery Controller process", func() {
 		natsServerPort = ports.PickAPort()
 		natsServer = RunNatsServerOnPort(natsServerPort)
 		port = ports.PickAPort()
+		prometheusPort = ports.PickAPort()
 		configPath = writeConfigFile(fmt.Sprintf(`{
 			"address":"127.0.0.1",
 			"port":"%d",
@@ -77,9 +79,11 @@ var _ = Describe("Service Discovery Controller process", func() {
 			"metron_port": %d,
 			"metrics_emit_seconds": 2,
 			"resume_pruning_delay_seconds": 1,
-			"warm_duration_seconds": 0
+			"warm_duration_seconds": 0,
+			"prometheus_listen_address": "127.0.0.1",
+			"prometheus_listen_port": %d
 		}`,
-			port, caFile, serverCert, serverKey, natsServerPort, stalenessThresholdSeconds, pruningIntervalSeconds, logLevelEndpointAddress, logLevelEndpointPort, fakeMetron.Port()))
+			port, caFile, serverCert, serverKey, natsServerPort, stalenessThresholdSeconds, pruningIntervalSeconds, logLevelEndpointAddress, logLevelEndpointPort, fakeMetron.Port(), prometheusPort))
 	})
 
 	AfterEach(func() {
@@ -549,6 +553,21 @@ var _ = Describe("Service Discovery Controller process", func() {
 					)))
 				})
 
+				It("serves the lookup duration and address table size on the prometheus endpoint", func() {
+					scrape := func() string {
+						resp, err := http.Get(fmt.Sprintf("http://127.0.0.1:%d/metrics", prometheusPort))
+						Expect(err).ToNot(HaveOccurred())
+						defer resp.Body.Close()
+
+						body, err := ioutil.ReadAll(resp.Body)
+						Expect(err).ToNot(HaveOccurred())
+						return string(body)
+					}
+
+					Expect(scrape()).To(ContainSubstring("service_discovery_controller_address_table_lookup_duration_seconds_count 1"))
+					Eventually(scrape).Should(ContainSubstring("service_discovery_controller_address_table_size 2"))
+				})
+
 			})
 		})
 